@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"html/template"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg"
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -19,8 +25,11 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/version"
+	"github.com/prometheus/exporter-toolkit/web"
 	"github.com/alecthomas/kingpin/v2"
 )
 
@@ -33,6 +42,13 @@ const (
 var (
 	listenAddress = kingpin.Flag("web.listen-address", "The address to listen on for HTTP requests.").Default(":9115").String()
 	metricsPath   = kingpin.Flag("web.telemetry-path", "Path under which to expose metrics.").Default("/metrics").String()
+	webConfigFile = kingpin.Flag("web.config.file", "Path to a web configuration file enabling TLS and basic auth.").Default("").String()
+	probeAddress  = kingpin.Flag("web.probe-address", "An additional address serving only /healthz and /readyz without TLS and basic auth, e.g. for the kubelet probes.").Default("").String()
+	enableDebug   = kingpin.Flag("web.enable-debug", "Expose pprof under /debug/pprof and all Go runtime metrics.").Default("false").Bool()
+
+	validateOnly  = kingpin.Flag("validate-config", "Validate the configuration files, print the effective configuration and exit.").Default("false").Bool()
+	validateOnAWS = kingpin.Flag("validate-config.aws", "With --validate-config, also resolve the AWS credentials and call STS GetCallerIdentity.").Default("false").Bool()
+	collectOnce   = kingpin.Flag("once", "Collect the metrics of all enabled collectors once, print them to stdout and exit.").Default("false").Bool()
 )
 
 func main() {
@@ -49,101 +65,795 @@ func getAwsAccountNumber(logger log.Logger, sess *session.Session) (string, erro
 	return *identityOutput.Account, nil
 }
 
-func setupCollectors(logger log.Logger, configFile string) ([]prometheus.Collector, error) {
+// sessionRegion returns the region the account is looked up in, AWS_REGION or the default region of the partition
+func sessionRegion(awsConfig pkg.AWSConfig) string {
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		return region
+	}
+	return awsConfig.DefaultRegion()
+}
+
+// reloadableCollector forwards to the currently active collectors, which are replaced when the configuration is
+// reloaded. It does not describe any metrics, as they change with the configuration.
+type reloadableCollector struct {
+	mu         sync.RWMutex
+	collectors []prometheus.Collector
+	config     *pkg.Config
+	cancel     context.CancelFunc
+}
+
+func (c *reloadableCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c *reloadableCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, collector := range c.collectors {
+		collector.Collect(ch)
+	}
+}
+
+// replace swaps in the new collectors and stops the collect loops of the previous ones. The readiness then depends on
+// the new collectors.
+func (c *reloadableCollector) replace(collectors []prometheus.Collector, names []string, config *pkg.Config, cancel context.CancelFunc) {
+	pkg.SetEnabledCollectors(names)
+	c.mu.Lock()
+	previousCancel := c.cancel
+	c.collectors = collectors
+	c.config = config
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	if previousCancel != nil {
+		previousCancel()
+	}
+}
+
+// activeConfig returns the configuration the active collectors were set up with
+func (c *reloadableCollector) activeConfig() *pkg.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config
+}
+
+// stop stops the collect loops of the active collectors, which keep serving their cached metrics
+func (c *reloadableCollector) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+}
+
+type collectLooper interface {
+	CollectLoop(ctx context.Context)
+}
+
+// collectLoops starts the collect loops of a configuration and remembers the names of their collectors, which the
+// readiness depends on
+type collectLoops struct {
+	ctx   context.Context
+	wg    *sync.WaitGroup
+	names []string
+	// once collects all exporters a single time when they are collected, instead of starting their loops
+	once bool
+}
+
+// start runs the collect loop of the exporter in the background until the context is cancelled. The name has to match
+// the collector the exporter publishes its last update for. The loops started with the same name, i.e. the parts of an
+// exporter split by region overrides, have to be ready on their own.
+func (l *collectLoops) start(name string, exporter collectLooper) {
+	part := 0
+	for _, started := range l.names {
+		if started == name {
+			part++
+		}
+	}
+	l.names = append(l.names, name)
+	ctx := pkg.WithCollectorPart(l.ctx, part)
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		exporter.CollectLoop(ctx)
+	}()
+}
+
+// add returns the collector serving the metrics of the exporter. In loop mode the collect loop of the exporter is
+// started, in scrape mode the exporter is updated whenever it is collected. Only collectors updating in the
+// background are considered for the readiness, as scrape mode ones update once Prometheus scrapes.
+func (l *collectLoops) add(name string, config pkg.BaseConfig, exporter pkg.Exporter) prometheus.Collector {
+	if config.Mode == pkg.COLLECT_MODE_SCRAPE || l.once {
+		return pkg.NewScrapeCollector(exporter)
+	}
+	l.start(name, pkg.WithSchedule(exporter, config))
+	return exporter
+}
+
+func setupCollectors(ctx context.Context, wg *sync.WaitGroup, logger log.Logger, configFile string, once bool) ([]prometheus.Collector, []string, *pkg.Config, error) {
 	var collectors []prometheus.Collector
 	config, err := pkg.LoadExporterConfiguration(logger, configFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	awsclient.SetRetrySettings(config.RetryConfig.MaxAttempts, config.RetryConfig.MaxBackoff)
+	if err := awsclient.SetRateLimits(config.RateLimits); err != nil {
+		return nil, nil, nil, err
+	}
+	// Create a single session here, because we need the accountid, before we create the other configs
+	sess, err := config.AWS.NewSession(nil, sessionRegion(config.AWS))
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	awsAccountId, err := getAwsAccountNumber(logger, sess)
+	if err != nil {
+		return collectors, nil, nil, err
+	}
+	if config.AutoDiscoverRegions {
+		regions, err := pkg.DiscoverRegions(ctx, awsclient.NewClientFromSession(sess))
+		if err != nil {
+			return collectors, nil, nil, err
+		}
+		level.Info(logger).Log("msg", "Discovered regions for exporters without regions", "regions", strings.Join(regions, ","))
+		config.FillEmptyRegions(regions)
+	}
+	loops := &collectLoops{ctx: ctx, wg: wg, once: once}
 	level.Info(logger).Log("msg", "Configuring vpc with regions", "regions", strings.Join(config.VpcConfig.Regions, ","))
 	level.Info(logger).Log("msg", "Configuring rds with regions", "regions", strings.Join(config.RdsConfig.Regions, ","))
 	level.Info(logger).Log("msg", "Configuring ec2 with regions", "regions", strings.Join(config.EC2Config.Regions, ","))
 	level.Info(logger).Log("msg", "Configuring route53 with region", "region", config.Route53Config.Region)
 	level.Info(logger).Log("msg", "Configuring elasticache with regions", "regions", strings.Join(config.ElastiCacheConfig.Regions, ","))
 	level.Info(logger).Log("msg", "Configuring msk with regions", "regions", strings.Join(config.MskConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring dynamodb with regions", "regions", strings.Join(config.DynamoDBConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring elb with regions", "regions", strings.Join(config.ELBConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring ebs with regions", "regions", strings.Join(config.EBSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring lambda with regions", "regions", strings.Join(config.LambdaConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring sqs_sns with regions", "regions", strings.Join(config.SQSSNSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring eks with regions", "regions", strings.Join(config.EKSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring opensearch with regions", "regions", strings.Join(config.OpenSearchConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring iam with region", "region", config.IAMConfig.Region)
+	level.Info(logger).Log("msg", "Configuring acm with regions", "regions", strings.Join(config.ACMConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring kms with regions", "regions", strings.Join(config.KMSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring secretsmanager with regions", "regions", strings.Join(config.SecretsManagerConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring ssm with regions", "regions", strings.Join(config.SSMConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring cloudfront with region", "region", config.CloudFrontConfig.Region)
+	level.Info(logger).Log("msg", "Configuring efs with regions", "regions", strings.Join(config.EFSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring redshift with regions", "regions", strings.Join(config.RedshiftConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring apigateway with regions", "regions", strings.Join(config.APIGatewayConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring kinesis with regions", "regions", strings.Join(config.KinesisConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring ecr with regions", "regions", strings.Join(config.ECRConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring ecs with regions", "regions", strings.Join(config.ECSConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring cloudwatchlogs with regions", "regions", strings.Join(config.CloudWatchLogsConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring s3 with regions", "regions", strings.Join(config.S3Config.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring servicequotas with regions", "regions", strings.Join(config.ServiceQuotasConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring trustedadvisor with region", "region", config.TrustedAdvisorConfig.Region)
+	level.Info(logger).Log("msg", "Configuring backup with regions", "regions", strings.Join(config.BackupConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring cloudtrail with region", "region", config.CloudTrailConfig.Region)
+	level.Info(logger).Log("msg", "Configuring securityfindings with regions", "regions", strings.Join(config.SecurityFindingsConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring configservice with regions", "regions", strings.Join(config.ConfigServiceConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring waf with regions", "regions", strings.Join(config.WAFConfig.Regions, ","))
+	level.Info(logger).Log("msg", "Configuring cost with granularity", "granularity", config.CostConfig.Granularity)
 	level.Info(logger).Log("msg", "Will VPC metrics be gathered?", "vpc-enabled", config.VpcConfig.Enabled)
 
-	sessionRegion := "us-east-1"
-	if sr := os.Getenv("AWS_REGION"); sr != "" {
-		sessionRegion = sr
-	}
-
-	// Create a single session here, because we need the accountid, before we create the other configs
-	awsConfig := aws.NewConfig().WithRegion(sessionRegion)
-	sess := session.Must(session.NewSession(awsConfig))
-	awsAccountId, err := getAwsAccountNumber(logger, sess)
-	if err != nil {
-		return collectors, err
+	var eolSource *eol.Source
+	level.Info(logger).Log("msg", "Will EOL dates be fetched?", "eol-source-enabled", config.EOLConfig.Source.Enabled)
+	if config.EOLConfig.Source.Enabled && once {
+		level.Warn(logger).Log("msg", "The EOL source is not fetched when collecting once, using the configured EOL dates")
+	} else if config.EOLConfig.Source.Enabled {
+		eolSource = pkg.NewEOLSource(logger, config.EOLConfig.Source)
+		loops.start("eol_source", eolSource)
 	}
-	var vpcSessions []*session.Session
+	// The EOL dates and thresholds shared by all exporters
+	eolChecker := eol.NewChecker(config.EOLConfig.EOLInfos, config.EOLConfig.Thresholds, eolSource)
 	if config.VpcConfig.Enabled {
-		for _, region := range config.VpcConfig.Regions {
-			config := aws.NewConfig().WithRegion(region)
-			sess := session.Must(session.NewSession(config))
-			vpcSessions = append(vpcSessions, sess)
+		for _, vpcConfig := range pkg.SplitByRegionOverrides(config.VpcConfig) {
+			vpcSessions, err := config.AWS.NewSessions(vpcConfig.AssumeRole, vpcConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			vpcExporter := pkg.NewVPCExporter(vpcSessions, logger, vpcConfig, awsAccountId)
+			collectors = append(collectors, loops.add("vpc", vpcConfig.BaseConfig, vpcExporter))
 		}
-		vpcExporter := pkg.NewVPCExporter(vpcSessions, logger, config.VpcConfig, awsAccountId)
-		collectors = append(collectors, vpcExporter)
-		go vpcExporter.CollectLoop()
 	}
 	level.Info(logger).Log("msg", "Will RDS metrics be gathered?", "rds-enabled", config.RdsConfig.Enabled)
-	var rdsSessions []*session.Session
 	if config.RdsConfig.Enabled {
-		for _, region := range config.RdsConfig.Regions {
-			config := aws.NewConfig().WithRegion(region)
-			sess := session.Must(session.NewSession(config))
-			rdsSessions = append(rdsSessions, sess)
+		for _, rdsConfig := range pkg.SplitByRegionOverrides(config.RdsConfig) {
+			rdsSessions, err := config.AWS.NewSessions(rdsConfig.AssumeRole, rdsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rdsExporter := pkg.NewRDSExporter(rdsSessions, logger, rdsConfig, awsAccountId, eolChecker)
+			collectors = append(collectors, loops.add("rds", rdsConfig.BaseConfig, rdsExporter))
 		}
-		rdsExporter := pkg.NewRDSExporter(rdsSessions, logger, config.RdsConfig, awsAccountId)
-		collectors = append(collectors, rdsExporter)
-		go rdsExporter.CollectLoop()
 	}
 	level.Info(logger).Log("msg", "Will EC2 metrics be gathered?", "ec2-enabled", config.EC2Config.Enabled)
-	var ec2Sessions []*session.Session
 	if config.EC2Config.Enabled {
-		for _, region := range config.EC2Config.Regions {
-			config := aws.NewConfig().WithRegion(region)
-			sess := session.Must(session.NewSession(config))
-			ec2Sessions = append(ec2Sessions, sess)
+		for _, ec2Config := range pkg.SplitByRegionOverrides(config.EC2Config) {
+			ec2Sessions, err := config.AWS.NewSessions(ec2Config.AssumeRole, ec2Config.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			ec2Exporter := pkg.NewEC2Exporter(ec2Sessions, logger, ec2Config, awsAccountId)
+			collectors = append(collectors, loops.add("ec2", ec2Config.BaseConfig, ec2Exporter))
 		}
-		ec2Exporter := pkg.NewEC2Exporter(ec2Sessions, logger, config.EC2Config, awsAccountId)
-		collectors = append(collectors, ec2Exporter)
-		go ec2Exporter.CollectLoop()
 	}
 	level.Info(logger).Log("msg", "Will Route53 metrics be gathered?", "route53-enabled", config.Route53Config.Enabled)
 	if config.Route53Config.Enabled {
-		awsConfig := aws.NewConfig().WithRegion(config.Route53Config.Region)
-		sess := session.Must(session.NewSession(awsConfig))
-		r53Exporter := pkg.NewRoute53Exporter(sess, logger, config.Route53Config, awsAccountId)
-		collectors = append(collectors, r53Exporter)
-		go r53Exporter.CollectLoop()
+		sess, err := config.AWS.NewSession(config.Route53Config.AssumeRole, config.Route53Config.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		r53Exporter := pkg.NewRoute53Exporter(awsclient.NewClientFromSession(sess), logger, config.Route53Config, awsAccountId)
+		collectors = append(collectors, loops.add("route53", config.Route53Config.BaseConfig, r53Exporter))
 	}
 	level.Info(logger).Log("msg", "Will ElastiCache metrics be gathered?", "elasticache-enabled", config.ElastiCacheConfig.Enabled)
-	var elasticacheSessions []*session.Session
 	if config.ElastiCacheConfig.Enabled {
-		for _, region := range config.ElastiCacheConfig.Regions {
-			config := aws.NewConfig().WithRegion(region)
-			sess := session.Must(session.NewSession(config))
-			elasticacheSessions = append(elasticacheSessions, sess)
+		for _, elasticacheConfig := range pkg.SplitByRegionOverrides(config.ElastiCacheConfig) {
+			elasticacheSessions, err := config.AWS.NewSessions(elasticacheConfig.AssumeRole, elasticacheConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			elasticacheExporter := pkg.NewElastiCacheExporter(elasticacheSessions, logger, elasticacheConfig, awsAccountId, eolChecker)
+			collectors = append(collectors, loops.add("elasticache", elasticacheConfig.BaseConfig, elasticacheExporter))
 		}
-		elasticacheExporter := pkg.NewElastiCacheExporter(elasticacheSessions, logger, config.ElastiCacheConfig, awsAccountId)
-		collectors = append(collectors, elasticacheExporter)
-		go elasticacheExporter.CollectLoop()
 	}
 	level.Info(logger).Log("msg", "Will MSK metrics be gathered?", "msk-enabled", config.MskConfig.Enabled)
-	var mskSessions []*session.Session
 	if config.MskConfig.Enabled {
-		for _, region := range config.MskConfig.Regions {
-			config := aws.NewConfig().WithRegion(region)
-			sess := session.Must(session.NewSession(config))
-			mskSessions = append(mskSessions, sess)
+		for _, mskConfig := range pkg.SplitByRegionOverrides(config.MskConfig) {
+			mskSessions, err := config.AWS.NewSessions(mskConfig.AssumeRole, mskConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			mskExporter := pkg.NewMSKExporter(mskSessions, logger, mskConfig, awsAccountId, eolChecker)
+			collectors = append(collectors, loops.add("msk", mskConfig.BaseConfig, mskExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will DynamoDB metrics be gathered?", "dynamodb-enabled", config.DynamoDBConfig.Enabled)
+	if config.DynamoDBConfig.Enabled {
+		for _, dynamodbConfig := range pkg.SplitByRegionOverrides(config.DynamoDBConfig) {
+			dynamodbSessions, err := config.AWS.NewSessions(dynamodbConfig.AssumeRole, dynamodbConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			dynamodbExporter := pkg.NewDynamoDBExporter(dynamodbSessions, logger, dynamodbConfig, awsAccountId)
+			collectors = append(collectors, loops.add("dynamodb", dynamodbConfig.BaseConfig, dynamodbExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will ELB metrics be gathered?", "elb-enabled", config.ELBConfig.Enabled)
+	if config.ELBConfig.Enabled {
+		for _, elbConfig := range pkg.SplitByRegionOverrides(config.ELBConfig) {
+			elbSessions, err := config.AWS.NewSessions(elbConfig.AssumeRole, elbConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			elbExporter := pkg.NewELBExporter(elbSessions, logger, elbConfig, awsAccountId)
+			collectors = append(collectors, loops.add("elb", elbConfig.BaseConfig, elbExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will EBS metrics be gathered?", "ebs-enabled", config.EBSConfig.Enabled)
+	if config.EBSConfig.Enabled {
+		for _, ebsConfig := range pkg.SplitByRegionOverrides(config.EBSConfig) {
+			ebsSessions, err := config.AWS.NewSessions(ebsConfig.AssumeRole, ebsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			ebsExporter := pkg.NewEBSExporter(ebsSessions, logger, ebsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("ebs", ebsConfig.BaseConfig, ebsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Lambda metrics be gathered?", "lambda-enabled", config.LambdaConfig.Enabled)
+	if config.LambdaConfig.Enabled {
+		for _, lambdaConfig := range pkg.SplitByRegionOverrides(config.LambdaConfig) {
+			lambdaSessions, err := config.AWS.NewSessions(lambdaConfig.AssumeRole, lambdaConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			lambdaExporter := pkg.NewLambdaExporter(lambdaSessions, logger, lambdaConfig, awsAccountId)
+			collectors = append(collectors, loops.add("lambda", lambdaConfig.BaseConfig, lambdaExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will SQS/SNS metrics be gathered?", "sqs_sns-enabled", config.SQSSNSConfig.Enabled)
+	if config.SQSSNSConfig.Enabled {
+		for _, sqsSnsConfig := range pkg.SplitByRegionOverrides(config.SQSSNSConfig) {
+			sqsSnsSessions, err := config.AWS.NewSessions(sqsSnsConfig.AssumeRole, sqsSnsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			sqsSnsExporter := pkg.NewSQSSNSExporter(sqsSnsSessions, logger, sqsSnsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("sqs_sns", sqsSnsConfig.BaseConfig, sqsSnsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will EKS metrics be gathered?", "eks-enabled", config.EKSConfig.Enabled)
+	if config.EKSConfig.Enabled {
+		for _, eksConfig := range pkg.SplitByRegionOverrides(config.EKSConfig) {
+			eksSessions, err := config.AWS.NewSessions(eksConfig.AssumeRole, eksConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			eksExporter := pkg.NewEKSExporter(eksSessions, logger, eksConfig, awsAccountId, eolChecker)
+			collectors = append(collectors, loops.add("eks", eksConfig.BaseConfig, eksExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will OpenSearch metrics be gathered?", "opensearch-enabled", config.OpenSearchConfig.Enabled)
+	if config.OpenSearchConfig.Enabled {
+		for _, openSearchConfig := range pkg.SplitByRegionOverrides(config.OpenSearchConfig) {
+			openSearchSessions, err := config.AWS.NewSessions(openSearchConfig.AssumeRole, openSearchConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			openSearchExporter := pkg.NewOpenSearchExporter(openSearchSessions, logger, openSearchConfig, awsAccountId, eolChecker)
+			collectors = append(collectors, loops.add("opensearch", openSearchConfig.BaseConfig, openSearchExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will IAM metrics be gathered?", "iam-enabled", config.IAMConfig.Enabled)
+	if config.IAMConfig.Enabled {
+		iamSession, err := config.AWS.NewSession(config.IAMConfig.AssumeRole, config.IAMConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		iamExporter := pkg.NewIAMExporter(iamSession, logger, config.IAMConfig, awsAccountId)
+		collectors = append(collectors, loops.add("iam", config.IAMConfig.BaseConfig, iamExporter))
+	}
+	level.Info(logger).Log("msg", "Will ACM metrics be gathered?", "acm-enabled", config.ACMConfig.Enabled)
+	if config.ACMConfig.Enabled {
+		for _, acmConfig := range pkg.SplitByRegionOverrides(config.ACMConfig) {
+			acmSessions, err := config.AWS.NewSessions(acmConfig.AssumeRole, acmConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			acmExporter := pkg.NewACMExporter(acmSessions, logger, acmConfig, awsAccountId)
+			collectors = append(collectors, loops.add("acm", acmConfig.BaseConfig, acmExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will KMS metrics be gathered?", "kms-enabled", config.KMSConfig.Enabled)
+	if config.KMSConfig.Enabled {
+		for _, kmsConfig := range pkg.SplitByRegionOverrides(config.KMSConfig) {
+			kmsSessions, err := config.AWS.NewSessions(kmsConfig.AssumeRole, kmsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			kmsExporter := pkg.NewKMSExporter(kmsSessions, logger, kmsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("kms", kmsConfig.BaseConfig, kmsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Secrets Manager metrics be gathered?", "secretsmanager-enabled", config.SecretsManagerConfig.Enabled)
+	if config.SecretsManagerConfig.Enabled {
+		for _, secretsManagerConfig := range pkg.SplitByRegionOverrides(config.SecretsManagerConfig) {
+			secretsManagerSessions, err := config.AWS.NewSessions(secretsManagerConfig.AssumeRole, secretsManagerConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			secretsManagerExporter := pkg.NewSecretsManagerExporter(secretsManagerSessions, logger, secretsManagerConfig, awsAccountId)
+			collectors = append(collectors, loops.add("secretsmanager", secretsManagerConfig.BaseConfig, secretsManagerExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will SSM metrics be gathered?", "ssm-enabled", config.SSMConfig.Enabled)
+	if config.SSMConfig.Enabled {
+		for _, ssmConfig := range pkg.SplitByRegionOverrides(config.SSMConfig) {
+			ssmSessions, err := config.AWS.NewSessions(ssmConfig.AssumeRole, ssmConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			ssmExporter := pkg.NewSSMExporter(ssmSessions, logger, ssmConfig, awsAccountId)
+			collectors = append(collectors, loops.add("ssm", ssmConfig.BaseConfig, ssmExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will CloudFront metrics be gathered?", "cloudfront-enabled", config.CloudFrontConfig.Enabled)
+	if config.CloudFrontConfig.Enabled {
+		cloudFrontSession, err := config.AWS.NewSession(config.CloudFrontConfig.AssumeRole, config.CloudFrontConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cloudFrontExporter := pkg.NewCloudFrontExporter(cloudFrontSession, logger, config.CloudFrontConfig, awsAccountId)
+		collectors = append(collectors, loops.add("cloudfront", config.CloudFrontConfig.BaseConfig, cloudFrontExporter))
+	}
+	level.Info(logger).Log("msg", "Will EFS metrics be gathered?", "efs-enabled", config.EFSConfig.Enabled)
+	if config.EFSConfig.Enabled {
+		for _, efsConfig := range pkg.SplitByRegionOverrides(config.EFSConfig) {
+			efsSessions, err := config.AWS.NewSessions(efsConfig.AssumeRole, efsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			efsExporter := pkg.NewEFSExporter(efsSessions, logger, efsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("efs", efsConfig.BaseConfig, efsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Redshift metrics be gathered?", "redshift-enabled", config.RedshiftConfig.Enabled)
+	if config.RedshiftConfig.Enabled {
+		for _, redshiftConfig := range pkg.SplitByRegionOverrides(config.RedshiftConfig) {
+			redshiftSessions, err := config.AWS.NewSessions(redshiftConfig.AssumeRole, redshiftConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			redshiftExporter := pkg.NewRedshiftExporter(redshiftSessions, logger, redshiftConfig, awsAccountId)
+			collectors = append(collectors, loops.add("redshift", redshiftConfig.BaseConfig, redshiftExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will API Gateway metrics be gathered?", "apigateway-enabled", config.APIGatewayConfig.Enabled)
+	if config.APIGatewayConfig.Enabled {
+		for _, apiGatewayConfig := range pkg.SplitByRegionOverrides(config.APIGatewayConfig) {
+			apiGatewaySessions, err := config.AWS.NewSessions(apiGatewayConfig.AssumeRole, apiGatewayConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			apiGatewayExporter := pkg.NewAPIGatewayExporter(apiGatewaySessions, logger, apiGatewayConfig, awsAccountId)
+			collectors = append(collectors, loops.add("apigateway", apiGatewayConfig.BaseConfig, apiGatewayExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Kinesis metrics be gathered?", "kinesis-enabled", config.KinesisConfig.Enabled)
+	if config.KinesisConfig.Enabled {
+		for _, kinesisConfig := range pkg.SplitByRegionOverrides(config.KinesisConfig) {
+			kinesisSessions, err := config.AWS.NewSessions(kinesisConfig.AssumeRole, kinesisConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			kinesisExporter := pkg.NewKinesisExporter(kinesisSessions, logger, kinesisConfig, awsAccountId)
+			collectors = append(collectors, loops.add("kinesis", kinesisConfig.BaseConfig, kinesisExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will ECR metrics be gathered?", "ecr-enabled", config.ECRConfig.Enabled)
+	if config.ECRConfig.Enabled {
+		for _, ecrConfig := range pkg.SplitByRegionOverrides(config.ECRConfig) {
+			ecrSessions, err := config.AWS.NewSessions(ecrConfig.AssumeRole, ecrConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			ecrExporter := pkg.NewECRExporter(ecrSessions, logger, ecrConfig, awsAccountId)
+			collectors = append(collectors, loops.add("ecr", ecrConfig.BaseConfig, ecrExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will ECS metrics be gathered?", "ecs-enabled", config.ECSConfig.Enabled)
+	if config.ECSConfig.Enabled {
+		for _, ecsConfig := range pkg.SplitByRegionOverrides(config.ECSConfig) {
+			ecsSessions, err := config.AWS.NewSessions(ecsConfig.AssumeRole, ecsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			ecsExporter := pkg.NewECSExporter(ecsSessions, logger, ecsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("ecs", ecsConfig.BaseConfig, ecsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will CloudWatch Logs metrics be gathered?", "cloudwatchlogs-enabled", config.CloudWatchLogsConfig.Enabled)
+	if config.CloudWatchLogsConfig.Enabled {
+		for _, cloudWatchLogsConfig := range pkg.SplitByRegionOverrides(config.CloudWatchLogsConfig) {
+			cloudWatchLogsSessions, err := config.AWS.NewSessions(cloudWatchLogsConfig.AssumeRole, cloudWatchLogsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			cloudWatchLogsExporter := pkg.NewCloudWatchLogsExporter(cloudWatchLogsSessions, logger, cloudWatchLogsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("cloudwatchlogs", cloudWatchLogsConfig.BaseConfig, cloudWatchLogsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will S3 metrics be gathered?", "s3-enabled", config.S3Config.Enabled)
+	if config.S3Config.Enabled {
+		// the buckets are counted in the first region, so the regions can't be split by overrides
+		s3Sessions, err := config.AWS.NewSessions(config.S3Config.AssumeRole, config.S3Config.Regions)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		s3Exporter := pkg.NewS3Exporter(s3Sessions, logger, config.S3Config, awsAccountId)
+		collectors = append(collectors, loops.add("s3", config.S3Config.BaseConfig, s3Exporter))
+	}
+	level.Info(logger).Log("msg", "Will Service Quotas metrics be gathered?", "servicequotas-enabled", config.ServiceQuotasConfig.Enabled)
+	if config.ServiceQuotasConfig.Enabled {
+		for _, serviceQuotasConfig := range pkg.SplitByRegionOverrides(config.ServiceQuotasConfig) {
+			serviceQuotasSessions, err := config.AWS.NewSessions(serviceQuotasConfig.AssumeRole, serviceQuotasConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			serviceQuotasExporter := pkg.NewServiceQuotasExporter(serviceQuotasSessions, logger, serviceQuotasConfig, awsAccountId)
+			collectors = append(collectors, loops.add("servicequotas", serviceQuotasConfig.BaseConfig, serviceQuotasExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Trusted Advisor metrics be gathered?", "trustedadvisor-enabled", config.TrustedAdvisorConfig.Enabled)
+	if config.TrustedAdvisorConfig.Enabled {
+		trustedAdvisorSession, err := config.AWS.NewSession(config.TrustedAdvisorConfig.AssumeRole, config.TrustedAdvisorConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		trustedAdvisorExporter := pkg.NewTrustedAdvisorExporter(trustedAdvisorSession, logger, config.TrustedAdvisorConfig, awsAccountId)
+		collectors = append(collectors, loops.add("trustedadvisor", config.TrustedAdvisorConfig.BaseConfig, trustedAdvisorExporter))
+	}
+	level.Info(logger).Log("msg", "Will Backup metrics be gathered?", "backup-enabled", config.BackupConfig.Enabled)
+	if config.BackupConfig.Enabled {
+		for _, backupConfig := range pkg.SplitByRegionOverrides(config.BackupConfig) {
+			backupSessions, err := config.AWS.NewSessions(backupConfig.AssumeRole, backupConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			backupExporter := pkg.NewBackupExporter(backupSessions, logger, backupConfig, awsAccountId)
+			collectors = append(collectors, loops.add("backup", backupConfig.BaseConfig, backupExporter))
 		}
-		mskExporter := pkg.NewMSKExporter(mskSessions, logger, config.MskConfig, awsAccountId)
-		collectors = append(collectors, mskExporter)
-		go mskExporter.CollectLoop()
+	}
+	level.Info(logger).Log("msg", "Will CloudTrail metrics be gathered?", "cloudtrail-enabled", config.CloudTrailConfig.Enabled)
+	if config.CloudTrailConfig.Enabled {
+		cloudTrailSession, err := config.AWS.NewSession(config.CloudTrailConfig.AssumeRole, config.CloudTrailConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cloudTrailExporter := pkg.NewCloudTrailExporter(cloudTrailSession, logger, config.CloudTrailConfig, awsAccountId)
+		collectors = append(collectors, loops.add("cloudtrail", config.CloudTrailConfig.BaseConfig, cloudTrailExporter))
+	}
+	level.Info(logger).Log("msg", "Will security findings metrics be gathered?", "securityfindings-enabled", config.SecurityFindingsConfig.Enabled)
+	if config.SecurityFindingsConfig.Enabled {
+		for _, securityFindingsConfig := range pkg.SplitByRegionOverrides(config.SecurityFindingsConfig) {
+			securityFindingsSessions, err := config.AWS.NewSessions(securityFindingsConfig.AssumeRole, securityFindingsConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			securityFindingsExporter := pkg.NewSecurityFindingsExporter(securityFindingsSessions, logger, securityFindingsConfig, awsAccountId)
+			collectors = append(collectors, loops.add("securityfindings", securityFindingsConfig.BaseConfig, securityFindingsExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Config metrics be gathered?", "configservice-enabled", config.ConfigServiceConfig.Enabled)
+	if config.ConfigServiceConfig.Enabled {
+		for _, configServiceConfig := range pkg.SplitByRegionOverrides(config.ConfigServiceConfig) {
+			configServiceSessions, err := config.AWS.NewSessions(configServiceConfig.AssumeRole, configServiceConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			configServiceExporter := pkg.NewConfigServiceExporter(configServiceSessions, logger, configServiceConfig, awsAccountId)
+			collectors = append(collectors, loops.add("configservice", configServiceConfig.BaseConfig, configServiceExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will WAF metrics be gathered?", "waf-enabled", config.WAFConfig.Enabled)
+	if config.WAFConfig.Enabled {
+		for _, wafConfig := range pkg.SplitByRegionOverrides(config.WAFConfig) {
+			wafSessions, err := config.AWS.NewSessions(wafConfig.AssumeRole, wafConfig.Regions)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			wafExporter := pkg.NewWAFExporter(wafSessions, logger, wafConfig, awsAccountId)
+			collectors = append(collectors, loops.add("waf", wafConfig.BaseConfig, wafExporter))
+		}
+	}
+	level.Info(logger).Log("msg", "Will Cost Explorer metrics be gathered?", "cost-enabled", config.CostConfig.Enabled)
+	if config.CostConfig.Enabled {
+		costConfig := config.CostConfig
+		if costConfig.Region == "" {
+			costConfig.Region = sessionRegion(config.AWS)
+		}
+		costSession, err := config.AWS.NewSession(costConfig.AssumeRole, costConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		costExporter := pkg.NewCostExporter(costSession, logger, costConfig, awsAccountId)
+		collectors = append(collectors, loops.add("cost", costConfig.BaseConfig, costExporter))
+	}
+	level.Info(logger).Log("msg", "Will the AWS credentials be checked?", "credentials-enabled", config.CredentialsConfig.Enabled)
+	if config.CredentialsConfig.Enabled {
+		credentialsConfig := config.CredentialsConfig
+		if credentialsConfig.Region == "" {
+			credentialsConfig.Region = sessionRegion(config.AWS)
+		}
+		credentialsSession, err := config.AWS.NewSession(credentialsConfig.AssumeRole, credentialsConfig.Region)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		credentialsExporter := pkg.NewCredentialsExporter(credentialsSession, logger, credentialsConfig, awsAccountId)
+		collectors = append(collectors, loops.add("credentials", credentialsConfig.BaseConfig, credentialsExporter))
+	}
+
+	return collectors, loops.names, config, nil
+}
+
+// healthResponse is served by the health and readiness endpoints
+type healthResponse struct {
+	Status     string                `json:"status"`
+	Collectors []pkg.CollectorStatus `json:"collectors"`
+}
+
+func writeHealthResponse(w http.ResponseWriter, code int, status string, collectors []pkg.CollectorStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(healthResponse{Status: status, Collectors: collectors})
+}
+
+// healthHandler reports whether the exporter is alive, together with the last update age of every collector
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, _ := pkg.CollectorStatuses()
+	writeHealthResponse(w, http.StatusOK, "ok", statuses)
+}
+
+// readyHandler fails until every part of every enabled collector has completed a successful loop
+func readyHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, ready := pkg.CollectorStatuses()
+	if !ready {
+		writeHealthResponse(w, http.StatusServiceUnavailable, "not ready", statuses)
+		return
+	}
+	writeHealthResponse(w, http.StatusOK, "ready", statuses)
+}
+
+// probeMux serves the health and readiness endpoints only
+func probeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", healthHandler)
+	mux.HandleFunc("/readyz", readyHandler)
+	return mux
+}
+
+var landingPageTemplate = template.Must(template.New("landing").Parse(`<html>
+<head><title>AWS Resources Exporter</title></head>
+<body>
+<h1>AWS Resources Exporter</h1>
+<p>{{.Version}}</p>
+<p><a href="{{.MetricsPath}}">Metrics</a> | <a href="/config">Configuration</a> | <a href="/healthz">Health</a> | <a href="/readyz">Readiness</a></p>
+<h2>Collectors</h2>
+<table border="1" cellpadding="4">
+<tr><th>Collector</th><th>Regions</th><th>Interval</th><th>Last update</th></tr>
+{{range .Collectors}}<tr><td>{{.Name}}</td><td>{{.Regions}}</td><td>{{.Interval}}</td><td>{{.LastUpdate}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+type landingPageCollector struct {
+	Name       string
+	Regions    string
+	Interval   string
+	LastUpdate string
+}
+
+// landingPageHandler lists the enabled collectors with their regions, intervals and last updates
+func (c *reloadableCollector) landingPageHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, _ := pkg.CollectorStatuses()
+	lastUpdateAges := map[string]*float64{}
+	for _, status := range statuses {
+		lastUpdateAges[status.Name] = status.LastUpdateAge
+	}
+
+	var collectors []landingPageCollector
+	if config := c.activeConfig(); config != nil {
+		for _, config := range config.Collectors() {
+			if !config.Enabled {
+				continue
+			}
+			collector := landingPageCollector{
+				Name:       config.Name,
+				Regions:    strings.Join(config.Regions, ", "),
+				Interval:   config.Interval.String(),
+				LastUpdate: "never",
+			}
+			if config.Mode == pkg.COLLECT_MODE_SCRAPE {
+				collector.Interval = "on scrape"
+				collector.LastUpdate = "on scrape"
+			} else if age := lastUpdateAges[config.Name]; age != nil {
+				collector.LastUpdate = (time.Duration(*age) * time.Second).String() + " ago"
+			}
+			collectors = append(collectors, collector)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	landingPageTemplate.Execute(w, struct {
+		Version     string
+		MetricsPath string
+		Collectors  []landingPageCollector
+	}{version.Info(), *metricsPath, collectors})
+}
+
+// configHandler renders the effective configuration, including the defaults, for debugging
+func (c *reloadableCollector) configHandler(w http.ResponseWriter, r *http.Request) {
+	config := c.activeConfig()
+	if config == nil {
+		http.Error(w, "no configuration loaded", http.StatusServiceUnavailable)
+		return
+	}
+	out, err := config.Sanitized()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(out)
+}
+
+// validateConfig loads the configuration and web configuration files and prints the effective configuration, e.g. to
+// check a configuration in CI before deploying it. It returns the exit code.
+func validateConfig(logger log.Logger, configFile string) int {
+	config, err := pkg.LoadExporterConfiguration(logger, configFile)
+	if err != nil {
+		level.Error(logger).Log("msg", "Invalid configuration file", "file", configFile, "err", err)
+		return 1
+	}
+	if err := web.Validate(*webConfigFile); err != nil {
+		level.Error(logger).Log("msg", "Invalid web configuration file", "file", *webConfigFile, "err", err)
+		return 1
+	}
+
+	if *validateOnAWS {
+		region := sessionRegion(config.AWS)
+		creds, err := config.AWS.RoleCredentials(nil, region)
+		if err != nil {
+			level.Error(logger).Log("msg", "Could not create AWS session", "err", err)
+			return 1
+		}
+		sess, err := session.NewSession(config.AWS.SessionConfig(region).WithCredentials(creds))
+		if err != nil {
+			level.Error(logger).Log("msg", "Could not create AWS session", "err", err)
+			return 1
+		}
+		if _, err := sess.Config.Credentials.Get(); err != nil {
+			level.Error(logger).Log("msg", "Could not resolve AWS credentials", "err", err)
+			return 1
+		}
+		identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			level.Error(logger).Log("msg", "Could not retrieve caller identity", "err", err)
+			return 1
+		}
+		level.Info(logger).Log("msg", "AWS credentials are valid", "account", aws.StringValue(identity.Account), "arn", aws.StringValue(identity.Arn))
 	}
 
-	return collectors, nil
+	out, err := config.Sanitized()
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not render configuration", "err", err)
+		return 1
+	}
+	os.Stdout.Write(out)
+	return 0
+}
+
+// collectAllOnce collects the metrics of all enabled collectors a single time and prints them in the text exposition
+// format, e.g. to check the IAM permissions without deploying the exporter. It returns a non-zero exit code if any
+// collection failed.
+func collectAllOnce(logger log.Logger, configFile string) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs, names, config, err := setupCollectors(ctx, &sync.WaitGroup{}, logger, configFile, true)
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not load configuration file", "err", err)
+		return 1
+	}
+	collectors := &reloadableCollector{}
+	collectors.replace(cs, names, config, cancel)
+
+	// the exporters are collected first, so the collector metrics include their results
+	exporters := prometheus.NewRegistry()
+	exporters.MustRegister(collectors)
+	collectorMetrics := prometheus.NewRegistry()
+	collectorMetrics.MustRegister(awsclient.AwsExporterMetrics)
+	collectorMetrics.MustRegister(pkg.CollectorMetrics()...)
+	families, err := prometheus.Gatherers{exporters, collectorMetrics}.Gather()
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not gather metrics", "err", err)
+		return 1
+	}
+
+	status := 0
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(os.Stdout, family); err != nil {
+			level.Error(logger).Log("msg", "Could not write metrics", "err", err)
+			return 1
+		}
+		if family.GetName() != namespace+"_collector_success" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetGauge().GetValue() == 0 {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				level.Error(logger).Log("msg", "Collection failed", "collector", labels["collector"], "region", labels["aws_region"])
+				status = 1
+			}
+		}
+	}
+	return status
+}
+
+// enableDebugHandlers serves pprof, e.g. to find leaking goroutines, and replaces the default Go collector with one
+// exposing all runtime metrics
+func enableDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	prometheus.Unregister(collectors.NewGoCollector())
+	prometheus.MustRegister(collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(collectors.MetricsAll)))
 }
 
 func run() int {
@@ -165,45 +875,120 @@ func run() int {
 	} else {
 		configFile = CONFIG_FILE_PATH
 	}
-	cs, err := setupCollectors(logger, configFile)
+	if *validateOnly {
+		return validateConfig(logger, configFile)
+	}
+	if *collectOnce {
+		return collectAllOnce(logger, configFile)
+	}
+	collectLoops := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cs, names, config, err := setupCollectors(ctx, collectLoops, logger, configFile, false)
 	if err != nil {
+		cancel()
 		level.Error(logger).Log("msg", "Could not load configuration file", "err", err)
 		return 1
 	}
-	collectors := append(cs, awsclient.AwsExporterMetrics)
-	prometheus.MustRegister(
-		collectors...,
-	)
-
-	http.Handle(*metricsPath, promhttp.Handler())
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(`<html>
-             <head><title>AWS Resources Exporter</title></head>
-             <body>
-             <h1>AWS Resources Exporter</h1>
-             <p><a href='` + *metricsPath + `'>Metrics</a></p>
-             </body>
-             </html>`))
-	})
-
-	srv := http.Server{Addr: *listenAddress}
+	shutdownTracing, err := pkg.SetupTracing(ctx, config.Tracing, version.Version)
+	if err != nil {
+		cancel()
+		level.Error(logger).Log("msg", "Could not set up tracing", "err", err)
+		return 1
+	}
+	collectors := &reloadableCollector{}
+	collectors.replace(cs, names, config, cancel)
+	prometheus.MustRegister(collectors, awsclient.AwsExporterMetrics)
+	prometheus.MustRegister(pkg.CollectorMetrics()...)
+
+	pushCtx, stopPush := context.WithCancel(context.Background())
+	defer stopPush()
+	pushDone := make(chan struct{})
+	if config.Push.URL != "" {
+		level.Info(logger).Log("msg", "Pushing metrics", "type", config.Push.Type, "url", config.Push.URL, "interval", config.Push.Interval)
+		go func() {
+			defer close(pushDone)
+			pkg.NewPusher(logger, config.Push, prometheus.DefaultGatherer).PushLoop(pushCtx)
+		}()
+	} else {
+		close(pushDone)
+	}
+
+	// a dedicated mux, so the debug endpoints are only served if enabled
+	mux := http.NewServeMux()
+	mux.Handle(*metricsPath, promhttp.Handler())
+	mux.HandleFunc("/healthz", healthHandler)
+	mux.HandleFunc("/readyz", readyHandler)
+	mux.HandleFunc("/config", collectors.configHandler)
+	mux.HandleFunc("/", collectors.landingPageHandler)
+	if *enableDebug {
+		level.Info(logger).Log("msg", "Exposing pprof and the Go runtime metrics")
+		enableDebugHandlers(mux)
+	}
+
+	// TLS and basic auth are applied by the exporter toolkit to the whole server, so the probes, which have no
+	// credentials, can be served by a separate server
+	srv := http.Server{Handler: mux}
+	probeSrv := http.Server{Addr: *probeAddress, Handler: probeMux()}
 	srvc := make(chan struct{})
 	term := make(chan os.Signal, 1)
 	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
 
 	go func() {
 		level.Info(logger).Log("msg", "Starting HTTP server", "address", *listenAddress)
-		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		err := web.ListenAndServe(&srv, &web.FlagConfig{WebListenAddresses: &[]string{*listenAddress}, WebConfigFile: webConfigFile}, logger)
+		if err != http.ErrServerClosed {
 			level.Error(logger).Log("msg", "Error starting HTTP server", "err", err)
 			close(srvc)
 		}
 	}()
+	if *probeAddress != "" {
+		go func() {
+			level.Info(logger).Log("msg", "Starting probe HTTP server", "address", *probeAddress)
+			if err := probeSrv.ListenAndServe(); err != http.ErrServerClosed {
+				level.Error(logger).Log("msg", "Error starting probe HTTP server", "err", err)
+				close(srvc)
+			}
+		}()
+	}
 
 	for {
 		select {
 		case <-term:
 			level.Info(logger).Log("msg", "Received SIGTERM, exiting gracefully...")
+			// Stop the collect loops and wait for the in-flight AWS calls before the HTTP server stops
+			collectors.stop()
+			collectLoops.Wait()
+			// the final metrics are pushed once more
+			stopPush()
+			<-pushDone
+
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), DEFAULT_TIMEOUT)
+			defer shutdownCancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				level.Error(logger).Log("msg", "Could not export the remaining spans", "err", err)
+			}
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				level.Error(logger).Log("msg", "Error shutting down HTTP server", "err", err)
+				return 1
+			}
+			if err := probeSrv.Shutdown(shutdownCtx); err != nil {
+				level.Error(logger).Log("msg", "Error shutting down probe HTTP server", "err", err)
+				return 1
+			}
 			return 0
+		case <-reload:
+			level.Info(logger).Log("msg", "Received SIGHUP, reloading configuration...")
+			ctx, cancel := context.WithCancel(context.Background())
+			cs, names, config, err := setupCollectors(ctx, collectLoops, logger, configFile, false)
+			if err != nil {
+				cancel()
+				level.Error(logger).Log("msg", "Could not reload configuration file, keeping the previous configuration", "err", err)
+				continue
+			}
+			collectors.replace(cs, names, config, cancel)
+			level.Info(logger).Log("msg", "Configuration reloaded")
 		case <-srvc:
 			return 1
 		}
@@ -0,0 +1,124 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ACMExporter struct {
+	sessions                   []*session.Session
+	CertificateExpiry          *prometheus.Desc
+	CertificateInUse           *prometheus.Desc
+	CertificateRenewalEligible *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewACMExporter creates a new ACMExporter instance
+func NewACMExporter(sessions []*session.Session, logger log.Logger, config ACMConfig, awsAccountId string) *ACMExporter {
+	level.Info(logger).Log("msg", "Initializing ACM exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId}
+	labels := []string{"aws_region", "certificate_arn", "domain_name"}
+
+	return &ACMExporter{
+		sessions:                   sessions,
+		CertificateExpiry:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "acm_certificate_expiry_timestamp_seconds"), "The expiration time of the ACM certificate (UTC timestamp)", append(labels, "type", "status"), constLabels),
+		CertificateInUse:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "acm_certificate_in_use"), "Whether the ACM certificate is associated with an AWS resource", labels, constLabels),
+		CertificateRenewalEligible: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "acm_certificate_renewal_eligible"), "Whether the ACM certificate is eligible for managed renewal", labels, constLabels),
+		cache:                      *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                     logger,
+		timeout:                    *config.Timeout,
+		interval:                   *config.Interval,
+	}
+}
+
+func (e *ACMExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.CertificateExpiry
+	ch <- e.CertificateInUse
+	ch <- e.CertificateRenewalEligible
+}
+
+func (e *ACMExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ACMExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "ACM metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "acm")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ACMExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "acm", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *ACMExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	certificates, err := client.ListCertificatesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListCertificatesAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	for _, certificate := range certificates {
+		arn := aws.StringValue(certificate.CertificateArn)
+		domainName := aws.StringValue(certificate.DomainName)
+
+		// certificates which are not issued yet have no expiry
+		if certificate.NotAfter != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.CertificateExpiry, prometheus.GaugeValue, float64(certificate.NotAfter.Unix()), region, arn, domainName, aws.StringValue(certificate.Type), aws.StringValue(certificate.Status)))
+		}
+
+		inUse := 0.0
+		if aws.BoolValue(certificate.InUse) {
+			inUse = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.CertificateInUse, prometheus.GaugeValue, inUse, region, arn, domainName))
+
+		renewalEligible := 0.0
+		if aws.StringValue(certificate.RenewalEligibility) == acm.RenewalEligibilityEligible {
+			renewalEligible = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.CertificateRenewalEligible, prometheus.GaugeValue, renewalEligible, region, arn, domainName))
+	}
+}
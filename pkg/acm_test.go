@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACMCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListCertificatesAll(ctx).Return([]*acm.CertificateSummary{
+		{
+			CertificateArn:     aws.String("arn:issued"),
+			DomainName:         aws.String("example.com"),
+			NotAfter:           aws.Time(notAfter),
+			InUse:              aws.Bool(true),
+			RenewalEligibility: aws.String(acm.RenewalEligibilityEligible),
+			Status:             aws.String(acm.CertificateStatusIssued),
+			Type:               aws.String(acm.CertificateTypeAmazonIssued),
+		},
+		{
+			CertificateArn: aws.String("arn:pending"),
+			DomainName:     aws.String("pending.example.com"),
+			Status:         aws.String(acm.CertificateStatusPendingValidation),
+		},
+	}, nil)
+
+	e := NewACMExporter(nil, log.NewNopLogger(), ACMConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("acm", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// the pending certificate has no expiry yet
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 5)
+	for _, metric := range metrics {
+		if metric.Desc().String() == e.CertificateExpiry.String() {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, float64(notAfter.Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestACMCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListCertificatesAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewACMExporter(nil, log.NewNopLogger(), ACMConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("acm", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -0,0 +1,269 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	apiGatewayServiceCode     string = "apigateway"
+	regionalRestApisQuotaCode string = "L-AA0FF27B"
+	edgeRestApisQuotaCode     string = "L-B97207D0"
+	privateRestApisQuotaCode  string = "L-B4B8A3B5"
+	routesPerApiQuotaCode     string = "L-E2A7B2B8"
+	usagePlansQuotaCode       string = "L-E8693075"
+	apiKeysQuotaCode          string = "L-01C8A9E0"
+	customDomainsQuotaCode    string = "L-A93447B8"
+)
+
+// restApisQuotaCodes maps the endpoint types to the quota of the REST APIs per region
+var restApisQuotaCodes = map[string]string{
+	apigateway.EndpointTypeRegional: regionalRestApisQuotaCode,
+	apigateway.EndpointTypeEdge:     edgeRestApisQuotaCode,
+	apigateway.EndpointTypePrivate:  privateRestApisQuotaCode,
+}
+
+type APIGatewayExporter struct {
+	sessions                 []*session.Session
+	RestApisQuota            *prometheus.Desc
+	RestApisUsage            *prometheus.Desc
+	RestApisUtilization      *prometheus.Desc
+	HTTPApisUsage            *prometheus.Desc
+	RoutesPerApiQuota        *prometheus.Desc
+	RoutesPerApiUsage        *prometheus.Desc
+	RoutesPerApiUtilization  *prometheus.Desc
+	UsagePlansQuota          *prometheus.Desc
+	UsagePlansUsage          *prometheus.Desc
+	UsagePlansUtilization    *prometheus.Desc
+	ApiKeysQuota             *prometheus.Desc
+	ApiKeysUsage             *prometheus.Desc
+	ApiKeysUtilization       *prometheus.Desc
+	CustomDomainsQuota       *prometheus.Desc
+	CustomDomainsUsage       *prometheus.Desc
+	CustomDomainsUtilization *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewAPIGatewayExporter creates a new APIGatewayExporter instance
+func NewAPIGatewayExporter(sessions []*session.Session, logger log.Logger, config APIGatewayConfig, awsAccountId string) *APIGatewayExporter {
+	level.Info(logger).Log("msg", "Initializing API Gateway exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: apiGatewayServiceCode}
+
+	routesPerApiLabels := WithKeyValue(constLabels, QUOTA_CODE_KEY, routesPerApiQuotaCode)
+	usagePlansLabels := WithKeyValue(constLabels, QUOTA_CODE_KEY, usagePlansQuotaCode)
+	apiKeysLabels := WithKeyValue(constLabels, QUOTA_CODE_KEY, apiKeysQuotaCode)
+	customDomainsLabels := WithKeyValue(constLabels, QUOTA_CODE_KEY, customDomainsQuotaCode)
+
+	return &APIGatewayExporter{
+		sessions:                 sessions,
+		RestApisQuota:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_restapis_quota"), "Quota for maximum number of REST APIs of an endpoint type in this region", []string{"aws_region", "endpoint_type", QUOTA_CODE_KEY}, constLabels),
+		RestApisUsage:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_restapis_usage"), "Number of REST APIs of an endpoint type in this region", []string{"aws_region", "endpoint_type", QUOTA_CODE_KEY}, constLabels),
+		RestApisUtilization:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_restapis_quota_utilization_ratio"), "Number of REST APIs of an endpoint type in this region relative to the quota", []string{"aws_region", "endpoint_type", QUOTA_CODE_KEY}, constLabels),
+		HTTPApisUsage:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_httpapis_usage"), "Number of HTTP and WebSocket APIs of a protocol type in this region", []string{"aws_region", "protocol_type"}, constLabels),
+		RoutesPerApiQuota:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_routesperapi_quota"), "Quota for maximum number of routes per HTTP or WebSocket API", []string{"aws_region"}, routesPerApiLabels),
+		RoutesPerApiUsage:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_routesperapi_usage"), "Number of routes of the HTTP or WebSocket API", []string{"aws_region", "api_id", "api_name"}, routesPerApiLabels),
+		RoutesPerApiUtilization:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_routesperapi_quota_utilization_ratio"), "Number of routes of the HTTP or WebSocket API relative to the quota", []string{"aws_region", "api_id", "api_name"}, routesPerApiLabels),
+		UsagePlansQuota:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_usageplans_quota"), "Quota for maximum number of usage plans in this region", []string{"aws_region"}, usagePlansLabels),
+		UsagePlansUsage:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_usageplans_usage"), "Number of usage plans in this region", []string{"aws_region"}, usagePlansLabels),
+		UsagePlansUtilization:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_usageplans_quota_utilization_ratio"), "Number of usage plans in this region relative to the quota", []string{"aws_region"}, usagePlansLabels),
+		ApiKeysQuota:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_apikeys_quota"), "Quota for maximum number of API keys in this region", []string{"aws_region"}, apiKeysLabels),
+		ApiKeysUsage:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_apikeys_usage"), "Number of API keys in this region", []string{"aws_region"}, apiKeysLabels),
+		ApiKeysUtilization:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_apikeys_quota_utilization_ratio"), "Number of API keys in this region relative to the quota", []string{"aws_region"}, apiKeysLabels),
+		CustomDomainsQuota:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_customdomains_quota"), "Quota for maximum number of custom domain names in this region", []string{"aws_region"}, customDomainsLabels),
+		CustomDomainsUsage:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_customdomains_usage"), "Number of custom domain names in this region", []string{"aws_region"}, customDomainsLabels),
+		CustomDomainsUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "apigateway_customdomains_quota_utilization_ratio"), "Number of custom domain names in this region relative to the quota", []string{"aws_region"}, customDomainsLabels),
+		cache:                    *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                   logger,
+		timeout:                  *config.Timeout,
+		interval:                 *config.Interval,
+	}
+}
+
+func (e *APIGatewayExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.RestApisQuota
+	ch <- e.RestApisUsage
+	ch <- e.RestApisUtilization
+	ch <- e.HTTPApisUsage
+	ch <- e.RoutesPerApiQuota
+	ch <- e.RoutesPerApiUsage
+	ch <- e.RoutesPerApiUtilization
+	ch <- e.UsagePlansQuota
+	ch <- e.UsagePlansUsage
+	ch <- e.UsagePlansUtilization
+	ch <- e.ApiKeysQuota
+	ch <- e.ApiKeysUsage
+	ch <- e.ApiKeysUtilization
+	ch <- e.CustomDomainsQuota
+	ch <- e.CustomDomainsUsage
+	ch <- e.CustomDomainsUtilization
+}
+
+func (e *APIGatewayExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *APIGatewayExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "API Gateway metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "apigateway")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *APIGatewayExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "apigateway", region)
+	defer run.finish()
+
+	quotas := e.collectQuotaMetrics(client, ctx, region, run)
+	e.collectRestApiMetrics(client, ctx, region, run, quotas)
+	e.collectHTTPApiMetrics(client, ctx, region, run, quotas)
+}
+
+// collectQuotaMetrics exports the quotas of the region and returns them by quota code. Quotas which couldn't be looked
+// up are missing, so their utilization isn't exported.
+func (e *APIGatewayExporter) collectQuotaMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) map[string]float64 {
+	quotas := map[string]float64{}
+	for endpointType, quotaCode := range restApisQuotaCodes {
+		quota, err := getQuotaValueWithContext(client, apiGatewayServiceCode, quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve API Gateway REST APIs quota", "region", region, "endpoint_type", endpointType, "err", err)
+			run.fail()
+			continue
+		}
+		quotas[quotaCode] = quota
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RestApisQuota, prometheus.GaugeValue, quota, region, endpointType, quotaCode))
+	}
+
+	for desc, quotaCode := range map[*prometheus.Desc]string{
+		e.RoutesPerApiQuota:  routesPerApiQuotaCode,
+		e.UsagePlansQuota:    usagePlansQuotaCode,
+		e.ApiKeysQuota:       apiKeysQuotaCode,
+		e.CustomDomainsQuota: customDomainsQuotaCode,
+	} {
+		quota, err := getQuotaValueWithContext(client, apiGatewayServiceCode, quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve API Gateway quota", "region", region, "quota_code", quotaCode, "err", err)
+			run.fail()
+			continue
+		}
+		quotas[quotaCode] = quota
+		e.cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, quota, region))
+	}
+	return quotas
+}
+
+func (e *APIGatewayExporter) collectRestApiMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun, quotas map[string]float64) {
+	restApis, err := client.GetRestApisAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetRestApisAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		restApisPerEndpointType := map[string]int{
+			apigateway.EndpointTypeRegional: 0,
+			apigateway.EndpointTypeEdge:     0,
+			apigateway.EndpointTypePrivate:  0,
+		}
+		for _, restApi := range restApis {
+			if restApi.EndpointConfiguration == nil {
+				continue
+			}
+			for _, endpointType := range restApi.EndpointConfiguration.Types {
+				restApisPerEndpointType[aws.StringValue(endpointType)]++
+			}
+		}
+		for endpointType, count := range restApisPerEndpointType {
+			quotaCode := restApisQuotaCodes[endpointType]
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.RestApisUsage, prometheus.GaugeValue, float64(count), region, endpointType, quotaCode))
+			e.cache.AddQuotaUtilization(e.RestApisUtilization, float64(count), quotas[quotaCode], region, endpointType, quotaCode)
+		}
+	}
+
+	usagePlans, err := client.GetUsagePlansAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetUsagePlansAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.UsagePlansUsage, prometheus.GaugeValue, float64(len(usagePlans)), region))
+		e.cache.AddQuotaUtilization(e.UsagePlansUtilization, float64(len(usagePlans)), quotas[usagePlansQuotaCode], region)
+	}
+
+	apiKeys, err := client.GetApiKeysAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetApiKeysAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ApiKeysUsage, prometheus.GaugeValue, float64(len(apiKeys)), region))
+		e.cache.AddQuotaUtilization(e.ApiKeysUtilization, float64(len(apiKeys)), quotas[apiKeysQuotaCode], region)
+	}
+
+	domainNames, err := client.GetDomainNamesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetDomainNamesAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.CustomDomainsUsage, prometheus.GaugeValue, float64(len(domainNames)), region))
+		e.cache.AddQuotaUtilization(e.CustomDomainsUtilization, float64(len(domainNames)), quotas[customDomainsQuotaCode], region)
+	}
+}
+
+func (e *APIGatewayExporter) collectHTTPApiMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun, quotas map[string]float64) {
+	apis, err := client.GetHTTPApisAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetHTTPApisAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	apisPerProtocolType := map[string]int{}
+	for _, api := range apis {
+		apisPerProtocolType[aws.StringValue(api.ProtocolType)]++
+
+		routes, err := client.GetRoutesAll(ctx, aws.StringValue(api.ApiId))
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetRoutesAll failed", "region", region, "api", aws.StringValue(api.ApiId), "err", err)
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RoutesPerApiUsage, prometheus.GaugeValue, float64(len(routes)), region, aws.StringValue(api.ApiId), aws.StringValue(api.Name)))
+		e.cache.AddQuotaUtilization(e.RoutesPerApiUtilization, float64(len(routes)), quotas[routesPerApiQuotaCode], region, aws.StringValue(api.ApiId), aws.StringValue(api.Name))
+	}
+	for protocolType, count := range apisPerProtocolType {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.HTTPApisUsage, prometheus.GaugeValue, float64(count), region, protocolType))
+	}
+}
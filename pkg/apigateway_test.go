@@ -0,0 +1,159 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIGatewayCollectQuotaMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(apiGatewayServiceCode, regionalRestApisQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(600)}}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(apiGatewayServiceCode, routesPerApiQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(300)}}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, gomock.Any()).Return(nil, errors.New("some error")).Times(5)
+
+	e := NewAPIGatewayExporter(nil, log.NewNopLogger(), APIGatewayConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("apigateway", "foo")
+	quotas := e.collectQuotaMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+
+	// quotas which couldn't be looked up are skipped
+	assert.Equal(t, map[string]float64{regionalRestApisQuotaCode: 600, routesPerApiQuotaCode: 300}, quotas)
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.RestApisQuota.String():
+			assert.Equal(t, 600.0, dtoMetric.GetGauge().GetValue())
+		case e.RoutesPerApiQuota.String():
+			assert.Equal(t, 300.0, dtoMetric.GetGauge().GetValue())
+		default:
+			t.Errorf("unexpected metric %s", metric.Desc().String())
+		}
+	}
+}
+
+func TestAPIGatewayCollectRestApiMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetRestApisAll(ctx).Return([]*apigateway.RestApi{
+		{EndpointConfiguration: &apigateway.EndpointConfiguration{Types: aws.StringSlice([]string{apigateway.EndpointTypeRegional})}},
+		{EndpointConfiguration: &apigateway.EndpointConfiguration{Types: aws.StringSlice([]string{apigateway.EndpointTypeRegional})}},
+		{EndpointConfiguration: &apigateway.EndpointConfiguration{Types: aws.StringSlice([]string{apigateway.EndpointTypeEdge})}},
+	}, nil)
+	mockClient.EXPECT().GetUsagePlansAll(ctx).Return([]*apigateway.UsagePlan{{}}, nil)
+	mockClient.EXPECT().GetApiKeysAll(ctx).Return(nil, errors.New("some error"))
+	mockClient.EXPECT().GetDomainNamesAll(ctx).Return([]*apigateway.DomainName{{}, {}}, nil)
+
+	e := NewAPIGatewayExporter(nil, log.NewNopLogger(), APIGatewayConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("apigateway", "foo")
+	e.collectRestApiMetrics(mockClient, ctx, "foo", run, map[string]float64{regionalRestApisQuotaCode: 4, usagePlansQuotaCode: 300})
+	assert.False(t, run.finish())
+
+	// 3 endpoint types, usage plans, custom domains and the utilization of the quotas which were looked up
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 7)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.RestApisUsage.String():
+			for _, label := range dtoMetric.GetLabel() {
+				if label.GetName() != "endpoint_type" {
+					continue
+				}
+				switch label.GetValue() {
+				case apigateway.EndpointTypeRegional:
+					assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+				case apigateway.EndpointTypeEdge:
+					assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+				case apigateway.EndpointTypePrivate:
+					assert.Equal(t, 0.0, dtoMetric.GetGauge().GetValue())
+				}
+			}
+		case e.RestApisUtilization.String():
+			assert.Equal(t, 0.5, dtoMetric.GetGauge().GetValue())
+		case e.UsagePlansUsage.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.UsagePlansUtilization.String():
+			assert.Equal(t, 1.0/300, dtoMetric.GetGauge().GetValue())
+		case e.CustomDomainsUsage.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		default:
+			t.Errorf("unexpected metric %s", metric.Desc().String())
+		}
+	}
+}
+
+func TestAPIGatewayCollectHTTPApiMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetHTTPApisAll(ctx).Return([]*apigatewayv2.Api{
+		{ApiId: aws.String("a"), Name: aws.String("api-a"), ProtocolType: aws.String(apigatewayv2.ProtocolTypeHttp)},
+		{ApiId: aws.String("b"), Name: aws.String("api-b"), ProtocolType: aws.String(apigatewayv2.ProtocolTypeWebsocket)},
+	}, nil)
+	mockClient.EXPECT().GetRoutesAll(ctx, "a").Return([]*apigatewayv2.Route{{}, {}, {}}, nil)
+	mockClient.EXPECT().GetRoutesAll(ctx, "b").Return([]*apigatewayv2.Route{{}}, nil)
+
+	e := NewAPIGatewayExporter(nil, log.NewNopLogger(), APIGatewayConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("apigateway", "foo")
+	e.collectHTTPApiMetrics(mockClient, ctx, "foo", run, map[string]float64{routesPerApiQuotaCode: 300})
+	assert.True(t, run.finish())
+
+	// routes per API with their utilization and APIs per protocol type
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 6)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.HTTPApisUsage.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.RoutesPerApiUsage.String():
+			assert.Contains(t, []float64{1, 3}, dtoMetric.GetGauge().GetValue())
+		case e.RoutesPerApiUtilization.String():
+			assert.Contains(t, []float64{1.0 / 300, 3.0 / 300}, dtoMetric.GetGauge().GetValue())
+		default:
+			t.Errorf("unexpected metric %s", metric.Desc().String())
+		}
+	}
+}
+
+func TestAPIGatewayCollectHTTPApiMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetHTTPApisAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewAPIGatewayExporter(nil, log.NewNopLogger(), APIGatewayConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("apigateway", "foo")
+	e.collectHTTPApiMetrics(mockClient, ctx, "foo", run, map[string]float64{})
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
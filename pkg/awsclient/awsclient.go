@@ -4,13 +4,82 @@ package awsclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/aws/aws-sdk-go/service/backup/backupiface"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/configservice/configserviceiface"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/aws/aws-sdk-go/service/guardduty/guarddutyiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kafkaconnect"
+	"github.com/aws/aws-sdk-go/service/kafkaconnect/kafkaconnectiface"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
+	"github.com/aws/aws-sdk-go/service/opensearchservice/opensearchserviceiface"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/redshift/redshiftiface"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/savingsplans"
+	"github.com/aws/aws-sdk-go/service/savingsplans/savingsplansiface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/aws/aws-sdk-go/service/securityhub/securityhubiface"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/aws/aws-sdk-go/service/support/supportiface"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -26,6 +95,27 @@ import (
 type Client interface {
 	//EC2
 	DescribeTransitGatewaysWithContext(ctx aws.Context, input *ec2.DescribeTransitGatewaysInput, opts ...request.Option) (*ec2.DescribeTransitGatewaysOutput, error)
+	DescribeAddressesWithContext(ctx aws.Context, input *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error)
+	DescribeNatGatewaysAll(ctx context.Context) ([]*ec2.NatGateway, error)
+	DescribeSubnetsAll(ctx context.Context) ([]*ec2.Subnet, error)
+	DescribeInstanceTypesWithContext(ctx aws.Context, input *ec2.DescribeInstanceTypesInput, opts ...request.Option) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeInstancesAll(ctx context.Context) ([]*ec2.Instance, error)
+	DescribeActiveReservedInstances(ctx context.Context) ([]*ec2.ReservedInstances, error)
+	DescribeSpotInstanceRequestsAll(ctx context.Context, states ...string) ([]*ec2.SpotInstanceRequest, error)
+	DescribeCapacityReservationsAll(ctx context.Context) ([]*ec2.CapacityReservation, error)
+	GetSpotPlacementScoresAll(ctx context.Context, input *ec2.GetSpotPlacementScoresInput) ([]*ec2.SpotPlacementScore, error)
+	DescribeOwnedImagesAll(ctx context.Context) ([]*ec2.Image, error)
+	DescribeVpcsAll(ctx context.Context) ([]*ec2.Vpc, error)
+	DescribeRouteTablesAll(ctx context.Context) ([]*ec2.RouteTable, error)
+	DescribeVpcEndpointsAll(ctx context.Context) ([]*ec2.VpcEndpoint, error)
+	DescribeNetworkInterfacesAll(ctx context.Context) ([]*ec2.NetworkInterface, error)
+	DescribeInternetGatewaysAll(ctx context.Context) ([]*ec2.InternetGateway, error)
+	DescribeTransitGatewayVpcAttachmentsAll(ctx context.Context) ([]*ec2.TransitGatewayVpcAttachment, error)
+	DescribeVpcPeeringConnectionsAll(ctx context.Context) ([]*ec2.VpcPeeringConnection, error)
+	DescribeSecurityGroupsAll(ctx context.Context) ([]*ec2.SecurityGroup, error)
+	DescribeVolumesAll(ctx context.Context) ([]*ec2.Volume, error)
+	DescribeSnapshotsAll(ctx context.Context) ([]*ec2.Snapshot, error)
+	DescribeRegionsWithContext(ctx aws.Context, input *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error)
 
 	//RDS
 	DescribeDBInstancesPagesWithContext(ctx aws.Context, input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool, opts ...request.Option) error
@@ -34,6 +124,12 @@ type Client interface {
 	DescribeDBLogFilesAll(ctx context.Context, instanceId string) ([]*rds.DescribeDBLogFilesOutput, error)
 	DescribePendingMaintenanceActionsAll(ctx context.Context) ([]*rds.ResourcePendingMaintenanceActions, error)
 	DescribeDBInstancesAll(ctx context.Context) ([]*rds.DBInstance, error)
+	DescribeDBClustersAll(ctx context.Context) ([]*rds.DBCluster, error)
+	DescribeCertificatesAll(ctx context.Context) ([]*rds.Certificate, error)
+	DescribeReservedDBInstancesAll(ctx context.Context) ([]*rds.ReservedDBInstance, error)
+	DescribeDBSnapshotsAll(ctx context.Context, snapshotType string) ([]*rds.DBSnapshot, error)
+	DescribeDBParameterGroupsAll(ctx context.Context) ([]*rds.DBParameterGroup, error)
+	DescribeDBParametersAll(ctx context.Context, parameterGroupName string) ([]*rds.Parameter, error)
 
 	// Service Quota
 	GetServiceQuotaWithContext(ctx aws.Context, input *servicequotas.GetServiceQuotaInput, opts ...request.Option) (*servicequotas.GetServiceQuotaOutput, error)
@@ -41,21 +137,196 @@ type Client interface {
 	//route53
 	ListHostedZonesWithContext(ctx context.Context, input *route53.ListHostedZonesInput, opts ...request.Option) (*route53.ListHostedZonesOutput, error)
 	GetHostedZoneLimitWithContext(ctx context.Context, input *route53.GetHostedZoneLimitInput, opts ...request.Option) (*route53.GetHostedZoneLimitOutput, error)
+	GetAccountLimitWithContext(ctx context.Context, input *route53.GetAccountLimitInput, opts ...request.Option) (*route53.GetAccountLimitOutput, error)
+	ListResourceRecordSetsWithContext(ctx context.Context, input *route53.ListResourceRecordSetsInput, opts ...request.Option) (*route53.ListResourceRecordSetsOutput, error)
+	GetHealthCheckStatusWithContext(ctx context.Context, input *route53.GetHealthCheckStatusInput, opts ...request.Option) (*route53.GetHealthCheckStatusOutput, error)
+	ListHealthChecksAll(ctx context.Context) ([]*route53.HealthCheck, error)
 
 	// ElastiCache
 	DescribeCacheClustersAll(ctx context.Context) ([]*elasticache.CacheCluster, error)
+	DescribeServerlessCachesAll(ctx context.Context) ([]*elasticache.ServerlessCache, error)
 
 	// MSK
 	ListClustersAll(ctx context.Context) ([]*kafka.ClusterInfo, error)
+	ListServerlessClustersAll(ctx context.Context) ([]*kafka.Cluster, error)
+
+	// MSK Connect
+	ListConnectorsAll(ctx context.Context) ([]*kafkaconnect.ConnectorSummary, error)
+
+	// DynamoDB
+	ListTablesAll(ctx context.Context) ([]*string, error)
+	DescribeTableWithContext(ctx aws.Context, input *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error)
+
+	// ELB
+	DescribeLoadBalancersAll(ctx context.Context) ([]*elbv2.LoadBalancer, error)
+	DescribeListenersAll(ctx context.Context, loadBalancerArn string) ([]*elbv2.Listener, error)
+	DescribeTargetGroupsAll(ctx context.Context) ([]*elbv2.TargetGroup, error)
+
+	// Lambda
+	GetAccountSettingsWithContext(ctx aws.Context, input *lambda.GetAccountSettingsInput, opts ...request.Option) (*lambda.GetAccountSettingsOutput, error)
+
+	// SQS
+	ListQueuesAll(ctx context.Context) ([]*string, error)
+
+	// SNS
+	ListTopicsAll(ctx context.Context) ([]*sns.Topic, error)
+	ListSubscriptionsAll(ctx context.Context) ([]*sns.Subscription, error)
+
+	// EKS
+	ListEKSClustersAll(ctx context.Context) ([]*string, error)
+	DescribeClusterWithContext(ctx aws.Context, input *eks.DescribeClusterInput, opts ...request.Option) (*eks.DescribeClusterOutput, error)
+
+	// OpenSearch
+	ListDomainNamesWithContext(ctx aws.Context, input *opensearchservice.ListDomainNamesInput, opts ...request.Option) (*opensearchservice.ListDomainNamesOutput, error)
+	DescribeDomainsWithContext(ctx aws.Context, input *opensearchservice.DescribeDomainsInput, opts ...request.Option) (*opensearchservice.DescribeDomainsOutput, error)
+
+	// IAM
+	GetAccountSummaryWithContext(ctx aws.Context, input *iam.GetAccountSummaryInput, opts ...request.Option) (*iam.GetAccountSummaryOutput, error)
+	GenerateCredentialReportWithContext(ctx aws.Context, input *iam.GenerateCredentialReportInput, opts ...request.Option) (*iam.GenerateCredentialReportOutput, error)
+	GetCredentialReportWithContext(ctx aws.Context, input *iam.GetCredentialReportInput, opts ...request.Option) (*iam.GetCredentialReportOutput, error)
+	GetRoleDetailsAll(ctx context.Context) ([]*iam.RoleDetail, error)
+
+	// ACM
+	ListCertificatesAll(ctx context.Context) ([]*acm.CertificateSummary, error)
+
+	// KMS
+	ListKeysAll(ctx context.Context) ([]*kms.KeyListEntry, error)
+	DescribeKeyWithContext(ctx aws.Context, input *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error)
+	GetKeyRotationStatusWithContext(ctx aws.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error)
+
+	// Secrets Manager
+	ListSecretsAll(ctx context.Context) ([]*secretsmanager.SecretListEntry, error)
+
+	// SSM
+	DescribeParametersAll(ctx context.Context) ([]*ssm.ParameterMetadata, error)
+
+	// CloudFront
+	ListDistributionsAll(ctx context.Context) ([]*cloudfront.DistributionSummary, error)
+
+	// EFS
+	DescribeFileSystemsAll(ctx context.Context) ([]*efs.FileSystemDescription, error)
+	DescribeMountTargetsAll(ctx context.Context, fileSystemId string) ([]*efs.MountTargetDescription, error)
+	DescribeLifecycleConfigurationWithContext(ctx aws.Context, input *efs.DescribeLifecycleConfigurationInput, opts ...request.Option) (*efs.DescribeLifecycleConfigurationOutput, error)
+
+	// Redshift
+	DescribeRedshiftClustersAll(ctx context.Context) ([]*redshift.Cluster, error)
+
+	// API Gateway
+	GetRestApisAll(ctx context.Context) ([]*apigateway.RestApi, error)
+	GetUsagePlansAll(ctx context.Context) ([]*apigateway.UsagePlan, error)
+	GetApiKeysAll(ctx context.Context) ([]*apigateway.ApiKey, error)
+	GetDomainNamesAll(ctx context.Context) ([]*apigateway.DomainName, error)
+	GetHTTPApisAll(ctx context.Context) ([]*apigatewayv2.Api, error)
+	GetRoutesAll(ctx context.Context, apiId string) ([]*apigatewayv2.Route, error)
+
+	// Kinesis
+	DescribeLimitsWithContext(ctx aws.Context, input *kinesis.DescribeLimitsInput, opts ...request.Option) (*kinesis.DescribeLimitsOutput, error)
+	DescribeStreamSummaryWithContext(ctx aws.Context, input *kinesis.DescribeStreamSummaryInput, opts ...request.Option) (*kinesis.DescribeStreamSummaryOutput, error)
+	ListStreamsAll(ctx context.Context) ([]*kinesis.StreamSummary, error)
+
+	// ECR
+	DescribeRepositoriesAll(ctx context.Context) ([]*ecr.Repository, error)
+	DescribeImagesAll(ctx context.Context, repositoryName string) ([]*ecr.ImageDetail, error)
+	GetLifecyclePolicyWithContext(ctx aws.Context, input *ecr.GetLifecyclePolicyInput, opts ...request.Option) (*ecr.GetLifecyclePolicyOutput, error)
+
+	// ECS
+	DescribeECSClustersAll(ctx context.Context) ([]*ecs.Cluster, error)
+	DescribeECSServicesAll(ctx context.Context, clusterArn string) ([]*ecs.Service, error)
+	DescribeECSRunningTasksAll(ctx context.Context, clusterArn string) ([]*ecs.Task, error)
+
+	// CloudWatch Logs
+	DescribeLogGroupsAll(ctx context.Context) ([]*cloudwatchlogs.LogGroup, error)
+
+	// S3
+	ListBucketsWithContext(ctx aws.Context, input *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error)
+
+	// CloudWatch
+	ListMetricsAll(ctx context.Context, input *cloudwatch.ListMetricsInput) ([]*cloudwatch.Metric, error)
+	GetMetricDataAll(ctx context.Context, input *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error)
+
+	// Trusted Advisor
+	DescribeTrustedAdvisorChecksWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorChecksInput, opts ...request.Option) (*support.DescribeTrustedAdvisorChecksOutput, error)
+	DescribeTrustedAdvisorCheckResultWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorCheckResultInput, opts ...request.Option) (*support.DescribeTrustedAdvisorCheckResultOutput, error)
+
+	// Savings Plans
+	DescribeActiveSavingsPlansAll(ctx context.Context) ([]*savingsplans.SavingsPlan, error)
+
+	// STS
+	GetCallerIdentityWithContext(ctx aws.Context, input *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error)
+
+	// Backup
+	ListBackupPlansAll(ctx context.Context) ([]*backup.PlansListMember, error)
+	ListProtectedResourcesAll(ctx context.Context) ([]*backup.ProtectedResource, error)
+	ListBackupJobsAll(ctx context.Context, state string, createdAfter time.Time) ([]*backup.Job, error)
+
+	// CloudTrail
+	ListTrailsAll(ctx context.Context) ([]*cloudtrail.TrailInfo, error)
+	DescribeTrailsWithContext(ctx aws.Context, input *cloudtrail.DescribeTrailsInput, opts ...request.Option) (*cloudtrail.DescribeTrailsOutput, error)
+	GetTrailStatusWithContext(ctx aws.Context, input *cloudtrail.GetTrailStatusInput, opts ...request.Option) (*cloudtrail.GetTrailStatusOutput, error)
+
+	// GuardDuty
+	ListDetectorsAll(ctx context.Context) ([]*string, error)
+	GetFindingsStatisticsWithContext(ctx aws.Context, input *guardduty.GetFindingsStatisticsInput, opts ...request.Option) (*guardduty.GetFindingsStatisticsOutput, error)
+
+	// Security Hub
+	GetEnabledStandardsAll(ctx context.Context) ([]*securityhub.StandardsSubscription, error)
+	GetFindingsAll(ctx context.Context, filters *securityhub.AwsSecurityFindingFilters) ([]*securityhub.AwsSecurityFinding, error)
+
+	// Config
+	DescribeConfigurationRecorderStatusWithContext(ctx aws.Context, input *configservice.DescribeConfigurationRecorderStatusInput, opts ...request.Option) (*configservice.DescribeConfigurationRecorderStatusOutput, error)
+	DescribeComplianceByConfigRuleAll(ctx context.Context) ([]*configservice.ComplianceByConfigRule, error)
+	GetComplianceDetailsByConfigRuleAll(ctx context.Context, configRuleName string, complianceType string) ([]*configservice.EvaluationResult, error)
+
+	// WAFv2
+	ListWebACLsAll(ctx context.Context, scope string) ([]*wafv2.WebACLSummary, error)
+	GetWebACLWithContext(ctx aws.Context, input *wafv2.GetWebACLInput, opts ...request.Option) (*wafv2.GetWebACLOutput, error)
+	ListRuleGroupsAll(ctx context.Context, scope string) ([]*wafv2.RuleGroupSummary, error)
+
+	// Cost Explorer
+	GetCostAndUsageAll(ctx context.Context, input *costexplorer.GetCostAndUsageInput) ([]*costexplorer.ResultByTime, error)
 }
 
 type awsClient struct {
-	ec2Client           ec2iface.EC2API
-	rdsClient           rds.RDS
-	serviceQuotasClient servicequotasiface.ServiceQuotasAPI
-	route53Client       route53iface.Route53API
-	elasticacheClient   elasticache.ElastiCache
-	mskClient           kafka.Kafka
+	ec2Client            ec2iface.EC2API
+	rdsClient            rds.RDS
+	serviceQuotasClient  servicequotasiface.ServiceQuotasAPI
+	route53Client        route53iface.Route53API
+	elasticacheClient    elasticache.ElastiCache
+	mskClient            kafka.Kafka
+	dynamodbClient       dynamodbiface.DynamoDBAPI
+	elbv2Client          elbv2iface.ELBV2API
+	lambdaClient         lambdaiface.LambdaAPI
+	sqsClient            sqsiface.SQSAPI
+	snsClient            snsiface.SNSAPI
+	eksClient            eksiface.EKSAPI
+	opensearchClient     opensearchserviceiface.OpenSearchServiceAPI
+	kafkaConnectClient   kafkaconnectiface.KafkaConnectAPI
+	iamClient            iamiface.IAMAPI
+	acmClient            acmiface.ACMAPI
+	kmsClient            kmsiface.KMSAPI
+	secretsManagerClient secretsmanageriface.SecretsManagerAPI
+	ssmClient            ssmiface.SSMAPI
+	cloudfrontClient     cloudfrontiface.CloudFrontAPI
+	efsClient            efsiface.EFSAPI
+	redshiftClient       redshiftiface.RedshiftAPI
+	apigatewayClient     apigatewayiface.APIGatewayAPI
+	apigatewayv2Client   apigatewayv2iface.ApiGatewayV2API
+	kinesisClient        kinesisiface.KinesisAPI
+	ecrClient            ecriface.ECRAPI
+	ecsClient            ecsiface.ECSAPI
+	cloudwatchlogsClient cloudwatchlogsiface.CloudWatchLogsAPI
+	s3Client             s3iface.S3API
+	cloudwatchClient     cloudwatchiface.CloudWatchAPI
+	supportClient        supportiface.SupportAPI
+	savingsPlansClient   savingsplansiface.SavingsPlansAPI
+	stsClient            stsiface.STSAPI
+	backupClient         backupiface.BackupAPI
+	cloudtrailClient     cloudtrailiface.CloudTrailAPI
+	guarddutyClient      guarddutyiface.GuardDutyAPI
+	securityhubClient    securityhubiface.SecurityHubAPI
+	configserviceClient  configserviceiface.ConfigServiceAPI
+	wafv2Client          wafv2iface.WAFV2API
+	costexplorerClient   costexploreriface.CostExplorerAPI
 }
 
 func (c *awsClient) DescribeTransitGatewaysWithContext(ctx aws.Context, input *ec2.DescribeTransitGatewaysInput, opts ...request.Option) (*ec2.DescribeTransitGatewaysOutput, error) {
@@ -66,6 +337,10 @@ func (c *awsClient) DescribeDBLogFilesPagesWithContext(ctx aws.Context, input *r
 	return c.rdsClient.DescribeDBLogFilesPagesWithContext(ctx, input, fn, opts...)
 }
 
+func (c *awsClient) DescribeInstanceTypesWithContext(ctx aws.Context, input *ec2.DescribeInstanceTypesInput, opts ...request.Option) (*ec2.DescribeInstanceTypesOutput, error) {
+	return c.ec2Client.DescribeInstanceTypesWithContext(ctx, input, opts...)
+}
+
 func (c *awsClient) DescribeDBInstancesPagesWithContext(ctx aws.Context, input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool, opts ...request.Option) error {
 	return c.rdsClient.DescribeDBInstancesPagesWithContext(ctx, input, fn, opts...)
 }
@@ -78,6 +353,22 @@ func (c *awsClient) GetServiceQuotaWithContext(ctx aws.Context, input *servicequ
 	return c.serviceQuotasClient.GetServiceQuotaWithContext(ctx, input, opts...)
 }
 
+func (c *awsClient) DescribeDBParametersAll(ctx context.Context, parameterGroupName string) ([]*rds.Parameter, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: &parameterGroupName,
+	}
+
+	var parameters []*rds.Parameter
+	err := c.rdsClient.DescribeDBParametersPagesWithContext(ctx, input, func(ddpo *rds.DescribeDBParametersOutput, lastPage bool) bool {
+		parameters = append(parameters, ddpo.Parameters...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
 func (c *awsClient) DescribeDBLogFilesAll(ctx context.Context, instanceId string) ([]*rds.DescribeDBLogFilesOutput, error) {
 	input := &rds.DescribeDBLogFilesInput{
 		DBInstanceIdentifier: &instanceId,
@@ -85,13 +376,11 @@ func (c *awsClient) DescribeDBLogFilesAll(ctx context.Context, instanceId string
 
 	var logOutPuts []*rds.DescribeDBLogFilesOutput
 	err := c.DescribeDBLogFilesPagesWithContext(ctx, input, func(ddlo *rds.DescribeDBLogFilesOutput, b bool) bool {
-		AwsExporterMetrics.IncrementRequests()
 		logOutPuts = append(logOutPuts, ddlo)
 		return true
 	})
 
 	if err != nil {
-		AwsExporterMetrics.IncrementErrors()
 		return nil, err
 	}
 
@@ -103,13 +392,11 @@ func (c *awsClient) DescribePendingMaintenanceActionsAll(ctx context.Context) ([
 
 	var instancesPendMaintActionsData []*rds.ResourcePendingMaintenanceActions
 	err := c.DescribePendingMaintenanceActionsPagesWithContext(ctx, describePendingMaintInput, func(dpm *rds.DescribePendingMaintenanceActionsOutput, b bool) bool {
-		AwsExporterMetrics.IncrementRequests()
 		instancesPendMaintActionsData = append(instancesPendMaintActionsData, dpm.PendingMaintenanceActions...)
 		return true
 	})
 
 	if err != nil {
-		AwsExporterMetrics.IncrementErrors()
 		return nil, err
 	}
 
@@ -121,17 +408,87 @@ func (c *awsClient) DescribeDBInstancesAll(ctx context.Context) ([]*rds.DBInstan
 
 	var instances []*rds.DBInstance
 	err := c.DescribeDBInstancesPagesWithContext(ctx, input, func(ddo *rds.DescribeDBInstancesOutput, b bool) bool {
-		AwsExporterMetrics.IncrementRequests()
 		instances = append(instances, ddo.DBInstances...)
 		return true
 	})
 	if err != nil {
-		AwsExporterMetrics.IncrementErrors()
 		return nil, err
 	}
 	return instances, nil
 }
 
+func (c *awsClient) DescribeDBClustersAll(ctx context.Context) ([]*rds.DBCluster, error) {
+	input := &rds.DescribeDBClustersInput{}
+
+	var clusters []*rds.DBCluster
+	err := c.rdsClient.DescribeDBClustersPagesWithContext(ctx, input, func(ddco *rds.DescribeDBClustersOutput, lastPage bool) bool {
+		clusters = append(clusters, ddco.DBClusters...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+func (c *awsClient) DescribeCertificatesAll(ctx context.Context) ([]*rds.Certificate, error) {
+	input := &rds.DescribeCertificatesInput{}
+
+	var certificates []*rds.Certificate
+	err := c.rdsClient.DescribeCertificatesPagesWithContext(ctx, input, func(dco *rds.DescribeCertificatesOutput, lastPage bool) bool {
+		certificates = append(certificates, dco.Certificates...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
+
+func (c *awsClient) DescribeReservedDBInstancesAll(ctx context.Context) ([]*rds.ReservedDBInstance, error) {
+	input := &rds.DescribeReservedDBInstancesInput{}
+
+	var reservedInstances []*rds.ReservedDBInstance
+	err := c.rdsClient.DescribeReservedDBInstancesPagesWithContext(ctx, input, func(drio *rds.DescribeReservedDBInstancesOutput, lastPage bool) bool {
+		reservedInstances = append(reservedInstances, drio.ReservedDBInstances...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservedInstances, nil
+}
+
+func (c *awsClient) DescribeDBSnapshotsAll(ctx context.Context, snapshotType string) ([]*rds.DBSnapshot, error) {
+	input := &rds.DescribeDBSnapshotsInput{
+		SnapshotType: aws.String(snapshotType),
+	}
+
+	var snapshots []*rds.DBSnapshot
+	err := c.rdsClient.DescribeDBSnapshotsPagesWithContext(ctx, input, func(ddso *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
+		snapshots = append(snapshots, ddso.DBSnapshots...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (c *awsClient) DescribeDBParameterGroupsAll(ctx context.Context) ([]*rds.DBParameterGroup, error) {
+	input := &rds.DescribeDBParameterGroupsInput{}
+
+	var parameterGroups []*rds.DBParameterGroup
+	err := c.rdsClient.DescribeDBParameterGroupsPagesWithContext(ctx, input, func(ddpgo *rds.DescribeDBParameterGroupsOutput, lastPage bool) bool {
+		parameterGroups = append(parameterGroups, ddpgo.DBParameterGroups...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parameterGroups, nil
+}
+
 func (c *awsClient) ListHostedZonesWithContext(ctx context.Context, input *route53.ListHostedZonesInput, opts ...request.Option) (*route53.ListHostedZonesOutput, error) {
 	return c.route53Client.ListHostedZonesWithContext(ctx, input, opts...)
 }
@@ -140,22 +497,42 @@ func (c *awsClient) GetHostedZoneLimitWithContext(ctx context.Context, input *ro
 	return c.route53Client.GetHostedZoneLimitWithContext(ctx, input, opts...)
 }
 
+func (c *awsClient) GetAccountLimitWithContext(ctx context.Context, input *route53.GetAccountLimitInput, opts ...request.Option) (*route53.GetAccountLimitOutput, error) {
+	return c.route53Client.GetAccountLimitWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListResourceRecordSetsWithContext(ctx context.Context, input *route53.ListResourceRecordSetsInput, opts ...request.Option) (*route53.ListResourceRecordSetsOutput, error) {
+	return c.route53Client.ListResourceRecordSetsWithContext(ctx, input, opts...)
+}
+
 func (c *awsClient) DescribeCacheClustersAll(ctx context.Context) ([]*elasticache.CacheCluster, error) {
 	input := &elasticache.DescribeCacheClustersInput{}
 
 	var clusters []*elasticache.CacheCluster
 	err := c.DescribeCacheClustersPagesWithContext(ctx, input, func(dco *elasticache.DescribeCacheClustersOutput, more bool) bool {
-		AwsExporterMetrics.IncrementRequests()
 		clusters = append(clusters, dco.CacheClusters...)
 		return more
 	})
 	if err != nil {
-		AwsExporterMetrics.IncrementErrors()
 		return nil, err
 	}
 	return clusters, nil
 }
 
+func (c *awsClient) DescribeServerlessCachesAll(ctx context.Context) ([]*elasticache.ServerlessCache, error) {
+	input := &elasticache.DescribeServerlessCachesInput{}
+
+	var caches []*elasticache.ServerlessCache
+	err := c.elasticacheClient.DescribeServerlessCachesPagesWithContext(ctx, input, func(dsco *elasticache.DescribeServerlessCachesOutput, lastPage bool) bool {
+		caches = append(caches, dsco.ServerlessCaches...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return caches, nil
+}
+
 func (c *awsClient) DescribeCacheClustersPagesWithContext(ctx aws.Context, input *elasticache.DescribeCacheClustersInput, fn func(*elasticache.DescribeCacheClustersOutput, bool) bool, opts ...request.Option) error {
 	return c.elasticacheClient.DescribeCacheClustersPagesWithContext(ctx, input, fn, opts...)
 }
@@ -169,26 +546,1286 @@ func (c *awsClient) ListClustersAll(ctx context.Context) ([]*kafka.ClusterInfo,
 
 	var clusters []*kafka.ClusterInfo
 	err := c.mskClient.ListClustersPagesWithContext(ctx, input, func(lco *kafka.ListClustersOutput, lastPage bool) bool {
-		AwsExporterMetrics.IncrementRequests()
 		clusters = append(clusters, lco.ClusterInfoList...)
 		return true
 	})
 
 	if err != nil {
-		AwsExporterMetrics.IncrementErrors()
 		return nil, err
 	}
 
 	return clusters, nil
 }
 
+func (c *awsClient) ListTablesAll(ctx context.Context) ([]*string, error) {
+	input := &dynamodb.ListTablesInput{}
+
+	var tableNames []*string
+	err := c.dynamodbClient.ListTablesPagesWithContext(ctx, input, func(lto *dynamodb.ListTablesOutput, lastPage bool) bool {
+		tableNames = append(tableNames, lto.TableNames...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tableNames, nil
+}
+
+func (c *awsClient) DescribeTableWithContext(ctx aws.Context, input *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
+	return c.dynamodbClient.DescribeTableWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeLoadBalancersAll(ctx context.Context) ([]*elbv2.LoadBalancer, error) {
+	input := &elbv2.DescribeLoadBalancersInput{}
+
+	var loadBalancers []*elbv2.LoadBalancer
+	err := c.elbv2Client.DescribeLoadBalancersPagesWithContext(ctx, input, func(dlo *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		loadBalancers = append(loadBalancers, dlo.LoadBalancers...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
+
+func (c *awsClient) DescribeListenersAll(ctx context.Context, loadBalancerArn string) ([]*elbv2.Listener, error) {
+	input := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: &loadBalancerArn,
+	}
+
+	var listeners []*elbv2.Listener
+	err := c.elbv2Client.DescribeListenersPagesWithContext(ctx, input, func(dlo *elbv2.DescribeListenersOutput, lastPage bool) bool {
+		listeners = append(listeners, dlo.Listeners...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listeners, nil
+}
+
+func (c *awsClient) DescribeTargetGroupsAll(ctx context.Context) ([]*elbv2.TargetGroup, error) {
+	input := &elbv2.DescribeTargetGroupsInput{}
+
+	var targetGroups []*elbv2.TargetGroup
+	err := c.elbv2Client.DescribeTargetGroupsPagesWithContext(ctx, input, func(dto *elbv2.DescribeTargetGroupsOutput, lastPage bool) bool {
+		targetGroups = append(targetGroups, dto.TargetGroups...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return targetGroups, nil
+}
+
+func (c *awsClient) DescribeAddressesWithContext(ctx aws.Context, input *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	return c.ec2Client.DescribeAddressesWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeNatGatewaysAll(ctx context.Context) ([]*ec2.NatGateway, error) {
+	input := &ec2.DescribeNatGatewaysInput{}
+
+	var natGateways []*ec2.NatGateway
+	err := c.ec2Client.DescribeNatGatewaysPagesWithContext(ctx, input, func(dno *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+		natGateways = append(natGateways, dno.NatGateways...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return natGateways, nil
+}
+
+func (c *awsClient) DescribeSubnetsAll(ctx context.Context) ([]*ec2.Subnet, error) {
+	input := &ec2.DescribeSubnetsInput{}
+
+	var subnets []*ec2.Subnet
+	err := c.ec2Client.DescribeSubnetsPagesWithContext(ctx, input, func(dso *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		subnets = append(subnets, dso.Subnets...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subnets, nil
+}
+
+func (c *awsClient) DescribeVpcsAll(ctx context.Context) ([]*ec2.Vpc, error) {
+	input := &ec2.DescribeVpcsInput{}
+
+	var vpcs []*ec2.Vpc
+	err := c.ec2Client.DescribeVpcsPagesWithContext(ctx, input, func(out *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		vpcs = append(vpcs, out.Vpcs...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vpcs, nil
+}
+
+func (c *awsClient) DescribeRouteTablesAll(ctx context.Context) ([]*ec2.RouteTable, error) {
+	input := &ec2.DescribeRouteTablesInput{}
+
+	var routeTables []*ec2.RouteTable
+	err := c.ec2Client.DescribeRouteTablesPagesWithContext(ctx, input, func(out *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+		routeTables = append(routeTables, out.RouteTables...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return routeTables, nil
+}
+
+func (c *awsClient) DescribeVpcEndpointsAll(ctx context.Context) ([]*ec2.VpcEndpoint, error) {
+	input := &ec2.DescribeVpcEndpointsInput{}
+
+	var vpcEndpoints []*ec2.VpcEndpoint
+	err := c.ec2Client.DescribeVpcEndpointsPagesWithContext(ctx, input, func(out *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+		vpcEndpoints = append(vpcEndpoints, out.VpcEndpoints...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vpcEndpoints, nil
+}
+
+func (c *awsClient) DescribeNetworkInterfacesAll(ctx context.Context) ([]*ec2.NetworkInterface, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{}
+
+	var networkInterfaces []*ec2.NetworkInterface
+	err := c.ec2Client.DescribeNetworkInterfacesPagesWithContext(ctx, input, func(out *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+		networkInterfaces = append(networkInterfaces, out.NetworkInterfaces...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return networkInterfaces, nil
+}
+
+func (c *awsClient) DescribeInternetGatewaysAll(ctx context.Context) ([]*ec2.InternetGateway, error) {
+	input := &ec2.DescribeInternetGatewaysInput{}
+
+	var internetGateways []*ec2.InternetGateway
+	err := c.ec2Client.DescribeInternetGatewaysPagesWithContext(ctx, input, func(out *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+		internetGateways = append(internetGateways, out.InternetGateways...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return internetGateways, nil
+}
+
+func (c *awsClient) DescribeTransitGatewayVpcAttachmentsAll(ctx context.Context) ([]*ec2.TransitGatewayVpcAttachment, error) {
+	input := &ec2.DescribeTransitGatewayVpcAttachmentsInput{}
+
+	var transitGatewayVpcAttachments []*ec2.TransitGatewayVpcAttachment
+	err := c.ec2Client.DescribeTransitGatewayVpcAttachmentsPagesWithContext(ctx, input, func(out *ec2.DescribeTransitGatewayVpcAttachmentsOutput, lastPage bool) bool {
+		transitGatewayVpcAttachments = append(transitGatewayVpcAttachments, out.TransitGatewayVpcAttachments...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transitGatewayVpcAttachments, nil
+}
+
+func (c *awsClient) DescribeVpcPeeringConnectionsAll(ctx context.Context) ([]*ec2.VpcPeeringConnection, error) {
+	input := &ec2.DescribeVpcPeeringConnectionsInput{}
+
+	var vpcPeeringConnections []*ec2.VpcPeeringConnection
+	err := c.ec2Client.DescribeVpcPeeringConnectionsPagesWithContext(ctx, input, func(out *ec2.DescribeVpcPeeringConnectionsOutput, lastPage bool) bool {
+		vpcPeeringConnections = append(vpcPeeringConnections, out.VpcPeeringConnections...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vpcPeeringConnections, nil
+}
+
+func (c *awsClient) DescribeSecurityGroupsAll(ctx context.Context) ([]*ec2.SecurityGroup, error) {
+	input := &ec2.DescribeSecurityGroupsInput{}
+
+	var securityGroups []*ec2.SecurityGroup
+	err := c.ec2Client.DescribeSecurityGroupsPagesWithContext(ctx, input, func(out *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		securityGroups = append(securityGroups, out.SecurityGroups...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return securityGroups, nil
+}
+
+func (c *awsClient) DescribeVolumesAll(ctx context.Context) ([]*ec2.Volume, error) {
+	input := &ec2.DescribeVolumesInput{}
+
+	var volumes []*ec2.Volume
+	err := c.ec2Client.DescribeVolumesPagesWithContext(ctx, input, func(dvo *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		volumes = append(volumes, dvo.Volumes...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+// DescribeSnapshotsAll returns all snapshots owned by the account
+func (c *awsClient) DescribeSnapshotsAll(ctx context.Context) ([]*ec2.Snapshot, error) {
+	input := &ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	}
+
+	var snapshots []*ec2.Snapshot
+	err := c.ec2Client.DescribeSnapshotsPagesWithContext(ctx, input, func(dso *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		snapshots = append(snapshots, dso.Snapshots...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func (c *awsClient) GetAccountSettingsWithContext(ctx aws.Context, input *lambda.GetAccountSettingsInput, opts ...request.Option) (*lambda.GetAccountSettingsOutput, error) {
+	return c.lambdaClient.GetAccountSettingsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListQueuesAll(ctx context.Context) ([]*string, error) {
+	// MaxResults has to be set, otherwise the response is not paginated and capped at 1000 queues
+	input := &sqs.ListQueuesInput{
+		MaxResults: aws.Int64(1000),
+	}
+
+	var queueUrls []*string
+	err := c.sqsClient.ListQueuesPagesWithContext(ctx, input, func(lqo *sqs.ListQueuesOutput, lastPage bool) bool {
+		queueUrls = append(queueUrls, lqo.QueueUrls...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return queueUrls, nil
+}
+
+func (c *awsClient) ListTopicsAll(ctx context.Context) ([]*sns.Topic, error) {
+	input := &sns.ListTopicsInput{}
+
+	var topics []*sns.Topic
+	err := c.snsClient.ListTopicsPagesWithContext(ctx, input, func(lto *sns.ListTopicsOutput, lastPage bool) bool {
+		topics = append(topics, lto.Topics...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+func (c *awsClient) ListSubscriptionsAll(ctx context.Context) ([]*sns.Subscription, error) {
+	input := &sns.ListSubscriptionsInput{}
+
+	var subscriptions []*sns.Subscription
+	err := c.snsClient.ListSubscriptionsPagesWithContext(ctx, input, func(lso *sns.ListSubscriptionsOutput, lastPage bool) bool {
+		subscriptions = append(subscriptions, lso.Subscriptions...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (c *awsClient) ListEKSClustersAll(ctx context.Context) ([]*string, error) {
+	input := &eks.ListClustersInput{}
+
+	var clusterNames []*string
+	err := c.eksClient.ListClustersPagesWithContext(ctx, input, func(lco *eks.ListClustersOutput, lastPage bool) bool {
+		clusterNames = append(clusterNames, lco.Clusters...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterNames, nil
+}
+
+func (c *awsClient) DescribeClusterWithContext(ctx aws.Context, input *eks.DescribeClusterInput, opts ...request.Option) (*eks.DescribeClusterOutput, error) {
+	return c.eksClient.DescribeClusterWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListDomainNamesWithContext(ctx aws.Context, input *opensearchservice.ListDomainNamesInput, opts ...request.Option) (*opensearchservice.ListDomainNamesOutput, error) {
+	return c.opensearchClient.ListDomainNamesWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeDomainsWithContext(ctx aws.Context, input *opensearchservice.DescribeDomainsInput, opts ...request.Option) (*opensearchservice.DescribeDomainsOutput, error) {
+	return c.opensearchClient.DescribeDomainsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListServerlessClustersAll(ctx context.Context) ([]*kafka.Cluster, error) {
+	// ListClusters only returns provisioned clusters, serverless ones are only listed by ListClustersV2
+	input := &kafka.ListClustersV2Input{
+		ClusterTypeFilter: aws.String(kafka.ClusterTypeServerless),
+	}
+
+	var clusters []*kafka.Cluster
+	err := c.mskClient.ListClustersV2PagesWithContext(ctx, input, func(lco *kafka.ListClustersV2Output, lastPage bool) bool {
+		clusters = append(clusters, lco.ClusterInfoList...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+func (c *awsClient) ListConnectorsAll(ctx context.Context) ([]*kafkaconnect.ConnectorSummary, error) {
+	input := &kafkaconnect.ListConnectorsInput{}
+
+	var connectors []*kafkaconnect.ConnectorSummary
+	err := c.kafkaConnectClient.ListConnectorsPagesWithContext(ctx, input, func(lco *kafkaconnect.ListConnectorsOutput, lastPage bool) bool {
+		connectors = append(connectors, lco.Connectors...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return connectors, nil
+}
+
+func (c *awsClient) GetAccountSummaryWithContext(ctx aws.Context, input *iam.GetAccountSummaryInput, opts ...request.Option) (*iam.GetAccountSummaryOutput, error) {
+	return c.iamClient.GetAccountSummaryWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GenerateCredentialReportWithContext(ctx aws.Context, input *iam.GenerateCredentialReportInput, opts ...request.Option) (*iam.GenerateCredentialReportOutput, error) {
+	return c.iamClient.GenerateCredentialReportWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetCredentialReportWithContext(ctx aws.Context, input *iam.GetCredentialReportInput, opts ...request.Option) (*iam.GetCredentialReportOutput, error) {
+	return c.iamClient.GetCredentialReportWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetRoleDetailsAll(ctx context.Context) ([]*iam.RoleDetail, error) {
+	// ListRoles doesn't return RoleLastUsed, the authorization details do without a GetRole call per role
+	input := &iam.GetAccountAuthorizationDetailsInput{
+		Filter: []*string{aws.String(iam.EntityTypeRole)},
+	}
+
+	var roles []*iam.RoleDetail
+	err := c.iamClient.GetAccountAuthorizationDetailsPagesWithContext(ctx, input, func(gado *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) bool {
+		roles = append(roles, gado.RoleDetailList...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (c *awsClient) ListCertificatesAll(ctx context.Context) ([]*acm.CertificateSummary, error) {
+	// without a key type filter only RSA 1024 and 2048 bit certificates are listed
+	input := &acm.ListCertificatesInput{
+		Includes: &acm.Filters{
+			KeyTypes: aws.StringSlice(acm.KeyAlgorithm_Values()),
+		},
+	}
+
+	var certificates []*acm.CertificateSummary
+	err := c.acmClient.ListCertificatesPagesWithContext(ctx, input, func(lco *acm.ListCertificatesOutput, lastPage bool) bool {
+		certificates = append(certificates, lco.CertificateSummaryList...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (c *awsClient) ListKeysAll(ctx context.Context) ([]*kms.KeyListEntry, error) {
+	input := &kms.ListKeysInput{}
+
+	var keys []*kms.KeyListEntry
+	err := c.kmsClient.ListKeysPagesWithContext(ctx, input, func(lko *kms.ListKeysOutput, lastPage bool) bool {
+		keys = append(keys, lko.Keys...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (c *awsClient) DescribeKeyWithContext(ctx aws.Context, input *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
+	return c.kmsClient.DescribeKeyWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetKeyRotationStatusWithContext(ctx aws.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+	return c.kmsClient.GetKeyRotationStatusWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListSecretsAll(ctx context.Context) ([]*secretsmanager.SecretListEntry, error) {
+	input := &secretsmanager.ListSecretsInput{}
+
+	var secrets []*secretsmanager.SecretListEntry
+	err := c.secretsManagerClient.ListSecretsPagesWithContext(ctx, input, func(lso *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		secrets = append(secrets, lso.SecretList...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func (c *awsClient) DescribeParametersAll(ctx context.Context) ([]*ssm.ParameterMetadata, error) {
+	input := &ssm.DescribeParametersInput{
+		MaxResults: aws.Int64(50),
+	}
+
+	var parameters []*ssm.ParameterMetadata
+	err := c.ssmClient.DescribeParametersPagesWithContext(ctx, input, func(dpo *ssm.DescribeParametersOutput, lastPage bool) bool {
+		parameters = append(parameters, dpo.Parameters...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+func (c *awsClient) ListDistributionsAll(ctx context.Context) ([]*cloudfront.DistributionSummary, error) {
+	input := &cloudfront.ListDistributionsInput{}
+
+	var distributions []*cloudfront.DistributionSummary
+	err := c.cloudfrontClient.ListDistributionsPagesWithContext(ctx, input, func(ldo *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+		if ldo.DistributionList != nil {
+			distributions = append(distributions, ldo.DistributionList.Items...)
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return distributions, nil
+}
+
+func (c *awsClient) DescribeFileSystemsAll(ctx context.Context) ([]*efs.FileSystemDescription, error) {
+	input := &efs.DescribeFileSystemsInput{}
+
+	var fileSystems []*efs.FileSystemDescription
+	err := c.efsClient.DescribeFileSystemsPagesWithContext(ctx, input, func(dfo *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+		fileSystems = append(fileSystems, dfo.FileSystems...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fileSystems, nil
+}
+
+func (c *awsClient) DescribeMountTargetsAll(ctx context.Context, fileSystemId string) ([]*efs.MountTargetDescription, error) {
+	input := &efs.DescribeMountTargetsInput{
+		FileSystemId: aws.String(fileSystemId),
+	}
+
+	var mountTargets []*efs.MountTargetDescription
+	err := c.efsClient.DescribeMountTargetsPagesWithContext(ctx, input, func(dmo *efs.DescribeMountTargetsOutput, lastPage bool) bool {
+		mountTargets = append(mountTargets, dmo.MountTargets...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mountTargets, nil
+}
+
+func (c *awsClient) DescribeLifecycleConfigurationWithContext(ctx aws.Context, input *efs.DescribeLifecycleConfigurationInput, opts ...request.Option) (*efs.DescribeLifecycleConfigurationOutput, error) {
+	return c.efsClient.DescribeLifecycleConfigurationWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeRedshiftClustersAll(ctx context.Context) ([]*redshift.Cluster, error) {
+	input := &redshift.DescribeClustersInput{}
+
+	var clusters []*redshift.Cluster
+	err := c.redshiftClient.DescribeClustersPagesWithContext(ctx, input, func(dco *redshift.DescribeClustersOutput, lastPage bool) bool {
+		clusters = append(clusters, dco.Clusters...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+func (c *awsClient) GetRestApisAll(ctx context.Context) ([]*apigateway.RestApi, error) {
+	input := &apigateway.GetRestApisInput{}
+
+	var restApis []*apigateway.RestApi
+	err := c.apigatewayClient.GetRestApisPagesWithContext(ctx, input, func(gro *apigateway.GetRestApisOutput, lastPage bool) bool {
+		restApis = append(restApis, gro.Items...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return restApis, nil
+}
+
+func (c *awsClient) GetUsagePlansAll(ctx context.Context) ([]*apigateway.UsagePlan, error) {
+	input := &apigateway.GetUsagePlansInput{}
+
+	var usagePlans []*apigateway.UsagePlan
+	err := c.apigatewayClient.GetUsagePlansPagesWithContext(ctx, input, func(guo *apigateway.GetUsagePlansOutput, lastPage bool) bool {
+		usagePlans = append(usagePlans, guo.Items...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usagePlans, nil
+}
+
+func (c *awsClient) GetApiKeysAll(ctx context.Context) ([]*apigateway.ApiKey, error) {
+	input := &apigateway.GetApiKeysInput{}
+
+	var apiKeys []*apigateway.ApiKey
+	err := c.apigatewayClient.GetApiKeysPagesWithContext(ctx, input, func(gao *apigateway.GetApiKeysOutput, lastPage bool) bool {
+		apiKeys = append(apiKeys, gao.Items...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (c *awsClient) GetDomainNamesAll(ctx context.Context) ([]*apigateway.DomainName, error) {
+	input := &apigateway.GetDomainNamesInput{}
+
+	var domainNames []*apigateway.DomainName
+	err := c.apigatewayClient.GetDomainNamesPagesWithContext(ctx, input, func(gdo *apigateway.GetDomainNamesOutput, lastPage bool) bool {
+		domainNames = append(domainNames, gdo.Items...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domainNames, nil
+}
+
+// GetHTTPApisAll lists the HTTP and WebSocket APIs. The API Gateway v2 client has no paginators, so the pages are
+// requested manually.
+func (c *awsClient) GetHTTPApisAll(ctx context.Context) ([]*apigatewayv2.Api, error) {
+	input := &apigatewayv2.GetApisInput{}
+
+	var apis []*apigatewayv2.Api
+	for {
+		output, err := c.apigatewayv2Client.GetApisWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, output.Items...)
+		if aws.StringValue(output.NextToken) == "" {
+			return apis, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (c *awsClient) GetRoutesAll(ctx context.Context, apiId string) ([]*apigatewayv2.Route, error) {
+	input := &apigatewayv2.GetRoutesInput{
+		ApiId: aws.String(apiId),
+	}
+
+	var routes []*apigatewayv2.Route
+	for {
+		output, err := c.apigatewayv2Client.GetRoutesWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, output.Items...)
+		if aws.StringValue(output.NextToken) == "" {
+			return routes, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (c *awsClient) DescribeLimitsWithContext(ctx aws.Context, input *kinesis.DescribeLimitsInput, opts ...request.Option) (*kinesis.DescribeLimitsOutput, error) {
+	return c.kinesisClient.DescribeLimitsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeStreamSummaryWithContext(ctx aws.Context, input *kinesis.DescribeStreamSummaryInput, opts ...request.Option) (*kinesis.DescribeStreamSummaryOutput, error) {
+	return c.kinesisClient.DescribeStreamSummaryWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListStreamsAll(ctx context.Context) ([]*kinesis.StreamSummary, error) {
+	input := &kinesis.ListStreamsInput{}
+
+	var streams []*kinesis.StreamSummary
+	err := c.kinesisClient.ListStreamsPagesWithContext(ctx, input, func(lso *kinesis.ListStreamsOutput, lastPage bool) bool {
+		streams = append(streams, lso.StreamSummaries...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return streams, nil
+}
+
+func (c *awsClient) DescribeRepositoriesAll(ctx context.Context) ([]*ecr.Repository, error) {
+	input := &ecr.DescribeRepositoriesInput{}
+
+	var repositories []*ecr.Repository
+	err := c.ecrClient.DescribeRepositoriesPagesWithContext(ctx, input, func(dro *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+		repositories = append(repositories, dro.Repositories...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories, nil
+}
+
+func (c *awsClient) DescribeImagesAll(ctx context.Context, repositoryName string) ([]*ecr.ImageDetail, error) {
+	input := &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repositoryName),
+	}
+
+	var images []*ecr.ImageDetail
+	err := c.ecrClient.DescribeImagesPagesWithContext(ctx, input, func(dio *ecr.DescribeImagesOutput, lastPage bool) bool {
+		images = append(images, dio.ImageDetails...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (c *awsClient) GetLifecyclePolicyWithContext(ctx aws.Context, input *ecr.GetLifecyclePolicyInput, opts ...request.Option) (*ecr.GetLifecyclePolicyOutput, error) {
+	return c.ecrClient.GetLifecyclePolicyWithContext(ctx, input, opts...)
+}
+
+// The ECS describe calls accept a limited number of resources per request
+const (
+	describeECSClustersMaxResults = 100
+	describeECSServicesMaxResults = 10
+	describeECSTasksMaxResults    = 100
+)
+
+func (c *awsClient) DescribeECSClustersAll(ctx context.Context) ([]*ecs.Cluster, error) {
+	var clusterArns []*string
+	err := c.ecsClient.ListClustersPagesWithContext(ctx, &ecs.ListClustersInput{}, func(lco *ecs.ListClustersOutput, lastPage bool) bool {
+		clusterArns = append(clusterArns, lco.ClusterArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []*ecs.Cluster
+	for start := 0; start < len(clusterArns); start += describeECSClustersMaxResults {
+		end := min(start+describeECSClustersMaxResults, len(clusterArns))
+		output, err := c.ecsClient.DescribeClustersWithContext(ctx, &ecs.DescribeClustersInput{
+			Clusters: clusterArns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.Clusters...)
+	}
+
+	return clusters, nil
+}
+
+func (c *awsClient) DescribeECSServicesAll(ctx context.Context, clusterArn string) ([]*ecs.Service, error) {
+	var serviceArns []*string
+	err := c.ecsClient.ListServicesPagesWithContext(ctx, &ecs.ListServicesInput{Cluster: aws.String(clusterArn)}, func(lso *ecs.ListServicesOutput, lastPage bool) bool {
+		serviceArns = append(serviceArns, lso.ServiceArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*ecs.Service
+	for start := 0; start < len(serviceArns); start += describeECSServicesMaxResults {
+		end := min(start+describeECSServicesMaxResults, len(serviceArns))
+		output, err := c.ecsClient.DescribeServicesWithContext(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(clusterArn),
+			Services: serviceArns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, output.Services...)
+	}
+
+	return services, nil
+}
+
+func (c *awsClient) DescribeECSRunningTasksAll(ctx context.Context, clusterArn string) ([]*ecs.Task, error) {
+	input := &ecs.ListTasksInput{
+		Cluster:       aws.String(clusterArn),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}
+
+	var taskArns []*string
+	err := c.ecsClient.ListTasksPagesWithContext(ctx, input, func(lto *ecs.ListTasksOutput, lastPage bool) bool {
+		taskArns = append(taskArns, lto.TaskArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*ecs.Task
+	for start := 0; start < len(taskArns); start += describeECSTasksMaxResults {
+		end := min(start+describeECSTasksMaxResults, len(taskArns))
+		output, err := c.ecsClient.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(clusterArn),
+			Tasks:   taskArns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, output.Tasks...)
+	}
+
+	return tasks, nil
+}
+
+func (c *awsClient) DescribeLogGroupsAll(ctx context.Context) ([]*cloudwatchlogs.LogGroup, error) {
+	input := &cloudwatchlogs.DescribeLogGroupsInput{}
+
+	var logGroups []*cloudwatchlogs.LogGroup
+	err := c.cloudwatchlogsClient.DescribeLogGroupsPagesWithContext(ctx, input, func(dlo *cloudwatchlogs.DescribeLogGroupsOutput, lastPage bool) bool {
+		logGroups = append(logGroups, dlo.LogGroups...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return logGroups, nil
+}
+
+func (c *awsClient) ListBucketsWithContext(ctx aws.Context, input *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
+	return c.s3Client.ListBucketsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListMetricsAll(ctx context.Context, input *cloudwatch.ListMetricsInput) ([]*cloudwatch.Metric, error) {
+	var metrics []*cloudwatch.Metric
+	err := c.cloudwatchClient.ListMetricsPagesWithContext(ctx, input, func(lmo *cloudwatch.ListMetricsOutput, lastPage bool) bool {
+		metrics = append(metrics, lmo.Metrics...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// GetMetricDataAll merges the results of all pages. Paginated results of the same query are not merged, so
+// callers should look up values by the query id.
+func (c *awsClient) GetMetricDataAll(ctx context.Context, input *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error) {
+	var results []*cloudwatch.MetricDataResult
+	err := c.cloudwatchClient.GetMetricDataPagesWithContext(ctx, input, func(gmo *cloudwatch.GetMetricDataOutput, lastPage bool) bool {
+		results = append(results, gmo.MetricDataResults...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (c *awsClient) DescribeTrustedAdvisorChecksWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorChecksInput, opts ...request.Option) (*support.DescribeTrustedAdvisorChecksOutput, error) {
+	return c.supportClient.DescribeTrustedAdvisorChecksWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeTrustedAdvisorCheckResultWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorCheckResultInput, opts ...request.Option) (*support.DescribeTrustedAdvisorCheckResultOutput, error) {
+	return c.supportClient.DescribeTrustedAdvisorCheckResultWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeRegionsWithContext(ctx aws.Context, input *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return c.ec2Client.DescribeRegionsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetHealthCheckStatusWithContext(ctx context.Context, input *route53.GetHealthCheckStatusInput, opts ...request.Option) (*route53.GetHealthCheckStatusOutput, error) {
+	return c.route53Client.GetHealthCheckStatusWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListHealthChecksAll(ctx context.Context) ([]*route53.HealthCheck, error) {
+	input := &route53.ListHealthChecksInput{}
+
+	var healthChecks []*route53.HealthCheck
+	err := c.route53Client.ListHealthChecksPagesWithContext(ctx, input, func(lhco *route53.ListHealthChecksOutput, lastPage bool) bool {
+		healthChecks = append(healthChecks, lhco.HealthChecks...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return healthChecks, nil
+}
+
+func (c *awsClient) DescribeInstancesAll(ctx context.Context) ([]*ec2.Instance, error) {
+	input := &ec2.DescribeInstancesInput{}
+
+	var instances []*ec2.Instance
+	err := c.ec2Client.DescribeInstancesPagesWithContext(ctx, input, func(dio *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range dio.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+func (c *awsClient) DescribeActiveReservedInstances(ctx context.Context) ([]*ec2.ReservedInstances, error) {
+	input := &ec2.DescribeReservedInstancesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("state"), Values: []*string{aws.String(ec2.ReservedInstanceStateActive)}}},
+	}
+
+	// DescribeReservedInstances isn't paginated
+	output, err := c.ec2Client.DescribeReservedInstancesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.ReservedInstances, nil
+}
+
+func (c *awsClient) DescribeActiveSavingsPlansAll(ctx context.Context) ([]*savingsplans.SavingsPlan, error) {
+	input := &savingsplans.DescribeSavingsPlansInput{
+		States: []*string{aws.String(savingsplans.SavingsPlanStateActive)},
+	}
+
+	var plans []*savingsplans.SavingsPlan
+	for {
+		output, err := c.savingsPlansClient.DescribeSavingsPlansWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, output.SavingsPlans...)
+		if aws.StringValue(output.NextToken) == "" {
+			return plans, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (c *awsClient) DescribeSpotInstanceRequestsAll(ctx context.Context, states ...string) ([]*ec2.SpotInstanceRequest, error) {
+	input := &ec2.DescribeSpotInstanceRequestsInput{}
+	if len(states) > 0 {
+		input.Filters = []*ec2.Filter{{Name: aws.String("state"), Values: aws.StringSlice(states)}}
+	}
+
+	var requests []*ec2.SpotInstanceRequest
+	err := c.ec2Client.DescribeSpotInstanceRequestsPagesWithContext(ctx, input, func(dsiro *ec2.DescribeSpotInstanceRequestsOutput, lastPage bool) bool {
+		requests = append(requests, dsiro.SpotInstanceRequests...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (c *awsClient) DescribeCapacityReservationsAll(ctx context.Context) ([]*ec2.CapacityReservation, error) {
+	input := &ec2.DescribeCapacityReservationsInput{}
+
+	var reservations []*ec2.CapacityReservation
+	err := c.ec2Client.DescribeCapacityReservationsPagesWithContext(ctx, input, func(dcro *ec2.DescribeCapacityReservationsOutput, lastPage bool) bool {
+		reservations = append(reservations, dcro.CapacityReservations...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (c *awsClient) GetSpotPlacementScoresAll(ctx context.Context, input *ec2.GetSpotPlacementScoresInput) ([]*ec2.SpotPlacementScore, error) {
+	var scores []*ec2.SpotPlacementScore
+	err := c.ec2Client.GetSpotPlacementScoresPagesWithContext(ctx, input, func(gspso *ec2.GetSpotPlacementScoresOutput, lastPage bool) bool {
+		scores = append(scores, gspso.SpotPlacementScores...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+func (c *awsClient) DescribeOwnedImagesAll(ctx context.Context) ([]*ec2.Image, error) {
+	input := &ec2.DescribeImagesInput{Owners: []*string{aws.String("self")}}
+
+	var images []*ec2.Image
+	err := c.ec2Client.DescribeImagesPagesWithContext(ctx, input, func(dio *ec2.DescribeImagesOutput, lastPage bool) bool {
+		images = append(images, dio.Images...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (c *awsClient) GetCallerIdentityWithContext(ctx aws.Context, input *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return c.stsClient.GetCallerIdentityWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListBackupPlansAll(ctx context.Context) ([]*backup.PlansListMember, error) {
+	var plans []*backup.PlansListMember
+	err := c.backupClient.ListBackupPlansPagesWithContext(ctx, &backup.ListBackupPlansInput{}, func(lbpo *backup.ListBackupPlansOutput, lastPage bool) bool {
+		plans = append(plans, lbpo.BackupPlansList...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (c *awsClient) ListProtectedResourcesAll(ctx context.Context) ([]*backup.ProtectedResource, error) {
+	var resources []*backup.ProtectedResource
+	err := c.backupClient.ListProtectedResourcesPagesWithContext(ctx, &backup.ListProtectedResourcesInput{}, func(lpro *backup.ListProtectedResourcesOutput, lastPage bool) bool {
+		resources = append(resources, lpro.Results...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// ListBackupJobsAll lists the backup jobs in the state, which were created after the given time
+func (c *awsClient) ListBackupJobsAll(ctx context.Context, state string, createdAfter time.Time) ([]*backup.Job, error) {
+	input := &backup.ListBackupJobsInput{
+		ByState:        aws.String(state),
+		ByCreatedAfter: aws.Time(createdAfter),
+	}
+
+	var jobs []*backup.Job
+	err := c.backupClient.ListBackupJobsPagesWithContext(ctx, input, func(lbjo *backup.ListBackupJobsOutput, lastPage bool) bool {
+		jobs = append(jobs, lbjo.BackupJobs...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// ListTrailsAll lists the trails of all regions
+func (c *awsClient) ListTrailsAll(ctx context.Context) ([]*cloudtrail.TrailInfo, error) {
+	var trails []*cloudtrail.TrailInfo
+	err := c.cloudtrailClient.ListTrailsPagesWithContext(ctx, &cloudtrail.ListTrailsInput{}, func(lto *cloudtrail.ListTrailsOutput, lastPage bool) bool {
+		trails = append(trails, lto.Trails...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trails, nil
+}
+
+func (c *awsClient) DescribeTrailsWithContext(ctx aws.Context, input *cloudtrail.DescribeTrailsInput, opts ...request.Option) (*cloudtrail.DescribeTrailsOutput, error) {
+	return c.cloudtrailClient.DescribeTrailsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetTrailStatusWithContext(ctx aws.Context, input *cloudtrail.GetTrailStatusInput, opts ...request.Option) (*cloudtrail.GetTrailStatusOutput, error) {
+	return c.cloudtrailClient.GetTrailStatusWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListDetectorsAll(ctx context.Context) ([]*string, error) {
+	var detectorIds []*string
+	err := c.guarddutyClient.ListDetectorsPagesWithContext(ctx, &guardduty.ListDetectorsInput{}, func(ldo *guardduty.ListDetectorsOutput, lastPage bool) bool {
+		detectorIds = append(detectorIds, ldo.DetectorIds...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return detectorIds, nil
+}
+
+func (c *awsClient) GetFindingsStatisticsWithContext(ctx aws.Context, input *guardduty.GetFindingsStatisticsInput, opts ...request.Option) (*guardduty.GetFindingsStatisticsOutput, error) {
+	return c.guarddutyClient.GetFindingsStatisticsWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) GetEnabledStandardsAll(ctx context.Context) ([]*securityhub.StandardsSubscription, error) {
+	var standards []*securityhub.StandardsSubscription
+	err := c.securityhubClient.GetEnabledStandardsPagesWithContext(ctx, &securityhub.GetEnabledStandardsInput{}, func(geso *securityhub.GetEnabledStandardsOutput, lastPage bool) bool {
+		standards = append(standards, geso.StandardsSubscriptions...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standards, nil
+}
+
+func (c *awsClient) GetFindingsAll(ctx context.Context, filters *securityhub.AwsSecurityFindingFilters) ([]*securityhub.AwsSecurityFinding, error) {
+	input := &securityhub.GetFindingsInput{
+		Filters:    filters,
+		MaxResults: aws.Int64(100),
+	}
+
+	var findings []*securityhub.AwsSecurityFinding
+	err := c.securityhubClient.GetFindingsPagesWithContext(ctx, input, func(gfo *securityhub.GetFindingsOutput, lastPage bool) bool {
+		findings = append(findings, gfo.Findings...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func (c *awsClient) DescribeConfigurationRecorderStatusWithContext(ctx aws.Context, input *configservice.DescribeConfigurationRecorderStatusInput, opts ...request.Option) (*configservice.DescribeConfigurationRecorderStatusOutput, error) {
+	return c.configserviceClient.DescribeConfigurationRecorderStatusWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) DescribeComplianceByConfigRuleAll(ctx context.Context) ([]*configservice.ComplianceByConfigRule, error) {
+	var compliances []*configservice.ComplianceByConfigRule
+	err := c.configserviceClient.DescribeComplianceByConfigRulePagesWithContext(ctx, &configservice.DescribeComplianceByConfigRuleInput{}, func(dcbcro *configservice.DescribeComplianceByConfigRuleOutput, lastPage bool) bool {
+		compliances = append(compliances, dcbcro.ComplianceByConfigRules...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compliances, nil
+}
+
+func (c *awsClient) GetComplianceDetailsByConfigRuleAll(ctx context.Context, configRuleName string, complianceType string) ([]*configservice.EvaluationResult, error) {
+	input := &configservice.GetComplianceDetailsByConfigRuleInput{
+		ConfigRuleName:  aws.String(configRuleName),
+		ComplianceTypes: aws.StringSlice([]string{complianceType}),
+		Limit:           aws.Int64(100),
+	}
+
+	var results []*configservice.EvaluationResult
+	err := c.configserviceClient.GetComplianceDetailsByConfigRulePagesWithContext(ctx, input, func(gcdbcro *configservice.GetComplianceDetailsByConfigRuleOutput, lastPage bool) bool {
+		results = append(results, gcdbcro.EvaluationResults...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ListWebACLsAll lists the web ACLs of the scope. The WAFv2 client has no paginators, so the pages are requested
+// manually.
+func (c *awsClient) ListWebACLsAll(ctx context.Context, scope string) ([]*wafv2.WebACLSummary, error) {
+	input := &wafv2.ListWebACLsInput{
+		Scope: aws.String(scope),
+	}
+
+	var webACLs []*wafv2.WebACLSummary
+	for {
+		output, err := c.wafv2Client.ListWebACLsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		webACLs = append(webACLs, output.WebACLs...)
+		if aws.StringValue(output.NextMarker) == "" {
+			return webACLs, nil
+		}
+		input.NextMarker = output.NextMarker
+	}
+}
+
+func (c *awsClient) GetWebACLWithContext(ctx aws.Context, input *wafv2.GetWebACLInput, opts ...request.Option) (*wafv2.GetWebACLOutput, error) {
+	return c.wafv2Client.GetWebACLWithContext(ctx, input, opts...)
+}
+
+func (c *awsClient) ListRuleGroupsAll(ctx context.Context, scope string) ([]*wafv2.RuleGroupSummary, error) {
+	input := &wafv2.ListRuleGroupsInput{
+		Scope: aws.String(scope),
+	}
+
+	var ruleGroups []*wafv2.RuleGroupSummary
+	for {
+		output, err := c.wafv2Client.ListRuleGroupsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		ruleGroups = append(ruleGroups, output.RuleGroups...)
+		if aws.StringValue(output.NextMarker) == "" {
+			return ruleGroups, nil
+		}
+		input.NextMarker = output.NextMarker
+	}
+}
+
+// GetCostAndUsageAll returns the results of all pages. The Cost Explorer client has no paginators, so the pages are
+// requested manually. Every page is billed, so callers should request as few periods and groups as possible.
+func (c *awsClient) GetCostAndUsageAll(ctx context.Context, input *costexplorer.GetCostAndUsageInput) ([]*costexplorer.ResultByTime, error) {
+	var results []*costexplorer.ResultByTime
+	for {
+		output, err := c.costexplorerClient.GetCostAndUsageWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, output.ResultsByTime...)
+		if aws.StringValue(output.NextPageToken) == "" {
+			return results, nil
+		}
+		input.NextPageToken = output.NextPageToken
+	}
+}
+
 func NewClientFromSession(sess *session.Session) Client {
+	sess = InstrumentSession(sess)
 	return &awsClient{
-		ec2Client:           ec2.New(sess),
-		serviceQuotasClient: servicequotas.New(sess),
-		rdsClient:           *rds.New(sess),
-		route53Client:       route53.New(sess),
-		elasticacheClient:   *elasticache.New(sess),
-		mskClient:           *kafka.New(sess),
+		ec2Client:            ec2.New(sess),
+		serviceQuotasClient:  servicequotas.New(sess),
+		rdsClient:            *rds.New(sess),
+		route53Client:        route53.New(sess),
+		elasticacheClient:    *elasticache.New(sess),
+		mskClient:            *kafka.New(sess),
+		dynamodbClient:       dynamodb.New(sess),
+		elbv2Client:          elbv2.New(sess),
+		lambdaClient:         lambda.New(sess),
+		sqsClient:            sqs.New(sess),
+		snsClient:            sns.New(sess),
+		eksClient:            eks.New(sess),
+		opensearchClient:     opensearchservice.New(sess),
+		kafkaConnectClient:   kafkaconnect.New(sess),
+		iamClient:            iam.New(sess),
+		acmClient:            acm.New(sess),
+		kmsClient:            kms.New(sess),
+		secretsManagerClient: secretsmanager.New(sess),
+		ssmClient:            ssm.New(sess),
+		cloudfrontClient:     cloudfront.New(sess),
+		efsClient:            efs.New(sess),
+		redshiftClient:       redshift.New(sess),
+		apigatewayClient:     apigateway.New(sess),
+		apigatewayv2Client:   apigatewayv2.New(sess),
+		kinesisClient:        kinesis.New(sess),
+		ecrClient:            ecr.New(sess),
+		ecsClient:            ecs.New(sess),
+		cloudwatchlogsClient: cloudwatchlogs.New(sess),
+		s3Client:             s3.New(sess),
+		cloudwatchClient:     cloudwatch.New(sess),
+		supportClient:        support.New(sess),
+		savingsPlansClient:   savingsplans.New(sess),
+		stsClient:            sts.New(sess),
+		backupClient:         backup.New(sess),
+		cloudtrailClient:     cloudtrail.New(sess),
+		guarddutyClient:      guardduty.New(sess),
+		securityhubClient:    securityhub.New(sess),
+		configserviceClient:  configservice.New(sess),
+		wafv2Client:          wafv2.New(sess),
+		costexplorerClient:   costexplorer.New(sess),
 	}
 }
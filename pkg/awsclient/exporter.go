@@ -1,68 +1,115 @@
 package awsclient
 
 import (
-	"sync"
-
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const unknownErrorCode = "Unknown"
+
 var AwsExporterMetrics *ExporterMetrics
 
 // ExporterMetrics defines an instance of the exporter metrics
 type ExporterMetrics struct {
-	sess *session.Session
-
-	APIRequestsCount float64
-	APIErrorsCount   float64
-
-	APIRequests *prometheus.Desc
-	APIErrors   *prometheus.Desc
-
-	mutex *sync.Mutex
+	APIRequests  *prometheus.CounterVec
+	APIErrors    *prometheus.CounterVec
+	APIThrottles *prometheus.CounterVec
 }
 
 // NewExporterMetrics creates a new exporter metrics instance
 func NewExporterMetrics(namespace string) *ExporterMetrics {
 	return &ExporterMetrics{
-		APIRequests: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "apirequests"),
-			"API requests made by the exporter.",
-			[]string{},
-			nil,
+		APIRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "aws_requests_total",
+				Help:      "API requests made by the exporter.",
+			},
+			[]string{"service", "operation", "region"},
+		),
+		APIErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "aws_errors_total",
+				Help:      "API errors encountered by the exporter.",
+			},
+			[]string{"service", "operation", "region", "error_code"},
 		),
-		APIErrors: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "apierrors"),
-			"API errors encountered by the exporter.",
-			[]string{},
-			nil,
+		APIThrottles: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "aws_throttles_total",
+				Help:      "Throttled API requests the exporter retried.",
+			},
+			[]string{"service", "operation", "region"},
 		),
-		mutex: &sync.Mutex{},
 	}
 }
 
 // Describe is used by the Prometheus client to return a description of the metrics
 func (e *ExporterMetrics) Describe(ch chan<- *prometheus.Desc) {
-	ch <- e.APIRequests
-	ch <- e.APIErrors
+	e.APIRequests.Describe(ch)
+	e.APIErrors.Describe(ch)
+	e.APIThrottles.Describe(ch)
 }
 
 // Collect is used by the Prometheus client to collect and return the metrics values
 func (e *ExporterMetrics) Collect(ch chan<- prometheus.Metric) {
-	ch <- prometheus.MustNewConstMetric(e.APIRequests, prometheus.CounterValue, e.APIRequestsCount)
-	ch <- prometheus.MustNewConstMetric(e.APIErrors, prometheus.CounterValue, e.APIErrorsCount)
+	e.APIRequests.Collect(ch)
+	e.APIErrors.Collect(ch)
+	e.APIThrottles.Collect(ch)
 }
 
-// IncrementRequests increments the API requests counter
-func (e *ExporterMetrics) IncrementRequests() {
-	e.mutex.Lock()
-	e.APIRequestsCount++
-	e.mutex.Unlock()
+// requestLabels returns the service, operation and region of the request
+func requestLabels(r *request.Request) (string, string, string) {
+	operation := ""
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+	return r.ClientInfo.ServiceName, operation, aws.StringValue(r.Config.Region)
+}
+
+// ObserveThrottle counts a throttled attempt of an API request which is retried
+func (e *ExporterMetrics) ObserveThrottle(r *request.Request) {
+	e.APIThrottles.WithLabelValues(requestLabels(r)).Inc()
+}
+
+// ObserveRequest counts a single attempt of an API request and its error, if any
+func (e *ExporterMetrics) ObserveRequest(r *request.Request) {
+	service, operation, region := requestLabels(r)
+
+	e.APIRequests.WithLabelValues(service, operation, region).Inc()
+	if r.Error != nil {
+		errorCode := unknownErrorCode
+		if awsErr, ok := r.Error.(awserr.Error); ok {
+			errorCode = awsErr.Code()
+		}
+		e.APIErrors.WithLabelValues(service, operation, region, errorCode).Inc()
+	}
 }
 
-// IncrementErrors increments the API requests error counter
-func (e *ExporterMetrics) IncrementErrors() {
-	e.mutex.Lock()
-	e.APIErrorsCount++
-	e.mutex.Unlock()
+// InstrumentSession returns a copy of the session whose clients report every request attempt to the exporter metrics.
+// Retries are counted separately, so throttled requests show up even if a retry succeeds. Every attempt waits for the
+// rate limit of its service first, failed attempts are retried according to the retry settings. Every request is traced
+// as a span, which includes its attempts.
+func InstrumentSession(sess *session.Session) *session.Session {
+	instrumented := sess.Copy()
+	request.WithRetryer(instrumented.Config, newRetryer(instrumented.Config))
+	instrumented.Handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: "awsclient.RateLimit",
+		Fn:   waitForRateLimit,
+	})
+	addTracingHandlers(&instrumented.Handlers)
+	instrumented.Handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: "awsclient.ExporterMetrics",
+		Fn: func(r *request.Request) {
+			if AwsExporterMetrics != nil {
+				AwsExporterMetrics.ObserveRequest(r)
+			}
+		},
+	})
+	return instrumented
 }
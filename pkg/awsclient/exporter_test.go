@@ -0,0 +1,34 @@
+package awsclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRequest(err error) *request.Request {
+	return &request.Request{
+		ClientInfo: metadata.ClientInfo{ServiceName: "ec2"},
+		Operation:  &request.Operation{Name: "DescribeVpcs"},
+		Config:     aws.Config{Region: aws.String("us-east-1")},
+		Error:      err,
+	}
+}
+
+func TestObserveRequest(t *testing.T) {
+	metrics := NewExporterMetrics("test")
+
+	metrics.ObserveRequest(newTestRequest(nil))
+	metrics.ObserveRequest(newTestRequest(awserr.New("Throttling", "Rate exceeded", nil)))
+	metrics.ObserveRequest(newTestRequest(errors.New("boom")))
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.APIRequests.WithLabelValues("ec2", "DescribeVpcs", "us-east-1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.APIErrors.WithLabelValues("ec2", "DescribeVpcs", "us-east-1", "Throttling")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.APIErrors.WithLabelValues("ec2", "DescribeVpcs", "us-east-1", unknownErrorCode)))
+}
@@ -7,15 +7,49 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	aws "github.com/aws/aws-sdk-go/aws"
 	request "github.com/aws/aws-sdk-go/aws/request"
+	acm "github.com/aws/aws-sdk-go/service/acm"
+	apigateway "github.com/aws/aws-sdk-go/service/apigateway"
+	apigatewayv2 "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	backup "github.com/aws/aws-sdk-go/service/backup"
+	cloudfront "github.com/aws/aws-sdk-go/service/cloudfront"
+	cloudtrail "github.com/aws/aws-sdk-go/service/cloudtrail"
+	cloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
+	cloudwatchlogs "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	configservice "github.com/aws/aws-sdk-go/service/configservice"
+	costexplorer "github.com/aws/aws-sdk-go/service/costexplorer"
+	dynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+	ecr "github.com/aws/aws-sdk-go/service/ecr"
+	ecs "github.com/aws/aws-sdk-go/service/ecs"
+	efs "github.com/aws/aws-sdk-go/service/efs"
+	eks "github.com/aws/aws-sdk-go/service/eks"
 	elasticache "github.com/aws/aws-sdk-go/service/elasticache"
+	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	guardduty "github.com/aws/aws-sdk-go/service/guardduty"
+	iam "github.com/aws/aws-sdk-go/service/iam"
 	kafka "github.com/aws/aws-sdk-go/service/kafka"
+	kafkaconnect "github.com/aws/aws-sdk-go/service/kafkaconnect"
+	kinesis "github.com/aws/aws-sdk-go/service/kinesis"
+	kms "github.com/aws/aws-sdk-go/service/kms"
+	lambda "github.com/aws/aws-sdk-go/service/lambda"
+	opensearchservice "github.com/aws/aws-sdk-go/service/opensearchservice"
 	rds "github.com/aws/aws-sdk-go/service/rds"
+	redshift "github.com/aws/aws-sdk-go/service/redshift"
 	route53 "github.com/aws/aws-sdk-go/service/route53"
+	s3 "github.com/aws/aws-sdk-go/service/s3"
+	savingsplans "github.com/aws/aws-sdk-go/service/savingsplans"
+	secretsmanager "github.com/aws/aws-sdk-go/service/secretsmanager"
+	securityhub "github.com/aws/aws-sdk-go/service/securityhub"
 	servicequotas "github.com/aws/aws-sdk-go/service/servicequotas"
+	sns "github.com/aws/aws-sdk-go/service/sns"
+	ssm "github.com/aws/aws-sdk-go/service/ssm"
+	sts "github.com/aws/aws-sdk-go/service/sts"
+	support "github.com/aws/aws-sdk-go/service/support"
+	wafv2 "github.com/aws/aws-sdk-go/service/wafv2"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -42,6 +76,56 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// DescribeActiveReservedInstances mocks base method.
+func (m *MockClient) DescribeActiveReservedInstances(ctx context.Context) ([]*ec2.ReservedInstances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeActiveReservedInstances", ctx)
+	ret0, _ := ret[0].([]*ec2.ReservedInstances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeActiveReservedInstances indicates an expected call of DescribeActiveReservedInstances.
+func (mr *MockClientMockRecorder) DescribeActiveReservedInstances(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeActiveReservedInstances", reflect.TypeOf((*MockClient)(nil).DescribeActiveReservedInstances), ctx)
+}
+
+// DescribeActiveSavingsPlansAll mocks base method.
+func (m *MockClient) DescribeActiveSavingsPlansAll(ctx context.Context) ([]*savingsplans.SavingsPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeActiveSavingsPlansAll", ctx)
+	ret0, _ := ret[0].([]*savingsplans.SavingsPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeActiveSavingsPlansAll indicates an expected call of DescribeActiveSavingsPlansAll.
+func (mr *MockClientMockRecorder) DescribeActiveSavingsPlansAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeActiveSavingsPlansAll", reflect.TypeOf((*MockClient)(nil).DescribeActiveSavingsPlansAll), ctx)
+}
+
+// DescribeAddressesWithContext mocks base method.
+func (m *MockClient) DescribeAddressesWithContext(ctx aws.Context, input *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAddressesWithContext", varargs...)
+	ret0, _ := ret[0].(*ec2.DescribeAddressesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAddressesWithContext indicates an expected call of DescribeAddressesWithContext.
+func (mr *MockClientMockRecorder) DescribeAddressesWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAddressesWithContext", reflect.TypeOf((*MockClient)(nil).DescribeAddressesWithContext), varargs...)
+}
+
 // DescribeCacheClustersAll mocks base method.
 func (m *MockClient) DescribeCacheClustersAll(ctx context.Context) ([]*elasticache.CacheCluster, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +141,106 @@ func (mr *MockClientMockRecorder) DescribeCacheClustersAll(ctx interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCacheClustersAll", reflect.TypeOf((*MockClient)(nil).DescribeCacheClustersAll), ctx)
 }
 
+// DescribeCapacityReservationsAll mocks base method.
+func (m *MockClient) DescribeCapacityReservationsAll(ctx context.Context) ([]*ec2.CapacityReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCapacityReservationsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.CapacityReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCapacityReservationsAll indicates an expected call of DescribeCapacityReservationsAll.
+func (mr *MockClientMockRecorder) DescribeCapacityReservationsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCapacityReservationsAll", reflect.TypeOf((*MockClient)(nil).DescribeCapacityReservationsAll), ctx)
+}
+
+// DescribeCertificatesAll mocks base method.
+func (m *MockClient) DescribeCertificatesAll(ctx context.Context) ([]*rds.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCertificatesAll", ctx)
+	ret0, _ := ret[0].([]*rds.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCertificatesAll indicates an expected call of DescribeCertificatesAll.
+func (mr *MockClientMockRecorder) DescribeCertificatesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCertificatesAll", reflect.TypeOf((*MockClient)(nil).DescribeCertificatesAll), ctx)
+}
+
+// DescribeClusterWithContext mocks base method.
+func (m *MockClient) DescribeClusterWithContext(ctx aws.Context, input *eks.DescribeClusterInput, opts ...request.Option) (*eks.DescribeClusterOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeClusterWithContext", varargs...)
+	ret0, _ := ret[0].(*eks.DescribeClusterOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeClusterWithContext indicates an expected call of DescribeClusterWithContext.
+func (mr *MockClientMockRecorder) DescribeClusterWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeClusterWithContext", reflect.TypeOf((*MockClient)(nil).DescribeClusterWithContext), varargs...)
+}
+
+// DescribeComplianceByConfigRuleAll mocks base method.
+func (m *MockClient) DescribeComplianceByConfigRuleAll(ctx context.Context) ([]*configservice.ComplianceByConfigRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeComplianceByConfigRuleAll", ctx)
+	ret0, _ := ret[0].([]*configservice.ComplianceByConfigRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeComplianceByConfigRuleAll indicates an expected call of DescribeComplianceByConfigRuleAll.
+func (mr *MockClientMockRecorder) DescribeComplianceByConfigRuleAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeComplianceByConfigRuleAll", reflect.TypeOf((*MockClient)(nil).DescribeComplianceByConfigRuleAll), ctx)
+}
+
+// DescribeConfigurationRecorderStatusWithContext mocks base method.
+func (m *MockClient) DescribeConfigurationRecorderStatusWithContext(ctx aws.Context, input *configservice.DescribeConfigurationRecorderStatusInput, opts ...request.Option) (*configservice.DescribeConfigurationRecorderStatusOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeConfigurationRecorderStatusWithContext", varargs...)
+	ret0, _ := ret[0].(*configservice.DescribeConfigurationRecorderStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeConfigurationRecorderStatusWithContext indicates an expected call of DescribeConfigurationRecorderStatusWithContext.
+func (mr *MockClientMockRecorder) DescribeConfigurationRecorderStatusWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeConfigurationRecorderStatusWithContext", reflect.TypeOf((*MockClient)(nil).DescribeConfigurationRecorderStatusWithContext), varargs...)
+}
+
+// DescribeDBClustersAll mocks base method.
+func (m *MockClient) DescribeDBClustersAll(ctx context.Context) ([]*rds.DBCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeDBClustersAll", ctx)
+	ret0, _ := ret[0].([]*rds.DBCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBClustersAll indicates an expected call of DescribeDBClustersAll.
+func (mr *MockClientMockRecorder) DescribeDBClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBClustersAll", reflect.TypeOf((*MockClient)(nil).DescribeDBClustersAll), ctx)
+}
+
 // DescribeDBInstancesAll mocks base method.
 func (m *MockClient) DescribeDBInstancesAll(ctx context.Context) ([]*rds.DBInstance, error) {
 	m.ctrl.T.Helper()
@@ -125,131 +309,1661 @@ func (mr *MockClientMockRecorder) DescribeDBLogFilesPagesWithContext(ctx, input,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBLogFilesPagesWithContext", reflect.TypeOf((*MockClient)(nil).DescribeDBLogFilesPagesWithContext), varargs...)
 }
 
-// DescribePendingMaintenanceActionsAll mocks base method.
-func (m *MockClient) DescribePendingMaintenanceActionsAll(ctx context.Context) ([]*rds.ResourcePendingMaintenanceActions, error) {
+// DescribeDBParameterGroupsAll mocks base method.
+func (m *MockClient) DescribeDBParameterGroupsAll(ctx context.Context) ([]*rds.DBParameterGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribePendingMaintenanceActionsAll", ctx)
-	ret0, _ := ret[0].([]*rds.ResourcePendingMaintenanceActions)
+	ret := m.ctrl.Call(m, "DescribeDBParameterGroupsAll", ctx)
+	ret0, _ := ret[0].([]*rds.DBParameterGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DescribePendingMaintenanceActionsAll indicates an expected call of DescribePendingMaintenanceActionsAll.
-func (mr *MockClientMockRecorder) DescribePendingMaintenanceActionsAll(ctx interface{}) *gomock.Call {
+// DescribeDBParameterGroupsAll indicates an expected call of DescribeDBParameterGroupsAll.
+func (mr *MockClientMockRecorder) DescribeDBParameterGroupsAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePendingMaintenanceActionsAll", reflect.TypeOf((*MockClient)(nil).DescribePendingMaintenanceActionsAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBParameterGroupsAll", reflect.TypeOf((*MockClient)(nil).DescribeDBParameterGroupsAll), ctx)
 }
 
-// DescribePendingMaintenanceActionsPagesWithContext mocks base method.
-func (m *MockClient) DescribePendingMaintenanceActionsPagesWithContext(ctx aws.Context, input *rds.DescribePendingMaintenanceActionsInput, fn func(*rds.DescribePendingMaintenanceActionsOutput, bool) bool, opts ...request.Option) error {
+// DescribeDBParametersAll mocks base method.
+func (m *MockClient) DescribeDBParametersAll(ctx context.Context, parameterGroupName string) ([]*rds.Parameter, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, input, fn}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "DescribePendingMaintenanceActionsPagesWithContext", varargs...)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DescribeDBParametersAll", ctx, parameterGroupName)
+	ret0, _ := ret[0].([]*rds.Parameter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DescribePendingMaintenanceActionsPagesWithContext indicates an expected call of DescribePendingMaintenanceActionsPagesWithContext.
-func (mr *MockClientMockRecorder) DescribePendingMaintenanceActionsPagesWithContext(ctx, input, fn interface{}, opts ...interface{}) *gomock.Call {
+// DescribeDBParametersAll indicates an expected call of DescribeDBParametersAll.
+func (mr *MockClientMockRecorder) DescribeDBParametersAll(ctx, parameterGroupName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, input, fn}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePendingMaintenanceActionsPagesWithContext", reflect.TypeOf((*MockClient)(nil).DescribePendingMaintenanceActionsPagesWithContext), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBParametersAll", reflect.TypeOf((*MockClient)(nil).DescribeDBParametersAll), ctx, parameterGroupName)
 }
 
-// DescribeTransitGatewaysWithContext mocks base method.
-func (m *MockClient) DescribeTransitGatewaysWithContext(ctx aws.Context, input *ec2.DescribeTransitGatewaysInput, opts ...request.Option) (*ec2.DescribeTransitGatewaysOutput, error) {
+// DescribeDBSnapshotsAll mocks base method.
+func (m *MockClient) DescribeDBSnapshotsAll(ctx context.Context, snapshotType string) ([]*rds.DBSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeDBSnapshotsAll", ctx, snapshotType)
+	ret0, _ := ret[0].([]*rds.DBSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBSnapshotsAll indicates an expected call of DescribeDBSnapshotsAll.
+func (mr *MockClientMockRecorder) DescribeDBSnapshotsAll(ctx, snapshotType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBSnapshotsAll", reflect.TypeOf((*MockClient)(nil).DescribeDBSnapshotsAll), ctx, snapshotType)
+}
+
+// DescribeDomainsWithContext mocks base method.
+func (m *MockClient) DescribeDomainsWithContext(ctx aws.Context, input *opensearchservice.DescribeDomainsInput, opts ...request.Option) (*opensearchservice.DescribeDomainsOutput, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, input}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "DescribeTransitGatewaysWithContext", varargs...)
-	ret0, _ := ret[0].(*ec2.DescribeTransitGatewaysOutput)
+	ret := m.ctrl.Call(m, "DescribeDomainsWithContext", varargs...)
+	ret0, _ := ret[0].(*opensearchservice.DescribeDomainsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DescribeTransitGatewaysWithContext indicates an expected call of DescribeTransitGatewaysWithContext.
-func (mr *MockClientMockRecorder) DescribeTransitGatewaysWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+// DescribeDomainsWithContext indicates an expected call of DescribeDomainsWithContext.
+func (mr *MockClientMockRecorder) DescribeDomainsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, input}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTransitGatewaysWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTransitGatewaysWithContext), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDomainsWithContext", reflect.TypeOf((*MockClient)(nil).DescribeDomainsWithContext), varargs...)
 }
 
-// GetHostedZoneLimitWithContext mocks base method.
-func (m *MockClient) GetHostedZoneLimitWithContext(ctx context.Context, input *route53.GetHostedZoneLimitInput, opts ...request.Option) (*route53.GetHostedZoneLimitOutput, error) {
+// DescribeECSClustersAll mocks base method.
+func (m *MockClient) DescribeECSClustersAll(ctx context.Context) ([]*ecs.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeECSClustersAll", ctx)
+	ret0, _ := ret[0].([]*ecs.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeECSClustersAll indicates an expected call of DescribeECSClustersAll.
+func (mr *MockClientMockRecorder) DescribeECSClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeECSClustersAll", reflect.TypeOf((*MockClient)(nil).DescribeECSClustersAll), ctx)
+}
+
+// DescribeECSRunningTasksAll mocks base method.
+func (m *MockClient) DescribeECSRunningTasksAll(ctx context.Context, clusterArn string) ([]*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeECSRunningTasksAll", ctx, clusterArn)
+	ret0, _ := ret[0].([]*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeECSRunningTasksAll indicates an expected call of DescribeECSRunningTasksAll.
+func (mr *MockClientMockRecorder) DescribeECSRunningTasksAll(ctx, clusterArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeECSRunningTasksAll", reflect.TypeOf((*MockClient)(nil).DescribeECSRunningTasksAll), ctx, clusterArn)
+}
+
+// DescribeECSServicesAll mocks base method.
+func (m *MockClient) DescribeECSServicesAll(ctx context.Context, clusterArn string) ([]*ecs.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeECSServicesAll", ctx, clusterArn)
+	ret0, _ := ret[0].([]*ecs.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeECSServicesAll indicates an expected call of DescribeECSServicesAll.
+func (mr *MockClientMockRecorder) DescribeECSServicesAll(ctx, clusterArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeECSServicesAll", reflect.TypeOf((*MockClient)(nil).DescribeECSServicesAll), ctx, clusterArn)
+}
+
+// DescribeFileSystemsAll mocks base method.
+func (m *MockClient) DescribeFileSystemsAll(ctx context.Context) ([]*efs.FileSystemDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeFileSystemsAll", ctx)
+	ret0, _ := ret[0].([]*efs.FileSystemDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeFileSystemsAll indicates an expected call of DescribeFileSystemsAll.
+func (mr *MockClientMockRecorder) DescribeFileSystemsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeFileSystemsAll", reflect.TypeOf((*MockClient)(nil).DescribeFileSystemsAll), ctx)
+}
+
+// DescribeImagesAll mocks base method.
+func (m *MockClient) DescribeImagesAll(ctx context.Context, repositoryName string) ([]*ecr.ImageDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeImagesAll", ctx, repositoryName)
+	ret0, _ := ret[0].([]*ecr.ImageDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeImagesAll indicates an expected call of DescribeImagesAll.
+func (mr *MockClientMockRecorder) DescribeImagesAll(ctx, repositoryName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeImagesAll", reflect.TypeOf((*MockClient)(nil).DescribeImagesAll), ctx, repositoryName)
+}
+
+// DescribeInstanceTypesWithContext mocks base method.
+func (m *MockClient) DescribeInstanceTypesWithContext(ctx aws.Context, input *ec2.DescribeInstanceTypesInput, opts ...request.Option) (*ec2.DescribeInstanceTypesOutput, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, input}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetHostedZoneLimitWithContext", varargs...)
-	ret0, _ := ret[0].(*route53.GetHostedZoneLimitOutput)
+	ret := m.ctrl.Call(m, "DescribeInstanceTypesWithContext", varargs...)
+	ret0, _ := ret[0].(*ec2.DescribeInstanceTypesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetHostedZoneLimitWithContext indicates an expected call of GetHostedZoneLimitWithContext.
-func (mr *MockClientMockRecorder) GetHostedZoneLimitWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+// DescribeInstanceTypesWithContext indicates an expected call of DescribeInstanceTypesWithContext.
+func (mr *MockClientMockRecorder) DescribeInstanceTypesWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, input}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneLimitWithContext", reflect.TypeOf((*MockClient)(nil).GetHostedZoneLimitWithContext), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstanceTypesWithContext", reflect.TypeOf((*MockClient)(nil).DescribeInstanceTypesWithContext), varargs...)
 }
 
-// GetServiceQuotaWithContext mocks base method.
-func (m *MockClient) GetServiceQuotaWithContext(ctx aws.Context, input *servicequotas.GetServiceQuotaInput, opts ...request.Option) (*servicequotas.GetServiceQuotaOutput, error) {
+// DescribeInstancesAll mocks base method.
+func (m *MockClient) DescribeInstancesAll(ctx context.Context) ([]*ec2.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInstancesAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInstancesAll indicates an expected call of DescribeInstancesAll.
+func (mr *MockClientMockRecorder) DescribeInstancesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstancesAll", reflect.TypeOf((*MockClient)(nil).DescribeInstancesAll), ctx)
+}
+
+// DescribeInternetGatewaysAll mocks base method.
+func (m *MockClient) DescribeInternetGatewaysAll(ctx context.Context) ([]*ec2.InternetGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInternetGatewaysAll", ctx)
+	ret0, _ := ret[0].([]*ec2.InternetGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInternetGatewaysAll indicates an expected call of DescribeInternetGatewaysAll.
+func (mr *MockClientMockRecorder) DescribeInternetGatewaysAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInternetGatewaysAll", reflect.TypeOf((*MockClient)(nil).DescribeInternetGatewaysAll), ctx)
+}
+
+// DescribeKeyWithContext mocks base method.
+func (m *MockClient) DescribeKeyWithContext(ctx aws.Context, input *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, input}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetServiceQuotaWithContext", varargs...)
-	ret0, _ := ret[0].(*servicequotas.GetServiceQuotaOutput)
+	ret := m.ctrl.Call(m, "DescribeKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*kms.DescribeKeyOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetServiceQuotaWithContext indicates an expected call of GetServiceQuotaWithContext.
-func (mr *MockClientMockRecorder) GetServiceQuotaWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+// DescribeKeyWithContext indicates an expected call of DescribeKeyWithContext.
+func (mr *MockClientMockRecorder) DescribeKeyWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, input}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceQuotaWithContext", reflect.TypeOf((*MockClient)(nil).GetServiceQuotaWithContext), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeKeyWithContext", reflect.TypeOf((*MockClient)(nil).DescribeKeyWithContext), varargs...)
 }
 
-// ListClustersAll mocks base method.
-func (m *MockClient) ListClustersAll(ctx context.Context) ([]*kafka.ClusterInfo, error) {
+// DescribeLifecycleConfigurationWithContext mocks base method.
+func (m *MockClient) DescribeLifecycleConfigurationWithContext(ctx aws.Context, input *efs.DescribeLifecycleConfigurationInput, opts ...request.Option) (*efs.DescribeLifecycleConfigurationOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClustersAll", ctx)
-	ret0, _ := ret[0].([]*kafka.ClusterInfo)
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeLifecycleConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*efs.DescribeLifecycleConfigurationOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListClustersAll indicates an expected call of ListClustersAll.
-func (mr *MockClientMockRecorder) ListClustersAll(ctx interface{}) *gomock.Call {
+// DescribeLifecycleConfigurationWithContext indicates an expected call of DescribeLifecycleConfigurationWithContext.
+func (mr *MockClientMockRecorder) DescribeLifecycleConfigurationWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClustersAll", reflect.TypeOf((*MockClient)(nil).ListClustersAll), ctx)
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLifecycleConfigurationWithContext", reflect.TypeOf((*MockClient)(nil).DescribeLifecycleConfigurationWithContext), varargs...)
 }
 
-// ListHostedZonesWithContext mocks base method.
-func (m *MockClient) ListHostedZonesWithContext(ctx context.Context, input *route53.ListHostedZonesInput, opts ...request.Option) (*route53.ListHostedZonesOutput, error) {
+// DescribeLimitsWithContext mocks base method.
+func (m *MockClient) DescribeLimitsWithContext(ctx aws.Context, input *kinesis.DescribeLimitsInput, opts ...request.Option) (*kinesis.DescribeLimitsOutput, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, input}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "ListHostedZonesWithContext", varargs...)
-	ret0, _ := ret[0].(*route53.ListHostedZonesOutput)
+	ret := m.ctrl.Call(m, "DescribeLimitsWithContext", varargs...)
+	ret0, _ := ret[0].(*kinesis.DescribeLimitsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListHostedZonesWithContext indicates an expected call of ListHostedZonesWithContext.
-func (mr *MockClientMockRecorder) ListHostedZonesWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+// DescribeLimitsWithContext indicates an expected call of DescribeLimitsWithContext.
+func (mr *MockClientMockRecorder) DescribeLimitsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, input}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesWithContext", reflect.TypeOf((*MockClient)(nil).ListHostedZonesWithContext), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLimitsWithContext", reflect.TypeOf((*MockClient)(nil).DescribeLimitsWithContext), varargs...)
+}
+
+// DescribeListenersAll mocks base method.
+func (m *MockClient) DescribeListenersAll(ctx context.Context, loadBalancerArn string) ([]*elbv2.Listener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeListenersAll", ctx, loadBalancerArn)
+	ret0, _ := ret[0].([]*elbv2.Listener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeListenersAll indicates an expected call of DescribeListenersAll.
+func (mr *MockClientMockRecorder) DescribeListenersAll(ctx, loadBalancerArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeListenersAll", reflect.TypeOf((*MockClient)(nil).DescribeListenersAll), ctx, loadBalancerArn)
+}
+
+// DescribeLoadBalancersAll mocks base method.
+func (m *MockClient) DescribeLoadBalancersAll(ctx context.Context) ([]*elbv2.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeLoadBalancersAll", ctx)
+	ret0, _ := ret[0].([]*elbv2.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLoadBalancersAll indicates an expected call of DescribeLoadBalancersAll.
+func (mr *MockClientMockRecorder) DescribeLoadBalancersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancersAll", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancersAll), ctx)
+}
+
+// DescribeLogGroupsAll mocks base method.
+func (m *MockClient) DescribeLogGroupsAll(ctx context.Context) ([]*cloudwatchlogs.LogGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeLogGroupsAll", ctx)
+	ret0, _ := ret[0].([]*cloudwatchlogs.LogGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLogGroupsAll indicates an expected call of DescribeLogGroupsAll.
+func (mr *MockClientMockRecorder) DescribeLogGroupsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLogGroupsAll", reflect.TypeOf((*MockClient)(nil).DescribeLogGroupsAll), ctx)
+}
+
+// DescribeMountTargetsAll mocks base method.
+func (m *MockClient) DescribeMountTargetsAll(ctx context.Context, fileSystemId string) ([]*efs.MountTargetDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeMountTargetsAll", ctx, fileSystemId)
+	ret0, _ := ret[0].([]*efs.MountTargetDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeMountTargetsAll indicates an expected call of DescribeMountTargetsAll.
+func (mr *MockClientMockRecorder) DescribeMountTargetsAll(ctx, fileSystemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeMountTargetsAll", reflect.TypeOf((*MockClient)(nil).DescribeMountTargetsAll), ctx, fileSystemId)
+}
+
+// DescribeNatGatewaysAll mocks base method.
+func (m *MockClient) DescribeNatGatewaysAll(ctx context.Context) ([]*ec2.NatGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNatGatewaysAll", ctx)
+	ret0, _ := ret[0].([]*ec2.NatGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNatGatewaysAll indicates an expected call of DescribeNatGatewaysAll.
+func (mr *MockClientMockRecorder) DescribeNatGatewaysAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNatGatewaysAll", reflect.TypeOf((*MockClient)(nil).DescribeNatGatewaysAll), ctx)
+}
+
+// DescribeNetworkInterfacesAll mocks base method.
+func (m *MockClient) DescribeNetworkInterfacesAll(ctx context.Context) ([]*ec2.NetworkInterface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNetworkInterfacesAll", ctx)
+	ret0, _ := ret[0].([]*ec2.NetworkInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNetworkInterfacesAll indicates an expected call of DescribeNetworkInterfacesAll.
+func (mr *MockClientMockRecorder) DescribeNetworkInterfacesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNetworkInterfacesAll", reflect.TypeOf((*MockClient)(nil).DescribeNetworkInterfacesAll), ctx)
+}
+
+// DescribeOwnedImagesAll mocks base method.
+func (m *MockClient) DescribeOwnedImagesAll(ctx context.Context) ([]*ec2.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeOwnedImagesAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeOwnedImagesAll indicates an expected call of DescribeOwnedImagesAll.
+func (mr *MockClientMockRecorder) DescribeOwnedImagesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeOwnedImagesAll", reflect.TypeOf((*MockClient)(nil).DescribeOwnedImagesAll), ctx)
+}
+
+// DescribeParametersAll mocks base method.
+func (m *MockClient) DescribeParametersAll(ctx context.Context) ([]*ssm.ParameterMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeParametersAll", ctx)
+	ret0, _ := ret[0].([]*ssm.ParameterMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeParametersAll indicates an expected call of DescribeParametersAll.
+func (mr *MockClientMockRecorder) DescribeParametersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeParametersAll", reflect.TypeOf((*MockClient)(nil).DescribeParametersAll), ctx)
+}
+
+// DescribePendingMaintenanceActionsAll mocks base method.
+func (m *MockClient) DescribePendingMaintenanceActionsAll(ctx context.Context) ([]*rds.ResourcePendingMaintenanceActions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribePendingMaintenanceActionsAll", ctx)
+	ret0, _ := ret[0].([]*rds.ResourcePendingMaintenanceActions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribePendingMaintenanceActionsAll indicates an expected call of DescribePendingMaintenanceActionsAll.
+func (mr *MockClientMockRecorder) DescribePendingMaintenanceActionsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePendingMaintenanceActionsAll", reflect.TypeOf((*MockClient)(nil).DescribePendingMaintenanceActionsAll), ctx)
+}
+
+// DescribePendingMaintenanceActionsPagesWithContext mocks base method.
+func (m *MockClient) DescribePendingMaintenanceActionsPagesWithContext(ctx aws.Context, input *rds.DescribePendingMaintenanceActionsInput, fn func(*rds.DescribePendingMaintenanceActionsOutput, bool) bool, opts ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribePendingMaintenanceActionsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribePendingMaintenanceActionsPagesWithContext indicates an expected call of DescribePendingMaintenanceActionsPagesWithContext.
+func (mr *MockClientMockRecorder) DescribePendingMaintenanceActionsPagesWithContext(ctx, input, fn interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePendingMaintenanceActionsPagesWithContext", reflect.TypeOf((*MockClient)(nil).DescribePendingMaintenanceActionsPagesWithContext), varargs...)
+}
+
+// DescribeRedshiftClustersAll mocks base method.
+func (m *MockClient) DescribeRedshiftClustersAll(ctx context.Context) ([]*redshift.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeRedshiftClustersAll", ctx)
+	ret0, _ := ret[0].([]*redshift.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRedshiftClustersAll indicates an expected call of DescribeRedshiftClustersAll.
+func (mr *MockClientMockRecorder) DescribeRedshiftClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRedshiftClustersAll", reflect.TypeOf((*MockClient)(nil).DescribeRedshiftClustersAll), ctx)
+}
+
+// DescribeRegionsWithContext mocks base method.
+func (m *MockClient) DescribeRegionsWithContext(ctx aws.Context, input *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeRegionsWithContext", varargs...)
+	ret0, _ := ret[0].(*ec2.DescribeRegionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRegionsWithContext indicates an expected call of DescribeRegionsWithContext.
+func (mr *MockClientMockRecorder) DescribeRegionsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRegionsWithContext", reflect.TypeOf((*MockClient)(nil).DescribeRegionsWithContext), varargs...)
+}
+
+// DescribeRepositoriesAll mocks base method.
+func (m *MockClient) DescribeRepositoriesAll(ctx context.Context) ([]*ecr.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeRepositoriesAll", ctx)
+	ret0, _ := ret[0].([]*ecr.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRepositoriesAll indicates an expected call of DescribeRepositoriesAll.
+func (mr *MockClientMockRecorder) DescribeRepositoriesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRepositoriesAll", reflect.TypeOf((*MockClient)(nil).DescribeRepositoriesAll), ctx)
+}
+
+// DescribeReservedDBInstancesAll mocks base method.
+func (m *MockClient) DescribeReservedDBInstancesAll(ctx context.Context) ([]*rds.ReservedDBInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeReservedDBInstancesAll", ctx)
+	ret0, _ := ret[0].([]*rds.ReservedDBInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReservedDBInstancesAll indicates an expected call of DescribeReservedDBInstancesAll.
+func (mr *MockClientMockRecorder) DescribeReservedDBInstancesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReservedDBInstancesAll", reflect.TypeOf((*MockClient)(nil).DescribeReservedDBInstancesAll), ctx)
+}
+
+// DescribeRouteTablesAll mocks base method.
+func (m *MockClient) DescribeRouteTablesAll(ctx context.Context) ([]*ec2.RouteTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeRouteTablesAll", ctx)
+	ret0, _ := ret[0].([]*ec2.RouteTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRouteTablesAll indicates an expected call of DescribeRouteTablesAll.
+func (mr *MockClientMockRecorder) DescribeRouteTablesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRouteTablesAll", reflect.TypeOf((*MockClient)(nil).DescribeRouteTablesAll), ctx)
+}
+
+// DescribeSecurityGroupsAll mocks base method.
+func (m *MockClient) DescribeSecurityGroupsAll(ctx context.Context) ([]*ec2.SecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeSecurityGroupsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.SecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSecurityGroupsAll indicates an expected call of DescribeSecurityGroupsAll.
+func (mr *MockClientMockRecorder) DescribeSecurityGroupsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSecurityGroupsAll", reflect.TypeOf((*MockClient)(nil).DescribeSecurityGroupsAll), ctx)
+}
+
+// DescribeServerlessCachesAll mocks base method.
+func (m *MockClient) DescribeServerlessCachesAll(ctx context.Context) ([]*elasticache.ServerlessCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeServerlessCachesAll", ctx)
+	ret0, _ := ret[0].([]*elasticache.ServerlessCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeServerlessCachesAll indicates an expected call of DescribeServerlessCachesAll.
+func (mr *MockClientMockRecorder) DescribeServerlessCachesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeServerlessCachesAll", reflect.TypeOf((*MockClient)(nil).DescribeServerlessCachesAll), ctx)
+}
+
+// DescribeSnapshotsAll mocks base method.
+func (m *MockClient) DescribeSnapshotsAll(ctx context.Context) ([]*ec2.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeSnapshotsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSnapshotsAll indicates an expected call of DescribeSnapshotsAll.
+func (mr *MockClientMockRecorder) DescribeSnapshotsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSnapshotsAll", reflect.TypeOf((*MockClient)(nil).DescribeSnapshotsAll), ctx)
+}
+
+// DescribeSpotInstanceRequestsAll mocks base method.
+func (m *MockClient) DescribeSpotInstanceRequestsAll(ctx context.Context, states ...string) ([]*ec2.SpotInstanceRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range states {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeSpotInstanceRequestsAll", varargs...)
+	ret0, _ := ret[0].([]*ec2.SpotInstanceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSpotInstanceRequestsAll indicates an expected call of DescribeSpotInstanceRequestsAll.
+func (mr *MockClientMockRecorder) DescribeSpotInstanceRequestsAll(ctx interface{}, states ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, states...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSpotInstanceRequestsAll", reflect.TypeOf((*MockClient)(nil).DescribeSpotInstanceRequestsAll), varargs...)
+}
+
+// DescribeStreamSummaryWithContext mocks base method.
+func (m *MockClient) DescribeStreamSummaryWithContext(ctx aws.Context, input *kinesis.DescribeStreamSummaryInput, opts ...request.Option) (*kinesis.DescribeStreamSummaryOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeStreamSummaryWithContext", varargs...)
+	ret0, _ := ret[0].(*kinesis.DescribeStreamSummaryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStreamSummaryWithContext indicates an expected call of DescribeStreamSummaryWithContext.
+func (mr *MockClientMockRecorder) DescribeStreamSummaryWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStreamSummaryWithContext", reflect.TypeOf((*MockClient)(nil).DescribeStreamSummaryWithContext), varargs...)
+}
+
+// DescribeSubnetsAll mocks base method.
+func (m *MockClient) DescribeSubnetsAll(ctx context.Context) ([]*ec2.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeSubnetsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSubnetsAll indicates an expected call of DescribeSubnetsAll.
+func (mr *MockClientMockRecorder) DescribeSubnetsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSubnetsAll", reflect.TypeOf((*MockClient)(nil).DescribeSubnetsAll), ctx)
+}
+
+// DescribeTableWithContext mocks base method.
+func (m *MockClient) DescribeTableWithContext(ctx aws.Context, input *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTableWithContext", varargs...)
+	ret0, _ := ret[0].(*dynamodb.DescribeTableOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTableWithContext indicates an expected call of DescribeTableWithContext.
+func (mr *MockClientMockRecorder) DescribeTableWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTableWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTableWithContext), varargs...)
+}
+
+// DescribeTargetGroupsAll mocks base method.
+func (m *MockClient) DescribeTargetGroupsAll(ctx context.Context) ([]*elbv2.TargetGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTargetGroupsAll", ctx)
+	ret0, _ := ret[0].([]*elbv2.TargetGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTargetGroupsAll indicates an expected call of DescribeTargetGroupsAll.
+func (mr *MockClientMockRecorder) DescribeTargetGroupsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetGroupsAll", reflect.TypeOf((*MockClient)(nil).DescribeTargetGroupsAll), ctx)
+}
+
+// DescribeTrailsWithContext mocks base method.
+func (m *MockClient) DescribeTrailsWithContext(ctx aws.Context, input *cloudtrail.DescribeTrailsInput, opts ...request.Option) (*cloudtrail.DescribeTrailsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrailsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudtrail.DescribeTrailsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrailsWithContext indicates an expected call of DescribeTrailsWithContext.
+func (mr *MockClientMockRecorder) DescribeTrailsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrailsWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTrailsWithContext), varargs...)
+}
+
+// DescribeTransitGatewayVpcAttachmentsAll mocks base method.
+func (m *MockClient) DescribeTransitGatewayVpcAttachmentsAll(ctx context.Context) ([]*ec2.TransitGatewayVpcAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTransitGatewayVpcAttachmentsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.TransitGatewayVpcAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTransitGatewayVpcAttachmentsAll indicates an expected call of DescribeTransitGatewayVpcAttachmentsAll.
+func (mr *MockClientMockRecorder) DescribeTransitGatewayVpcAttachmentsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTransitGatewayVpcAttachmentsAll", reflect.TypeOf((*MockClient)(nil).DescribeTransitGatewayVpcAttachmentsAll), ctx)
+}
+
+// DescribeTransitGatewaysWithContext mocks base method.
+func (m *MockClient) DescribeTransitGatewaysWithContext(ctx aws.Context, input *ec2.DescribeTransitGatewaysInput, opts ...request.Option) (*ec2.DescribeTransitGatewaysOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTransitGatewaysWithContext", varargs...)
+	ret0, _ := ret[0].(*ec2.DescribeTransitGatewaysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTransitGatewaysWithContext indicates an expected call of DescribeTransitGatewaysWithContext.
+func (mr *MockClientMockRecorder) DescribeTransitGatewaysWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTransitGatewaysWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTransitGatewaysWithContext), varargs...)
+}
+
+// DescribeTrustedAdvisorCheckResultWithContext mocks base method.
+func (m *MockClient) DescribeTrustedAdvisorCheckResultWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorCheckResultInput, opts ...request.Option) (*support.DescribeTrustedAdvisorCheckResultOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustedAdvisorCheckResultWithContext", varargs...)
+	ret0, _ := ret[0].(*support.DescribeTrustedAdvisorCheckResultOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustedAdvisorCheckResultWithContext indicates an expected call of DescribeTrustedAdvisorCheckResultWithContext.
+func (mr *MockClientMockRecorder) DescribeTrustedAdvisorCheckResultWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustedAdvisorCheckResultWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTrustedAdvisorCheckResultWithContext), varargs...)
+}
+
+// DescribeTrustedAdvisorChecksWithContext mocks base method.
+func (m *MockClient) DescribeTrustedAdvisorChecksWithContext(ctx aws.Context, input *support.DescribeTrustedAdvisorChecksInput, opts ...request.Option) (*support.DescribeTrustedAdvisorChecksOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustedAdvisorChecksWithContext", varargs...)
+	ret0, _ := ret[0].(*support.DescribeTrustedAdvisorChecksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustedAdvisorChecksWithContext indicates an expected call of DescribeTrustedAdvisorChecksWithContext.
+func (mr *MockClientMockRecorder) DescribeTrustedAdvisorChecksWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustedAdvisorChecksWithContext", reflect.TypeOf((*MockClient)(nil).DescribeTrustedAdvisorChecksWithContext), varargs...)
+}
+
+// DescribeVolumesAll mocks base method.
+func (m *MockClient) DescribeVolumesAll(ctx context.Context) ([]*ec2.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeVolumesAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeVolumesAll indicates an expected call of DescribeVolumesAll.
+func (mr *MockClientMockRecorder) DescribeVolumesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVolumesAll", reflect.TypeOf((*MockClient)(nil).DescribeVolumesAll), ctx)
+}
+
+// DescribeVpcEndpointsAll mocks base method.
+func (m *MockClient) DescribeVpcEndpointsAll(ctx context.Context) ([]*ec2.VpcEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeVpcEndpointsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.VpcEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeVpcEndpointsAll indicates an expected call of DescribeVpcEndpointsAll.
+func (mr *MockClientMockRecorder) DescribeVpcEndpointsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointsAll", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointsAll), ctx)
+}
+
+// DescribeVpcPeeringConnectionsAll mocks base method.
+func (m *MockClient) DescribeVpcPeeringConnectionsAll(ctx context.Context) ([]*ec2.VpcPeeringConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeVpcPeeringConnectionsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.VpcPeeringConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeVpcPeeringConnectionsAll indicates an expected call of DescribeVpcPeeringConnectionsAll.
+func (mr *MockClientMockRecorder) DescribeVpcPeeringConnectionsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcPeeringConnectionsAll", reflect.TypeOf((*MockClient)(nil).DescribeVpcPeeringConnectionsAll), ctx)
+}
+
+// DescribeVpcsAll mocks base method.
+func (m *MockClient) DescribeVpcsAll(ctx context.Context) ([]*ec2.Vpc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeVpcsAll", ctx)
+	ret0, _ := ret[0].([]*ec2.Vpc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeVpcsAll indicates an expected call of DescribeVpcsAll.
+func (mr *MockClientMockRecorder) DescribeVpcsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcsAll", reflect.TypeOf((*MockClient)(nil).DescribeVpcsAll), ctx)
+}
+
+// GenerateCredentialReportWithContext mocks base method.
+func (m *MockClient) GenerateCredentialReportWithContext(ctx aws.Context, input *iam.GenerateCredentialReportInput, opts ...request.Option) (*iam.GenerateCredentialReportOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateCredentialReportWithContext", varargs...)
+	ret0, _ := ret[0].(*iam.GenerateCredentialReportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateCredentialReportWithContext indicates an expected call of GenerateCredentialReportWithContext.
+func (mr *MockClientMockRecorder) GenerateCredentialReportWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCredentialReportWithContext", reflect.TypeOf((*MockClient)(nil).GenerateCredentialReportWithContext), varargs...)
+}
+
+// GetAccountLimitWithContext mocks base method.
+func (m *MockClient) GetAccountLimitWithContext(ctx context.Context, input *route53.GetAccountLimitInput, opts ...request.Option) (*route53.GetAccountLimitOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountLimitWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetAccountLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLimitWithContext indicates an expected call of GetAccountLimitWithContext.
+func (mr *MockClientMockRecorder) GetAccountLimitWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLimitWithContext", reflect.TypeOf((*MockClient)(nil).GetAccountLimitWithContext), varargs...)
+}
+
+// GetAccountSettingsWithContext mocks base method.
+func (m *MockClient) GetAccountSettingsWithContext(ctx aws.Context, input *lambda.GetAccountSettingsInput, opts ...request.Option) (*lambda.GetAccountSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountSettingsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetAccountSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountSettingsWithContext indicates an expected call of GetAccountSettingsWithContext.
+func (mr *MockClientMockRecorder) GetAccountSettingsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSettingsWithContext", reflect.TypeOf((*MockClient)(nil).GetAccountSettingsWithContext), varargs...)
+}
+
+// GetAccountSummaryWithContext mocks base method.
+func (m *MockClient) GetAccountSummaryWithContext(ctx aws.Context, input *iam.GetAccountSummaryInput, opts ...request.Option) (*iam.GetAccountSummaryOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountSummaryWithContext", varargs...)
+	ret0, _ := ret[0].(*iam.GetAccountSummaryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountSummaryWithContext indicates an expected call of GetAccountSummaryWithContext.
+func (mr *MockClientMockRecorder) GetAccountSummaryWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSummaryWithContext", reflect.TypeOf((*MockClient)(nil).GetAccountSummaryWithContext), varargs...)
+}
+
+// GetApiKeysAll mocks base method.
+func (m *MockClient) GetApiKeysAll(ctx context.Context) ([]*apigateway.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeysAll", ctx)
+	ret0, _ := ret[0].([]*apigateway.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeysAll indicates an expected call of GetApiKeysAll.
+func (mr *MockClientMockRecorder) GetApiKeysAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeysAll", reflect.TypeOf((*MockClient)(nil).GetApiKeysAll), ctx)
+}
+
+// GetCallerIdentityWithContext mocks base method.
+func (m *MockClient) GetCallerIdentityWithContext(ctx aws.Context, input *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCallerIdentityWithContext", varargs...)
+	ret0, _ := ret[0].(*sts.GetCallerIdentityOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallerIdentityWithContext indicates an expected call of GetCallerIdentityWithContext.
+func (mr *MockClientMockRecorder) GetCallerIdentityWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentityWithContext", reflect.TypeOf((*MockClient)(nil).GetCallerIdentityWithContext), varargs...)
+}
+
+// GetComplianceDetailsByConfigRuleAll mocks base method.
+func (m *MockClient) GetComplianceDetailsByConfigRuleAll(ctx context.Context, configRuleName, complianceType string) ([]*configservice.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComplianceDetailsByConfigRuleAll", ctx, configRuleName, complianceType)
+	ret0, _ := ret[0].([]*configservice.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComplianceDetailsByConfigRuleAll indicates an expected call of GetComplianceDetailsByConfigRuleAll.
+func (mr *MockClientMockRecorder) GetComplianceDetailsByConfigRuleAll(ctx, configRuleName, complianceType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComplianceDetailsByConfigRuleAll", reflect.TypeOf((*MockClient)(nil).GetComplianceDetailsByConfigRuleAll), ctx, configRuleName, complianceType)
+}
+
+// GetCostAndUsageAll mocks base method.
+func (m *MockClient) GetCostAndUsageAll(ctx context.Context, input *costexplorer.GetCostAndUsageInput) ([]*costexplorer.ResultByTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCostAndUsageAll", ctx, input)
+	ret0, _ := ret[0].([]*costexplorer.ResultByTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCostAndUsageAll indicates an expected call of GetCostAndUsageAll.
+func (mr *MockClientMockRecorder) GetCostAndUsageAll(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCostAndUsageAll", reflect.TypeOf((*MockClient)(nil).GetCostAndUsageAll), ctx, input)
+}
+
+// GetCredentialReportWithContext mocks base method.
+func (m *MockClient) GetCredentialReportWithContext(ctx aws.Context, input *iam.GetCredentialReportInput, opts ...request.Option) (*iam.GetCredentialReportOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCredentialReportWithContext", varargs...)
+	ret0, _ := ret[0].(*iam.GetCredentialReportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialReportWithContext indicates an expected call of GetCredentialReportWithContext.
+func (mr *MockClientMockRecorder) GetCredentialReportWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialReportWithContext", reflect.TypeOf((*MockClient)(nil).GetCredentialReportWithContext), varargs...)
+}
+
+// GetDomainNamesAll mocks base method.
+func (m *MockClient) GetDomainNamesAll(ctx context.Context) ([]*apigateway.DomainName, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainNamesAll", ctx)
+	ret0, _ := ret[0].([]*apigateway.DomainName)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainNamesAll indicates an expected call of GetDomainNamesAll.
+func (mr *MockClientMockRecorder) GetDomainNamesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainNamesAll", reflect.TypeOf((*MockClient)(nil).GetDomainNamesAll), ctx)
+}
+
+// GetEnabledStandardsAll mocks base method.
+func (m *MockClient) GetEnabledStandardsAll(ctx context.Context) ([]*securityhub.StandardsSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledStandardsAll", ctx)
+	ret0, _ := ret[0].([]*securityhub.StandardsSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledStandardsAll indicates an expected call of GetEnabledStandardsAll.
+func (mr *MockClientMockRecorder) GetEnabledStandardsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledStandardsAll", reflect.TypeOf((*MockClient)(nil).GetEnabledStandardsAll), ctx)
+}
+
+// GetFindingsAll mocks base method.
+func (m *MockClient) GetFindingsAll(ctx context.Context, filters *securityhub.AwsSecurityFindingFilters) ([]*securityhub.AwsSecurityFinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFindingsAll", ctx, filters)
+	ret0, _ := ret[0].([]*securityhub.AwsSecurityFinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFindingsAll indicates an expected call of GetFindingsAll.
+func (mr *MockClientMockRecorder) GetFindingsAll(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFindingsAll", reflect.TypeOf((*MockClient)(nil).GetFindingsAll), ctx, filters)
+}
+
+// GetFindingsStatisticsWithContext mocks base method.
+func (m *MockClient) GetFindingsStatisticsWithContext(ctx aws.Context, input *guardduty.GetFindingsStatisticsInput, opts ...request.Option) (*guardduty.GetFindingsStatisticsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFindingsStatisticsWithContext", varargs...)
+	ret0, _ := ret[0].(*guardduty.GetFindingsStatisticsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFindingsStatisticsWithContext indicates an expected call of GetFindingsStatisticsWithContext.
+func (mr *MockClientMockRecorder) GetFindingsStatisticsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFindingsStatisticsWithContext", reflect.TypeOf((*MockClient)(nil).GetFindingsStatisticsWithContext), varargs...)
+}
+
+// GetHTTPApisAll mocks base method.
+func (m *MockClient) GetHTTPApisAll(ctx context.Context) ([]*apigatewayv2.Api, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPApisAll", ctx)
+	ret0, _ := ret[0].([]*apigatewayv2.Api)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHTTPApisAll indicates an expected call of GetHTTPApisAll.
+func (mr *MockClientMockRecorder) GetHTTPApisAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPApisAll", reflect.TypeOf((*MockClient)(nil).GetHTTPApisAll), ctx)
+}
+
+// GetHealthCheckStatusWithContext mocks base method.
+func (m *MockClient) GetHealthCheckStatusWithContext(ctx context.Context, input *route53.GetHealthCheckStatusInput, opts ...request.Option) (*route53.GetHealthCheckStatusOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealthCheckStatusWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHealthCheckStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckStatusWithContext indicates an expected call of GetHealthCheckStatusWithContext.
+func (mr *MockClientMockRecorder) GetHealthCheckStatusWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckStatusWithContext", reflect.TypeOf((*MockClient)(nil).GetHealthCheckStatusWithContext), varargs...)
+}
+
+// GetHostedZoneLimitWithContext mocks base method.
+func (m *MockClient) GetHostedZoneLimitWithContext(ctx context.Context, input *route53.GetHostedZoneLimitInput, opts ...request.Option) (*route53.GetHostedZoneLimitOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostedZoneLimitWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHostedZoneLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneLimitWithContext indicates an expected call of GetHostedZoneLimitWithContext.
+func (mr *MockClientMockRecorder) GetHostedZoneLimitWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneLimitWithContext", reflect.TypeOf((*MockClient)(nil).GetHostedZoneLimitWithContext), varargs...)
+}
+
+// GetKeyRotationStatusWithContext mocks base method.
+func (m *MockClient) GetKeyRotationStatusWithContext(ctx aws.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetKeyRotationStatusWithContext", varargs...)
+	ret0, _ := ret[0].(*kms.GetKeyRotationStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyRotationStatusWithContext indicates an expected call of GetKeyRotationStatusWithContext.
+func (mr *MockClientMockRecorder) GetKeyRotationStatusWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyRotationStatusWithContext", reflect.TypeOf((*MockClient)(nil).GetKeyRotationStatusWithContext), varargs...)
+}
+
+// GetLifecyclePolicyWithContext mocks base method.
+func (m *MockClient) GetLifecyclePolicyWithContext(ctx aws.Context, input *ecr.GetLifecyclePolicyInput, opts ...request.Option) (*ecr.GetLifecyclePolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLifecyclePolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*ecr.GetLifecyclePolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLifecyclePolicyWithContext indicates an expected call of GetLifecyclePolicyWithContext.
+func (mr *MockClientMockRecorder) GetLifecyclePolicyWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLifecyclePolicyWithContext", reflect.TypeOf((*MockClient)(nil).GetLifecyclePolicyWithContext), varargs...)
+}
+
+// GetMetricDataAll mocks base method.
+func (m *MockClient) GetMetricDataAll(ctx context.Context, input *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricDataAll", ctx, input)
+	ret0, _ := ret[0].([]*cloudwatch.MetricDataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricDataAll indicates an expected call of GetMetricDataAll.
+func (mr *MockClientMockRecorder) GetMetricDataAll(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricDataAll", reflect.TypeOf((*MockClient)(nil).GetMetricDataAll), ctx, input)
+}
+
+// GetRestApisAll mocks base method.
+func (m *MockClient) GetRestApisAll(ctx context.Context) ([]*apigateway.RestApi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestApisAll", ctx)
+	ret0, _ := ret[0].([]*apigateway.RestApi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestApisAll indicates an expected call of GetRestApisAll.
+func (mr *MockClientMockRecorder) GetRestApisAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestApisAll", reflect.TypeOf((*MockClient)(nil).GetRestApisAll), ctx)
+}
+
+// GetRoleDetailsAll mocks base method.
+func (m *MockClient) GetRoleDetailsAll(ctx context.Context) ([]*iam.RoleDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleDetailsAll", ctx)
+	ret0, _ := ret[0].([]*iam.RoleDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleDetailsAll indicates an expected call of GetRoleDetailsAll.
+func (mr *MockClientMockRecorder) GetRoleDetailsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleDetailsAll", reflect.TypeOf((*MockClient)(nil).GetRoleDetailsAll), ctx)
+}
+
+// GetRoutesAll mocks base method.
+func (m *MockClient) GetRoutesAll(ctx context.Context, apiId string) ([]*apigatewayv2.Route, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutesAll", ctx, apiId)
+	ret0, _ := ret[0].([]*apigatewayv2.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutesAll indicates an expected call of GetRoutesAll.
+func (mr *MockClientMockRecorder) GetRoutesAll(ctx, apiId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutesAll", reflect.TypeOf((*MockClient)(nil).GetRoutesAll), ctx, apiId)
+}
+
+// GetServiceQuotaWithContext mocks base method.
+func (m *MockClient) GetServiceQuotaWithContext(ctx aws.Context, input *servicequotas.GetServiceQuotaInput, opts ...request.Option) (*servicequotas.GetServiceQuotaOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetServiceQuotaWithContext", varargs...)
+	ret0, _ := ret[0].(*servicequotas.GetServiceQuotaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceQuotaWithContext indicates an expected call of GetServiceQuotaWithContext.
+func (mr *MockClientMockRecorder) GetServiceQuotaWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceQuotaWithContext", reflect.TypeOf((*MockClient)(nil).GetServiceQuotaWithContext), varargs...)
+}
+
+// GetSpotPlacementScoresAll mocks base method.
+func (m *MockClient) GetSpotPlacementScoresAll(ctx context.Context, input *ec2.GetSpotPlacementScoresInput) ([]*ec2.SpotPlacementScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpotPlacementScoresAll", ctx, input)
+	ret0, _ := ret[0].([]*ec2.SpotPlacementScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpotPlacementScoresAll indicates an expected call of GetSpotPlacementScoresAll.
+func (mr *MockClientMockRecorder) GetSpotPlacementScoresAll(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpotPlacementScoresAll", reflect.TypeOf((*MockClient)(nil).GetSpotPlacementScoresAll), ctx, input)
+}
+
+// GetTrailStatusWithContext mocks base method.
+func (m *MockClient) GetTrailStatusWithContext(ctx aws.Context, input *cloudtrail.GetTrailStatusInput, opts ...request.Option) (*cloudtrail.GetTrailStatusOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrailStatusWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudtrail.GetTrailStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrailStatusWithContext indicates an expected call of GetTrailStatusWithContext.
+func (mr *MockClientMockRecorder) GetTrailStatusWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrailStatusWithContext", reflect.TypeOf((*MockClient)(nil).GetTrailStatusWithContext), varargs...)
+}
+
+// GetUsagePlansAll mocks base method.
+func (m *MockClient) GetUsagePlansAll(ctx context.Context) ([]*apigateway.UsagePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagePlansAll", ctx)
+	ret0, _ := ret[0].([]*apigateway.UsagePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagePlansAll indicates an expected call of GetUsagePlansAll.
+func (mr *MockClientMockRecorder) GetUsagePlansAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagePlansAll", reflect.TypeOf((*MockClient)(nil).GetUsagePlansAll), ctx)
+}
+
+// GetWebACLWithContext mocks base method.
+func (m *MockClient) GetWebACLWithContext(ctx aws.Context, input *wafv2.GetWebACLInput, opts ...request.Option) (*wafv2.GetWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACLWithContext indicates an expected call of GetWebACLWithContext.
+func (mr *MockClientMockRecorder) GetWebACLWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLWithContext", reflect.TypeOf((*MockClient)(nil).GetWebACLWithContext), varargs...)
+}
+
+// ListBackupJobsAll mocks base method.
+func (m *MockClient) ListBackupJobsAll(ctx context.Context, state string, createdAfter time.Time) ([]*backup.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackupJobsAll", ctx, state, createdAfter)
+	ret0, _ := ret[0].([]*backup.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackupJobsAll indicates an expected call of ListBackupJobsAll.
+func (mr *MockClientMockRecorder) ListBackupJobsAll(ctx, state, createdAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackupJobsAll", reflect.TypeOf((*MockClient)(nil).ListBackupJobsAll), ctx, state, createdAfter)
+}
+
+// ListBackupPlansAll mocks base method.
+func (m *MockClient) ListBackupPlansAll(ctx context.Context) ([]*backup.PlansListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackupPlansAll", ctx)
+	ret0, _ := ret[0].([]*backup.PlansListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackupPlansAll indicates an expected call of ListBackupPlansAll.
+func (mr *MockClientMockRecorder) ListBackupPlansAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackupPlansAll", reflect.TypeOf((*MockClient)(nil).ListBackupPlansAll), ctx)
+}
+
+// ListBucketsWithContext mocks base method.
+func (m *MockClient) ListBucketsWithContext(ctx aws.Context, input *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBucketsWithContext", varargs...)
+	ret0, _ := ret[0].(*s3.ListBucketsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBucketsWithContext indicates an expected call of ListBucketsWithContext.
+func (mr *MockClientMockRecorder) ListBucketsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBucketsWithContext", reflect.TypeOf((*MockClient)(nil).ListBucketsWithContext), varargs...)
+}
+
+// ListCertificatesAll mocks base method.
+func (m *MockClient) ListCertificatesAll(ctx context.Context) ([]*acm.CertificateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificatesAll", ctx)
+	ret0, _ := ret[0].([]*acm.CertificateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificatesAll indicates an expected call of ListCertificatesAll.
+func (mr *MockClientMockRecorder) ListCertificatesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesAll", reflect.TypeOf((*MockClient)(nil).ListCertificatesAll), ctx)
+}
+
+// ListClustersAll mocks base method.
+func (m *MockClient) ListClustersAll(ctx context.Context) ([]*kafka.ClusterInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClustersAll", ctx)
+	ret0, _ := ret[0].([]*kafka.ClusterInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClustersAll indicates an expected call of ListClustersAll.
+func (mr *MockClientMockRecorder) ListClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClustersAll", reflect.TypeOf((*MockClient)(nil).ListClustersAll), ctx)
+}
+
+// ListConnectorsAll mocks base method.
+func (m *MockClient) ListConnectorsAll(ctx context.Context) ([]*kafkaconnect.ConnectorSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConnectorsAll", ctx)
+	ret0, _ := ret[0].([]*kafkaconnect.ConnectorSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConnectorsAll indicates an expected call of ListConnectorsAll.
+func (mr *MockClientMockRecorder) ListConnectorsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnectorsAll", reflect.TypeOf((*MockClient)(nil).ListConnectorsAll), ctx)
+}
+
+// ListDetectorsAll mocks base method.
+func (m *MockClient) ListDetectorsAll(ctx context.Context) ([]*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDetectorsAll", ctx)
+	ret0, _ := ret[0].([]*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDetectorsAll indicates an expected call of ListDetectorsAll.
+func (mr *MockClientMockRecorder) ListDetectorsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDetectorsAll", reflect.TypeOf((*MockClient)(nil).ListDetectorsAll), ctx)
+}
+
+// ListDistributionsAll mocks base method.
+func (m *MockClient) ListDistributionsAll(ctx context.Context) ([]*cloudfront.DistributionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDistributionsAll", ctx)
+	ret0, _ := ret[0].([]*cloudfront.DistributionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDistributionsAll indicates an expected call of ListDistributionsAll.
+func (mr *MockClientMockRecorder) ListDistributionsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDistributionsAll", reflect.TypeOf((*MockClient)(nil).ListDistributionsAll), ctx)
+}
+
+// ListDomainNamesWithContext mocks base method.
+func (m *MockClient) ListDomainNamesWithContext(ctx aws.Context, input *opensearchservice.ListDomainNamesInput, opts ...request.Option) (*opensearchservice.ListDomainNamesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDomainNamesWithContext", varargs...)
+	ret0, _ := ret[0].(*opensearchservice.ListDomainNamesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomainNamesWithContext indicates an expected call of ListDomainNamesWithContext.
+func (mr *MockClientMockRecorder) ListDomainNamesWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomainNamesWithContext", reflect.TypeOf((*MockClient)(nil).ListDomainNamesWithContext), varargs...)
+}
+
+// ListEKSClustersAll mocks base method.
+func (m *MockClient) ListEKSClustersAll(ctx context.Context) ([]*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEKSClustersAll", ctx)
+	ret0, _ := ret[0].([]*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEKSClustersAll indicates an expected call of ListEKSClustersAll.
+func (mr *MockClientMockRecorder) ListEKSClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEKSClustersAll", reflect.TypeOf((*MockClient)(nil).ListEKSClustersAll), ctx)
+}
+
+// ListHealthChecksAll mocks base method.
+func (m *MockClient) ListHealthChecksAll(ctx context.Context) ([]*route53.HealthCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHealthChecksAll", ctx)
+	ret0, _ := ret[0].([]*route53.HealthCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHealthChecksAll indicates an expected call of ListHealthChecksAll.
+func (mr *MockClientMockRecorder) ListHealthChecksAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecksAll", reflect.TypeOf((*MockClient)(nil).ListHealthChecksAll), ctx)
+}
+
+// ListHostedZonesWithContext mocks base method.
+func (m *MockClient) ListHostedZonesWithContext(ctx context.Context, input *route53.ListHostedZonesInput, opts ...request.Option) (*route53.ListHostedZonesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHostedZonesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListHostedZonesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesWithContext indicates an expected call of ListHostedZonesWithContext.
+func (mr *MockClientMockRecorder) ListHostedZonesWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesWithContext", reflect.TypeOf((*MockClient)(nil).ListHostedZonesWithContext), varargs...)
+}
+
+// ListKeysAll mocks base method.
+func (m *MockClient) ListKeysAll(ctx context.Context) ([]*kms.KeyListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeysAll", ctx)
+	ret0, _ := ret[0].([]*kms.KeyListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeysAll indicates an expected call of ListKeysAll.
+func (mr *MockClientMockRecorder) ListKeysAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeysAll", reflect.TypeOf((*MockClient)(nil).ListKeysAll), ctx)
+}
+
+// ListMetricsAll mocks base method.
+func (m *MockClient) ListMetricsAll(ctx context.Context, input *cloudwatch.ListMetricsInput) ([]*cloudwatch.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricsAll", ctx, input)
+	ret0, _ := ret[0].([]*cloudwatch.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricsAll indicates an expected call of ListMetricsAll.
+func (mr *MockClientMockRecorder) ListMetricsAll(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsAll", reflect.TypeOf((*MockClient)(nil).ListMetricsAll), ctx, input)
+}
+
+// ListProtectedResourcesAll mocks base method.
+func (m *MockClient) ListProtectedResourcesAll(ctx context.Context) ([]*backup.ProtectedResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProtectedResourcesAll", ctx)
+	ret0, _ := ret[0].([]*backup.ProtectedResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProtectedResourcesAll indicates an expected call of ListProtectedResourcesAll.
+func (mr *MockClientMockRecorder) ListProtectedResourcesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProtectedResourcesAll", reflect.TypeOf((*MockClient)(nil).ListProtectedResourcesAll), ctx)
+}
+
+// ListQueuesAll mocks base method.
+func (m *MockClient) ListQueuesAll(ctx context.Context) ([]*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueuesAll", ctx)
+	ret0, _ := ret[0].([]*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueuesAll indicates an expected call of ListQueuesAll.
+func (mr *MockClientMockRecorder) ListQueuesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueuesAll", reflect.TypeOf((*MockClient)(nil).ListQueuesAll), ctx)
+}
+
+// ListResourceRecordSetsWithContext mocks base method.
+func (m *MockClient) ListResourceRecordSetsWithContext(ctx context.Context, input *route53.ListResourceRecordSetsInput, opts ...request.Option) (*route53.ListResourceRecordSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListResourceRecordSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceRecordSetsWithContext indicates an expected call of ListResourceRecordSetsWithContext.
+func (mr *MockClientMockRecorder) ListResourceRecordSetsWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsWithContext", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSetsWithContext), varargs...)
+}
+
+// ListRuleGroupsAll mocks base method.
+func (m *MockClient) ListRuleGroupsAll(ctx context.Context, scope string) ([]*wafv2.RuleGroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleGroupsAll", ctx, scope)
+	ret0, _ := ret[0].([]*wafv2.RuleGroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleGroupsAll indicates an expected call of ListRuleGroupsAll.
+func (mr *MockClientMockRecorder) ListRuleGroupsAll(ctx, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleGroupsAll", reflect.TypeOf((*MockClient)(nil).ListRuleGroupsAll), ctx, scope)
+}
+
+// ListSecretsAll mocks base method.
+func (m *MockClient) ListSecretsAll(ctx context.Context) ([]*secretsmanager.SecretListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretsAll", ctx)
+	ret0, _ := ret[0].([]*secretsmanager.SecretListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretsAll indicates an expected call of ListSecretsAll.
+func (mr *MockClientMockRecorder) ListSecretsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretsAll", reflect.TypeOf((*MockClient)(nil).ListSecretsAll), ctx)
+}
+
+// ListServerlessClustersAll mocks base method.
+func (m *MockClient) ListServerlessClustersAll(ctx context.Context) ([]*kafka.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServerlessClustersAll", ctx)
+	ret0, _ := ret[0].([]*kafka.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServerlessClustersAll indicates an expected call of ListServerlessClustersAll.
+func (mr *MockClientMockRecorder) ListServerlessClustersAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerlessClustersAll", reflect.TypeOf((*MockClient)(nil).ListServerlessClustersAll), ctx)
+}
+
+// ListStreamsAll mocks base method.
+func (m *MockClient) ListStreamsAll(ctx context.Context) ([]*kinesis.StreamSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStreamsAll", ctx)
+	ret0, _ := ret[0].([]*kinesis.StreamSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStreamsAll indicates an expected call of ListStreamsAll.
+func (mr *MockClientMockRecorder) ListStreamsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStreamsAll", reflect.TypeOf((*MockClient)(nil).ListStreamsAll), ctx)
+}
+
+// ListSubscriptionsAll mocks base method.
+func (m *MockClient) ListSubscriptionsAll(ctx context.Context) ([]*sns.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionsAll", ctx)
+	ret0, _ := ret[0].([]*sns.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionsAll indicates an expected call of ListSubscriptionsAll.
+func (mr *MockClientMockRecorder) ListSubscriptionsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsAll", reflect.TypeOf((*MockClient)(nil).ListSubscriptionsAll), ctx)
+}
+
+// ListTablesAll mocks base method.
+func (m *MockClient) ListTablesAll(ctx context.Context) ([]*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTablesAll", ctx)
+	ret0, _ := ret[0].([]*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTablesAll indicates an expected call of ListTablesAll.
+func (mr *MockClientMockRecorder) ListTablesAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTablesAll", reflect.TypeOf((*MockClient)(nil).ListTablesAll), ctx)
+}
+
+// ListTopicsAll mocks base method.
+func (m *MockClient) ListTopicsAll(ctx context.Context) ([]*sns.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopicsAll", ctx)
+	ret0, _ := ret[0].([]*sns.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopicsAll indicates an expected call of ListTopicsAll.
+func (mr *MockClientMockRecorder) ListTopicsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopicsAll", reflect.TypeOf((*MockClient)(nil).ListTopicsAll), ctx)
+}
+
+// ListTrailsAll mocks base method.
+func (m *MockClient) ListTrailsAll(ctx context.Context) ([]*cloudtrail.TrailInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrailsAll", ctx)
+	ret0, _ := ret[0].([]*cloudtrail.TrailInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrailsAll indicates an expected call of ListTrailsAll.
+func (mr *MockClientMockRecorder) ListTrailsAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrailsAll", reflect.TypeOf((*MockClient)(nil).ListTrailsAll), ctx)
+}
+
+// ListWebACLsAll mocks base method.
+func (m *MockClient) ListWebACLsAll(ctx context.Context, scope string) ([]*wafv2.WebACLSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebACLsAll", ctx, scope)
+	ret0, _ := ret[0].([]*wafv2.WebACLSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebACLsAll indicates an expected call of ListWebACLsAll.
+func (mr *MockClientMockRecorder) ListWebACLsAll(ctx, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebACLsAll", reflect.TypeOf((*MockClient)(nil).ListWebACLsAll), ctx, scope)
 }
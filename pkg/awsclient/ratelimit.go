@@ -0,0 +1,78 @@
+package awsclient
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiters holds a token bucket per service. The services are named like the service label of the request
+// metrics, e.g. ec2, route53 or monitoring for CloudWatch.
+var rateLimiters = struct {
+	mu       sync.RWMutex
+	limiters map[string]*rate.Limiter
+}{limiters: map[string]*rate.Limiter{}}
+
+// ParseRateLimit parses a rate like "10/s" or "100/m". A plain number is a rate per second.
+func ParseRateLimit(value string) (rate.Limit, error) {
+	count, unit, found := strings.Cut(strings.TrimSpace(value), "/")
+	per := time.Second
+	if found {
+		switch strings.TrimSpace(unit) {
+		case "s":
+		case "m":
+			per = time.Minute
+		case "h":
+			per = time.Hour
+		default:
+			return 0, fmt.Errorf("invalid rate limit unit in %q, expected s, m or h", value)
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate limit %q, expected a positive number of requests", value)
+	}
+	return rate.Limit(n / per.Seconds()), nil
+}
+
+// SetRateLimits replaces the rate limits of the services. The limiters are shared by all clients, so the limit
+// applies to the whole exporter instead of a single collector or region. Services without a limit are not limited.
+func SetRateLimits(limits map[string]string) error {
+	limiters := map[string]*rate.Limiter{}
+	for service, value := range limits {
+		limit, err := ParseRateLimit(value)
+		if err != nil {
+			return fmt.Errorf("rate limit of %s: %w", service, err)
+		}
+		// allow a burst of up to one second of requests
+		limiters[service] = rate.NewLimiter(limit, int(math.Max(1, math.Ceil(float64(limit)))))
+	}
+
+	rateLimiters.mu.Lock()
+	defer rateLimiters.mu.Unlock()
+	rateLimiters.limiters = limiters
+	return nil
+}
+
+// waitForRateLimit delays the request attempt until the rate limit of its service allows it. It runs before the
+// attempt is signed, so the signature doesn't age while waiting, and an error aborts the request. Retries are limited
+// as well, as they count towards the API budget of the account too.
+func waitForRateLimit(r *request.Request) {
+	rateLimiters.mu.RLock()
+	limiter := rateLimiters.limiters[r.ClientInfo.ServiceName]
+	rateLimiters.mu.RUnlock()
+
+	if limiter == nil {
+		return
+	}
+	if err := limiter.Wait(r.Context()); err != nil {
+		r.Error = err
+	}
+}
@@ -0,0 +1,53 @@
+package awsclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	for value, expected := range map[string]rate.Limit{
+		"10/s":  10,
+		"2":     2,
+		"60/m":  1,
+		" 5/s ": 5,
+	} {
+		limit, err := ParseRateLimit(value)
+		assert.Nil(t, err, value)
+		assert.Equal(t, expected, limit, value)
+	}
+
+	for _, value := range []string{"", "foo", "0/s", "-1/s", "10/d"} {
+		_, err := ParseRateLimit(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestSetRateLimits(t *testing.T) {
+	assert.NotNil(t, SetRateLimits(map[string]string{"ec2": "foo"}))
+	assert.Nil(t, SetRateLimits(map[string]string{"ec2": "1/h"}))
+	defer SetRateLimits(nil)
+
+	sess := InstrumentSession(session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String("http://127.0.0.1:1"),
+		MaxRetries:  aws.Int(0),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})))
+	client := ec2.New(sess)
+
+	// the first request uses the burst, the second one waits for the rate limit until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{})
+	_, err := client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{})
+	assert.ErrorContains(t, err, "rate")
+}
@@ -0,0 +1,60 @@
+package awsclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// retrySettings configure the retries of all clients created afterwards
+var retrySettings = struct {
+	mu          sync.RWMutex
+	maxAttempts int
+	maxBackoff  time.Duration
+}{}
+
+// SetRetrySettings configures the maximum number of attempts of a request, including the first one, and the maximum
+// backoff between attempts. Zero values keep the SDK defaults of 4 attempts and a backoff of up to 5 minutes.
+func SetRetrySettings(maxAttempts int, maxBackoff time.Duration) {
+	retrySettings.mu.Lock()
+	defer retrySettings.mu.Unlock()
+	retrySettings.maxAttempts = maxAttempts
+	retrySettings.maxBackoff = maxBackoff
+}
+
+// retryer retries with the exponential backoff and jitter of the SDK, which backs off longer when throttled, and
+// counts the throttled attempts it retries
+type retryer struct {
+	client.DefaultRetryer
+}
+
+// newRetryer creates the retryer of a session from the retry settings. The max retries of the session are used if
+// no max attempts are configured.
+func newRetryer(config *aws.Config) request.Retryer {
+	retrySettings.mu.RLock()
+	defer retrySettings.mu.RUnlock()
+
+	r := retryer{client.DefaultRetryer{NumMaxRetries: client.DefaultRetryerMaxNumRetries}}
+	if retrySettings.maxAttempts > 0 {
+		r.NumMaxRetries = retrySettings.maxAttempts - 1
+	} else if config.MaxRetries != nil && *config.MaxRetries != aws.UseServiceDefaultRetries {
+		r.NumMaxRetries = *config.MaxRetries
+	}
+	if retrySettings.maxBackoff > 0 {
+		r.MaxRetryDelay = retrySettings.maxBackoff
+		r.MaxThrottleDelay = retrySettings.maxBackoff
+		r.MinThrottleDelay = min(client.DefaultRetryerMinThrottleDelay, retrySettings.maxBackoff)
+		r.MinRetryDelay = min(client.DefaultRetryerMinRetryDelay, retrySettings.maxBackoff)
+	}
+	return r
+}
+
+func (r retryer) RetryRules(req *request.Request) time.Duration {
+	if req.IsErrorThrottle() && AwsExporterMetrics != nil {
+		AwsExporterMetrics.ObserveThrottle(req)
+	}
+	return r.DefaultRetryer.RetryRules(req)
+}
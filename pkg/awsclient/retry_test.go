@@ -0,0 +1,48 @@
+package awsclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRetryer(t *testing.T) {
+	defer SetRetrySettings(0, 0)
+
+	r := newRetryer(&aws.Config{}).(retryer)
+	assert.Equal(t, client.DefaultRetryerMaxNumRetries, r.MaxRetries())
+
+	r = newRetryer(&aws.Config{MaxRetries: aws.Int(0)}).(retryer)
+	assert.Equal(t, 0, r.MaxRetries())
+
+	SetRetrySettings(5, 2*time.Second)
+	r = newRetryer(&aws.Config{MaxRetries: aws.Int(0)}).(retryer)
+	assert.Equal(t, 4, r.MaxRetries())
+	assert.Equal(t, 2*time.Second, r.MaxThrottleDelay)
+	assert.Equal(t, 2*time.Second, r.MaxRetryDelay)
+}
+
+func TestRetryRulesCountsThrottles(t *testing.T) {
+	previous := AwsExporterMetrics
+	AwsExporterMetrics = NewExporterMetrics("test")
+	defer func() { AwsExporterMetrics = previous }()
+
+	SetRetrySettings(3, time.Millisecond)
+	defer SetRetrySettings(0, 0)
+	r := newRetryer(&aws.Config{})
+
+	throttled := newTestRequest(awserr.New("Throttling", "Rate exceeded", nil))
+	throttled.HTTPResponse = &http.Response{StatusCode: http.StatusBadRequest}
+	assert.LessOrEqual(t, r.RetryRules(throttled), time.Millisecond)
+	failed := newTestRequest(awserr.New("InternalError", "boom", nil))
+	failed.HTTPResponse = &http.Response{StatusCode: http.StatusInternalServerError}
+	r.RetryRules(failed)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(AwsExporterMetrics.APIThrottles.WithLabelValues("ec2", "DescribeVpcs", "us-east-1")))
+}
@@ -0,0 +1,71 @@
+package awsclient
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+
+// requestParentKey holds the context of the request before its span was started
+type requestParentKey struct{}
+
+// startRequestSpan starts a span for the request, which covers all its attempts including the rate limit waits. The
+// span is a child of the span in the context of the request, e.g. the span of the collector run.
+func startRequestSpan(r *request.Request) {
+	service, operation, region := requestLabels(r)
+	parent := r.Context()
+	ctx, _ := otel.Tracer(tracerName).Start(parent, service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("aws-api"),
+			semconv.RPCService(service),
+			semconv.RPCMethod(operation),
+			semconv.CloudRegion(region),
+		),
+	)
+	r.SetContext(context.WithValue(ctx, requestParentKey{}, parent))
+}
+
+// endRequestSpan ends the span of the request and restores the context it had before
+func endRequestSpan(r *request.Request) {
+	parent, ok := r.Context().Value(requestParentKey{}).(context.Context)
+	if !ok {
+		// the request failed before its span was started
+		return
+	}
+	span := trace.SpanFromContext(r.Context())
+	span.SetAttributes(
+		semconv.AWSRequestID(r.RequestID),
+		attribute.Int("aws.retry_count", r.RetryCount),
+	)
+	if r.Error != nil {
+		span.RecordError(r.Error)
+		description := r.Error.Error()
+		if awsErr, ok := r.Error.(awserr.Error); ok {
+			description = awsErr.Code()
+		}
+		span.SetStatus(codes.Error, description)
+	}
+	span.End()
+	r.SetContext(parent)
+}
+
+// addTracingHandlers traces every request of the session. Without a configured tracer provider the spans are no-ops.
+func addTracingHandlers(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: "awsclient.StartSpan",
+		Fn:   startRequestSpan,
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "awsclient.EndSpan",
+		Fn:   endRequestSpan,
+	})
+}
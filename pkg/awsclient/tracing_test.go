@@ -0,0 +1,48 @@
+package awsclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestRequestSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	parent, parentSpan := otel.Tracer("test").Start(context.Background(), "collect ec2")
+	r := newTestRequest(nil)
+	r.HTTPRequest = &http.Request{}
+	r.SetContext(parent)
+	startRequestSpan(r)
+	r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+	r.RetryCount = 2
+	endRequestSpan(r)
+	parentSpan.End()
+
+	// the context of the request is restored, so the next page isn't traced as a child of this one
+	assert.Equal(t, parent, r.Context())
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "ec2.DescribeVpcs", span.Name())
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "Throttling", span.Status().Description)
+	assert.Contains(t, span.Attributes(), semconv.CloudRegion("us-east-1"))
+
+	// requests failing before the span was started are ignored
+	r = newTestRequest(nil)
+	endRequestSpan(r)
+	assert.Len(t, recorder.Ended(), 2)
+}
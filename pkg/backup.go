@@ -0,0 +1,150 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	backupServiceCode string = "backup"
+	// backupFailedJobsWindow is how far back the failed backup jobs are counted
+	backupFailedJobsWindow = 24 * time.Hour
+)
+
+// BackupExporter exports the AWS Backup compliance of the resources, e.g. of RDS, EFS and EBS, in one place
+type BackupExporter struct {
+	sessions           []*session.Session
+	BackupPlans        *prometheus.Desc
+	ProtectedResources *prometheus.Desc
+	LastBackup         *prometheus.Desc
+	FailedJobs         *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewBackupExporter creates a new BackupExporter instance
+func NewBackupExporter(sessions []*session.Session, logger log.Logger, config BackupConfig, awsAccountId string) *BackupExporter {
+	level.Info(logger).Log("msg", "Initializing Backup exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: backupServiceCode}
+
+	return &BackupExporter{
+		sessions:           sessions,
+		BackupPlans:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backup_plans_total"), "Number of AWS Backup plans in this region", []string{"aws_region"}, constLabels),
+		ProtectedResources: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backup_protected_resources_total"), "Number of resources backed up by AWS Backup per resource type", []string{"aws_region", "resource_type"}, constLabels),
+		LastBackup:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backup_last_backup_timestamp_seconds"), "The time of the last successful backup of a resource of the resource type", []string{"aws_region", "resource_type"}, constLabels),
+		FailedJobs:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backup_failed_jobs_last_24h"), "Number of backup jobs which failed in the last 24 hours per resource type", []string{"aws_region", "resource_type"}, constLabels),
+		cache:              *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:             logger,
+		timeout:            *config.Timeout,
+		interval:           *config.Interval,
+	}
+}
+
+func (e *BackupExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.BackupPlans
+	ch <- e.ProtectedResources
+	ch <- e.LastBackup
+	ch <- e.FailedJobs
+}
+
+func (e *BackupExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *BackupExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Backup metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "backup")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *BackupExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "backup", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *BackupExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	plans, err := client.ListBackupPlansAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListBackupPlansAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.BackupPlans, prometheus.GaugeValue, float64(len(plans)), region))
+	}
+
+	// the failed jobs are exported for all resource types with protected resources, so they go back to 0
+	failedJobs := map[string]int{}
+	resources, err := client.ListProtectedResourcesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListProtectedResourcesAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		protectedResources := map[string]int{}
+		lastBackups := map[string]time.Time{}
+		for _, resource := range resources {
+			resourceType := aws.StringValue(resource.ResourceType)
+			protectedResources[resourceType]++
+			failedJobs[resourceType] = 0
+			if lastBackup := aws.TimeValue(resource.LastBackupTime); lastBackup.After(lastBackups[resourceType]) {
+				lastBackups[resourceType] = lastBackup
+			}
+		}
+		for resourceType, count := range protectedResources {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.ProtectedResources, prometheus.GaugeValue, float64(count), region, resourceType))
+		}
+		for resourceType, lastBackup := range lastBackups {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.LastBackup, prometheus.GaugeValue, float64(lastBackup.Unix()), region, resourceType))
+		}
+	}
+
+	jobs, err := client.ListBackupJobsAll(ctx, backup.JobStateFailed, time.Now().Add(-backupFailedJobsWindow))
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListBackupJobsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	for _, job := range jobs {
+		failedJobs[aws.StringValue(job.ResourceType)]++
+	}
+	for resourceType, count := range failedJobs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.FailedJobs, prometheus.GaugeValue, float64(count), region, resourceType))
+	}
+}
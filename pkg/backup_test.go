@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastBackup := time.Now().Add(-time.Hour).Truncate(time.Second)
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListBackupPlansAll(ctx).Return([]*backup.PlansListMember{{BackupPlanName: aws.String("daily")}}, nil)
+	mockClient.EXPECT().ListProtectedResourcesAll(ctx).Return([]*backup.ProtectedResource{
+		{ResourceType: aws.String("RDS"), LastBackupTime: aws.Time(lastBackup.Add(-24 * time.Hour))},
+		{ResourceType: aws.String("RDS"), LastBackupTime: aws.Time(lastBackup)},
+		{ResourceType: aws.String("EFS"), LastBackupTime: aws.Time(lastBackup)},
+	}, nil)
+	mockClient.EXPECT().ListBackupJobsAll(ctx, backup.JobStateFailed, gomock.Any()).Return([]*backup.Job{
+		{ResourceType: aws.String("RDS")},
+		{ResourceType: aws.String("EBS")},
+	}, nil)
+
+	e := NewBackupExporter(nil, log.NewNopLogger(), BackupConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("backup", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// plan count, 2x protected resources and last backup, failed jobs of RDS, EFS and EBS
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 8)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.BackupPlans.String():
+			assert.Equal(t, 1.0, value)
+		case e.ProtectedResources.String():
+			assert.Equal(t, map[string]float64{"RDS": 2, "EFS": 1}[labels["resource_type"]], value)
+		case e.LastBackup.String():
+			assert.Equal(t, float64(lastBackup.Unix()), value)
+		case e.FailedJobs.String():
+			assert.Equal(t, map[string]float64{"RDS": 1, "EFS": 0, "EBS": 1}[labels["resource_type"]], value)
+		}
+	}
+}
+
+func TestBackupCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListBackupPlansAll(ctx).Return(nil, errors.New("AccessDeniedException"))
+	mockClient.EXPECT().ListProtectedResourcesAll(ctx).Return(nil, errors.New("AccessDeniedException"))
+	mockClient.EXPECT().ListBackupJobsAll(ctx, backup.JobStateFailed, gomock.Any()).Return([]*backup.Job{}, nil)
+
+	e := NewBackupExporter(nil, log.NewNopLogger(), BackupConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("backup", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 0)
+}
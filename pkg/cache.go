@@ -1,59 +1,164 @@
 package pkg
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type MetricsCache struct {
 	cacheMutex *sync.Mutex
 	entries    map[string]cacheEntry
 	ttl        time.Duration
+	// staleRegions are the regions whose last collection failed. Their metrics are kept past the TTL until a collection
+	// of the region succeeds again, so an API error doesn't look like deleted resources.
+	staleRegions map[string]bool
+	// next collects the metrics of the generation begun by BeginGeneration. The metrics are served from entries until
+	// the generation is committed.
+	next map[string]cacheEntry
+	// timestamps exports the metrics with the time they were added
+	timestamps bool
 }
 
 func NewMetricsCache(ttl time.Duration) *MetricsCache {
 	return &MetricsCache{
-		cacheMutex: &sync.Mutex{},
-		entries:    map[string]cacheEntry{},
-		ttl:        ttl,
+		cacheMutex:   &sync.Mutex{},
+		entries:      map[string]cacheEntry{},
+		ttl:          ttl,
+		staleRegions: map[string]bool{},
 	}
 }
 
+// NewMetricsCacheFromConfig creates the cache of an exporter with its configured TTL and timestamps
+func NewMetricsCacheFromConfig(config BaseConfig) *MetricsCache {
+	cache := NewMetricsCache(*config.CacheTTL)
+	cache.timestamps = config.MetricTimestamps
+	return cache
+}
+
 func getMetricHash(metric prometheus.Metric) string {
+	hash, _ := getMetricHashAndRegion(metric)
+	return hash
+}
+
+// getMetricHashAndRegion returns the hash of the metric and the value of its aws_region label
+func getMetricHashAndRegion(metric prometheus.Metric) (string, string) {
 	var dto dto.Metric
 	metric.Write(&dto)
 	labelString := metric.Desc().String()
 
+	region := ""
 	for _, labelPair := range dto.GetLabel() {
 		labelString = fmt.Sprintf("%s,%s,%s", labelString, labelPair.GetName(), labelPair.GetValue())
+		if labelPair.GetName() == "aws_region" {
+			region = labelPair.GetValue()
+		}
 	}
 
 	checksum := sha256.Sum256([]byte(labelString))
-	return fmt.Sprintf("%x", checksum[:])
+	return fmt.Sprintf("%x", checksum[:]), region
 }
 
-// AddMetric adds a metric to the cache
+// AddMetric adds a metric to the cache, which expires after the TTL of the cache
 func (mc *MetricsCache) AddMetric(metric prometheus.Metric) {
+	mc.AddMetricWithTTL(metric, 0)
+}
+
+// AddQuotaUtilization adds the usage relative to the quota as a metric of the desc. A quota which couldn't be looked up
+// is 0 and adds no metric.
+func (mc *MetricsCache) AddQuotaUtilization(desc *prometheus.Desc, usage float64, quota float64, labelValues ...string) {
+	if quota <= 0 {
+		return
+	}
+	mc.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, usage/quota, labelValues...))
+}
+
+// AddMetricWithTTL adds a metric to the cache, which expires after the given TTL instead of the TTL of the cache. This
+// lets expensive metrics, which are refreshed less often, live longer than the others. A zero TTL uses the TTL of the
+// cache.
+func (mc *MetricsCache) AddMetricWithTTL(metric prometheus.Metric, ttl time.Duration) {
+	hash, region := getMetricHashAndRegion(metric)
 	mc.cacheMutex.Lock()
-	mc.entries[getMetricHash(metric)] = cacheEntry{
-		creation: time.Now(),
-		metric:   metric,
+	entries := mc.entries
+	if mc.next != nil {
+		entries = mc.next
+	}
+	entries[hash] = cacheEntry{
+		creation:   time.Now(),
+		metric:     metric,
+		region:     region,
+		ttl:        ttl,
+		generation: mc.next != nil,
 	}
 	mc.cacheMutex.Unlock()
 }
 
-// GetAllMetrics Iterates over all cached metrics and discards expired ones.
+// BeginGeneration starts collecting a new set of metrics. Until CommitGeneration is called, the added metrics are not
+// served and the metrics of the previous generation stay as they are, so a long collection never serves a mix of old
+// and new metrics.
+func (mc *MetricsCache) BeginGeneration() {
+	mc.cacheMutex.Lock()
+	defer mc.cacheMutex.Unlock()
+	mc.next = map[string]cacheEntry{}
+}
+
+// CommitGeneration replaces the served metrics with the ones added since BeginGeneration. The metrics of a generation
+// don't expire with the TTL of the cache, they are replaced by the next generation instead, so a collection taking
+// longer than the TTL doesn't make them flap. The metrics of stale regions and the metrics with their own TTL missing in
+// the new generation are kept.
+func (mc *MetricsCache) CommitGeneration() {
+	mc.cacheMutex.Lock()
+	defer mc.cacheMutex.Unlock()
+	if mc.next == nil {
+		return
+	}
+	for k, v := range mc.entries {
+		if _, ok := mc.next[k]; !ok && (mc.isStale(v.region) || v.ttl != 0 && !mc.isExpired(v)) {
+			mc.next[k] = v
+		}
+	}
+	mc.entries = mc.next
+	mc.next = nil
+}
+
+// InvalidateByDesc removes the metrics of the descriptor in the given regions, or in all regions if none are given.
+// During a generation it only removes the metrics added to the generation.
+// Exporters use it to drop the entries of resources which are gone once a region was refreshed successfully, instead
+// of waiting for them to expire.
+func (mc *MetricsCache) InvalidateByDesc(desc *prometheus.Desc, regions ...string) {
+	mc.cacheMutex.Lock()
+	defer mc.cacheMutex.Unlock()
+	entries := mc.entries
+	if mc.next != nil {
+		entries = mc.next
+	}
+	for k, v := range entries {
+		if v.metric.Desc() == desc && (len(regions) == 0 || slices.Contains(regions, v.region)) {
+			delete(entries, k)
+		}
+	}
+}
+
+// GetAllMetrics Iterates over all cached metrics and discards expired ones. Metrics of stale regions don't expire,
+// metrics without a region are kept as long as any region is stale.
 func (mc *MetricsCache) GetAllMetrics() []prometheus.Metric {
 	mc.cacheMutex.Lock()
 	returnArr := make([]prometheus.Metric, 0)
 	for k, v := range mc.entries {
-		if time.Since(v.creation).Seconds() > mc.ttl.Seconds() {
+		if mc.isExpired(v) && !mc.isStale(v.region) {
 			delete(mc.entries, k)
+		} else if mc.timestamps {
+			returnArr = append(returnArr, prometheus.NewMetricWithTimestamp(v.creation, v.metric))
 		} else {
 			returnArr = append(returnArr, v.metric)
 		}
@@ -62,7 +167,72 @@ func (mc *MetricsCache) GetAllMetrics() []prometheus.Metric {
 	return returnArr
 }
 
+// HasMetric returns whether the cache holds an unexpired metric with the descriptor and label values, either served or
+// added to the current generation
+func (mc *MetricsCache) HasMetric(desc *prometheus.Desc, labelValues ...string) bool {
+	metric, err := prometheus.NewConstMetric(desc, prometheus.UntypedValue, 0, labelValues...)
+	if err != nil {
+		return false
+	}
+	hash := getMetricHash(metric)
+
+	mc.cacheMutex.Lock()
+	defer mc.cacheMutex.Unlock()
+	for _, entries := range []map[string]cacheEntry{mc.next, mc.entries} {
+		if entry, ok := entries[hash]; ok && !mc.isExpired(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpired returns whether the entry outlived its TTL. The metrics of a generation only expire with their own TTL.
+func (mc *MetricsCache) isExpired(entry cacheEntry) bool {
+	if entry.ttl == 0 {
+		return !entry.generation && time.Since(entry.creation) > mc.ttl
+	}
+	return time.Since(entry.creation) > entry.ttl
+}
+
+func (mc *MetricsCache) isStale(region string) bool {
+	if region == "" {
+		return len(mc.staleRegions) > 0
+	}
+	return mc.staleRegions[region]
+}
+
+// setRegionStale marks the metrics of the region as stale after a failed collection, or as up to date once a
+// collection succeeded
+func (mc *MetricsCache) setRegionStale(region string, stale bool) {
+	mc.cacheMutex.Lock()
+	defer mc.cacheMutex.Unlock()
+	if stale {
+		mc.staleRegions[region] = true
+	} else {
+		delete(mc.staleRegions, region)
+	}
+}
+
+// startCollectorRun starts a run of the collector in the region, which keeps the metrics of the region in the cache
+// if it fails. The returned context carries the span of the run, so the API requests made with it are traced as its
+// children.
+func (mc *MetricsCache) startCollectorRun(ctx context.Context, collector string, region string) (context.Context, *collectorRun) {
+	run := startCollectorRun(collector, region)
+	run.cache = mc
+	run.loop, _ = ctx.Value(collectorLoopKey{}).(*collectorLoop)
+	ctx, run.span = otel.Tracer(tracerName).Start(ctx, "collect "+collector, trace.WithAttributes(
+		attribute.String("collector", collector),
+		semconv.CloudRegion(region),
+	))
+	return ctx, run
+}
+
 type cacheEntry struct {
 	creation time.Time
 	metric   prometheus.Metric
+	region   string
+	// ttl overrides the TTL of the cache if set
+	ttl time.Duration
+	// generation is set for the metrics added in a generation, which only expire with their own TTL
+	generation bool
 }
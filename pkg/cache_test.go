@@ -1,11 +1,18 @@
 package pkg
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func createTestMetric(fqdn string, value float64) prometheus.Metric {
@@ -47,3 +54,161 @@ func TestMetricCacheGetAllWithTTL(t *testing.T) {
 	time.Sleep(2 * time.Second)
 	assert.Len(t, cache.GetAllMetrics(), 0)
 }
+
+func TestMetricCacheKeepsStaleRegions(t *testing.T) {
+	cache := NewMetricsCache(1 * time.Second)
+	desc := prometheus.NewDesc("test", "multimetric", []string{"aws_region"}, nil)
+	global := createTestMetric("global", 1)
+	cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-east-1"))
+	cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "eu-west-1"))
+	cache.AddMetric(global)
+
+	_, run := cache.startCollectorRun(context.Background(), "test", "eu-west-1")
+	run.fail()
+	run.finish()
+	time.Sleep(2 * time.Second)
+
+	// the metrics of the failed region and the ones without region are kept
+	metrics := cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	assert.Contains(t, metrics, global)
+
+	_, run = cache.startCollectorRun(context.Background(), "test", "eu-west-1")
+	run.finish()
+	assert.Len(t, cache.GetAllMetrics(), 0)
+}
+
+func TestMetricCacheAddMetricWithTTL(t *testing.T) {
+	cache := NewMetricsCache(1 * time.Second)
+	cheap := createTestMetric("cheap", 1)
+	expensive := createTestMetric("expensive", 1)
+	cache.AddMetric(cheap)
+	cache.AddMetricWithTTL(expensive, time.Minute)
+
+	time.Sleep(2 * time.Second)
+	assert.Equal(t, []prometheus.Metric{expensive}, cache.GetAllMetrics())
+}
+
+func TestMetricCacheAddQuotaUtilization(t *testing.T) {
+	cache := NewMetricsCache(time.Minute)
+	desc := prometheus.NewDesc("test", "utilization", []string{"aws_region"}, nil)
+	cache.AddQuotaUtilization(desc, 15, 60, "us-east-1")
+	// a quota which couldn't be looked up has no utilization
+	cache.AddQuotaUtilization(desc, 15, 0, "us-west-1")
+
+	metrics := cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, 0.25, dtoMetric.GetGauge().GetValue())
+	assert.Equal(t, "us-east-1", dtoMetric.GetLabel()[0].GetValue())
+}
+
+func TestMetricCacheInvalidateByDesc(t *testing.T) {
+	cache := NewMetricsCache(time.Minute)
+	desc := prometheus.NewDesc("test", "multimetric", []string{"aws_region"}, nil)
+	other := prometheus.NewDesc("other", "multimetric", []string{"aws_region"}, nil)
+	otherMetric := prometheus.MustNewConstMetric(other, prometheus.GaugeValue, 1, "us-east-1")
+	westMetric := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-west-1")
+	cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-east-1"))
+	cache.AddMetric(westMetric)
+	cache.AddMetric(otherMetric)
+
+	cache.InvalidateByDesc(desc, "us-east-1")
+	assert.ElementsMatch(t, []prometheus.Metric{westMetric, otherMetric}, cache.GetAllMetrics())
+
+	cache.InvalidateByDesc(desc)
+	assert.Equal(t, []prometheus.Metric{otherMetric}, cache.GetAllMetrics())
+}
+
+func TestMetricCacheGeneration(t *testing.T) {
+	cache := NewMetricsCache(1 * time.Second)
+	desc := prometheus.NewDesc("test", "multimetric", []string{"aws_region"}, nil)
+	old := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-east-1")
+	deleted := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-west-1")
+	cache.BeginGeneration()
+	cache.AddMetric(old)
+	cache.AddMetric(deleted)
+	cache.CommitGeneration()
+
+	cache.BeginGeneration()
+	updated := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 2, "us-east-1")
+	cache.AddMetric(updated)
+	// the previous generation is served until the new one is committed, even past the TTL
+	time.Sleep(2 * time.Second)
+	assert.ElementsMatch(t, []prometheus.Metric{old, deleted}, cache.GetAllMetrics())
+
+	cache.CommitGeneration()
+	assert.Equal(t, []prometheus.Metric{updated}, cache.GetAllMetrics())
+}
+
+func TestMetricCacheGenerationKeepsStaleRegions(t *testing.T) {
+	cache := NewMetricsCache(time.Minute)
+	desc := prometheus.NewDesc("test", "multimetric", []string{"aws_region"}, nil)
+	east := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-east-1")
+	west := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-west-1")
+	cache.BeginGeneration()
+	cache.AddMetric(east)
+	cache.AddMetric(west)
+	cache.CommitGeneration()
+
+	cache.BeginGeneration()
+	_, run := cache.startCollectorRun(context.Background(), "test", "us-west-1")
+	run.fail()
+	run.finish()
+	cache.CommitGeneration()
+	assert.Equal(t, []prometheus.Metric{west}, cache.GetAllMetrics())
+}
+
+func TestMetricCacheHasMetric(t *testing.T) {
+	cache := NewMetricsCache(time.Minute)
+	desc := prometheus.NewDesc("test", "multimetric", []string{"aws_region"}, nil)
+	cache.AddMetricWithTTL(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "us-east-1"), time.Second)
+	assert.True(t, cache.HasMetric(desc, "us-east-1"))
+	assert.False(t, cache.HasMetric(desc, "us-west-1"))
+
+	// metrics with their own TTL are carried over to the next generation until they expire
+	cache.BeginGeneration()
+	cache.CommitGeneration()
+	assert.True(t, cache.HasMetric(desc, "us-east-1"))
+
+	time.Sleep(2 * time.Second)
+	assert.False(t, cache.HasMetric(desc, "us-east-1"))
+}
+
+func TestMetricCacheTimestamps(t *testing.T) {
+	ttl := time.Minute
+	cache := NewMetricsCacheFromConfig(BaseConfig{CacheTTL: &ttl, MetricTimestamps: true})
+	before := time.Now()
+	cache.AddMetric(createTestMetric("testing", 1))
+
+	metrics := cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	out := dto.Metric{}
+	assert.Nil(t, metrics[0].Write(&out))
+	assert.GreaterOrEqual(t, out.GetTimestampMs(), before.UnixMilli())
+
+	cache = NewMetricsCacheFromConfig(BaseConfig{CacheTTL: &ttl})
+	cache.AddMetric(createTestMetric("testing", 1))
+	out = dto.Metric{}
+	assert.Nil(t, cache.GetAllMetrics()[0].Write(&out))
+	assert.Nil(t, out.TimestampMs)
+}
+
+func TestCollectorRunSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	cache := NewMetricsCache(time.Minute)
+	ctx, run := cache.startCollectorRun(context.Background(), "test", "eu-west-1")
+	assert.True(t, trace.SpanFromContext(ctx).SpanContext().IsValid())
+	run.fail()
+	run.finish()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "collect test", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	cloudfrontServiceCode           string = "cloudfront"
+	distributionsQuotaCode          string = "L-24B04930"
+	aliasesPerDistributionQuotaCode string = "L-ED3F7F9A"
+)
+
+type CloudFrontExporter struct {
+	client                      awsclient.Client
+	region                      string
+	DistributionsQuota          *prometheus.Desc
+	DistributionsUsage          *prometheus.Desc
+	AliasesPerDistributionQuota *prometheus.Desc
+	AliasesPerDistributionUsage *prometheus.Desc
+	DistributionEnabled         *prometheus.Desc
+	DistributionStatus          *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewCloudFrontExporter creates a new CloudFrontExporter instance. CloudFront is a global service, so there is a
+// single session only.
+func NewCloudFrontExporter(sess *session.Session, logger log.Logger, config CloudFrontConfig, awsAccountId string) *CloudFrontExporter {
+	level.Info(logger).Log("msg", "Initializing CloudFront exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: cloudfrontServiceCode}
+
+	return &CloudFrontExporter{
+		client:                      awsclient.NewClientFromSession(sess),
+		region:                      config.Region,
+		DistributionsQuota:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_distributions_quota"), "Quota for maximum number of CloudFront distributions in an account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, distributionsQuotaCode)),
+		DistributionsUsage:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_distributions_usage"), "Number of CloudFront distributions in the account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, distributionsQuotaCode)),
+		AliasesPerDistributionQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_aliasesperdistribution_quota"), "Quota for maximum number of alternate domain names (CNAMEs) per CloudFront distribution", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, aliasesPerDistributionQuotaCode)),
+		AliasesPerDistributionUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_aliasesperdistribution_usage"), "Number of alternate domain names (CNAMEs) of the CloudFront distribution", []string{"distribution_id"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, aliasesPerDistributionQuotaCode)),
+		DistributionEnabled:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_distribution_enabled"), "Whether the CloudFront distribution is enabled", []string{"distribution_id", "domain_name"}, constLabels),
+		DistributionStatus:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudfront_distribution_status"), "The deployment status of the CloudFront distribution", []string{"distribution_id", "status"}, constLabels),
+		cache:                       *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                      logger,
+		interval:                    *config.Interval,
+		timeout:                     *config.Timeout,
+	}
+}
+
+func (e *CloudFrontExporter) collectMetrics(ctx context.Context, run *collectorRun) {
+	quotas := []struct {
+		quotaCode string
+		desc      *prometheus.Desc
+	}{
+		{distributionsQuotaCode, e.DistributionsQuota},
+		{aliasesPerDistributionQuotaCode, e.AliasesPerDistributionQuota},
+	}
+	for _, quota := range quotas {
+		value, err := getQuotaValueWithContext(e.client, cloudfrontServiceCode, quota.quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve CloudFront quota", "quota_code", quota.quotaCode, "err", err)
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(quota.desc, prometheus.GaugeValue, value))
+	}
+
+	distributions, err := e.client.ListDistributionsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListDistributionsAll failed", "err", err)
+		run.fail()
+		return
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.DistributionsUsage, prometheus.GaugeValue, float64(len(distributions))))
+	for _, distribution := range distributions {
+		distributionId := aws.StringValue(distribution.Id)
+
+		aliases := 0.0
+		if distribution.Aliases != nil {
+			aliases = float64(aws.Int64Value(distribution.Aliases.Quantity))
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.AliasesPerDistributionUsage, prometheus.GaugeValue, aliases, distributionId))
+
+		enabled := 0.0
+		if aws.BoolValue(distribution.Enabled) {
+			enabled = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.DistributionEnabled, prometheus.GaugeValue, enabled, distributionId, aws.StringValue(distribution.DomainName)))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.DistributionStatus, prometheus.GaugeValue, 1, distributionId, aws.StringValue(distribution.Status)))
+	}
+}
+
+func (e *CloudFrontExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "cloudfront", e.region)
+
+		e.collectMetrics(collectCtx, run)
+
+		level.Info(e.logger).Log("msg", "CloudFront metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "cloudfront")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *CloudFrontExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *CloudFrontExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.DistributionsQuota
+	ch <- e.DistributionsUsage
+	ch <- e.AliasesPerDistributionQuota
+	ch <- e.AliasesPerDistributionUsage
+	ch <- e.DistributionEnabled
+	ch <- e.DistributionStatus
+}
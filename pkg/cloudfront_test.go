@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudFrontCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(cloudfrontServiceCode, distributionsQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(200)}}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(cloudfrontServiceCode, aliasesPerDistributionQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100)}}, nil)
+	mockClient.EXPECT().ListDistributionsAll(ctx).Return([]*cloudfront.DistributionSummary{
+		{
+			Id:         aws.String("E1"),
+			DomainName: aws.String("d1.cloudfront.net"),
+			Enabled:    aws.Bool(true),
+			Status:     aws.String("Deployed"),
+			Aliases:    &cloudfront.Aliases{Quantity: aws.Int64(3)},
+		},
+	}, nil)
+
+	e := NewCloudFrontExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), CloudFrontConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	e.client = mockClient
+	run := startCollectorRun("cloudfront", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.True(t, run.finish())
+
+	expected := map[string]float64{
+		e.DistributionsQuota.String():          200,
+		e.DistributionsUsage.String():          1,
+		e.AliasesPerDistributionQuota.String(): 100,
+		e.AliasesPerDistributionUsage.String(): 3,
+		e.DistributionEnabled.String():         1,
+		e.DistributionStatus.String():          1,
+	}
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, len(expected))
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		assert.Equal(t, expected[metric.Desc().String()], dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+	}
+}
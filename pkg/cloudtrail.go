@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const cloudtrailServiceCode string = "cloudtrail"
+
+// CloudTrailExporter exports the trails of all regions for security baseline monitoring. The trails are listed in a
+// single region, the trails of the other regions are described by their ARN.
+type CloudTrailExporter struct {
+	client                        awsclient.Client
+	region                        string
+	Trails                        *prometheus.Desc
+	MultiRegionTrailLogValidation *prometheus.Desc
+	TrailMultiRegion              *prometheus.Desc
+	TrailLogFileValidation        *prometheus.Desc
+	TrailLogging                  *prometheus.Desc
+	TrailLastDelivery             *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewCloudTrailExporter creates a new CloudTrailExporter instance
+func NewCloudTrailExporter(sess *session.Session, logger log.Logger, config CloudTrailConfig, awsAccountId string) *CloudTrailExporter {
+	level.Info(logger).Log("msg", "Initializing CloudTrail exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: cloudtrailServiceCode}
+	trailLabels := []string{"trail_name", "home_region"}
+
+	return &CloudTrailExporter{
+		client:                        awsclient.NewClientFromSession(sess),
+		region:                        config.Region,
+		Trails:                        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_trails_total"), "Number of CloudTrail trails in all regions", []string{}, constLabels),
+		MultiRegionTrailLogValidation: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_multiregion_trail_log_file_validation"), "Whether a multi-region trail with log file validation exists", []string{}, constLabels),
+		TrailMultiRegion:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_trail_multiregion"), "Whether the trail logs the events of all regions", trailLabels, constLabels),
+		TrailLogFileValidation:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_trail_log_file_validation"), "Whether log file validation is enabled for the trail", trailLabels, constLabels),
+		TrailLogging:                  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_trail_logging"), "Whether the trail is logging", trailLabels, constLabels),
+		TrailLastDelivery:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudtrail_trail_last_delivery_timestamp_seconds"), "The time the trail last delivered log files to its S3 bucket", trailLabels, constLabels),
+		cache:                         *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                        logger,
+		interval:                      *config.Interval,
+		timeout:                       *config.Timeout,
+	}
+}
+
+func (e *CloudTrailExporter) collectMetrics(ctx context.Context, run *collectorRun) {
+	trailInfos, err := e.client.ListTrailsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListTrailsAll failed", "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.Trails, prometheus.GaugeValue, float64(len(trailInfos))))
+
+	var trails []*cloudtrail.Trail
+	if len(trailInfos) > 0 {
+		var trailARNs []*string
+		for _, trail := range trailInfos {
+			trailARNs = append(trailARNs, trail.TrailARN)
+		}
+		// the trails of other regions are only described by their ARN
+		output, err := e.client.DescribeTrailsWithContext(ctx, &cloudtrail.DescribeTrailsInput{TrailNameList: trailARNs})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeTrails failed", "err", err)
+			run.fail()
+			return
+		}
+		trails = output.TrailList
+	}
+
+	multiRegionLogValidation := 0.0
+	for _, trail := range trails {
+		labels := []string{aws.StringValue(trail.Name), aws.StringValue(trail.HomeRegion)}
+
+		multiRegion := 0.0
+		if aws.BoolValue(trail.IsMultiRegionTrail) {
+			multiRegion = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TrailMultiRegion, prometheus.GaugeValue, multiRegion, labels...))
+
+		logFileValidation := 0.0
+		if aws.BoolValue(trail.LogFileValidationEnabled) {
+			logFileValidation = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TrailLogFileValidation, prometheus.GaugeValue, logFileValidation, labels...))
+		if multiRegion == 1 && logFileValidation == 1 {
+			multiRegionLogValidation = 1
+		}
+
+		status, err := e.client.GetTrailStatusWithContext(ctx, &cloudtrail.GetTrailStatusInput{Name: trail.TrailARN})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetTrailStatus failed", "trail", aws.StringValue(trail.TrailARN), "err", err)
+			run.fail()
+			continue
+		}
+		logging := 0.0
+		if aws.BoolValue(status.IsLogging) {
+			logging = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TrailLogging, prometheus.GaugeValue, logging, labels...))
+		// trails which never delivered a log file have no delivery time
+		if status.LatestDeliveryTime != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.TrailLastDelivery, prometheus.GaugeValue, float64(status.LatestDeliveryTime.Unix()), labels...))
+		}
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.MultiRegionTrailLogValidation, prometheus.GaugeValue, multiRegionLogValidation))
+}
+
+func (e *CloudTrailExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "cloudtrail", e.region)
+
+		e.collectMetrics(collectCtx, run)
+
+		level.Info(e.logger).Log("msg", "CloudTrail metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "cloudtrail")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *CloudTrailExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *CloudTrailExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.Trails
+	ch <- e.MultiRegionTrailLogValidation
+	ch <- e.TrailMultiRegion
+	ch <- e.TrailLogFileValidation
+	ch <- e.TrailLogging
+	ch <- e.TrailLastDelivery
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCloudTrailExporter(mockClient *mock.MockClient) *CloudTrailExporter {
+	e := NewCloudTrailExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), CloudTrailConfig{BaseConfig: createTestBaseConfig(), Region: "us-east-1"}, "1234567890")
+	e.client = mockClient
+	return e
+}
+
+func TestCloudTrailCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delivery := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	organizationARN := "arn:aws:cloudtrail:us-east-1:1234567890:trail/organization"
+	regionalARN := "arn:aws:cloudtrail:eu-west-1:1234567890:trail/regional"
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListTrailsAll(ctx).Return([]*cloudtrail.TrailInfo{
+		{TrailARN: aws.String(organizationARN), Name: aws.String("organization"), HomeRegion: aws.String("us-east-1")},
+		{TrailARN: aws.String(regionalARN), Name: aws.String("regional"), HomeRegion: aws.String("eu-west-1")},
+	}, nil)
+	mockClient.EXPECT().DescribeTrailsWithContext(ctx, &cloudtrail.DescribeTrailsInput{TrailNameList: aws.StringSlice([]string{organizationARN, regionalARN})}).Return(
+		&cloudtrail.DescribeTrailsOutput{TrailList: []*cloudtrail.Trail{
+			{TrailARN: aws.String(organizationARN), Name: aws.String("organization"), HomeRegion: aws.String("us-east-1"), IsMultiRegionTrail: aws.Bool(true), LogFileValidationEnabled: aws.Bool(true)},
+			{TrailARN: aws.String(regionalARN), Name: aws.String("regional"), HomeRegion: aws.String("eu-west-1"), IsMultiRegionTrail: aws.Bool(false), LogFileValidationEnabled: aws.Bool(false)},
+		}}, nil)
+	mockClient.EXPECT().GetTrailStatusWithContext(ctx, &cloudtrail.GetTrailStatusInput{Name: aws.String(organizationARN)}).Return(
+		&cloudtrail.GetTrailStatusOutput{IsLogging: aws.Bool(true), LatestDeliveryTime: aws.Time(delivery)}, nil)
+	// the trail never delivered a log file
+	mockClient.EXPECT().GetTrailStatusWithContext(ctx, &cloudtrail.GetTrailStatusInput{Name: aws.String(regionalARN)}).Return(
+		&cloudtrail.GetTrailStatusOutput{IsLogging: aws.Bool(false)}, nil)
+
+	e := createTestCloudTrailExporter(mockClient)
+	run := startCollectorRun("cloudtrail", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.True(t, run.finish())
+
+	// trail count and baseline, 2x multi-region, validation and logging, delivery of the organization trail
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 9)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.Trails.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.MultiRegionTrailLogValidation.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.TrailLastDelivery.String():
+			assert.Equal(t, float64(delivery.Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestCloudTrailCollectMetricsWithoutTrails(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListTrailsAll(ctx).Return(nil, nil)
+
+	e := createTestCloudTrailExporter(mockClient)
+	run := startCollectorRun("cloudtrail", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.True(t, run.finish())
+
+	// the baseline is violated without any trail
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		assert.Equal(t, 0.0, dtoMetric.GetGauge().GetValue())
+	}
+}
+
+func TestCloudTrailCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListTrailsAll(ctx).Return(nil, errors.New("AccessDeniedException"))
+
+	e := createTestCloudTrailExporter(mockClient)
+	run := startCollectorRun("cloudtrail", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.False(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 0)
+}
@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const cloudwatchLogsServiceCode string = "logs"
+
+type CloudWatchLogsExporter struct {
+	sessions                  []*session.Session
+	LogGroupsPerRegion        *prometheus.Desc
+	LogGroupsWithoutRetention *prometheus.Desc
+	LogGroupRetention         *prometheus.Desc
+	LogGroupStoredBytes       *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewCloudWatchLogsExporter creates a new CloudWatchLogsExporter instance
+func NewCloudWatchLogsExporter(sessions []*session.Session, logger log.Logger, config CloudWatchLogsConfig, awsAccountId string) *CloudWatchLogsExporter {
+	level.Info(logger).Log("msg", "Initializing CloudWatch Logs exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: cloudwatchLogsServiceCode}
+	logGroupLabels := []string{"aws_region", "log_group_name"}
+
+	return &CloudWatchLogsExporter{
+		sessions:                  sessions,
+		LogGroupsPerRegion:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudwatchlogs_loggroupsperregion_usage"), "Number of CloudWatch log groups in this region", []string{"aws_region"}, constLabels),
+		LogGroupsWithoutRetention: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudwatchlogs_loggroups_without_retention"), "Number of CloudWatch log groups in this region whose events never expire", []string{"aws_region"}, constLabels),
+		LogGroupRetention:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudwatchlogs_loggroup_retention_days"), "Number of days the events of the CloudWatch log group are retained. Not exported if they never expire", logGroupLabels, constLabels),
+		LogGroupStoredBytes:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cloudwatchlogs_loggroup_stored_bytes"), "Number of bytes stored in the CloudWatch log group", logGroupLabels, constLabels),
+		cache:                     *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                    logger,
+		timeout:                   *config.Timeout,
+		interval:                  *config.Interval,
+	}
+}
+
+func (e *CloudWatchLogsExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.LogGroupsPerRegion
+	ch <- e.LogGroupsWithoutRetention
+	ch <- e.LogGroupRetention
+	ch <- e.LogGroupStoredBytes
+}
+
+func (e *CloudWatchLogsExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *CloudWatchLogsExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "CloudWatch Logs metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "cloudwatchlogs")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *CloudWatchLogsExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "cloudwatchlogs", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *CloudWatchLogsExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	logGroups, err := client.DescribeLogGroupsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeLogGroupsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	withoutRetention := 0
+	for _, logGroup := range logGroups {
+		if logGroup.RetentionInDays == nil {
+			withoutRetention++
+		}
+		e.addLogGroupMetrics(region, logGroup)
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.LogGroupsPerRegion, prometheus.GaugeValue, float64(len(logGroups)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.LogGroupsWithoutRetention, prometheus.GaugeValue, float64(withoutRetention), region))
+}
+
+func (e *CloudWatchLogsExporter) addLogGroupMetrics(region string, logGroup *cloudwatchlogs.LogGroup) {
+	logGroupName := aws.StringValue(logGroup.LogGroupName)
+
+	if logGroup.RetentionInDays != nil {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.LogGroupRetention, prometheus.GaugeValue, float64(aws.Int64Value(logGroup.RetentionInDays)), region, logGroupName))
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.LogGroupStoredBytes, prometheus.GaugeValue, float64(aws.Int64Value(logGroup.StoredBytes)), region, logGroupName))
+}
@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudWatchLogsCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeLogGroupsAll(ctx).Return([]*cloudwatchlogs.LogGroup{
+		{LogGroupName: aws.String("a"), RetentionInDays: aws.Int64(30), StoredBytes: aws.Int64(1024)},
+		{LogGroupName: aws.String("b"), StoredBytes: aws.Int64(2048)},
+	}, nil)
+
+	e := NewCloudWatchLogsExporter(nil, log.NewNopLogger(), CloudWatchLogsConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("cloudwatchlogs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// stored bytes per log group, a single retention and the 2 region metrics
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 5)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.LogGroupsPerRegion.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.LogGroupsWithoutRetention.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.LogGroupRetention.String():
+			assert.Equal(t, 30.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestCloudWatchLogsCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeLogGroupsAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewCloudWatchLogsExporter(nil, log.NewNopLogger(), CloudWatchLogsConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("cloudwatchlogs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
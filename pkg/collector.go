@@ -0,0 +1,233 @@
+package pkg
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CollectorLastUpdate is shared by all exporters to publish when their metrics were last updated, so stale
+// collectors can be alerted on
+var CollectorLastUpdate = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "collector_last_update_timestamp_seconds",
+		Help:      "Last time the collector successfully finished updating its metrics.",
+	},
+	[]string{"collector"},
+)
+
+// CollectorDuration and CollectorSuccess are published for every collection of a collector in a region, similar to
+// the node_exporter scrape metrics
+var CollectorDuration = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "collector_duration_seconds",
+		Help:      "Duration of the last collection of the collector in the region.",
+	},
+	[]string{"collector", "aws_region"},
+)
+
+var CollectorSuccess = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "collector_success",
+		Help:      "Whether the last collection of the collector in the region succeeded.",
+	},
+	[]string{"collector", "aws_region"},
+)
+
+// CollectorRegionUp tells a failed API call in a region apart from deleted resources: while it is 0, the metrics of the
+// region from earlier collections are kept instead of expiring
+var CollectorRegionUp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "collector_region_up",
+		Help:      "Whether the metrics of the collector in the region are up to date. Stale metrics are kept while it is 0.",
+	},
+	[]string{"collector", "aws_region"},
+)
+
+// CollectorMetrics returns the metrics shared by all exporters, which have to be registered once
+func CollectorMetrics() []prometheus.Collector {
+	return []prometheus.Collector{CollectorLastUpdate, CollectorDuration, CollectorSuccess, CollectorRegionUp}
+}
+
+type collectorPartKey struct{}
+
+// WithCollectorPart marks the collect loop run with the context as the given part of its collector. An exporter split
+// by region overrides starts a collect loop per part, which are numbered from 0 in the order they are started.
+func WithCollectorPart(ctx context.Context, part int) context.Context {
+	return context.WithValue(ctx, collectorPartKey{}, part)
+}
+
+// setCollectorLastUpdate records the current time as last update of the given collector. It must only be called after
+// a successful loop. The part of the collector is taken from the context of the loop.
+func setCollectorLastUpdate(ctx context.Context, collector string) {
+	part, _ := ctx.Value(collectorPartKey{}).(int)
+	CollectorLastUpdate.WithLabelValues(collector).SetToCurrentTime()
+	collectorStatuses.setLastUpdate(collector, part, time.Now())
+}
+
+// CollectorStatus is the state of an enabled collector as reported by the health endpoint
+type CollectorStatus struct {
+	Name string `json:"name"`
+	// Ready is set once every part of the collector completed a successful loop
+	Ready bool `json:"ready"`
+	// LastUpdateAge is the number of seconds since the last successful loop of the part updated longest ago. It is
+	// omitted until the collector is ready.
+	LastUpdateAge *float64 `json:"last_update_age_seconds,omitempty"`
+}
+
+// collectorStatusRegistry tracks the collectors of the active configuration and when their parts were last updated
+type collectorStatusRegistry struct {
+	mu sync.RWMutex
+	// enabled holds the number of parts of every enabled collector
+	enabled    map[string]int
+	lastUpdate map[string]map[int]time.Time
+}
+
+var collectorStatuses = &collectorStatusRegistry{enabled: map[string]int{}, lastUpdate: map[string]map[int]time.Time{}}
+
+func (r *collectorStatusRegistry) setLastUpdate(collector string, part int, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastUpdate[collector] == nil {
+		r.lastUpdate[collector] = map[int]time.Time{}
+	}
+	r.lastUpdate[collector][part] = t
+}
+
+// SetEnabledCollectors replaces the collectors the readiness depends on, e.g. when the configuration is reloaded.
+// The names have to match the collector label of the last update metric. A name is given once per part of the
+// collector.
+func SetEnabledCollectors(names []string) {
+	enabled := map[string]int{}
+	for _, name := range names {
+		enabled[name]++
+	}
+
+	collectorStatuses.mu.Lock()
+	defer collectorStatuses.mu.Unlock()
+	collectorStatuses.enabled = enabled
+}
+
+// CollectorStatuses returns the status of every enabled collector and whether all of them are ready
+func CollectorStatuses() ([]CollectorStatus, bool) {
+	collectorStatuses.mu.RLock()
+	defer collectorStatuses.mu.RUnlock()
+
+	names := make([]string, 0, len(collectorStatuses.enabled))
+	for name := range collectorStatuses.enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ready := true
+	statuses := make([]CollectorStatus, 0, len(names))
+	for _, name := range names {
+		status := CollectorStatus{Name: name, Ready: true}
+		var oldest time.Time
+		for part := 0; part < collectorStatuses.enabled[name]; part++ {
+			lastUpdate, ok := collectorStatuses.lastUpdate[name][part]
+			if !ok {
+				status.Ready = false
+				break
+			}
+			if oldest.IsZero() || lastUpdate.Before(oldest) {
+				oldest = lastUpdate
+			}
+		}
+		if status.Ready {
+			age := time.Since(oldest).Seconds()
+			status.LastUpdateAge = &age
+		} else {
+			ready = false
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, ready
+}
+
+// collectorRun tracks a single collection of a collector in a region. Exporters start a run before collecting the
+// metrics of a region, mark it as failed on any error and finish it once all metrics are collected.
+type collectorRun struct {
+	collector string
+	region    string
+	start     time.Time
+	failed    atomic.Bool
+	// cache holds the metrics of the collector, which are kept if the run fails
+	cache *MetricsCache
+	// span traces the run, if it was started with a context
+	span trace.Span
+	// loop is the loop the run is part of, if it was started with the context of a loop
+	loop *collectorLoop
+}
+
+func startCollectorRun(collector string, region string) *collectorRun {
+	return &collectorRun{
+		collector: collector,
+		region:    region,
+		start:     time.Now(),
+	}
+}
+
+// fail marks the run as failed. It is safe to be called from multiple goroutines.
+func (r *collectorRun) fail() {
+	r.failed.Store(true)
+}
+
+// finish publishes the duration and success of the run and returns whether it succeeded
+func (r *collectorRun) finish() bool {
+	CollectorDuration.WithLabelValues(r.collector, r.region).Set(time.Since(r.start).Seconds())
+
+	success := !r.failed.Load()
+	if success {
+		CollectorSuccess.WithLabelValues(r.collector, r.region).Set(1)
+		CollectorRegionUp.WithLabelValues(r.collector, r.region).Set(1)
+	} else {
+		CollectorSuccess.WithLabelValues(r.collector, r.region).Set(0)
+		CollectorRegionUp.WithLabelValues(r.collector, r.region).Set(0)
+	}
+	if r.cache != nil {
+		r.cache.setRegionStale(r.region, !success)
+	}
+	if r.loop != nil && !success {
+		r.loop.failed.Store(true)
+	}
+	if r.span != nil {
+		if !success {
+			r.span.SetStatus(codes.Error, "collection failed")
+		}
+		r.span.End()
+	}
+	return success
+}
+
+type collectorLoopKey struct{}
+
+// collectorLoop tracks a loop over the regions of an exporter. The runs started with the context of the loop report
+// their result to it, so the last update of the collector is only published if the runs of all regions succeeded.
+type collectorLoop struct {
+	ctx    context.Context
+	failed atomic.Bool
+}
+
+// startCollectorLoop starts a loop, the runs of the regions have to be started with the returned context
+func startCollectorLoop(ctx context.Context) (context.Context, *collectorLoop) {
+	loop := &collectorLoop{}
+	loop.ctx = context.WithValue(ctx, collectorLoopKey{}, loop)
+	return loop.ctx, loop
+}
+
+// finish returns whether the runs of all regions succeeded. The loop fails as well if its context is done, as the
+// regions which weren't started yet are skipped then.
+func (l *collectorLoop) finish() bool {
+	return !l.failed.Load() && l.ctx.Err() == nil
+}
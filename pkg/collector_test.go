@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCollectorLastUpdate(t *testing.T) {
+	before := float64(time.Now().Unix())
+	setCollectorLastUpdate(context.Background(), "test")
+
+	assert.GreaterOrEqual(t, testutil.ToFloat64(CollectorLastUpdate.WithLabelValues("test")), before)
+}
+
+func TestCollectorRun(t *testing.T) {
+	run := startCollectorRun("test", "success")
+	assert.True(t, run.finish())
+	assert.Equal(t, float64(1), testutil.ToFloat64(CollectorSuccess.WithLabelValues("test", "success")))
+
+	run = startCollectorRun("test", "failure")
+	run.fail()
+	assert.False(t, run.finish())
+	assert.Equal(t, float64(0), testutil.ToFloat64(CollectorSuccess.WithLabelValues("test", "failure")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(CollectorRegionUp.WithLabelValues("test", "failure")))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(CollectorDuration.WithLabelValues("test", "failure")), float64(0))
+}
+
+func TestCollectorStatuses(t *testing.T) {
+	ctx := context.Background()
+	SetEnabledCollectors([]string{"status_b", "status_a"})
+	setCollectorLastUpdate(ctx, "status_a")
+
+	statuses, ready := CollectorStatuses()
+	assert.False(t, ready)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "status_a", statuses[0].Name)
+	assert.True(t, statuses[0].Ready)
+	assert.NotNil(t, statuses[0].LastUpdateAge)
+	assert.False(t, statuses[1].Ready)
+	assert.Nil(t, statuses[1].LastUpdateAge)
+
+	setCollectorLastUpdate(ctx, "status_b")
+	_, ready = CollectorStatuses()
+	assert.True(t, ready)
+
+	SetEnabledCollectors(nil)
+}
+
+func TestCollectorStatusesParts(t *testing.T) {
+	SetEnabledCollectors([]string{"status_parts", "status_parts"})
+	setCollectorLastUpdate(WithCollectorPart(context.Background(), 1), "status_parts")
+
+	// the collector is only ready once each part completed a successful loop
+	statuses, ready := CollectorStatuses()
+	assert.False(t, ready)
+	assert.Len(t, statuses, 1)
+	assert.False(t, statuses[0].Ready)
+
+	setCollectorLastUpdate(WithCollectorPart(context.Background(), 0), "status_parts")
+	statuses, ready = CollectorStatuses()
+	assert.True(t, ready)
+	assert.True(t, statuses[0].Ready)
+
+	SetEnabledCollectors(nil)
+}
+
+func TestCollectorLoop(t *testing.T) {
+	cache := NewMetricsCache(time.Minute)
+	ctx, loop := startCollectorLoop(context.Background())
+	_, run := cache.startCollectorRun(ctx, "test", "us-east-1")
+	run.finish()
+	assert.True(t, loop.finish())
+
+	// a single failed region fails the whole loop
+	_, run = cache.startCollectorRun(ctx, "test", "eu-west-1")
+	run.fail()
+	run.finish()
+	_, run = cache.startCollectorRun(ctx, "test", "us-east-1")
+	run.finish()
+	assert.False(t, loop.finish())
+
+	// the regions which weren't started when the loop was cancelled don't count as success
+	ctx, cancel := context.WithCancel(context.Background())
+	_, loop = startCollectorLoop(ctx)
+	cancel()
+	assert.False(t, loop.finish())
+}
@@ -2,12 +2,23 @@ package pkg
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 type BaseConfig struct {
@@ -15,68 +26,345 @@ type BaseConfig struct {
 	Interval *time.Duration `yaml:"interval"`
 	Timeout  *time.Duration `yaml:"timeout"`
 	CacheTTL *time.Duration `yaml:"cache_ttl"`
+	// Mode is either "loop" to collect in the background or "scrape" to collect when the metrics are scraped
+	Mode string `yaml:"mode"`
+	// StartupJitter delays the first collection by a random duration up to the jitter
+	StartupJitter time.Duration `yaml:"startup_jitter"`
+	// RegionStagger delays the collection of each region after the previous one
+	RegionStagger time.Duration `yaml:"region_stagger"`
+	// RegionOverrides maps regions to a different interval or timeout, e.g. for regions with many resources
+	RegionOverrides map[string]RegionOverride `yaml:"region_overrides"`
+	// Filter selects the resources to export metrics for. It is only supported by the exporters in filterCollectors.
+	Filter ResourceFilter `yaml:"filter"`
+	// MetricTimestamps exports the metrics with the time they were collected instead of the scrape time
+	MetricTimestamps bool `yaml:"metric_timestamps"`
+	// AssumeRole is assumed by the exporter instead of the role of the aws block
+	AssumeRole *AssumeRoleConfig `yaml:"assume_role"`
+}
+
+type RegionOverride struct {
+	Interval *time.Duration `yaml:"interval"`
+	Timeout  *time.Duration `yaml:"timeout"`
 }
 
 type RDSConfig struct {
 	BaseConfig `yaml:"base,inline"`
-	Regions    []string    `yaml:"regions"`
-	EOLInfos   []EOLInfo   `yaml:"eol_info"`
-	Thresholds []Threshold `yaml:"thresholds"`
+	Regions    []string        `yaml:"regions"`
+	EOLInfos   []eol.Info      `yaml:"eol_info"`
+	Thresholds []eol.Threshold `yaml:"thresholds"`
+	// MaxConnectionsOverrides maps instance class -> parameter group -> max_connections. They are merged over the
+	// built-in DBMaxConnections map.
+	MaxConnectionsOverrides map[string]map[string]int64 `yaml:"max_connections_overrides"`
+	// TagLabels are the tags of the instances attached as tag_<key> labels to their metrics
+	TagLabels []string `yaml:"tag_labels"`
+	// LogsMetricsTTL is how long the log file metrics of an instance are cached, as they take an API call per instance
+	LogsMetricsTTL *time.Duration `yaml:"logs_metrics_ttl"`
+	// LogsMetricsWorkers is the number of instances whose log files are requested in parallel
+	LogsMetricsWorkers int `yaml:"logs_metrics_workers"`
+	// RegionConcurrency is the number of regions collected in parallel, each with its own timeout
+	RegionConcurrency int `yaml:"region_concurrency"`
 }
-type Threshold struct {
-	Name string `yaml:"name"`
-	Days int    `yaml:"days"`
+type VPCConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+	// MaxConcurrency is the number of regions collected in parallel, all regions if not set
+	MaxConcurrency int `yaml:"max_concurrency"`
 }
 
-type EOLInfo struct {
-	Engine  string `yaml:"engine"`
-	EOL     string `yaml:"eol"`
-	Version string `yaml:"version"`
+type Route53Config struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // Use only a single Region for now, as the current metric is global
+	// RecordTypeBreakdown lists all records of every hosted zone to count them per type. This is expensive for large zones.
+	RecordTypeBreakdown bool `yaml:"record_type_breakdown"`
+	// HealthCheckStatus requests the status of every health check from the Route53 health checkers
+	HealthCheckStatus bool `yaml:"health_check_status"`
+	// ZoneFilter selects the hosted zones whose records are counted
+	ZoneFilter Route53ZoneFilter `yaml:"zone_filter"`
+	// MaxConcurrency is the number of hosted zones and health checks requested in parallel
+	MaxConcurrency int `yaml:"max_concurrency"`
 }
 
-type EOLKey struct {
-	Engine  string
-	Version string
+// Route53ZoneFilter selects hosted zones by name and type. Include and Exclude are regular expressions matching the
+// whole zone name without the trailing dot.
+type Route53ZoneFilter struct {
+	Include string `yaml:"include"`
+	Exclude string `yaml:"exclude"`
+	// PrivateZone selects only private (true) or only public (false) zones, both if unset
+	PrivateZone *bool `yaml:"private_zone"`
 }
 
-type VPCConfig struct {
+type EC2Config struct {
 	BaseConfig `yaml:"base,inline"`
 	Regions    []string `yaml:"regions"`
+	// SavingsPlans requests the active Savings Plans of the account
+	SavingsPlans bool `yaml:"savings_plans"`
+	// AMIMetrics exports the age and protection of every AMI owned by the account
+	AMIMetrics bool `yaml:"ami_metrics"`
+	// SpotPlacementScores requests how likely the Spot capacity is fulfilled in the regions of the exporter
+	SpotPlacementScores *SpotPlacementScoresConfig `yaml:"spot_placement_scores"`
 }
 
-type Route53Config struct {
+// SpotPlacementScoresConfig is the Spot capacity the placement scores are requested for. AWS limits the number of
+// different configurations scored per day, so it should rarely change.
+type SpotPlacementScoresConfig struct {
+	InstanceTypes  []string `yaml:"instance_types"`
+	TargetCapacity int64    `yaml:"target_capacity"`
+}
+
+type ElastiCacheConfig struct {
 	BaseConfig `yaml:"base,inline"`
-	Region     string `yaml:"region"` // Use only a single Region for now, as the current metric is global
+	Regions    []string        `yaml:"regions"`
+	EOLInfos   []eol.Info      `yaml:"eol_info"`
+	Thresholds []eol.Threshold `yaml:"thresholds"`
+}
+type MSKConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string        `yaml:"regions"`
+	MSKInfos   []MSKInfo       `yaml:"msk_info"`
+	Thresholds []eol.Threshold `yaml:"thresholds"`
+	// RegionConcurrency is the number of regions collected in parallel, each with its own timeout
+	RegionConcurrency int `yaml:"region_concurrency"`
 }
 
-type EC2Config struct {
+type MSKInfo = eol.VersionInfo
+
+type DynamoDBConfig struct {
 	BaseConfig `yaml:"base,inline"`
 	Regions    []string `yaml:"regions"`
 }
 
-type ElastiCacheConfig struct {
+type ELBConfig struct {
 	BaseConfig `yaml:"base,inline"`
 	Regions    []string `yaml:"regions"`
 }
-type MSKConfig struct {
+
+type EBSConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type LambdaConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type SQSSNSConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type EKSConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string        `yaml:"regions"`
+	EKSInfos   []EKSInfo       `yaml:"eks_info"`
+	Thresholds []eol.Threshold `yaml:"thresholds"`
+}
+
+type EKSInfo = eol.VersionInfo
+
+type OpenSearchConfig struct {
+	BaseConfig      `yaml:"base,inline"`
+	Regions         []string         `yaml:"regions"`
+	OpenSearchInfos []OpenSearchInfo `yaml:"eol_info"`
+	Thresholds      []eol.Threshold  `yaml:"thresholds"`
+}
+
+type OpenSearchInfo = eol.VersionInfo
+
+type IAMConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // IAM is global, the region only selects the endpoint
+	// CredentialReport generates and downloads the credential report to export the credential age of every user
+	CredentialReport bool `yaml:"credential_report"`
+	// RoleLastUsed lists the details of all roles to export when they were last used
+	RoleLastUsed bool `yaml:"role_last_used"`
+}
+
+type ACMConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type KMSConfig struct {
 	BaseConfig `yaml:"base,inline"`
-	Regions    []string    `yaml:"regions"`
-	MSKInfos   []MSKInfo   `yaml:"msk_info"`
-	Thresholds []Threshold `yaml:"thresholds"`
+	Regions    []string `yaml:"regions"`
+}
+
+type SecretsManagerConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type SSMConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
 }
 
-type MSKInfo struct {
-	EOL     string `yaml:"eol"`
-	Version string `yaml:"version"`
+type CloudFrontConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // CloudFront is global, the region only selects the endpoint
+}
+
+type EFSConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type RedshiftConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type APIGatewayConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type KinesisConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type ECRConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type ECSConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type CloudWatchLogsConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type S3Config struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+	// StorageMetrics queries the daily S3 storage metrics from CloudWatch, which bills every requested metric
+	StorageMetrics bool `yaml:"storage_metrics"`
+	// StorageMetricsTTL is how long the storage metrics of a region are cached before they are queried again
+	StorageMetricsTTL *time.Duration `yaml:"storage_metrics_ttl"`
+}
+
+// ServiceQuotaConfig selects a quota to export by its service and quota code, as listed by
+// `aws service-quotas list-service-quotas`
+type ServiceQuotaConfig struct {
+	ServiceCode string `yaml:"service_code"`
+	QuotaCode   string `yaml:"quota_code"`
+	// Regions restricts the quota to some of the exporter regions. It is requested in all of them if empty.
+	Regions []string `yaml:"regions"`
+}
+
+type ServiceQuotasConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string             `yaml:"regions"`
+	Quotas     []ServiceQuotaConfig `yaml:"quotas"`
+}
+
+type TrustedAdvisorConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // The AWS Support API is only available in us-east-1
+}
+
+type BackupConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type CloudTrailConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // The trails of all regions are listed in a single region
+}
+
+type SecurityFindingsConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type ConfigServiceConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"`
+}
+
+type WAFConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Regions    []string `yaml:"regions"` // us-east-1 also collects the web ACLs of CloudFront
+}
+
+// CostConfig configures the Cost Explorer exporter. Every request to Cost Explorer is billed, so the costs are queried
+// once a day by default.
+type CostConfig struct {
+	BaseConfig  `yaml:"base,inline"`
+	Region      string `yaml:"region"`      // Cost Explorer is global, the region only selects the endpoint
+	Granularity string `yaml:"granularity"` // DAILY or MONTHLY
+}
+
+// CredentialsConfig configures the periodic check of the AWS credentials of the exporter
+type CredentialsConfig struct {
+	BaseConfig `yaml:"base,inline"`
+	Region     string `yaml:"region"` // STS is global, the region only selects the endpoint
 }
 
 type Config struct {
-	RdsConfig         RDSConfig         `yaml:"rds"`
-	VpcConfig         VPCConfig         `yaml:"vpc"`
-	Route53Config     Route53Config     `yaml:"route53"`
-	EC2Config         EC2Config         `yaml:"ec2"`
-	ElastiCacheConfig ElastiCacheConfig `yaml:"elasticache"`
-	MskConfig         MSKConfig         `yaml:"msk"`
+	RdsConfig              RDSConfig              `yaml:"rds"`
+	VpcConfig              VPCConfig              `yaml:"vpc"`
+	Route53Config          Route53Config          `yaml:"route53"`
+	EC2Config              EC2Config              `yaml:"ec2"`
+	ElastiCacheConfig      ElastiCacheConfig      `yaml:"elasticache"`
+	MskConfig              MSKConfig              `yaml:"msk"`
+	DynamoDBConfig         DynamoDBConfig         `yaml:"dynamodb"`
+	ELBConfig              ELBConfig              `yaml:"elb"`
+	EBSConfig              EBSConfig              `yaml:"ebs"`
+	LambdaConfig           LambdaConfig           `yaml:"lambda"`
+	SQSSNSConfig           SQSSNSConfig           `yaml:"sqs_sns"`
+	EKSConfig              EKSConfig              `yaml:"eks"`
+	OpenSearchConfig       OpenSearchConfig       `yaml:"opensearch"`
+	IAMConfig              IAMConfig              `yaml:"iam"`
+	ACMConfig              ACMConfig              `yaml:"acm"`
+	KMSConfig              KMSConfig              `yaml:"kms"`
+	SecretsManagerConfig   SecretsManagerConfig   `yaml:"secretsmanager"`
+	SSMConfig              SSMConfig              `yaml:"ssm"`
+	CloudFrontConfig       CloudFrontConfig       `yaml:"cloudfront"`
+	EFSConfig              EFSConfig              `yaml:"efs"`
+	RedshiftConfig         RedshiftConfig         `yaml:"redshift"`
+	APIGatewayConfig       APIGatewayConfig       `yaml:"apigateway"`
+	KinesisConfig          KinesisConfig          `yaml:"kinesis"`
+	ECRConfig              ECRConfig              `yaml:"ecr"`
+	ECSConfig              ECSConfig              `yaml:"ecs"`
+	CloudWatchLogsConfig   CloudWatchLogsConfig   `yaml:"cloudwatchlogs"`
+	S3Config               S3Config               `yaml:"s3"`
+	ServiceQuotasConfig    ServiceQuotasConfig    `yaml:"servicequotas"`
+	TrustedAdvisorConfig   TrustedAdvisorConfig   `yaml:"trustedadvisor"`
+	BackupConfig           BackupConfig           `yaml:"backup"`
+	CloudTrailConfig       CloudTrailConfig       `yaml:"cloudtrail"`
+	SecurityFindingsConfig SecurityFindingsConfig `yaml:"securityfindings"`
+	ConfigServiceConfig    ConfigServiceConfig    `yaml:"configservice"`
+	WAFConfig              WAFConfig              `yaml:"waf"`
+	CostConfig             CostConfig             `yaml:"cost"`
+	CredentialsConfig      CredentialsConfig      `yaml:"credentials"`
+	EOLConfig              eol.Config             `yaml:"eol"`
+	// RateLimits maps the AWS services to the rate of requests all collectors may make, e.g. "10/s"
+	RateLimits  map[string]string `yaml:"rate_limits"`
+	RetryConfig RetryConfig       `yaml:"retry"`
+	// DefaultRegions are used by the exporters without regions
+	DefaultRegions []string `yaml:"default_regions"`
+	// AutoDiscoverRegions uses the regions enabled for the account for the exporters still without regions
+	AutoDiscoverRegions bool `yaml:"auto_discover_regions"`
+	// AWS configures the partition and endpoints of all sessions
+	AWS AWSConfig `yaml:"aws"`
+	// Tracing and Push are only applied on startup, not when the configuration is reloaded
+	Tracing TracingConfig `yaml:"tracing"`
+	Push    PushConfig    `yaml:"push"`
+}
+
+// RetryConfig configures the retries of all AWS API requests. Zero values keep the SDK defaults.
+type RetryConfig struct {
+	// MaxAttempts includes the first attempt
+	MaxAttempts int           `yaml:"max_attempts"`
+	MaxBackoff  time.Duration `yaml:"max_backoff"`
 }
 
 func LoadExporterConfiguration(logger log.Logger, configFile string) (*Config, error) {
@@ -86,73 +374,493 @@ func LoadExporterConfiguration(logger log.Logger, configFile string) (*Config, e
 		level.Error(logger).Log("Could not load configuration file")
 		return nil, errors.New("Could not load configuration file: " + configFile)
 	}
-	yaml.Unmarshal(file, &config)
+	file, err = expandEnv(file)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse configuration file %s: %w", configFile, err)
+	}
+	// unknown fields fail, so typos don't silently fall back to the defaults
+	if err := yaml.UnmarshalStrict(file, &config); err != nil {
+		if strings.Contains(err.Error(), "not found in type") {
+			return nil, fmt.Errorf("Could not parse configuration file %s, remove or rename the unknown keys, they were ignored by previous versions: %w", configFile, err)
+		}
+		return nil, fmt.Errorf("Could not parse configuration file %s: %w", configFile, err)
+	}
 
-	if config.RdsConfig.CacheTTL == nil {
-		config.RdsConfig.CacheTTL = durationPtr(35 * time.Second)
+	// STS is called once a minute by default, the metrics are kept until the next call
+	credentialsBase := &config.CredentialsConfig.BaseConfig
+	if credentialsBase.Interval == nil {
+		credentialsBase.Interval = durationPtr(time.Minute)
+	}
+	if credentialsBase.CacheTTL == nil {
+		credentialsBase.CacheTTL = durationPtr(2 * time.Minute)
+	}
+
+	// Cost Explorer is billed per request and its costs are updated a few times a day, so they are queried daily and
+	// kept until the next query
+	costConfig := &config.CostConfig
+	if costConfig.Interval == nil {
+		costConfig.Interval = durationPtr(24 * time.Hour)
+	}
+	if costConfig.CacheTTL == nil {
+		costConfig.CacheTTL = durationPtr(48 * time.Hour)
 	}
-	if config.VpcConfig.CacheTTL == nil {
-		config.VpcConfig.CacheTTL = durationPtr(35 * time.Second)
+	if costConfig.Timeout == nil {
+		costConfig.Timeout = durationPtr(time.Minute)
 	}
-	if config.Route53Config.CacheTTL == nil {
-		config.Route53Config.CacheTTL = durationPtr(35 * time.Second)
+	if costConfig.Granularity == "" {
+		costConfig.Granularity = costexplorer.GranularityDaily
 	}
-	if config.EC2Config.CacheTTL == nil {
-		config.EC2Config.CacheTTL = durationPtr(35 * time.Second)
+
+	// Security Hub returns 100 findings per request and throttles GetFindings to a few requests per second, so the
+	// findings of all regions are listed hourly and kept until the next listing
+	securityFindingsBase := &config.SecurityFindingsConfig.BaseConfig
+	if securityFindingsBase.Interval == nil {
+		securityFindingsBase.Interval = durationPtr(time.Hour)
 	}
-	if config.ElastiCacheConfig.CacheTTL == nil {
-		config.ElastiCacheConfig.CacheTTL = durationPtr(35 * time.Second)
+	if securityFindingsBase.CacheTTL == nil {
+		securityFindingsBase.CacheTTL = durationPtr(2 * time.Hour)
 	}
-	if config.MskConfig.CacheTTL == nil {
-		config.MskConfig.CacheTTL = durationPtr(35 * time.Second)
+	if securityFindingsBase.Timeout == nil {
+		securityFindingsBase.Timeout = durationPtr(5 * time.Minute)
 	}
 
-	if config.RdsConfig.Interval == nil {
-		config.RdsConfig.Interval = durationPtr(15 * time.Second)
+	for _, base := range []*BaseConfig{
+		&config.RdsConfig.BaseConfig,
+		&config.VpcConfig.BaseConfig,
+		&config.Route53Config.BaseConfig,
+		&config.EC2Config.BaseConfig,
+		&config.ElastiCacheConfig.BaseConfig,
+		&config.MskConfig.BaseConfig,
+		&config.DynamoDBConfig.BaseConfig,
+		&config.ELBConfig.BaseConfig,
+		&config.EBSConfig.BaseConfig,
+		&config.LambdaConfig.BaseConfig,
+		&config.SQSSNSConfig.BaseConfig,
+		&config.EKSConfig.BaseConfig,
+		&config.OpenSearchConfig.BaseConfig,
+		&config.IAMConfig.BaseConfig,
+		&config.ACMConfig.BaseConfig,
+		&config.KMSConfig.BaseConfig,
+		&config.SecretsManagerConfig.BaseConfig,
+		&config.SSMConfig.BaseConfig,
+		&config.CloudFrontConfig.BaseConfig,
+		&config.EFSConfig.BaseConfig,
+		&config.RedshiftConfig.BaseConfig,
+		&config.APIGatewayConfig.BaseConfig,
+		&config.KinesisConfig.BaseConfig,
+		&config.ECRConfig.BaseConfig,
+		&config.ECSConfig.BaseConfig,
+		&config.CloudWatchLogsConfig.BaseConfig,
+		&config.S3Config.BaseConfig,
+		&config.ServiceQuotasConfig.BaseConfig,
+		&config.TrustedAdvisorConfig.BaseConfig,
+		&config.BackupConfig.BaseConfig,
+		&config.CloudTrailConfig.BaseConfig,
+		&config.SecurityFindingsConfig.BaseConfig,
+		&config.ConfigServiceConfig.BaseConfig,
+		&config.WAFConfig.BaseConfig,
+		&config.CostConfig.BaseConfig,
+		&config.CredentialsConfig.BaseConfig,
+	} {
+		if base.CacheTTL == nil {
+			base.CacheTTL = durationPtr(35 * time.Second)
+		}
+		if base.Interval == nil {
+			base.Interval = durationPtr(15 * time.Second)
+		}
+		if base.Timeout == nil {
+			base.Timeout = durationPtr(10 * time.Second)
+		}
+		if base.Mode == "" {
+			base.Mode = COLLECT_MODE_LOOP
+		}
 	}
-	if config.VpcConfig.Interval == nil {
-		config.VpcConfig.Interval = durationPtr(15 * time.Second)
+
+	// The environment variables are still supported, the configuration file takes precedence
+	rdsConfig := &config.RdsConfig
+	if rdsConfig.LogsMetricsTTL == nil {
+		rdsConfig.LogsMetricsTTL = durationPtr(RDS_LOGS_METRICS_TTL_DEFAULT)
+		if ttl, err := GetEnvIntValue(RDS_LOGS_METRICS_TTL); err == nil && ttl != nil {
+			level.Warn(logger).Log("msg", "The environment variable "+RDS_LOGS_METRICS_TTL+" is deprecated, use rds.logs_metrics_ttl")
+			rdsConfig.LogsMetricsTTL = durationPtr(time.Duration(*ttl) * time.Second)
+		}
 	}
-	if config.Route53Config.Interval == nil {
-		config.Route53Config.Interval = durationPtr(15 * time.Second)
+	if config.S3Config.StorageMetricsTTL == nil {
+		config.S3Config.StorageMetricsTTL = durationPtr(S3_STORAGE_METRICS_TTL_DEFAULT)
 	}
-	if config.EC2Config.Interval == nil {
-		config.EC2Config.Interval = durationPtr(15 * time.Second)
+	if rdsConfig.LogsMetricsWorkers == 0 {
+		rdsConfig.LogsMetricsWorkers = RDS_LOGS_METRICS_WORKERS_DEFAULT
+		if workers, err := GetEnvIntValue(RDS_LOGS_METRICS_WORKERS); err == nil && workers != nil {
+			level.Warn(logger).Log("msg", "The environment variable "+RDS_LOGS_METRICS_WORKERS+" is deprecated, use rds.logs_metrics_workers")
+			rdsConfig.LogsMetricsWorkers = *workers
+		}
 	}
-	if config.ElastiCacheConfig.Interval == nil {
-		config.ElastiCacheConfig.Interval = durationPtr(15 * time.Second)
+
+	for _, concurrency := range []*int{&rdsConfig.RegionConcurrency, &config.MskConfig.RegionConcurrency} {
+		if *concurrency == 0 {
+			*concurrency = defaultRegionConcurrency
+		}
 	}
-	if config.MskConfig.Interval == nil {
-		config.MskConfig.Interval = durationPtr(15 * time.Second)
+	if config.Route53Config.MaxConcurrency == 0 {
+		config.Route53Config.MaxConcurrency = route53DefaultMaxConcurrency
 	}
 
-	if config.RdsConfig.Timeout == nil {
-		config.RdsConfig.Timeout = durationPtr(10 * time.Second)
+	// Setting defaults when threshold is not defined to ease the transition from hardcoded thresholds. The exporters
+	// fall back to the shared thresholds.
+	if len(config.EOLConfig.Thresholds) == 0 {
+		config.EOLConfig.Thresholds = eol.DefaultThresholds()
 	}
-	if config.VpcConfig.Timeout == nil {
-		config.VpcConfig.Timeout = durationPtr(10 * time.Second)
+
+	eolSource := &config.EOLConfig.Source
+	if eolSource.URL == "" {
+		eolSource.URL = eol.DEFAULT_SOURCE_URL
 	}
-	if config.Route53Config.Timeout == nil {
-		config.Route53Config.Timeout = durationPtr(10 * time.Second)
+	if eolSource.Interval == nil {
+		eolSource.Interval = durationPtr(24 * time.Hour)
 	}
-	if config.EC2Config.Timeout == nil {
-		config.EC2Config.Timeout = durationPtr(10 * time.Second)
+	if eolSource.Timeout == nil {
+		eolSource.Timeout = durationPtr(30 * time.Second)
 	}
-	if config.ElastiCacheConfig.Timeout == nil {
-		config.ElastiCacheConfig.Timeout = durationPtr(10 * time.Second)
+	if eolSource.Products == nil {
+		eolSource.Products = eol.DefaultSourceProducts
 	}
-	if config.MskConfig.Timeout == nil {
-		config.MskConfig.Timeout = durationPtr(10 * time.Second)
+
+	if config.AWS.Partition == "" {
+		config.AWS.Partition = endpoints.AwsPartitionID
 	}
 
-	// Setting defaults when threshold is not defined to ease the transition from hardcoded thresholds
-	if len(config.RdsConfig.Thresholds) == 0 {
-		config.RdsConfig.Thresholds = []Threshold{
-			{Name: "red", Days: 90},
-			{Name: "yellow", Days: 180},
-			{Name: "green", Days: 365},
-		}
+	if config.Push.Interval == nil {
+		config.Push.Interval = durationPtr(time.Minute)
+	}
+	if config.Push.Timeout == nil {
+		config.Push.Timeout = durationPtr(10 * time.Second)
 	}
 
+	warnings, err := config.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid configuration file %s: %w", configFile, err)
+	}
+	for _, warning := range warnings {
+		level.Warn(logger).Log("msg", "Deprecated configuration, this will be an error in a future release", "file", configFile, "warning", warning)
+	}
+	config.FillEmptyRegions(config.DefaultRegions)
+	config.fillRoleSessionNames()
 	return &config, nil
 }
+
+// SplitByRegionOverrides splits the configuration of an exporter with regions into one configuration per distinct
+// interval and timeout of its regions, so an exporter can be created for each. The region overrides are applied to
+// the interval and timeout of the configurations.
+func SplitByRegionOverrides[C any](config C) []C {
+	v := reflect.ValueOf(&config).Elem()
+	base := v.FieldByName("BaseConfig").Interface().(BaseConfig)
+	if len(base.RegionOverrides) == 0 {
+		return []C{config}
+	}
+
+	type schedule struct{ interval, timeout time.Duration }
+	var schedules []schedule
+	regions := map[schedule][]string{}
+	for _, region := range v.FieldByName("Regions").Interface().([]string) {
+		s := schedule{durationValue(base.Interval), durationValue(base.Timeout)}
+		if override, ok := base.RegionOverrides[region]; ok {
+			if override.Interval != nil {
+				s.interval = *override.Interval
+			}
+			if override.Timeout != nil {
+				s.timeout = *override.Timeout
+			}
+		}
+		if _, ok := regions[s]; !ok {
+			schedules = append(schedules, s)
+		}
+		regions[s] = append(regions[s], region)
+	}
+
+	var configs []C
+	for _, s := range schedules {
+		split := config
+		splitValue := reflect.ValueOf(&split).Elem()
+		splitBase := base
+		splitBase.Interval = durationPtr(s.interval)
+		splitBase.Timeout = durationPtr(s.timeout)
+		splitBase.RegionOverrides = nil
+		splitValue.FieldByName("BaseConfig").Set(reflect.ValueOf(splitBase))
+		splitValue.FieldByName("Regions").Set(reflect.ValueOf(regions[s]))
+		configs = append(configs, split)
+	}
+	return configs
+}
+
+// FillEmptyRegions sets the regions of the exporters which have a list of regions, but no regions configured
+func (c *Config) FillEmptyRegions(regions []string) {
+	if len(regions) == 0 {
+		return
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Struct || !field.FieldByName("BaseConfig").IsValid() {
+			continue
+		}
+		if field := field.FieldByName("Regions"); field.IsValid() && field.Len() == 0 {
+			field.Set(reflect.ValueOf(append([]string{}, regions...)))
+		}
+	}
+}
+
+// fillRoleSessionNames gives every collector a role session named after it, so CloudTrail shows which collector made an
+// API call. Collectors without a role of their own get a copy of the role of the aws block.
+func (c *Config) fillRoleSessionNames() {
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Struct || !field.FieldByName("BaseConfig").IsValid() {
+			continue
+		}
+		base := field.FieldByName("BaseConfig").Addr().Interface().(*BaseConfig)
+		sessionName := roleSessionNamePrefix + "-" + strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if base.AssumeRole != nil {
+			base.AssumeRole = base.AssumeRole.withSessionName(sessionName)
+		} else if c.AWS.AssumeRole != nil {
+			// a session name configured for the role of the aws block is kept
+			base.AssumeRole = c.AWS.AssumeRole.withSessionName(sessionName)
+		}
+	}
+	if c.AWS.AssumeRole != nil {
+		c.AWS.AssumeRole = c.AWS.AssumeRole.withSessionName(roleSessionNamePrefix)
+	}
+}
+
+// envPattern matches ${VAR} and ${VAR:-default}. Other uses of $ are kept, e.g. in bcrypt hashes.
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces the environment variables in the configuration file, so it can be shared between environments.
+// Variables without a default have to be set.
+func expandEnv(file []byte) ([]byte, error) {
+	var missing []string
+	expanded := envPattern.ReplaceAllFunc(file, func(match []byte) []byte {
+		groups := envPattern.FindSubmatch(match)
+		if value, ok := os.LookupEnv(string(groups[1])); ok {
+			return []byte(value)
+		}
+		if groups[2] != nil {
+			return groups[3]
+		}
+		missing = append(missing, string(groups[1]))
+		return match
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("undefined environment variables: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// regionPattern matches region names like us-east-1, us-gov-west-1 or cn-north-1. New regions don't need to be known
+// by the SDK.
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// filterCollectors are the collectors supporting resource filters
+var filterCollectors = map[string]bool{"rds": true, "vpc": true}
+
+// validate checks the configuration with the defaults applied and reports all problems at once. The warnings are
+// accepted for now, as previous versions accepted them as well.
+func (c *Config) validate() ([]string, error) {
+	var errs []error
+	var warnings []string
+	validateRegions := func(name string, regions []string) {
+		for _, region := range regions {
+			if !regionPattern.MatchString(region) {
+				errs = append(errs, fmt.Errorf("%s: invalid region %q", name, region))
+			} else if !c.AWS.inPartition(region) {
+				errs = append(errs, fmt.Errorf("%s: region %q is not in partition %s", name, region, c.AWS.Partition))
+			}
+		}
+	}
+
+	// the key is the prefix of the timeout and interval keys in the configuration file
+	validateSchedule := func(name, key string, enabled bool, mode string, interval, timeout time.Duration) {
+		switch {
+		case timeout <= 0 || interval <= 0:
+			errs = append(errs, fmt.Errorf("%s: timeout and interval have to be positive", name))
+		case enabled && mode == COLLECT_MODE_LOOP && timeout >= interval:
+			warnings = append(warnings, fmt.Sprintf("%s: timeout %s has to be shorter than the interval %s, lower %s.timeout or raise %s.interval", name, timeout, interval, key, key))
+		}
+	}
+
+	for _, collector := range c.Collectors() {
+		validateRegions(collector.Name, collector.Regions)
+		if collector.Mode != COLLECT_MODE_LOOP && collector.Mode != COLLECT_MODE_SCRAPE {
+			errs = append(errs, fmt.Errorf("%s: invalid mode %q, expected %s or %s", collector.Name, collector.Mode, COLLECT_MODE_LOOP, COLLECT_MODE_SCRAPE))
+			continue
+		}
+		validateSchedule(collector.Name, collector.Name, collector.Enabled, collector.Mode, collector.Interval, collector.Timeout)
+
+		for region, override := range collector.RegionOverrides {
+			validateRegions(collector.Name+" region_overrides", []string{region})
+			interval, timeout := collector.Interval, collector.Timeout
+			if override.Interval != nil {
+				interval = *override.Interval
+			}
+			if override.Timeout != nil {
+				timeout = *override.Timeout
+			}
+			validateSchedule(collector.Name+" "+region, collector.Name+".region_overrides."+region, collector.Enabled, collector.Mode, interval, timeout)
+		}
+
+		if !collector.Filter.isEmpty() && !filterCollectors[collector.Name] {
+			errs = append(errs, fmt.Errorf("%s: filter is not supported", collector.Name))
+		} else if err := collector.Filter.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", collector.Name, err))
+		}
+		if err := collector.AssumeRole.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", collector.Name, err))
+		}
+	}
+	validateRegions("default_regions", c.DefaultRegions)
+	tagLabelNames := map[string]string{}
+	for _, key := range c.RdsConfig.TagLabels {
+		if other, ok := tagLabelNames[tagLabelName(key)]; ok {
+			errs = append(errs, fmt.Errorf("rds: tag_labels %q and %q have the same label %s", other, key, tagLabelName(key)))
+		}
+		tagLabelNames[tagLabelName(key)] = key
+	}
+	for _, pattern := range []string{c.Route53Config.ZoneFilter.Include, c.Route53Config.ZoneFilter.Exclude} {
+		if _, err := regexp.Compile(anchoredPattern(pattern)); err != nil {
+			errs = append(errs, fmt.Errorf("route53: zone_filter: %w", err))
+		}
+	}
+	if durationValue(c.RdsConfig.LogsMetricsTTL) < 0 || c.RdsConfig.LogsMetricsWorkers < 1 {
+		errs = append(errs, errors.New("rds: logs_metrics_ttl must not be negative and logs_metrics_workers has to be positive"))
+	}
+	if c.RdsConfig.RegionConcurrency < 0 {
+		errs = append(errs, errors.New("rds: region_concurrency must not be negative"))
+	}
+	if c.MskConfig.RegionConcurrency < 0 {
+		errs = append(errs, errors.New("msk: region_concurrency must not be negative"))
+	}
+	if scores := c.EC2Config.SpotPlacementScores; scores != nil && (len(scores.InstanceTypes) == 0 || scores.TargetCapacity < 1) {
+		errs = append(errs, errors.New("ec2: spot_placement_scores requires instance_types and a positive target_capacity"))
+	}
+	if c.Route53Config.MaxConcurrency < 0 {
+		errs = append(errs, errors.New("route53: max_concurrency must not be negative"))
+	}
+	if c.VpcConfig.MaxConcurrency < 0 {
+		errs = append(errs, errors.New("vpc: max_concurrency must not be negative"))
+	}
+	if g := c.CostConfig.Granularity; g != costexplorer.GranularityDaily && g != costexplorer.GranularityMonthly {
+		errs = append(errs, fmt.Errorf("cost: invalid granularity %q, expected %s or %s", g, costexplorer.GranularityDaily, costexplorer.GranularityMonthly))
+	}
+	if durationValue(c.S3Config.StorageMetricsTTL) < 0 {
+		errs = append(errs, errors.New("s3: storage_metrics_ttl must not be negative"))
+	}
+	if len(c.S3Config.RegionOverrides) > 0 {
+		errs = append(errs, errors.New("s3: region_overrides are not supported, as the buckets are counted in the first region"))
+	}
+	for _, quota := range c.ServiceQuotasConfig.Quotas {
+		validateRegions("servicequotas "+quota.ServiceCode+"/"+quota.QuotaCode, quota.Regions)
+	}
+
+	for service, limit := range c.RateLimits {
+		if _, err := awsclient.ParseRateLimit(limit); err != nil {
+			errs = append(errs, fmt.Errorf("rate_limits: %s: %w", service, err))
+		}
+	}
+	if c.RetryConfig.MaxAttempts < 0 || c.RetryConfig.MaxBackoff < 0 {
+		errs = append(errs, errors.New("retry: max_attempts and max_backoff must not be negative"))
+	}
+	errs = append(errs, c.AWS.validate()...)
+	if err := c.Tracing.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Push.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// CollectorConfig summarizes the configuration of a collector for the landing page
+type CollectorConfig struct {
+	Name     string
+	Enabled  bool
+	Mode     string
+	Regions  []string
+	Interval time.Duration
+	Timeout  time.Duration
+	// RegionOverrides are only applied by the exporters with a list of regions
+	RegionOverrides map[string]RegionOverride
+	Filter          ResourceFilter
+	AssumeRole      *AssumeRoleConfig
+}
+
+// Collectors returns the configuration of every collector, named like the collector label of the last update metric
+func (c *Config) Collectors() []CollectorConfig {
+	var collectors []CollectorConfig
+	v := reflect.ValueOf(*c)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Struct || !field.FieldByName("BaseConfig").IsValid() {
+			continue
+		}
+		base := field.FieldByName("BaseConfig").Interface().(BaseConfig)
+
+		collector := CollectorConfig{
+			Name:     strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0],
+			Enabled:  base.Enabled,
+			Mode:     base.Mode,
+			Interval: durationValue(base.Interval),
+			Timeout:  durationValue(base.Timeout),
+
+			RegionOverrides: base.RegionOverrides,
+			Filter:          base.Filter,
+			AssumeRole:      base.AssumeRole,
+		}
+		if regions := field.FieldByName("Regions"); regions.IsValid() {
+			collector.Regions = regions.Interface().([]string)
+		} else if region := field.FieldByName("Region"); region.IsValid() && region.String() != "" {
+			collector.Regions = []string{region.String()}
+		}
+		collectors = append(collectors, collector)
+	}
+
+	return append(collectors, CollectorConfig{
+		Name:     "eol_source",
+		Enabled:  c.EOLConfig.Source.Enabled,
+		Mode:     COLLECT_MODE_LOOP,
+		Interval: durationValue(c.EOLConfig.Source.Interval),
+		Timeout:  durationValue(c.EOLConfig.Source.Timeout),
+	})
+}
+
+// Sanitized renders the effective configuration as YAML with credentials removed. yaml.v3 is used, as it renders
+// durations human readable.
+func (c *Config) Sanitized() ([]byte, error) {
+	config := *c
+	if u, err := url.Parse(config.EOLConfig.Source.URL); err == nil && u.User != nil {
+		u.User = url.User("redacted")
+		config.EOLConfig.Source.URL = u.String()
+	}
+	// the headers usually authenticate at the receiver
+	if len(config.Tracing.Headers) > 0 {
+		headers := map[string]string{}
+		for name := range config.Tracing.Headers {
+			headers[name] = "redacted"
+		}
+		config.Tracing.Headers = headers
+	}
+	if config.Push.BasicAuth != nil {
+		config.Push.BasicAuth = &PushBasicAuth{Username: config.Push.BasicAuth.Username, Password: "redacted"}
+	}
+	if config.Push.BearerToken != "" {
+		config.Push.BearerToken = "redacted"
+	}
+	return yamlv3.Marshal(config)
+}
+
+func durationValue(d *time.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return *d
+}
@@ -0,0 +1,141 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const configServiceCode string = "config"
+
+// ConfigServiceExporter exports whether AWS Config records the configuration changes and the non-compliant resources
+// of the Config rules, e.g. for drift dashboards
+type ConfigServiceExporter struct {
+	sessions                  []*session.Session
+	RecorderEnabled           *prometheus.Desc
+	RuleNonCompliantResources *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewConfigServiceExporter creates a new ConfigServiceExporter instance
+func NewConfigServiceExporter(sessions []*session.Session, logger log.Logger, config ConfigServiceConfig, awsAccountId string) *ConfigServiceExporter {
+	level.Info(logger).Log("msg", "Initializing Config exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: configServiceCode}
+
+	return &ConfigServiceExporter{
+		sessions:                  sessions,
+		RecorderEnabled:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "config_recorder_enabled"), "Whether a configuration recorder is recording in this region", []string{"aws_region"}, constLabels),
+		RuleNonCompliantResources: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "config_rule_noncompliant_resources"), "Number of resources which are non-compliant with the Config rule", []string{"aws_region", "config_rule"}, constLabels),
+		cache:                     *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                    logger,
+		timeout:                   *config.Timeout,
+		interval:                  *config.Interval,
+	}
+}
+
+func (e *ConfigServiceExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.RecorderEnabled
+	ch <- e.RuleNonCompliantResources
+}
+
+func (e *ConfigServiceExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ConfigServiceExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Config metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "configservice")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ConfigServiceExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "configservice", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *ConfigServiceExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	status, err := client.DescribeConfigurationRecorderStatusWithContext(ctx, &configservice.DescribeConfigurationRecorderStatusInput{})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeConfigurationRecorderStatus failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		// there is at most one configuration recorder per region
+		recording := 0.0
+		for _, recorder := range status.ConfigurationRecordersStatus {
+			if aws.BoolValue(recorder.Recording) {
+				recording = 1
+			}
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecorderEnabled, prometheus.GaugeValue, recording, region))
+	}
+
+	compliances, err := client.DescribeComplianceByConfigRuleAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeComplianceByConfigRuleAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	for _, compliance := range compliances {
+		ruleName := aws.StringValue(compliance.ConfigRuleName)
+		// rules without evaluations yet have no compliance
+		if compliance.Compliance == nil {
+			continue
+		}
+
+		nonCompliant := 0.0
+		if aws.StringValue(compliance.Compliance.ComplianceType) == configservice.ComplianceTypeNonCompliant && compliance.Compliance.ComplianceContributorCount != nil {
+			count := compliance.Compliance.ComplianceContributorCount
+			nonCompliant = float64(aws.Int64Value(count.CappedCount))
+			// the contributor count is capped at 100 resources, beyond that the evaluation results are counted
+			if aws.BoolValue(count.CapExceeded) {
+				results, err := client.GetComplianceDetailsByConfigRuleAll(ctx, ruleName, configservice.ComplianceTypeNonCompliant)
+				if err != nil {
+					level.Error(e.logger).Log("msg", "Call to GetComplianceDetailsByConfigRuleAll failed", "region", region, "config_rule", ruleName, "err", err)
+					run.fail()
+					continue
+				}
+				nonCompliant = float64(len(results))
+			}
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RuleNonCompliantResources, prometheus.GaugeValue, nonCompliant, region, ruleName))
+	}
+}
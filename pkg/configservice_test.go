@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigServiceCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compliance := func(complianceType string, count int64, capExceeded bool) *configservice.Compliance {
+		return &configservice.Compliance{
+			ComplianceType:             aws.String(complianceType),
+			ComplianceContributorCount: &configservice.ComplianceContributorCount{CappedCount: aws.Int64(count), CapExceeded: aws.Bool(capExceeded)},
+		}
+	}
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeConfigurationRecorderStatusWithContext(ctx, gomock.Any()).Return(&configservice.DescribeConfigurationRecorderStatusOutput{
+		ConfigurationRecordersStatus: []*configservice.ConfigurationRecorderStatus{{Name: aws.String("default"), Recording: aws.Bool(true)}},
+	}, nil)
+	mockClient.EXPECT().DescribeComplianceByConfigRuleAll(ctx).Return([]*configservice.ComplianceByConfigRule{
+		{ConfigRuleName: aws.String("s3-bucket-versioning-enabled"), Compliance: compliance(configservice.ComplianceTypeNonCompliant, 3, false)},
+		{ConfigRuleName: aws.String("encrypted-volumes"), Compliance: compliance(configservice.ComplianceTypeNonCompliant, 100, true)},
+		{ConfigRuleName: aws.String("rds-storage-encrypted"), Compliance: &configservice.Compliance{ComplianceType: aws.String(configservice.ComplianceTypeCompliant)}},
+		{ConfigRuleName: aws.String("new-rule")},
+	}, nil)
+	mockClient.EXPECT().GetComplianceDetailsByConfigRuleAll(ctx, "encrypted-volumes", configservice.ComplianceTypeNonCompliant).Return(make([]*configservice.EvaluationResult, 150), nil)
+
+	e := NewConfigServiceExporter(nil, log.NewNopLogger(), ConfigServiceConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("configservice", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// recorder enabled and the evaluated rules
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 4)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.RecorderEnabled.String():
+			assert.Equal(t, 1.0, value)
+		case e.RuleNonCompliantResources.String():
+			expected := map[string]float64{"s3-bucket-versioning-enabled": 3, "encrypted-volumes": 150, "rds-storage-encrypted": 0}
+			assert.Equal(t, expected[labels["config_rule"]], value)
+		}
+	}
+}
+
+func TestConfigServiceCollectMetricsNoRecorder(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeConfigurationRecorderStatusWithContext(ctx, gomock.Any()).Return(&configservice.DescribeConfigurationRecorderStatusOutput{}, nil)
+	mockClient.EXPECT().DescribeComplianceByConfigRuleAll(ctx).Return(nil, errors.New("AccessDeniedException"))
+
+	e := NewConfigServiceExporter(nil, log.NewNopLogger(), ConfigServiceConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("configservice", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, e.RecorderEnabled.String(), metrics[0].Desc().String())
+	assert.Equal(t, 0.0, dtoMetric.GetGauge().GetValue())
+}
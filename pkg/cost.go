@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	costServiceCode string = "ce"
+	costMetric      string = "UnblendedCost"
+	// costDateLayout is the date format of the time periods of Cost Explorer
+	costDateLayout = "2006-01-02"
+)
+
+// CostExporter exports the unblended cost per service and linked account from Cost Explorer. Every request to the Cost
+// Explorer API is billed, so a period is only queried once and its costs are served from the cache afterwards.
+type CostExporter struct {
+	client        awsclient.Client
+	region        string
+	granularity   string
+	UnblendedCost *prometheus.Desc
+	PeriodStart   *prometheus.Desc
+
+	// lastPeriod is the period which was queried last, it isn't queried again while its metrics are cached
+	lastPeriod string
+	cache      MetricsCache
+	logger     log.Logger
+	interval   time.Duration
+	timeout    time.Duration
+}
+
+// NewCostExporter creates a new CostExporter instance
+func NewCostExporter(sess *session.Session, logger log.Logger, config CostConfig, awsAccountId string) *CostExporter {
+	level.Info(logger).Log("msg", "Initializing Cost Explorer exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: costServiceCode}
+
+	return &CostExporter{
+		client:        awsclient.NewClientFromSession(sess),
+		region:        config.Region,
+		granularity:   config.Granularity,
+		UnblendedCost: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cost_unblended"), "The unblended cost of the service in the linked account during the last period of the configured granularity", []string{"service", "linked_account", "unit", "granularity"}, constLabels),
+		PeriodStart:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cost_period_start_timestamp_seconds"), "The start of the period of the exported costs", []string{"granularity"}, constLabels),
+		cache:         *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:        logger,
+		interval:      *config.Interval,
+		timeout:       *config.Timeout,
+	}
+}
+
+// costPeriod returns the period of the costs to export. For the DAILY granularity it's the previous day, for the
+// MONTHLY granularity the month to date, which is the previous month on the first day of a month. The end is exclusive.
+func costPeriod(now time.Time, granularity string) (time.Time, time.Time) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if granularity == costexplorer.GranularityMonthly {
+		// the month of the previous day, as the start and end of a period must not be the same day
+		last := end.AddDate(0, 0, -1)
+		return time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC), end
+	}
+	return end.AddDate(0, 0, -1), end
+}
+
+func (e *CostExporter) collectMetrics(ctx context.Context, now time.Time, run *collectorRun) {
+	start, end := costPeriod(now.UTC(), e.granularity)
+	period := start.Format(costDateLayout) + "/" + end.Format(costDateLayout)
+	if period == e.lastPeriod && e.cache.HasMetric(e.PeriodStart, e.granularity) {
+		level.Debug(e.logger).Log("msg", "Costs of the period are still cached", "period", period)
+		return
+	}
+
+	results, err := e.client.GetCostAndUsageAll(ctx, &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &costexplorer.DateInterval{
+			Start: aws.String(start.Format(costDateLayout)),
+			End:   aws.String(end.Format(costDateLayout)),
+		},
+		Granularity: aws.String(e.granularity),
+		Metrics:     []*string{aws.String(costMetric)},
+		GroupBy: []*costexplorer.GroupDefinition{
+			{Type: aws.String(costexplorer.GroupDefinitionTypeDimension), Key: aws.String(costexplorer.DimensionService)},
+			{Type: aws.String(costexplorer.GroupDefinitionTypeDimension), Key: aws.String(costexplorer.DimensionLinkedAccount)},
+		},
+	})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetCostAndUsageAll failed", "period", period, "err", err)
+		run.fail()
+		return
+	}
+
+	// the costs of a service and account of several pages are summed up
+	costs := map[[3]string]float64{}
+	for _, result := range results {
+		for _, group := range result.Groups {
+			if len(group.Keys) != 2 {
+				continue
+			}
+			value, ok := group.Metrics[costMetric]
+			if !ok {
+				continue
+			}
+			amount, err := strconv.ParseFloat(aws.StringValue(value.Amount), 64)
+			if err != nil {
+				level.Warn(e.logger).Log("msg", "Could not parse the cost", "service", aws.StringValue(group.Keys[0]), "amount", aws.StringValue(value.Amount), "err", err)
+				continue
+			}
+			costs[[3]string{aws.StringValue(group.Keys[0]), aws.StringValue(group.Keys[1]), aws.StringValue(value.Unit)}] += amount
+		}
+	}
+
+	// the services without costs in the new period are dropped
+	e.cache.InvalidateByDesc(e.UnblendedCost)
+	for key, amount := range costs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.UnblendedCost, prometheus.GaugeValue, amount, key[0], key[1], key[2], e.granularity))
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.PeriodStart, prometheus.GaugeValue, float64(start.Unix()), e.granularity))
+	e.lastPeriod = period
+}
+
+func (e *CostExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "cost", e.region)
+
+		e.collectMetrics(collectCtx, time.Now(), run)
+
+		level.Info(e.logger).Log("msg", "Cost Explorer metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "cost")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *CostExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *CostExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.UnblendedCost
+	ch <- e.PeriodStart
+}
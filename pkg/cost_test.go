@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCostGroup(service string, account string, amount string) *costexplorer.Group {
+	return &costexplorer.Group{
+		Keys:    []*string{aws.String(service), aws.String(account)},
+		Metrics: map[string]*costexplorer.MetricValue{costMetric: {Amount: aws.String(amount), Unit: aws.String("USD")}},
+	}
+}
+
+func createTestCostExporter(mockClient *mock.MockClient, granularity string) *CostExporter {
+	e := NewCostExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), CostConfig{BaseConfig: createTestBaseConfig(), Granularity: granularity}, "1234567890")
+	e.client = mockClient
+	return e
+}
+
+func TestCostPeriod(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC)
+	start, end := costPeriod(now, costexplorer.GranularityDaily)
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// the whole previous month on the first day of a month
+	start, end = costPeriod(now, costexplorer.GranularityMonthly)
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = costPeriod(now.AddDate(0, 0, 14), costexplorer.GranularityMonthly)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestCostCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 15, 13, 0, 0, 0, time.UTC)
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetCostAndUsageAll(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, input *costexplorer.GetCostAndUsageInput) ([]*costexplorer.ResultByTime, error) {
+		assert.Equal(t, "2024-03-14", aws.StringValue(input.TimePeriod.Start))
+		assert.Equal(t, "2024-03-15", aws.StringValue(input.TimePeriod.End))
+		assert.Equal(t, costexplorer.GranularityDaily, aws.StringValue(input.Granularity))
+		return []*costexplorer.ResultByTime{{Groups: []*costexplorer.Group{
+			createTestCostGroup("Amazon Elastic Compute Cloud - Compute", "1234567890", "12.5"),
+			createTestCostGroup("Amazon Simple Storage Service", "1234567890", "0.25"),
+			createTestCostGroup("Amazon Simple Storage Service", "0987654321", "1"),
+		}}}, nil
+	}).Times(1)
+
+	e := createTestCostExporter(mockClient, costexplorer.GranularityDaily)
+
+	run := startCollectorRun("cost", "us-east-1")
+	e.collectMetrics(ctx, now, run)
+	assert.True(t, run.finish())
+	// the period was already queried, so Cost Explorer isn't called again
+	run = startCollectorRun("cost", "us-east-1")
+	e.collectMetrics(ctx, now.Add(time.Hour), run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 4)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		assert.Equal(t, costexplorer.GranularityDaily, labels["granularity"])
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.PeriodStart.String():
+			assert.Equal(t, float64(time.Date(2024, time.March, 14, 0, 0, 0, 0, time.UTC).Unix()), value)
+		case e.UnblendedCost.String():
+			assert.Equal(t, "USD", labels["unit"])
+			switch labels["service"] + "/" + labels["linked_account"] {
+			case "Amazon Elastic Compute Cloud - Compute/1234567890":
+				assert.Equal(t, 12.5, value)
+			case "Amazon Simple Storage Service/1234567890":
+				assert.Equal(t, 0.25, value)
+			case "Amazon Simple Storage Service/0987654321":
+				assert.Equal(t, 1.0, value)
+			default:
+				t.Errorf("unexpected cost %v", labels)
+			}
+		}
+	}
+}
+
+func TestCostCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetCostAndUsageAll(ctx, gomock.Any()).Return(nil, errors.New("AccessDeniedException")).Times(2)
+
+	e := createTestCostExporter(mockClient, costexplorer.GranularityMonthly)
+	now := time.Now()
+	run := startCollectorRun("cost", "us-east-1")
+	e.collectMetrics(ctx, now, run)
+	assert.False(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 0)
+
+	// a failed period is queried again
+	run = startCollectorRun("cost", "us-east-1")
+	e.collectMetrics(ctx, now, run)
+	assert.False(t, run.finish())
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CredentialsExporter validates the credentials of the exporter with STS GetCallerIdentity, which also refreshes them
+// once they expired. Expiring web identity or assumed role credentials show up in its metrics before the other
+// collectors start failing.
+type CredentialsExporter struct {
+	client                   awsclient.Client
+	credentials              *credentials.Credentials
+	region                   string
+	CredentialsExpiry        *prometheus.Desc
+	GetCallerIdentitySuccess *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewCredentialsExporter creates a new CredentialsExporter instance checking the credentials of the session
+func NewCredentialsExporter(sess *session.Session, logger log.Logger, config CredentialsConfig, awsAccountId string) *CredentialsExporter {
+	level.Info(logger).Log("msg", "Initializing credentials exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId}
+
+	return &CredentialsExporter{
+		client:                   awsclient.NewClientFromSession(sess),
+		credentials:              sess.Config.Credentials,
+		region:                   config.Region,
+		CredentialsExpiry:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "aws_credentials_expiry_timestamp_seconds"), "The time the AWS credentials of the exporter expire, only for credentials which expire like web identity or assumed role credentials", []string{}, constLabels),
+		GetCallerIdentitySuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sts_getcalleridentity_success"), "Whether the last STS GetCallerIdentity call with the AWS credentials of the exporter succeeded", []string{}, constLabels),
+		cache:                    *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                   logger,
+		interval:                 *config.Interval,
+		timeout:                  *config.Timeout,
+	}
+}
+
+func (e *CredentialsExporter) collectMetrics(ctx context.Context, run *collectorRun) {
+	success := 1.0
+	identity, err := e.client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetCallerIdentity failed, the AWS credentials are invalid or expired", "err", err)
+		run.fail()
+		success = 0
+	} else {
+		level.Debug(e.logger).Log("msg", "AWS credentials are valid", "arn", aws.StringValue(identity.Arn))
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.GetCallerIdentitySuccess, prometheus.GaugeValue, success))
+
+	// credentials of the environment or instance profiles don't report when they expire
+	expiry, err := e.credentials.ExpiresAt()
+	if err != nil || expiry.IsZero() {
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.CredentialsExpiry, prometheus.GaugeValue, float64(expiry.Unix())))
+}
+
+func (e *CredentialsExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "credentials", e.region)
+
+		e.collectMetrics(collectCtx, run)
+
+		level.Info(e.logger).Log("msg", "Credentials metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "credentials")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *CredentialsExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *CredentialsExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.CredentialsExpiry
+	ch <- e.GetCallerIdentitySuccess
+}
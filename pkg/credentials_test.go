@@ -0,0 +1,90 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// expiringProvider provides credentials expiring at a fixed time, like assumed role credentials
+type expiringProvider struct {
+	credentials.Expiry
+}
+
+func (p *expiringProvider) Retrieve() (credentials.Value, error) {
+	return credentials.Value{AccessKeyID: "AKID", SecretAccessKey: "SECRET", ProviderName: "expiringProvider"}, nil
+}
+
+func createTestCredentialsExporter(mockClient *mock.MockClient, creds *credentials.Credentials) *CredentialsExporter {
+	sess := session.New(&aws.Config{Region: aws.String("us-east-1"), Credentials: creds})
+	e := NewCredentialsExporter(sess, log.NewNopLogger(), CredentialsConfig{BaseConfig: createTestBaseConfig(), Region: "us-east-1"}, "1234567890")
+	e.client = mockClient
+	return e
+}
+
+func getCredentialsMetrics(t *testing.T, e *CredentialsExporter) map[string]float64 {
+	values := map[string]float64{}
+	for _, metric := range e.cache.GetAllMetrics() {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		values[metric.Desc().String()] = dtoMetric.GetGauge().GetValue()
+	}
+	return values
+}
+
+func TestCredentialsCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	provider := &expiringProvider{}
+	provider.SetExpiration(expiry, 0)
+	creds := credentials.NewCredentials(provider)
+	_, err := creds.Get()
+	assert.Nil(t, err)
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{}).Return(
+		&sts.GetCallerIdentityOutput{Account: aws.String("1234567890"), Arn: aws.String("arn:aws:sts::1234567890:assumed-role/exporter/session")}, nil)
+
+	e := createTestCredentialsExporter(mockClient, creds)
+	run := startCollectorRun("credentials", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.True(t, run.finish())
+
+	values := getCredentialsMetrics(t, e)
+	assert.Len(t, values, 2)
+	assert.Equal(t, 1.0, values[e.GetCallerIdentitySuccess.String()])
+	assert.Equal(t, float64(expiry.Unix()), values[e.CredentialsExpiry.String()])
+}
+
+func TestCredentialsCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetCallerIdentityWithContext(ctx, gomock.Any()).Return(nil, errors.New("ExpiredToken"))
+
+	// static credentials don't expire, so only the failed call is exported
+	e := createTestCredentialsExporter(mockClient, credentials.NewStaticCredentials("AKID", "SECRET", ""))
+	run := startCollectorRun("credentials", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.False(t, run.finish())
+
+	values := getCredentialsMetrics(t, e)
+	assert.Len(t, values, 1)
+	assert.Equal(t, 0.0, values[e.GetCallerIdentitySuccess.String()])
+}
@@ -0,0 +1,155 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	dynamodbServiceCode      string = "dynamodb"
+	tablesPerRegionQuotaCode string = "L-F98FE922"
+)
+
+type DynamoDBExporter struct {
+	sessions             []*session.Session
+	TablesPerRegionQuota *prometheus.Desc
+	TablesPerRegionUsage *prometheus.Desc
+	TableProvisionedRCU  *prometheus.Desc
+	TableProvisionedWCU  *prometheus.Desc
+	TableStatus          *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewDynamoDBExporter creates a new DynamoDBExporter instance
+func NewDynamoDBExporter(sessions []*session.Session, logger log.Logger, config DynamoDBConfig, awsAccountId string) *DynamoDBExporter {
+	level.Info(logger).Log("msg", "Initializing DynamoDB exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: dynamodbServiceCode}
+
+	return &DynamoDBExporter{
+		sessions:             sessions,
+		TablesPerRegionQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "dynamodb_tablesperregion_quota"), "Quota for maximum number of DynamoDB tables in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, tablesPerRegionQuotaCode)),
+		TablesPerRegionUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "dynamodb_tablesperregion_usage"), "Number of DynamoDB tables in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, tablesPerRegionQuotaCode)),
+		TableProvisionedRCU:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "dynamodb_table_provisioned_rcu"), "Provisioned read capacity units of the table. 0 for on-demand tables", []string{"aws_region", "table_name"}, constLabels),
+		TableProvisionedWCU:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "dynamodb_table_provisioned_wcu"), "Provisioned write capacity units of the table. 0 for on-demand tables", []string{"aws_region", "table_name"}, constLabels),
+		TableStatus:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "dynamodb_table_status"), "The table status", []string{"aws_region", "table_name", "table_status"}, constLabels),
+		cache:                *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:               logger,
+		timeout:              *config.Timeout,
+		interval:             *config.Interval,
+	}
+}
+
+func (e *DynamoDBExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.TablesPerRegionQuota
+	ch <- e.TablesPerRegionUsage
+	ch <- e.TableProvisionedRCU
+	ch <- e.TableProvisionedWCU
+	ch <- e.TableStatus
+}
+
+func (e *DynamoDBExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *DynamoDBExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "DynamoDB metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "dynamodb")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *DynamoDBExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "dynamodb", region)
+	defer run.finish()
+
+	quota, err := getQuotaValueWithContext(client, dynamodbServiceCode, tablesPerRegionQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve DynamoDB tables quota", "region", region, "error", err.Error())
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TablesPerRegionQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	tableNames, err := client.ListTablesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListTablesAll failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TablesPerRegionUsage, prometheus.GaugeValue, float64(len(tableNames)), region))
+
+	for _, tableName := range tableNames {
+		table, err := describeTableWithContext(client, ctx, tableName)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeTable failed", "region", region, "table", aws.StringValue(tableName), "error", err.Error())
+			run.fail()
+			continue
+		}
+		e.addTableMetrics(region, table)
+	}
+}
+
+func (e *DynamoDBExporter) addTableMetrics(region string, table *dynamodb.TableDescription) {
+	tableName := aws.StringValue(table.TableName)
+
+	var rcu, wcu float64
+	if table.ProvisionedThroughput != nil {
+		rcu = float64(aws.Int64Value(table.ProvisionedThroughput.ReadCapacityUnits))
+		wcu = float64(aws.Int64Value(table.ProvisionedThroughput.WriteCapacityUnits))
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TableProvisionedRCU, prometheus.GaugeValue, rcu, region, tableName))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TableProvisionedWCU, prometheus.GaugeValue, wcu, region, tableName))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TableStatus, prometheus.GaugeValue, 1, region, tableName, aws.StringValue(table.TableStatus)))
+}
+
+func createDescribeTableInput(tableName *string) *dynamodb.DescribeTableInput {
+	return &dynamodb.DescribeTableInput{
+		TableName: tableName,
+	}
+}
+
+func describeTableWithContext(client awsclient.Client, ctx context.Context, tableName *string) (*dynamodb.TableDescription, error) {
+	output, err := client.DescribeTableWithContext(ctx, createDescribeTableInput(tableName))
+	if err != nil {
+		return nil, err
+	}
+	return output.Table, nil
+}
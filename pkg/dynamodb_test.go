@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeTableWithContext(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+
+	mockClient.EXPECT().DescribeTableWithContext(ctx, createDescribeTableInput(aws.String("test-table"))).
+		Return(&dynamodb.DescribeTableOutput{
+			Table: &dynamodb.TableDescription{TableName: aws.String("test-table")},
+		}, nil)
+
+	table, err := describeTableWithContext(mockClient, ctx, aws.String("test-table"))
+	assert.Nil(t, err)
+	assert.Equal(t, "test-table", *table.TableName)
+}
+
+func createTestBaseConfig() BaseConfig {
+	return BaseConfig{
+		CacheTTL: durationPtr(10 * time.Second),
+		Timeout:  durationPtr(10 * time.Second),
+		Interval: durationPtr(10 * time.Second),
+	}
+}
+
+func TestAddTableMetrics(t *testing.T) {
+	e := NewDynamoDBExporter(nil, log.NewNopLogger(), DynamoDBConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+
+	// On-demand tables have no provisioned throughput
+	e.addTableMetrics("foo", &dynamodb.TableDescription{
+		TableName:   aws.String("on-demand"),
+		TableStatus: aws.String(dynamodb.TableStatusActive),
+	})
+	assert.Len(t, e.cache.GetAllMetrics(), 3)
+
+	e.addTableMetrics("foo", &dynamodb.TableDescription{
+		TableName:   aws.String("provisioned"),
+		TableStatus: aws.String(dynamodb.TableStatusActive),
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+	})
+	assert.Len(t, e.cache.GetAllMetrics(), 6)
+}
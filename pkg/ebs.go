@@ -0,0 +1,187 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Storage quotas are given in TiB, but volume sizes in GiB
+const ebsGiBPerTiB = 1024
+
+const (
+	ebsServiceCode              string = "ebs"
+	snapshotsPerRegionQuotaCode string = "L-309BACF6"
+	ebsStandardStorageQuotaCode string = "L-9CF3C2EB"
+	ebsGp2StorageQuotaCode      string = "L-D18FCD1D"
+	ebsGp3StorageQuotaCode      string = "L-7A658B76"
+	ebsIo1StorageQuotaCode      string = "L-FD252861"
+	ebsIo2StorageQuotaCode      string = "L-09BD8365"
+	ebsSt1StorageQuotaCode      string = "L-82ACEF56"
+	ebsSc1StorageQuotaCode      string = "L-17AF77E8"
+)
+
+// ebsStorageQuotaCodes maps the volume types to the quota of the total storage (in TiB) per region
+var ebsStorageQuotaCodes = map[string]string{
+	ec2.VolumeTypeStandard: ebsStandardStorageQuotaCode,
+	ec2.VolumeTypeGp2:      ebsGp2StorageQuotaCode,
+	ec2.VolumeTypeGp3:      ebsGp3StorageQuotaCode,
+	ec2.VolumeTypeIo1:      ebsIo1StorageQuotaCode,
+	ec2.VolumeTypeIo2:      ebsIo2StorageQuotaCode,
+	ec2.VolumeTypeSt1:      ebsSt1StorageQuotaCode,
+	ec2.VolumeTypeSc1:      ebsSc1StorageQuotaCode,
+}
+
+type EBSExporter struct {
+	sessions                []*session.Session
+	VolumesPerRegionUsage   *prometheus.Desc
+	UnencryptedVolumesUsage *prometheus.Desc
+	StorageQuota            *prometheus.Desc
+	StorageUsage            *prometheus.Desc
+	SnapshotsPerRegionQuota *prometheus.Desc
+	SnapshotsPerRegionUsage *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewEBSExporter creates a new EBSExporter instance
+func NewEBSExporter(sessions []*session.Session, logger log.Logger, config EBSConfig, awsAccountId string) *EBSExporter {
+	level.Info(logger).Log("msg", "Initializing EBS exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: ebsServiceCode}
+
+	return &EBSExporter{
+		sessions:                sessions,
+		VolumesPerRegionUsage:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_volumesperregion_usage"), "Number of EBS volumes in this region", []string{"aws_region"}, constLabels),
+		UnencryptedVolumesUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_unencryptedvolumes_usage"), "Number of unencrypted EBS volumes in this region", []string{"aws_region"}, constLabels),
+		StorageQuota:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_storage_quota_gib"), "Quota for the total provisioned storage of a volume type in this region (in GiB)", []string{"aws_region", "volume_type", QUOTA_CODE_KEY}, constLabels),
+		StorageUsage:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_storage_usage_gib"), "Total provisioned storage of a volume type in this region (in GiB)", []string{"aws_region", "volume_type", QUOTA_CODE_KEY}, constLabels),
+		SnapshotsPerRegionQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_snapshotsperregion_quota"), "Quota for maximum number of EBS snapshots in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, snapshotsPerRegionQuotaCode)),
+		SnapshotsPerRegionUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ebs_snapshotsperregion_usage"), "Number of EBS snapshots owned by the account in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, snapshotsPerRegionQuotaCode)),
+		cache:                   *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                  logger,
+		timeout:                 *config.Timeout,
+		interval:                *config.Interval,
+	}
+}
+
+func (e *EBSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.VolumesPerRegionUsage
+	ch <- e.UnencryptedVolumesUsage
+	ch <- e.StorageQuota
+	ch <- e.StorageUsage
+	ch <- e.SnapshotsPerRegionQuota
+	ch <- e.SnapshotsPerRegionUsage
+}
+
+func (e *EBSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *EBSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "EBS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "ebs")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *EBSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "ebs", region)
+	defer run.finish()
+
+	e.collectVolumeMetrics(client, ctx, region, run)
+	e.collectSnapshotMetrics(client, ctx, region, run)
+}
+
+func (e *EBSExporter) collectVolumeMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	for volumeType, quotaCode := range ebsStorageQuotaCodes {
+		quota, err := getQuotaValueWithContext(client, ebsServiceCode, quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve EBS storage quota", "region", region, "volume_type", volumeType, "err", err)
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.StorageQuota, prometheus.GaugeValue, quota*ebsGiBPerTiB, region, volumeType, quotaCode))
+	}
+
+	volumes, err := client.DescribeVolumesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeVolumesAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.addVolumeMetrics(region, volumes)
+}
+
+func (e *EBSExporter) addVolumeMetrics(region string, volumes []*ec2.Volume) {
+	storagePerType := make(map[string]int64)
+	for volumeType := range ebsStorageQuotaCodes {
+		storagePerType[volumeType] = 0
+	}
+
+	unencrypted := 0
+	for _, volume := range volumes {
+		storagePerType[aws.StringValue(volume.VolumeType)] += aws.Int64Value(volume.Size)
+		if !aws.BoolValue(volume.Encrypted) {
+			unencrypted++
+		}
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.VolumesPerRegionUsage, prometheus.GaugeValue, float64(len(volumes)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.UnencryptedVolumesUsage, prometheus.GaugeValue, float64(unencrypted), region))
+	for volumeType, size := range storagePerType {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.StorageUsage, prometheus.GaugeValue, float64(size), region, volumeType, ebsStorageQuotaCodes[volumeType]))
+	}
+}
+
+func (e *EBSExporter) collectSnapshotMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, ebsServiceCode, snapshotsPerRegionQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve EBS snapshots quota", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SnapshotsPerRegionQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	snapshots, err := client.DescribeSnapshotsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeSnapshotsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SnapshotsPerRegionUsage, prometheus.GaugeValue, float64(len(snapshots)), region))
+}
@@ -0,0 +1,22 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddVolumeMetrics(t *testing.T) {
+	e := NewEBSExporter(nil, log.NewNopLogger(), EBSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+
+	e.addVolumeMetrics("foo", []*ec2.Volume{
+		{VolumeType: aws.String(ec2.VolumeTypeGp3), Size: aws.Int64(100), Encrypted: aws.Bool(true)},
+		{VolumeType: aws.String(ec2.VolumeTypeGp3), Size: aws.Int64(50), Encrypted: aws.Bool(false)},
+	})
+
+	// volume count, unencrypted count and one storage usage metric per known volume type
+	assert.Len(t, e.cache.GetAllMetrics(), 2+len(ebsStorageQuotaCodes))
+}
@@ -3,6 +3,7 @@ package pkg
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,15 +19,43 @@ import (
 
 const (
 	transitGatewayPerAccountQuotaCode string = "L-A2478D36"
+	eipsPerRegionQuotaCode            string = "L-0263D0A3"
+	natGatewaysPerAZQuotaCode         string = "L-FE5A380F"
+	standardOnDemandVCPUsQuotaCode    string = "L-1216C47A"
 	ec2ServiceCode                    string = "ec2"
 )
 
 var TransitGatewaysQuota *prometheus.Desc
 var TransitGatewaysUsage *prometheus.Desc
+var TransitGatewaysUtilization *prometheus.Desc
+var TransitGatewaysForecast *prometheus.Desc
+var EIPsQuota *prometheus.Desc
+var EIPsUsage *prometheus.Desc
+var EIPsUtilization *prometheus.Desc
+var NatGatewaysQuota *prometheus.Desc
+var NatGatewaysUsage *prometheus.Desc
+var NatGatewaysUtilization *prometheus.Desc
+var Instances *prometheus.Desc
+var StandardOnDemandVCPUsQuota *prometheus.Desc
+var StandardOnDemandVCPUsUsage *prometheus.Desc
+var StandardOnDemandVCPUsUtilization *prometheus.Desc
+var InstanceIMDSv2Required *prometheus.Desc
+
+// standardInstanceFamilies are the first letters of the instance families counting against the Running On-Demand
+// Standard (A, C, D, H, I, M, R, T, Z) instances quota
+const standardInstanceFamilies = "acdhimrtz"
+
+// nonStandardInstanceFamilies are families starting with one of the standard letters which have their own quota
+var nonStandardInstanceFamilies = []string{"dl", "hpc", "inf", "mac", "trn"}
 
 type EC2Exporter struct {
-	sessions []*session.Session
-	cache    MetricsCache
+	sessions            []*session.Session
+	cache               MetricsCache
+	savingsPlans        bool
+	amiMetrics          bool
+	spotPlacementScores *SpotPlacementScoresConfig
+	// forecaster forecasts when the transit gateways reach their quota
+	forecaster *quotaForecaster
 
 	logger   log.Logger
 	timeout  time.Duration
@@ -40,10 +69,37 @@ func NewEC2Exporter(sessions []*session.Session, logger log.Logger, config EC2Co
 
 	TransitGatewaysQuota = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_transitgatewaysperregion_quota"), "Quota for maximum number of Transitgateways in this account", []string{"aws_region"}, constLabels)
 	TransitGatewaysUsage = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_transitgatewaysperregion_usage"), "Number of Tranitgatewyas in the AWS Account", []string{"aws_region"}, constLabels)
+	TransitGatewaysUtilization = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_transitgatewaysperregion_quota_utilization_ratio"), "Number of transit gateways in this region relative to the quota", []string{"aws_region"}, constLabels)
+	TransitGatewaysForecast = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_transitgatewaysperregion_days_until_quota_exhausted"), "The days until the transit gateways reach their quota at the growth of the last week", []string{"aws_region"}, constLabels)
+
+	eipLabels := map[string]string{"aws_account_id": awsAccountId, QUOTA_CODE_KEY: eipsPerRegionQuotaCode, SERVICE_CODE_KEY: ec2ServiceCode}
+	EIPsQuota = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_eips_quota"), "Quota for maximum number of Elastic IPs in this region", []string{"aws_region"}, eipLabels)
+	EIPsUsage = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_eips_usage"), "Number of Elastic IPs allocated in this region", []string{"aws_region"}, eipLabels)
+	EIPsUtilization = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_eips_quota_utilization_ratio"), "Number of Elastic IPs allocated in this region relative to the quota", []string{"aws_region"}, eipLabels)
+
+	natGatewayLabels := map[string]string{"aws_account_id": awsAccountId, QUOTA_CODE_KEY: natGatewaysPerAZQuotaCode, SERVICE_CODE_KEY: SERVICE_CODE_VPC}
+	NatGatewaysQuota = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_natgateways_quota"), "Quota for maximum number of NAT gateways per availability zone", []string{"aws_region"}, natGatewayLabels)
+	NatGatewaysUsage = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_natgateways_usage"), "Number of NAT gateways in the availability zone", []string{"aws_region", "availability_zone"}, natGatewayLabels)
+	NatGatewaysUtilization = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_natgateways_quota_utilization_ratio"), "Number of NAT gateways in the availability zone relative to the quota", []string{"aws_region", "availability_zone"}, natGatewayLabels)
+
+	Instances = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_instances"), "Number of EC2 instances per state, instance type and availability zone", []string{"aws_region", "state", "instance_type", "availability_zone"}, map[string]string{"aws_account_id": awsAccountId})
+	vcpuLabels := map[string]string{"aws_account_id": awsAccountId, QUOTA_CODE_KEY: standardOnDemandVCPUsQuotaCode, SERVICE_CODE_KEY: ec2ServiceCode}
+	StandardOnDemandVCPUsQuota = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_standard_ondemand_vcpus_quota"), "Quota for maximum number of vCPUs of running On-Demand standard instances in this region", []string{"aws_region"}, vcpuLabels)
+	StandardOnDemandVCPUsUsage = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_standard_ondemand_vcpus_usage"), "Number of vCPUs of running On-Demand standard instances in this region", []string{"aws_region"}, vcpuLabels)
+	StandardOnDemandVCPUsUtilization = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_standard_ondemand_vcpus_quota_utilization_ratio"), "Number of vCPUs of running On-Demand standard instances in this region relative to the quota", []string{"aws_region"}, vcpuLabels)
+	InstanceIMDSv2Required = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_instance_imdsv2_required"), "Whether the instance metadata service of the instance requires session tokens (IMDSv2)", []string{"aws_region", "instance_id"}, map[string]string{"aws_account_id": awsAccountId})
+
+	initEC2ReservationDescs(awsAccountId)
+	initEC2CapacityDescs(awsAccountId)
+	initEC2AMIDescs(awsAccountId)
 
 	return &EC2Exporter{
-		sessions: sessions,
-		cache:    *NewMetricsCache(*config.CacheTTL),
+		sessions:            sessions,
+		cache:               *NewMetricsCacheFromConfig(config.BaseConfig),
+		savingsPlans:        config.SavingsPlans,
+		amiMetrics:          config.AMIMetrics,
+		spotPlacementScores: config.SpotPlacementScores,
+		forecaster:          quotaForecasterFor("ec2"),
 
 		logger:   logger,
 		timeout:  *config.Timeout,
@@ -57,50 +113,195 @@ func (e *EC2Exporter) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
-func (e *EC2Exporter) CollectLoop() {
+func (e *EC2Exporter) CollectLoop(ctx context.Context) {
 	for {
-		ctx, ctxCancel := context.WithTimeout(context.Background(), e.timeout)
-		defer ctxCancel()
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
 		wg := &sync.WaitGroup{}
 		wg.Add(len(e.sessions))
 
-		for _, sess := range e.sessions {
-			go e.collectInRegion(sess, e.logger, wg, ctx)
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			// Savings Plans apply to the whole account and the Spot placement scores of all regions are requested at
+			// once, so they are only collected in the first region
+			go e.collectInRegion(sess, i == 0, e.logger, wg, collectCtx)
 		}
 		wg.Wait()
 
 		level.Info(e.logger).Log("msg", "EC2 metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "ec2")
+		}
 
-		time.Sleep(e.interval)
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
 	}
 }
 
-func (e *EC2Exporter) collectInRegion(sess *session.Session, logger log.Logger, wg *sync.WaitGroup, ctx context.Context) {
+func (e *EC2Exporter) collectInRegion(sess *session.Session, firstRegion bool, logger log.Logger, wg *sync.WaitGroup, ctx context.Context) {
 	defer wg.Done()
 
 	aws := awsclient.NewClientFromSession(sess)
+	ctx, run := e.cache.startCollectorRun(ctx, "ec2", *sess.Config.Region)
+	defer run.finish()
 
-	quota, err := getQuotaValueWithContext(aws, ec2ServiceCode, transitGatewayPerAccountQuotaCode, ctx)
+	e.collectTransitGatewayMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectEIPMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectNatGatewayMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectInstanceMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectReservedInstanceMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectSpotInstanceRequestMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	e.collectCapacityReservationMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	if e.amiMetrics {
+		e.collectAMIMetrics(aws, ctx, *sess.Config.Region, logger, run)
+	}
+	if e.savingsPlans && firstRegion {
+		e.collectSavingsPlanMetrics(aws, ctx, logger, run)
+	}
+	if e.spotPlacementScores != nil && firstRegion {
+		e.collectSpotPlacementScores(aws, ctx, logger, run)
+	}
+}
+
+func (e *EC2Exporter) collectTransitGatewayMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, ec2ServiceCode, transitGatewayPerAccountQuotaCode, ctx)
 	if err != nil {
 		level.Error(logger).Log("msg", "Could not retrieve Transit Gateway quota", "error", err.Error())
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		run.fail()
 		return
 	}
 
-	gateways, err := getAllTransitGatewaysWithContext(aws, ctx)
+	gateways, err := getAllTransitGatewaysWithContext(client, ctx)
 	if err != nil {
 		level.Error(logger).Log("msg", "Could not retrieve Transit Gateway quota", "error", err.Error())
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		run.fail()
+		return
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(TransitGatewaysUsage, prometheus.GaugeValue, float64(len(gateways)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(TransitGatewaysQuota, prometheus.GaugeValue, quota, region))
+	e.cache.AddQuotaUtilization(TransitGatewaysUtilization, float64(len(gateways)), quota, region)
+	if days, ok := e.forecaster.forecast(time.Now(), float64(len(gateways)), quota, region); ok {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(TransitGatewaysForecast, prometheus.GaugeValue, days, region))
+	}
+}
+
+func (e *EC2Exporter) collectEIPMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, ec2ServiceCode, eipsPerRegionQuotaCode, ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not retrieve Elastic IP quota", "region", region, "error", err.Error())
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(EIPsQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	addresses, err := getAllAddressesWithContext(client, ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not retrieve Elastic IPs", "region", region, "error", err.Error())
+		run.fail()
 		return
 	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(EIPsUsage, prometheus.GaugeValue, float64(len(addresses)), region))
+	e.cache.AddQuotaUtilization(EIPsUtilization, float64(len(addresses)), quota, region)
+}
+
+func (e *EC2Exporter) collectNatGatewayMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, SERVICE_CODE_VPC, natGatewaysPerAZQuotaCode, ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not retrieve NAT gateway quota", "region", region, "error", err.Error())
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(NatGatewaysQuota, prometheus.GaugeValue, quota, region))
+	}
 
-	e.cache.AddMetric(prometheus.MustNewConstMetric(TransitGatewaysUsage, prometheus.GaugeValue, float64(len(gateways)), *sess.Config.Region))
-	e.cache.AddMetric(prometheus.MustNewConstMetric(TransitGatewaysQuota, prometheus.GaugeValue, quota, *sess.Config.Region))
+	natGateways, err := client.DescribeNatGatewaysAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeNatGatewaysAll failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+	// NAT gateways only reference their subnet, the availability zone has to be looked up from there
+	subnets, err := client.DescribeSubnetsAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeSubnetsAll failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+
+	for az, count := range countNatGatewaysPerAZ(natGateways, subnets) {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(NatGatewaysUsage, prometheus.GaugeValue, float64(count), region, az))
+		e.cache.AddQuotaUtilization(NatGatewaysUtilization, float64(count), quota, region, az)
+	}
+}
+
+func (e *EC2Exporter) collectInstanceMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, ec2ServiceCode, standardOnDemandVCPUsQuotaCode, ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Could not retrieve standard On-Demand vCPU quota", "region", region, "error", err.Error())
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(StandardOnDemandVCPUsQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	instances, err := client.DescribeInstancesAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeInstancesAll failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+
+	counts, vcpus := countInstances(instances)
+	for key, count := range counts {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(Instances, prometheus.GaugeValue, float64(count), region, key.state, key.instanceType, key.availabilityZone))
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(StandardOnDemandVCPUsUsage, prometheus.GaugeValue, float64(vcpus), region))
+	e.cache.AddQuotaUtilization(StandardOnDemandVCPUsUtilization, float64(vcpus), quota, region)
+	e.addInstanceMetadataMetrics(region, instances)
+}
+
+// addInstanceMetadataMetrics adds whether IMDSv2 is enforced for every instance which wasn't terminated
+func (e *EC2Exporter) addInstanceMetadataMetrics(region string, instances []*ec2.Instance) {
+	for _, instance := range instances {
+		if instance.State != nil && aws.StringValue(instance.State.Name) == ec2.InstanceStateNameTerminated {
+			continue
+		}
+		required := 0.0
+		if instance.MetadataOptions != nil && aws.StringValue(instance.MetadataOptions.HttpTokens) == ec2.HttpTokensStateRequired {
+			required = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(InstanceIMDSv2Required, prometheus.GaugeValue, required, region, aws.StringValue(instance.InstanceId)))
+	}
 }
 
 func (e *EC2Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- TransitGatewaysQuota
 	ch <- TransitGatewaysUsage
+	ch <- TransitGatewaysUtilization
+	ch <- TransitGatewaysForecast
+	ch <- EIPsQuota
+	ch <- EIPsUsage
+	ch <- EIPsUtilization
+	ch <- NatGatewaysQuota
+	ch <- NatGatewaysUsage
+	ch <- NatGatewaysUtilization
+	ch <- Instances
+	ch <- StandardOnDemandVCPUsQuota
+	ch <- StandardOnDemandVCPUsUsage
+	ch <- StandardOnDemandVCPUsUtilization
+	ch <- InstanceIMDSv2Required
+	ch <- ReservedInstances
+	ch <- ReservedInstanceExpiry
+	ch <- SavingsPlanExpiry
+	ch <- SavingsPlanCommitment
+	ch <- SpotInstanceRequests
+	ch <- SpotPlacementScore
+	ch <- CapacityReservationInstances
+	ch <- CapacityReservationUsedInstances
+	ch <- AMIs
+	ch <- AMIAge
+	ch <- AMIDeregistrationProtection
 }
 
 func createDescribeTransitGatewayInput() *ec2.DescribeTransitGatewaysInput {
@@ -152,3 +353,71 @@ func getQuotaValueWithContext(client awsclient.Client, serviceCode string, quota
 
 	return *sqOutput.Quota.Value, nil
 }
+
+func getAllAddressesWithContext(client awsclient.Client, ctx context.Context) ([]*ec2.Address, error) {
+	describeAddressesOutput, err := client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+	return describeAddressesOutput.Addresses, nil
+}
+
+// countNatGatewaysPerAZ counts the NAT gateways which count against the quota (i.e. not failed or deleted) per availability zone
+func countNatGatewaysPerAZ(natGateways []*ec2.NatGateway, subnets []*ec2.Subnet) map[string]int {
+	subnetAZs := make(map[string]string)
+	for _, subnet := range subnets {
+		subnetAZs[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+
+	counts := make(map[string]int)
+	for _, natGateway := range natGateways {
+		switch aws.StringValue(natGateway.State) {
+		case ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable:
+			counts[subnetAZs[aws.StringValue(natGateway.SubnetId)]]++
+		}
+	}
+	return counts
+}
+
+type instanceKey struct {
+	state            string
+	instanceType     string
+	availabilityZone string
+}
+
+// countInstances counts the instances per state, instance type and availability zone and sums up the vCPUs of the
+// running On-Demand standard instances, which count against the standard On-Demand vCPU quota
+func countInstances(instances []*ec2.Instance) (map[instanceKey]int, int64) {
+	counts := make(map[instanceKey]int)
+	var vcpus int64
+	for _, instance := range instances {
+		key := instanceKey{instanceType: aws.StringValue(instance.InstanceType)}
+		if instance.State != nil {
+			key.state = aws.StringValue(instance.State.Name)
+		}
+		if instance.Placement != nil {
+			key.availabilityZone = aws.StringValue(instance.Placement.AvailabilityZone)
+		}
+		counts[key]++
+
+		// spot and scheduled instances have a lifecycle, On-Demand instances don't
+		if key.state == ec2.InstanceStateNameRunning && instance.InstanceLifecycle == nil && isStandardInstanceType(key.instanceType) && instance.CpuOptions != nil {
+			vcpus += aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+		}
+	}
+	return counts, vcpus
+}
+
+// isStandardInstanceType returns whether instances of the type, e.g. m5.large, count against the standard On-Demand
+// vCPU quota
+func isStandardInstanceType(instanceType string) bool {
+	if instanceType == "" || !strings.ContainsRune(standardInstanceFamilies, rune(instanceType[0])) {
+		return false
+	}
+	for _, family := range nonStandardInstanceFamilies {
+		if strings.HasPrefix(instanceType, family) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var AMIs *prometheus.Desc
+var AMIAge *prometheus.Desc
+var AMIDeregistrationProtection *prometheus.Desc
+
+// initEC2AMIDescs creates the descriptions of the AMI metrics, which carry the account id as constant label
+func initEC2AMIDescs(awsAccountId string) {
+	constLabels := map[string]string{"aws_account_id": awsAccountId}
+
+	AMIs = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_amis"), "The number of AMIs owned by the account", []string{"aws_region"}, constLabels)
+	AMIAge = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_ami_age_days"), "The days since the AMI was created", []string{"aws_region", "image_id", "name"}, constLabels)
+	AMIDeregistrationProtection = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_ami_deregistration_protection"), "Whether the AMI is protected from deregistration", []string{"aws_region", "image_id", "name"}, constLabels)
+}
+
+func (e *EC2Exporter) collectAMIMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	images, err := client.DescribeOwnedImagesAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeImages failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+	e.addAMIMetrics(region, images, time.Now(), logger)
+}
+
+func (e *EC2Exporter) addAMIMetrics(region string, images []*ec2.Image, now time.Time, logger log.Logger) {
+	e.cache.AddMetric(prometheus.MustNewConstMetric(AMIs, prometheus.GaugeValue, float64(len(images)), region))
+
+	for _, image := range images {
+		imageId := aws.StringValue(image.ImageId)
+		name := aws.StringValue(image.Name)
+
+		// the protection is "disabled", "enabled" or "enabled-with-cooldown"
+		protected := 0.0
+		if strings.HasPrefix(aws.StringValue(image.DeregistrationProtection), "enabled") {
+			protected = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(AMIDeregistrationProtection, prometheus.GaugeValue, protected, region, imageId, name))
+
+		creation, err := time.Parse(time.RFC3339, aws.StringValue(image.CreationDate))
+		if err != nil {
+			level.Warn(logger).Log("msg", "Could not parse the creation date of the AMI", "region", region, "image_id", imageId, "error", err.Error())
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(AMIAge, prometheus.GaugeValue, now.Sub(creation).Hours()/24, region, imageId, name))
+	}
+}
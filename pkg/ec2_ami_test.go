@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectAMIMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2AMIDescs("123456789012")
+
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+	mockClient.EXPECT().DescribeOwnedImagesAll(ctx).Return([]*ec2.Image{}, nil)
+
+	run := startCollectorRun("ec2", "foo")
+	x.collectAMIMetrics(mockClient, ctx, "foo", log.NewNopLogger(), run)
+	assert.False(t, run.failed.Load())
+	assert.Equal(t, map[string]float64{"foo": 0}, gaugeValues(t, &x, AMIs.String(), "aws_region"))
+}
+
+func TestAddAMIMetrics(t *testing.T) {
+	initEC2AMIDescs("123456789012")
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+
+	now := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	x.addAMIMetrics("foo", []*ec2.Image{
+		{ImageId: aws.String("ami-1"), Name: aws.String("golden-1"), CreationDate: aws.String("2024-03-01T00:00:00.000Z"), DeregistrationProtection: aws.String("enabled-with-cooldown")},
+		{ImageId: aws.String("ami-2"), Name: aws.String("golden-2"), CreationDate: aws.String("2024-03-10T12:00:00.000Z"), DeregistrationProtection: aws.String("disabled")},
+		// an AMI without a valid creation date is still counted
+		{ImageId: aws.String("ami-3"), Name: aws.String("golden-3")},
+	}, now, log.NewNopLogger())
+
+	assert.Equal(t, map[string]float64{"foo": 3}, gaugeValues(t, &x, AMIs.String(), "aws_region"))
+	assert.Equal(t, map[string]float64{"ami-1": 10, "ami-2": 0.5}, gaugeValues(t, &x, AMIAge.String(), "image_id"))
+	assert.Equal(t, map[string]float64{"ami-1": 1, "ami-2": 0, "ami-3": 0}, gaugeValues(t, &x, AMIDeregistrationProtection.String(), "image_id"))
+}
@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/savingsplans"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ReservedInstances *prometheus.Desc
+var ReservedInstanceExpiry *prometheus.Desc
+var SavingsPlanExpiry *prometheus.Desc
+var SavingsPlanCommitment *prometheus.Desc
+
+// initEC2ReservationDescs creates the descriptions of the reservations, which carry the account id as constant label
+func initEC2ReservationDescs(awsAccountId string) {
+	constLabels := map[string]string{"aws_account_id": awsAccountId}
+
+	ReservedInstances = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_reserved_instances"), "The number of active reserved instances per instance family", []string{"aws_region", "instance_family", "offering_class"}, constLabels)
+	ReservedInstanceExpiry = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_reserved_instance_expiry_timestamp_seconds"), "The expiration time of an active instance reservation (UTC timestamp).", []string{"aws_region", "reserved_instances_id", "instance_type", "offering_class"}, constLabels)
+	SavingsPlanExpiry = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_savings_plan_expiry_timestamp_seconds"), "The expiration time of an active Savings Plan (UTC timestamp).", []string{"savings_plan_id", "savings_plan_type", "payment_option"}, constLabels)
+	SavingsPlanCommitment = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_savings_plan_hourly_commitment"), "The hourly commitment of an active Savings Plan in its currency.", []string{"savings_plan_id", "savings_plan_type", "currency"}, constLabels)
+}
+
+// instanceFamily returns the family of the instance type, e.g. m5 for m5.large
+func instanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
+
+type reservedInstancesFamilyKey struct {
+	instanceFamily string
+	offeringClass  string
+}
+
+func (e *EC2Exporter) collectReservedInstanceMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	reservedInstances, err := client.DescribeActiveReservedInstances(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeReservedInstances failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+	e.addReservedInstanceMetrics(region, reservedInstances)
+}
+
+func (e *EC2Exporter) addReservedInstanceMetrics(region string, reservedInstances []*ec2.ReservedInstances) {
+	counts := make(map[reservedInstancesFamilyKey]int64)
+	for _, reservedInstance := range reservedInstances {
+		instanceType := aws.StringValue(reservedInstance.InstanceType)
+		offeringClass := aws.StringValue(reservedInstance.OfferingClass)
+		counts[reservedInstancesFamilyKey{instanceFamily(instanceType), offeringClass}] += aws.Int64Value(reservedInstance.InstanceCount)
+
+		if reservedInstance.End != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(ReservedInstanceExpiry, prometheus.GaugeValue, float64(reservedInstance.End.Unix()), region, aws.StringValue(reservedInstance.ReservedInstancesId), instanceType, offeringClass))
+		}
+	}
+
+	for key, count := range counts {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(ReservedInstances, prometheus.GaugeValue, float64(count), region, key.instanceFamily, key.offeringClass))
+	}
+}
+
+// collectSavingsPlanMetrics adds the active Savings Plans. They apply to the whole account, so they are only collected
+// in the first region.
+func (e *EC2Exporter) collectSavingsPlanMetrics(client awsclient.Client, ctx context.Context, logger log.Logger, run *collectorRun) {
+	plans, err := client.DescribeActiveSavingsPlansAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeSavingsPlans failed", "error", err.Error())
+		run.fail()
+		return
+	}
+	e.addSavingsPlanMetrics(plans, logger)
+}
+
+func (e *EC2Exporter) addSavingsPlanMetrics(plans []*savingsplans.SavingsPlan, logger log.Logger) {
+	for _, plan := range plans {
+		id := aws.StringValue(plan.SavingsPlanId)
+		planType := aws.StringValue(plan.SavingsPlanType)
+
+		// the Savings Plans API returns the times and amounts as strings
+		if end, err := time.Parse(time.RFC3339, aws.StringValue(plan.End)); err == nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(SavingsPlanExpiry, prometheus.GaugeValue, float64(end.Unix()), id, planType, aws.StringValue(plan.PaymentOption)))
+		} else {
+			level.Warn(logger).Log("msg", "Could not parse the end of the Savings Plan", "savings_plan_id", id, "error", err.Error())
+		}
+		if commitment, err := strconv.ParseFloat(aws.StringValue(plan.Commitment), 64); err == nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(SavingsPlanCommitment, prometheus.GaugeValue, commitment, id, planType, aws.StringValue(plan.Currency)))
+		} else {
+			level.Warn(logger).Log("msg", "Could not parse the commitment of the Savings Plan", "savings_plan_id", id, "error", err.Error())
+		}
+	}
+}
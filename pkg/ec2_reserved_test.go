@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/savingsplans"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectReservedInstanceMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2ReservationDescs("123456789012")
+
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().DescribeActiveReservedInstances(ctx).Return([]*ec2.ReservedInstances{
+		{ReservedInstancesId: aws.String("ri-1"), InstanceType: aws.String("m5.large"), OfferingClass: aws.String("standard"), InstanceCount: aws.Int64(2), End: aws.Time(end)},
+		{ReservedInstancesId: aws.String("ri-2"), InstanceType: aws.String("m5.xlarge"), OfferingClass: aws.String("standard"), InstanceCount: aws.Int64(1), End: aws.Time(end)},
+	}, nil)
+
+	run := startCollectorRun("ec2", "foo")
+	x.collectReservedInstanceMetrics(mockClient, ctx, "foo", log.NewNopLogger(), run)
+	assert.False(t, run.failed.Load())
+
+	metrics := x.cache.GetAllMetrics()
+	// two expiries and one count for the m5 family
+	assert.Len(t, metrics, 3)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case ReservedInstances.String():
+			assert.Equal(t, 3.0, dtoMetric.GetGauge().GetValue())
+		case ReservedInstanceExpiry.String():
+			assert.Equal(t, float64(end.Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestCollectSavingsPlanMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2ReservationDescs("123456789012")
+
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+	mockClient.EXPECT().DescribeActiveSavingsPlansAll(ctx).Return([]*savingsplans.SavingsPlan{
+		{
+			SavingsPlanId:   aws.String("sp-1"),
+			SavingsPlanType: aws.String(savingsplans.SavingsPlanTypeCompute),
+			PaymentOption:   aws.String(savingsplans.SavingsPlanPaymentOptionNoUpfront),
+			Currency:        aws.String(savingsplans.CurrencyCodeUsd),
+			Commitment:      aws.String("1.5"),
+			End:             aws.String("2026-03-01T12:00:00.000Z"),
+		},
+		// values which can't be parsed are left out
+		{SavingsPlanId: aws.String("sp-2"), Commitment: aws.String(""), End: aws.String("")},
+	}, nil)
+
+	run := startCollectorRun("ec2", "foo")
+	x.collectSavingsPlanMetrics(mockClient, ctx, log.NewNopLogger(), run)
+	assert.False(t, run.failed.Load())
+
+	metrics := x.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case SavingsPlanCommitment.String():
+			assert.Equal(t, 1.5, dtoMetric.GetGauge().GetValue())
+		case SavingsPlanExpiry.String():
+			assert.Equal(t, float64(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestInstanceFamily(t *testing.T) {
+	assert.Equal(t, "m5", instanceFamily("m5.large"))
+	assert.Equal(t, "u-6tb1", instanceFamily("u-6tb1.metal"))
+	assert.Equal(t, "", instanceFamily(""))
+}
@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var SpotInstanceRequests *prometheus.Desc
+var SpotPlacementScore *prometheus.Desc
+var CapacityReservationInstances *prometheus.Desc
+var CapacityReservationUsedInstances *prometheus.Desc
+
+// spotInstanceRequestStates are the states of the Spot instance requests which are counted
+var spotInstanceRequestStates = []string{ec2.SpotInstanceStateOpen, ec2.SpotInstanceStateActive}
+
+// initEC2CapacityDescs creates the descriptions of the Spot and capacity reservation metrics, which carry the account
+// id as constant label
+func initEC2CapacityDescs(awsAccountId string) {
+	constLabels := map[string]string{"aws_account_id": awsAccountId}
+
+	SpotInstanceRequests = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_spot_instance_requests"), "The number of open and active Spot instance requests", []string{"aws_region", "state"}, constLabels)
+	SpotPlacementScore = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_spot_placement_score"), "The likelihood from 1 to 10 that the configured Spot capacity is fulfilled in the region", []string{"aws_region"}, constLabels)
+	CapacityReservationInstances = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_capacity_reservation_instances"), "The number of instances of an active On-Demand capacity reservation", []string{"aws_region", "capacity_reservation_id", "instance_type", "availability_zone"}, constLabels)
+	CapacityReservationUsedInstances = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ec2_capacity_reservation_used_instances"), "The number of instances running in an active On-Demand capacity reservation", []string{"aws_region", "capacity_reservation_id", "instance_type", "availability_zone"}, constLabels)
+}
+
+func (e *EC2Exporter) collectSpotInstanceRequestMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	requests, err := client.DescribeSpotInstanceRequestsAll(ctx, spotInstanceRequestStates...)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeSpotInstanceRequests failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+
+	// every state is exported, so a missing series doesn't hide that there are no requests
+	counts := make(map[string]int)
+	for _, state := range spotInstanceRequestStates {
+		counts[state] = 0
+	}
+	for _, request := range requests {
+		counts[aws.StringValue(request.State)]++
+	}
+	for state, count := range counts {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(SpotInstanceRequests, prometheus.GaugeValue, float64(count), region, state))
+	}
+}
+
+func (e *EC2Exporter) collectCapacityReservationMetrics(client awsclient.Client, ctx context.Context, region string, logger log.Logger, run *collectorRun) {
+	reservations, err := client.DescribeCapacityReservationsAll(ctx)
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to DescribeCapacityReservations failed", "region", region, "error", err.Error())
+		run.fail()
+		return
+	}
+
+	for _, reservation := range reservations {
+		if aws.StringValue(reservation.State) != ec2.CapacityReservationStateActive {
+			continue
+		}
+		total := aws.Int64Value(reservation.TotalInstanceCount)
+		labels := []string{region, aws.StringValue(reservation.CapacityReservationId), aws.StringValue(reservation.InstanceType), aws.StringValue(reservation.AvailabilityZone)}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(CapacityReservationInstances, prometheus.GaugeValue, float64(total), labels...))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(CapacityReservationUsedInstances, prometheus.GaugeValue, float64(total-aws.Int64Value(reservation.AvailableInstanceCount)), labels...))
+	}
+}
+
+// collectSpotPlacementScores requests the Spot placement scores of all regions of the exporter at once, as the number
+// of different configurations which can be scored is limited
+func (e *EC2Exporter) collectSpotPlacementScores(client awsclient.Client, ctx context.Context, logger log.Logger, run *collectorRun) {
+	regions := make([]string, 0, len(e.sessions))
+	for _, sess := range e.sessions {
+		regions = append(regions, aws.StringValue(sess.Config.Region))
+	}
+
+	scores, err := client.GetSpotPlacementScoresAll(ctx, &ec2.GetSpotPlacementScoresInput{
+		InstanceTypes:  aws.StringSlice(e.spotPlacementScores.InstanceTypes),
+		TargetCapacity: aws.Int64(e.spotPlacementScores.TargetCapacity),
+		RegionNames:    aws.StringSlice(regions),
+	})
+	if err != nil {
+		level.Error(logger).Log("msg", "Call to GetSpotPlacementScores failed", "error", err.Error())
+		run.fail()
+		return
+	}
+
+	for _, score := range scores {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(SpotPlacementScore, prometheus.GaugeValue, float64(aws.Int64Value(score.Score)), aws.StringValue(score.Region)))
+	}
+}
@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// gaugeValues returns the values of the metrics with the descriptor by the value of the label
+func gaugeValues(t *testing.T, x *EC2Exporter, desc string, label string) map[string]float64 {
+	values := make(map[string]float64)
+	for _, metric := range x.cache.GetAllMetrics() {
+		if metric.Desc().String() != desc {
+			continue
+		}
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		for _, l := range dtoMetric.GetLabel() {
+			if l.GetName() == label {
+				values[l.GetValue()] = dtoMetric.GetGauge().GetValue()
+			}
+		}
+	}
+	return values
+}
+
+func TestCollectSpotInstanceRequestMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2CapacityDescs("123456789012")
+
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+	mockClient.EXPECT().DescribeSpotInstanceRequestsAll(ctx, ec2.SpotInstanceStateOpen, ec2.SpotInstanceStateActive).Return([]*ec2.SpotInstanceRequest{
+		{State: aws.String(ec2.SpotInstanceStateActive)},
+		{State: aws.String(ec2.SpotInstanceStateActive)},
+	}, nil)
+
+	x.collectSpotInstanceRequestMetrics(mockClient, ctx, "foo", log.NewNopLogger(), startCollectorRun("ec2", "foo"))
+	// the open requests are exported although there are none
+	assert.Equal(t, map[string]float64{"open": 0, "active": 2}, gaugeValues(t, &x, SpotInstanceRequests.String(), "state"))
+}
+
+func TestCollectCapacityReservationMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2CapacityDescs("123456789012")
+
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+	mockClient.EXPECT().DescribeCapacityReservationsAll(ctx).Return([]*ec2.CapacityReservation{
+		{CapacityReservationId: aws.String("cr-1"), State: aws.String(ec2.CapacityReservationStateActive), TotalInstanceCount: aws.Int64(4), AvailableInstanceCount: aws.Int64(1)},
+		{CapacityReservationId: aws.String("cr-2"), State: aws.String(ec2.CapacityReservationStateExpired), TotalInstanceCount: aws.Int64(4), AvailableInstanceCount: aws.Int64(4)},
+	}, nil)
+
+	x.collectCapacityReservationMetrics(mockClient, ctx, "foo", log.NewNopLogger(), startCollectorRun("ec2", "foo"))
+	assert.Equal(t, map[string]float64{"cr-1": 4}, gaugeValues(t, &x, CapacityReservationInstances.String(), "capacity_reservation_id"))
+	assert.Equal(t, map[string]float64{"cr-1": 3}, gaugeValues(t, &x, CapacityReservationUsedInstances.String(), "capacity_reservation_id"))
+}
+
+func TestCollectSpotPlacementScores(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+	initEC2CapacityDescs("123456789012")
+
+	x := EC2Exporter{
+		sessions: []*session.Session{
+			session.New(&aws.Config{Region: aws.String("us-east-1")}),
+			session.New(&aws.Config{Region: aws.String("eu-west-1")}),
+		},
+		cache:               *NewMetricsCache(10 * time.Second),
+		spotPlacementScores: &SpotPlacementScoresConfig{InstanceTypes: []string{"m5.large"}, TargetCapacity: 10},
+	}
+	mockClient.EXPECT().GetSpotPlacementScoresAll(ctx, &ec2.GetSpotPlacementScoresInput{
+		InstanceTypes:  aws.StringSlice([]string{"m5.large"}),
+		TargetCapacity: aws.Int64(10),
+		RegionNames:    aws.StringSlice([]string{"us-east-1", "eu-west-1"}),
+	}).Return([]*ec2.SpotPlacementScore{
+		{Region: aws.String("us-east-1"), Score: aws.Int64(9)},
+		{Region: aws.String("eu-west-1"), Score: aws.Int64(3)},
+	}, nil)
+
+	x.collectSpotPlacementScores(mockClient, ctx, log.NewNopLogger(), startCollectorRun("ec2", "us-east-1"))
+	assert.Equal(t, map[string]float64{"us-east-1": 9, "eu-west-1": 3}, gaugeValues(t, &x, SpotPlacementScore.String(), "aws_region"))
+}
@@ -3,11 +3,13 @@ package pkg
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -62,3 +64,83 @@ func TestGetQuotaValueWithContextError(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, quotaValue, 0.0)
 }
+
+func TestGetAllAddressesWithContext(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+
+	mockClient.EXPECT().DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{}).
+		Return(&ec2.DescribeAddressesOutput{
+			Addresses: []*ec2.Address{{}, {}},
+		}, nil)
+
+	addresses, err := getAllAddressesWithContext(mockClient, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, addresses, 2)
+}
+
+func TestCountNatGatewaysPerAZ(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a")},
+		{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1b")},
+	}
+	natGateways := []*ec2.NatGateway{
+		{SubnetId: aws.String("subnet-a"), State: aws.String(ec2.NatGatewayStateAvailable)},
+		{SubnetId: aws.String("subnet-a"), State: aws.String(ec2.NatGatewayStatePending)},
+		{SubnetId: aws.String("subnet-a"), State: aws.String(ec2.NatGatewayStateDeleted)},
+		{SubnetId: aws.String("subnet-b"), State: aws.String(ec2.NatGatewayStateAvailable)},
+	}
+
+	counts := countNatGatewaysPerAZ(natGateways, subnets)
+	assert.Equal(t, map[string]int{"us-east-1a": 2, "us-east-1b": 1}, counts)
+}
+
+func TestCountInstances(t *testing.T) {
+	cpuOptions := &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}
+	placement := &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")}
+	running := &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)}
+	instances := []*ec2.Instance{
+		{InstanceType: aws.String("m5.xlarge"), State: running, Placement: placement, CpuOptions: cpuOptions},
+		{InstanceType: aws.String("m5.xlarge"), State: running, Placement: placement, CpuOptions: cpuOptions},
+		// spot, stopped and non-standard instances don't count against the quota
+		{InstanceType: aws.String("m5.xlarge"), State: running, Placement: placement, CpuOptions: cpuOptions, InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot)},
+		{InstanceType: aws.String("m5.xlarge"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameStopped)}, Placement: placement, CpuOptions: cpuOptions},
+		{InstanceType: aws.String("p3.2xlarge"), State: running, Placement: placement, CpuOptions: cpuOptions},
+		{InstanceType: aws.String("inf1.xlarge"), State: running, Placement: placement, CpuOptions: cpuOptions},
+	}
+
+	counts, vcpus := countInstances(instances)
+	assert.Equal(t, map[instanceKey]int{
+		{state: "running", instanceType: "m5.xlarge", availabilityZone: "us-east-1a"}:   3,
+		{state: "stopped", instanceType: "m5.xlarge", availabilityZone: "us-east-1a"}:   1,
+		{state: "running", instanceType: "p3.2xlarge", availabilityZone: "us-east-1a"}:  1,
+		{state: "running", instanceType: "inf1.xlarge", availabilityZone: "us-east-1a"}: 1,
+	}, counts)
+	assert.Equal(t, int64(8), vcpus)
+}
+
+func TestIsStandardInstanceType(t *testing.T) {
+	for _, instanceType := range []string{"a1.large", "c7g.xlarge", "im4gn.large", "t3.micro", "z1d.large"} {
+		assert.True(t, isStandardInstanceType(instanceType), instanceType)
+	}
+	for _, instanceType := range []string{"", "g5.xlarge", "dl1.24xlarge", "trn1.2xlarge", "mac1.metal", "x2idn.16xlarge"} {
+		assert.False(t, isStandardInstanceType(instanceType), instanceType)
+	}
+}
+
+func TestAddInstanceMetadataMetrics(t *testing.T) {
+	NewEC2Exporter(nil, log.NewNopLogger(), EC2Config{BaseConfig: createTestBaseConfig()}, "123456789012")
+	x := EC2Exporter{cache: *NewMetricsCache(10 * time.Second)}
+
+	running := &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)}
+	x.addInstanceMetadataMetrics("foo", []*ec2.Instance{
+		{InstanceId: aws.String("i-1"), State: running, MetadataOptions: &ec2.InstanceMetadataOptionsResponse{HttpTokens: aws.String(ec2.HttpTokensStateRequired)}},
+		{InstanceId: aws.String("i-2"), State: running, MetadataOptions: &ec2.InstanceMetadataOptionsResponse{HttpTokens: aws.String(ec2.HttpTokensStateOptional)}},
+		{InstanceId: aws.String("i-3"), State: running},
+		{InstanceId: aws.String("i-4"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameTerminated)}},
+	})
+	assert.Equal(t, map[string]float64{"i-1": 1, "i-2": 0, "i-3": 0}, gaugeValues(t, &x, InstanceIMDSv2Required.String(), "instance_id"))
+}
@@ -0,0 +1,169 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ecrServiceCode                 string = "ecr"
+	repositoriesPerRegionQuotaCode string = "L-CFEB8E8D"
+	imagesPerRepositoryQuotaCode   string = "L-03A36CE1"
+)
+
+type ECRExporter struct {
+	sessions                   []*session.Session
+	RepositoriesPerRegionQuota *prometheus.Desc
+	RepositoriesPerRegionUsage *prometheus.Desc
+	ImagesPerRepositoryQuota   *prometheus.Desc
+	ImagesPerRepositoryUsage   *prometheus.Desc
+	ScanOnPush                 *prometheus.Desc
+	LifecyclePolicy            *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewECRExporter creates a new ECRExporter instance
+func NewECRExporter(sessions []*session.Session, logger log.Logger, config ECRConfig, awsAccountId string) *ECRExporter {
+	level.Info(logger).Log("msg", "Initializing ECR exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: ecrServiceCode}
+	repositoryLabels := []string{"aws_region", "repository_name"}
+
+	return &ECRExporter{
+		sessions:                   sessions,
+		RepositoriesPerRegionQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_repositoriesperregion_quota"), "Quota for maximum number of ECR repositories in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, repositoriesPerRegionQuotaCode)),
+		RepositoriesPerRegionUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_repositoriesperregion_usage"), "Number of ECR repositories in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, repositoriesPerRegionQuotaCode)),
+		ImagesPerRepositoryQuota:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_imagesperrepository_quota"), "Quota for maximum number of images per ECR repository", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, imagesPerRepositoryQuotaCode)),
+		ImagesPerRepositoryUsage:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_imagesperrepository_usage"), "Number of images in the ECR repository", repositoryLabels, WithKeyValue(constLabels, QUOTA_CODE_KEY, imagesPerRepositoryQuotaCode)),
+		ScanOnPush:                 prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_repository_scan_on_push"), "Whether images are scanned after being pushed to the ECR repository", repositoryLabels, constLabels),
+		LifecyclePolicy:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecr_repository_lifecycle_policy"), "Whether the ECR repository has a lifecycle policy", repositoryLabels, constLabels),
+		cache:                      *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                     logger,
+		timeout:                    *config.Timeout,
+		interval:                   *config.Interval,
+	}
+}
+
+func (e *ECRExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.RepositoriesPerRegionQuota
+	ch <- e.RepositoriesPerRegionUsage
+	ch <- e.ImagesPerRepositoryQuota
+	ch <- e.ImagesPerRepositoryUsage
+	ch <- e.ScanOnPush
+	ch <- e.LifecyclePolicy
+}
+
+func (e *ECRExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ECRExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "ECR metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "ecr")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ECRExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "ecr", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *ECRExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	for desc, quotaCode := range map[*prometheus.Desc]string{
+		e.RepositoriesPerRegionQuota: repositoriesPerRegionQuotaCode,
+		e.ImagesPerRepositoryQuota:   imagesPerRepositoryQuotaCode,
+	} {
+		quota, err := getQuotaValueWithContext(client, ecrServiceCode, quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve ECR quota", "region", region, "quota_code", quotaCode, "error", err.Error())
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, quota, region))
+	}
+
+	repositories, err := client.DescribeRepositoriesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeRepositoriesAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RepositoriesPerRegionUsage, prometheus.GaugeValue, float64(len(repositories)), region))
+
+	for _, repository := range repositories {
+		e.addRepositoryMetrics(client, ctx, region, run, repository)
+	}
+}
+
+func (e *ECRExporter) addRepositoryMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun, repository *ecr.Repository) {
+	repositoryName := aws.StringValue(repository.RepositoryName)
+
+	scanOnPush := 0.0
+	if repository.ImageScanningConfiguration != nil && aws.BoolValue(repository.ImageScanningConfiguration.ScanOnPush) {
+		scanOnPush = 1
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ScanOnPush, prometheus.GaugeValue, scanOnPush, region, repositoryName))
+
+	images, err := client.DescribeImagesAll(ctx, repositoryName)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeImagesAll failed", "region", region, "repository", repositoryName, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ImagesPerRepositoryUsage, prometheus.GaugeValue, float64(len(images)), region, repositoryName))
+	}
+
+	lifecyclePolicy := 1.0
+	_, err = client.GetLifecyclePolicyWithContext(ctx, &ecr.GetLifecyclePolicyInput{
+		RepositoryName: repository.RepositoryName,
+	})
+	if err != nil {
+		// repositories without a lifecycle policy are reported as an error by the API
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != ecr.ErrCodeLifecyclePolicyNotFoundException {
+			level.Error(e.logger).Log("msg", "Call to GetLifecyclePolicy failed", "region", region, "repository", repositoryName, "err", err)
+			run.fail()
+			return
+		}
+		lifecyclePolicy = 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.LifecyclePolicy, prometheus.GaugeValue, lifecyclePolicy, region, repositoryName))
+}
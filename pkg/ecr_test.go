@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECRCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(ecrServiceCode, repositoriesPerRegionQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100000)}}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(ecrServiceCode, imagesPerRepositoryQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(20000)}}, nil)
+	mockClient.EXPECT().DescribeRepositoriesAll(ctx).Return([]*ecr.Repository{
+		{
+			RepositoryName:             aws.String("a"),
+			ImageScanningConfiguration: &ecr.ImageScanningConfiguration{ScanOnPush: aws.Bool(true)},
+		},
+		{RepositoryName: aws.String("b")},
+	}, nil)
+	mockClient.EXPECT().DescribeImagesAll(ctx, "a").Return([]*ecr.ImageDetail{{}, {}, {}}, nil)
+	mockClient.EXPECT().DescribeImagesAll(ctx, "b").Return([]*ecr.ImageDetail{}, nil)
+	mockClient.EXPECT().GetLifecyclePolicyWithContext(ctx, &ecr.GetLifecyclePolicyInput{RepositoryName: aws.String("a")}).Return(&ecr.GetLifecyclePolicyOutput{}, nil)
+	mockClient.EXPECT().GetLifecyclePolicyWithContext(ctx, &ecr.GetLifecyclePolicyInput{RepositoryName: aws.String("b")}).Return(
+		nil, awserr.New(ecr.ErrCodeLifecyclePolicyNotFoundException, "not found", nil))
+
+	e := NewECRExporter(nil, log.NewNopLogger(), ECRConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ecr", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// 2 quotas, the repository count and 3 metrics per repository
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 9)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.RepositoriesPerRegionUsage.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.ImagesPerRepositoryQuota.String():
+			assert.Equal(t, 20000.0, dtoMetric.GetGauge().GetValue())
+		case e.LifecyclePolicy.String(), e.ScanOnPush.String():
+			expected := 0.0
+			for _, label := range dtoMetric.GetLabel() {
+				if label.GetName() == "repository_name" && label.GetValue() == "a" {
+					expected = 1
+				}
+			}
+			assert.Equal(t, expected, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestECRCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, gomock.Any()).Return(nil, errors.New("some error")).Times(2)
+	mockClient.EXPECT().DescribeRepositoriesAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewECRExporter(nil, log.NewNopLogger(), ECRConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ecr", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -0,0 +1,201 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ecsServiceCode               string  = "ecs"
+	fargateServiceCode           string  = "fargate"
+	clustersPerAccountQuotaCode  string  = "L-21C621EB"
+	servicesPerClusterQuotaCode  string  = "L-9EF96962"
+	fargateOnDemandVCPUQuotaCode string  = "L-3032A538"
+	fargateSpotCapacityProvider  string  = "FARGATE_SPOT"
+	ecsCPUUnitsPerVCPU           float64 = 1024
+)
+
+type ECSExporter struct {
+	sessions                 []*session.Session
+	ClustersPerAccountQuota  *prometheus.Desc
+	ClustersPerAccountUsage  *prometheus.Desc
+	ServicesPerClusterQuota  *prometheus.Desc
+	ServicesPerClusterUsage  *prometheus.Desc
+	ServiceRunningTasks      *prometheus.Desc
+	ServiceDesiredTasks      *prometheus.Desc
+	FargateOnDemandVCPUQuota *prometheus.Desc
+	FargateOnDemandVCPUUsage *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewECSExporter creates a new ECSExporter instance
+func NewECSExporter(sessions []*session.Session, logger log.Logger, config ECSConfig, awsAccountId string) *ECSExporter {
+	level.Info(logger).Log("msg", "Initializing ECS exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: ecsServiceCode}
+	fargateConstLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: fargateServiceCode, QUOTA_CODE_KEY: fargateOnDemandVCPUQuotaCode}
+	serviceLabels := []string{"aws_region", "cluster_name", "service_name"}
+
+	return &ECSExporter{
+		sessions:                 sessions,
+		ClustersPerAccountQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_clustersperaccount_quota"), "Quota for maximum number of ECS clusters in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, clustersPerAccountQuotaCode)),
+		ClustersPerAccountUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_clustersperaccount_usage"), "Number of ECS clusters in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, clustersPerAccountQuotaCode)),
+		ServicesPerClusterQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_servicespercluster_quota"), "Quota for maximum number of services per ECS cluster", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, servicesPerClusterQuotaCode)),
+		ServicesPerClusterUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_servicespercluster_usage"), "Number of active services of the ECS cluster", []string{"aws_region", "cluster_name"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, servicesPerClusterQuotaCode)),
+		ServiceRunningTasks:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_service_running_tasks"), "Number of running tasks of the ECS service", serviceLabels, constLabels),
+		ServiceDesiredTasks:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ecs_service_desired_tasks"), "Desired number of tasks of the ECS service", serviceLabels, constLabels),
+		FargateOnDemandVCPUQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "fargate_ondemandvcpu_quota"), "Quota for maximum number of vCPUs of Fargate On-Demand tasks in this region", []string{"aws_region"}, fargateConstLabels),
+		FargateOnDemandVCPUUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "fargate_ondemandvcpu_usage"), "Number of vCPUs of running Fargate On-Demand ECS tasks in this region", []string{"aws_region"}, fargateConstLabels),
+		cache:                    *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                   logger,
+		timeout:                  *config.Timeout,
+		interval:                 *config.Interval,
+	}
+}
+
+func (e *ECSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ClustersPerAccountQuota
+	ch <- e.ClustersPerAccountUsage
+	ch <- e.ServicesPerClusterQuota
+	ch <- e.ServicesPerClusterUsage
+	ch <- e.ServiceRunningTasks
+	ch <- e.ServiceDesiredTasks
+	ch <- e.FargateOnDemandVCPUQuota
+	ch <- e.FargateOnDemandVCPUUsage
+}
+
+func (e *ECSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ECSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "ECS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "ecs")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ECSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "ecs", region)
+	defer run.finish()
+
+	e.collectQuotaMetrics(client, ctx, region, run)
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *ECSExporter) collectQuotaMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	for _, quota := range []struct {
+		desc        *prometheus.Desc
+		serviceCode string
+		quotaCode   string
+	}{
+		{e.ClustersPerAccountQuota, ecsServiceCode, clustersPerAccountQuotaCode},
+		{e.ServicesPerClusterQuota, ecsServiceCode, servicesPerClusterQuotaCode},
+		{e.FargateOnDemandVCPUQuota, fargateServiceCode, fargateOnDemandVCPUQuotaCode},
+	} {
+		value, err := getQuotaValueWithContext(client, quota.serviceCode, quota.quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve ECS quota", "region", region, "quota_code", quota.quotaCode, "error", err.Error())
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(quota.desc, prometheus.GaugeValue, value, region))
+	}
+}
+
+func (e *ECSExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	clusters, err := client.DescribeECSClustersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeECSClustersAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClustersPerAccountUsage, prometheus.GaugeValue, float64(len(clusters)), region))
+
+	var fargateVCPUs float64
+	fargateComplete := true
+	for _, cluster := range clusters {
+		clusterArn := aws.StringValue(cluster.ClusterArn)
+		clusterName := aws.StringValue(cluster.ClusterName)
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServicesPerClusterUsage, prometheus.GaugeValue, float64(aws.Int64Value(cluster.ActiveServicesCount)), region, clusterName))
+
+		services, err := client.DescribeECSServicesAll(ctx, clusterArn)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeECSServicesAll failed", "region", region, "cluster", clusterName, "err", err)
+			run.fail()
+		} else {
+			for _, service := range services {
+				serviceName := aws.StringValue(service.ServiceName)
+				e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceRunningTasks, prometheus.GaugeValue, float64(aws.Int64Value(service.RunningCount)), region, clusterName, serviceName))
+				e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceDesiredTasks, prometheus.GaugeValue, float64(aws.Int64Value(service.DesiredCount)), region, clusterName, serviceName))
+			}
+		}
+
+		tasks, err := client.DescribeECSRunningTasksAll(ctx, clusterArn)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeECSRunningTasksAll failed", "region", region, "cluster", clusterName, "err", err)
+			run.fail()
+			fargateComplete = false
+			continue
+		}
+		fargateVCPUs += fargateOnDemandVCPUs(tasks)
+	}
+
+	// a partial sum would understate the usage, so it is only exported if the tasks of all clusters are known
+	if fargateComplete {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.FargateOnDemandVCPUUsage, prometheus.GaugeValue, fargateVCPUs, region))
+	}
+}
+
+// fargateOnDemandVCPUs sums up the vCPUs of the tasks which count towards the Fargate On-Demand quota
+func fargateOnDemandVCPUs(tasks []*ecs.Task) float64 {
+	var vCPUs float64
+	for _, task := range tasks {
+		if aws.StringValue(task.LaunchType) != ecs.LaunchTypeFargate || aws.StringValue(task.CapacityProviderName) == fargateSpotCapacityProvider {
+			continue
+		}
+		cpuUnits, err := strconv.ParseFloat(aws.StringValue(task.Cpu), 64)
+		if err != nil {
+			continue
+		}
+		vCPUs += cpuUnits / ecsCPUUnitsPerVCPU
+	}
+	return vCPUs
+}
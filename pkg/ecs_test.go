@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECSCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeECSClustersAll(ctx).Return([]*ecs.Cluster{
+		{ClusterArn: aws.String("arn:a"), ClusterName: aws.String("a"), ActiveServicesCount: aws.Int64(1)},
+	}, nil)
+	mockClient.EXPECT().DescribeECSServicesAll(ctx, "arn:a").Return([]*ecs.Service{
+		{ServiceName: aws.String("svc"), RunningCount: aws.Int64(2), DesiredCount: aws.Int64(3)},
+	}, nil)
+	mockClient.EXPECT().DescribeECSRunningTasksAll(ctx, "arn:a").Return([]*ecs.Task{
+		{LaunchType: aws.String(ecs.LaunchTypeFargate), Cpu: aws.String("512")},
+		{LaunchType: aws.String(ecs.LaunchTypeFargate), Cpu: aws.String("1024")},
+		{LaunchType: aws.String(ecs.LaunchTypeFargate), CapacityProviderName: aws.String(fargateSpotCapacityProvider), Cpu: aws.String("1024")},
+		{LaunchType: aws.String(ecs.LaunchTypeEc2), Cpu: aws.String("1024")},
+	}, nil)
+
+	e := NewECSExporter(nil, log.NewNopLogger(), ECSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ecs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// clusters, services per cluster, 2 metrics for the service and the Fargate vCPUs
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 5)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.ClustersPerAccountUsage.String(), e.ServicesPerClusterUsage.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.ServiceRunningTasks.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.ServiceDesiredTasks.String():
+			assert.Equal(t, 3.0, dtoMetric.GetGauge().GetValue())
+		case e.FargateOnDemandVCPUUsage.String():
+			assert.Equal(t, 1.5, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestECSCollectMetricsTasksFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeECSClustersAll(ctx).Return([]*ecs.Cluster{
+		{ClusterArn: aws.String("arn:a"), ClusterName: aws.String("a"), ActiveServicesCount: aws.Int64(0)},
+	}, nil)
+	mockClient.EXPECT().DescribeECSServicesAll(ctx, "arn:a").Return(nil, nil)
+	mockClient.EXPECT().DescribeECSRunningTasksAll(ctx, "arn:a").Return(nil, errors.New("some error"))
+
+	e := NewECSExporter(nil, log.NewNopLogger(), ECSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ecs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+
+	// the Fargate vCPU usage is not exported if the tasks of a cluster are unknown
+	for _, metric := range e.cache.GetAllMetrics() {
+		assert.NotEqual(t, e.FargateOnDemandVCPUUsage.String(), metric.Desc().String())
+	}
+}
+
+func TestECSCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeECSClustersAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewECSExporter(nil, log.NewNopLogger(), ECSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ecs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const efsServiceCode string = "elasticfilesystem"
+
+type EFSExporter struct {
+	sessions              []*session.Session
+	FileSystemsPerRegion  *prometheus.Desc
+	LifecyclePolicy       *prometheus.Desc
+	Encrypted             *prometheus.Desc
+	ThroughputMode        *prometheus.Desc
+	ProvisionedThroughput *prometheus.Desc
+	MountTargetsPerAZ     *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewEFSExporter creates a new EFSExporter instance
+func NewEFSExporter(sessions []*session.Session, logger log.Logger, config EFSConfig, awsAccountId string) *EFSExporter {
+	level.Info(logger).Log("msg", "Initializing EFS exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: efsServiceCode}
+
+	return &EFSExporter{
+		sessions:              sessions,
+		FileSystemsPerRegion:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_filesystemsperregion_usage"), "Number of EFS file systems in this region", []string{"aws_region"}, constLabels),
+		LifecyclePolicy:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_filesystem_lifecycle_policy"), "Whether the EFS file system has a lifecycle policy", []string{"aws_region", "filesystem_id"}, constLabels),
+		Encrypted:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_filesystem_encrypted"), "Whether the EFS file system is encrypted", []string{"aws_region", "filesystem_id"}, constLabels),
+		ThroughputMode:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_filesystem_throughput_mode"), "The throughput mode of the EFS file system", []string{"aws_region", "filesystem_id", "throughput_mode"}, constLabels),
+		ProvisionedThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_filesystem_provisioned_throughput_mibps"), "The provisioned throughput of the EFS file system in MiB/s", []string{"aws_region", "filesystem_id"}, constLabels),
+		MountTargetsPerAZ:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "efs_mounttargets_usage"), "Number of EFS mount targets per availability zone", []string{"aws_region", "availability_zone"}, constLabels),
+		cache:                 *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                logger,
+		timeout:               *config.Timeout,
+		interval:              *config.Interval,
+	}
+}
+
+func (e *EFSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.FileSystemsPerRegion
+	ch <- e.LifecyclePolicy
+	ch <- e.Encrypted
+	ch <- e.ThroughputMode
+	ch <- e.ProvisionedThroughput
+	ch <- e.MountTargetsPerAZ
+}
+
+func (e *EFSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *EFSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "EFS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "efs")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *EFSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "efs", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *EFSExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	fileSystems, err := client.DescribeFileSystemsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeFileSystemsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.FileSystemsPerRegion, prometheus.GaugeValue, float64(len(fileSystems)), region))
+
+	mountTargetsPerAZ := make(map[string]int)
+	for _, fileSystem := range fileSystems {
+		fileSystemId := aws.StringValue(fileSystem.FileSystemId)
+
+		encrypted := 0.0
+		if aws.BoolValue(fileSystem.Encrypted) {
+			encrypted = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.Encrypted, prometheus.GaugeValue, encrypted, region, fileSystemId))
+
+		throughputMode := aws.StringValue(fileSystem.ThroughputMode)
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ThroughputMode, prometheus.GaugeValue, 1, region, fileSystemId, throughputMode))
+		if throughputMode == efs.ThroughputModeProvisioned {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.ProvisionedThroughput, prometheus.GaugeValue, aws.Float64Value(fileSystem.ProvisionedThroughputInMibps), region, fileSystemId))
+		}
+
+		lifecycle, err := client.DescribeLifecycleConfigurationWithContext(ctx, &efs.DescribeLifecycleConfigurationInput{FileSystemId: fileSystem.FileSystemId})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeLifecycleConfiguration failed", "region", region, "filesystem_id", fileSystemId, "err", err)
+			run.fail()
+		} else {
+			lifecyclePolicy := 0.0
+			if len(lifecycle.LifecyclePolicies) > 0 {
+				lifecyclePolicy = 1
+			}
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.LifecyclePolicy, prometheus.GaugeValue, lifecyclePolicy, region, fileSystemId))
+		}
+
+		// file systems without mount targets don't need to be described
+		if aws.Int64Value(fileSystem.NumberOfMountTargets) == 0 {
+			continue
+		}
+		mountTargets, err := client.DescribeMountTargetsAll(ctx, fileSystemId)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeMountTargetsAll failed", "region", region, "filesystem_id", fileSystemId, "err", err)
+			run.fail()
+			continue
+		}
+		for _, mountTarget := range mountTargets {
+			mountTargetsPerAZ[aws.StringValue(mountTarget.AvailabilityZoneName)]++
+		}
+	}
+
+	for availabilityZone, count := range mountTargetsPerAZ {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.MountTargetsPerAZ, prometheus.GaugeValue, float64(count), region, availabilityZone))
+	}
+}
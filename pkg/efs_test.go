@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEFSCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeFileSystemsAll(ctx).Return([]*efs.FileSystemDescription{
+		{
+			FileSystemId:                 aws.String("fs-1"),
+			Encrypted:                    aws.Bool(true),
+			ThroughputMode:               aws.String(efs.ThroughputModeProvisioned),
+			ProvisionedThroughputInMibps: aws.Float64(128),
+			NumberOfMountTargets:         aws.Int64(2),
+		},
+		{
+			FileSystemId:         aws.String("fs-2"),
+			ThroughputMode:       aws.String(efs.ThroughputModeBursting),
+			NumberOfMountTargets: aws.Int64(0),
+		},
+	}, nil)
+	mockClient.EXPECT().DescribeLifecycleConfigurationWithContext(ctx, &efs.DescribeLifecycleConfigurationInput{FileSystemId: aws.String("fs-1")}).Return(
+		&efs.DescribeLifecycleConfigurationOutput{LifecyclePolicies: []*efs.LifecyclePolicy{{TransitionToIA: aws.String(efs.TransitionToIARulesAfter30Days)}}}, nil)
+	mockClient.EXPECT().DescribeLifecycleConfigurationWithContext(ctx, &efs.DescribeLifecycleConfigurationInput{FileSystemId: aws.String("fs-2")}).Return(
+		&efs.DescribeLifecycleConfigurationOutput{}, nil)
+	// only the file system with mount targets is described
+	mockClient.EXPECT().DescribeMountTargetsAll(ctx, "fs-1").Return([]*efs.MountTargetDescription{
+		{AvailabilityZoneName: aws.String("foo-1a")},
+		{AvailabilityZoneName: aws.String("foo-1b")},
+	}, nil)
+
+	e := NewEFSExporter(nil, log.NewNopLogger(), EFSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("efs", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// file system count, 2x encrypted, throughput mode and lifecycle policy, provisioned throughput of fs-1 and 2 AZs
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 10)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.FileSystemsPerRegion.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.ProvisionedThroughput.String():
+			assert.Equal(t, 128.0, dtoMetric.GetGauge().GetValue())
+		case e.MountTargetsPerAZ.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var EKSInfos *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "eks_info"),
+	"The EKS cluster Kubernetes version, platform version and the eol date and status for the version.",
+	[]string{"aws_region", "cluster_name", "kubernetes_version", "platform_version", "eol_date", "eol_status"},
+	nil,
+)
+
+// eksEOLEngine is the engine the Kubernetes versions are looked up with in the EOL dates
+const eksEOLEngine = "kubernetes"
+
+type EKSExporter struct {
+	sessions   []*session.Session
+	eolChecker *eol.Checker
+	cache      MetricsCache
+
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewEKSExporter creates a new EKSExporter instance
+func NewEKSExporter(sessions []*session.Session, logger log.Logger, config EKSConfig, awsAccountId string, eolChecker *eol.Checker) *EKSExporter {
+	level.Info(logger).Log("msg", "Initializing EKS exporter")
+
+	return &EKSExporter{
+		sessions:   sessions,
+		eolChecker: eolChecker.With(eol.ForEngine(eksEOLEngine, config.EKSInfos), config.Thresholds),
+		cache:      *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:     logger,
+		timeout:    *config.Timeout,
+		interval:   *config.Interval,
+	}
+}
+
+func (e *EKSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- EKSInfos
+}
+
+func (e *EKSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *EKSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "EKS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "eks")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *EKSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "eks", region)
+	defer run.finish()
+
+	clusters, err := getEKSClustersWithContext(client, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve EKS clusters", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.addMetricFromEKSInfo(region, clusters)
+}
+
+func (e *EKSExporter) addMetricFromEKSInfo(region string, clusters []*eks.Cluster) {
+	for _, cluster := range clusters {
+		clusterName := aws.StringValue(cluster.Name)
+		kubernetesVersion := aws.StringValue(cluster.Version)
+		platformVersion := aws.StringValue(cluster.PlatformVersion)
+
+		if eolDate, found := e.eolChecker.Lookup(eksEOLEngine, kubernetesVersion); found {
+			eolStatus, err := e.eolChecker.Status(eolDate)
+			if err != nil {
+				level.Error(e.logger).Log("msg", "Error determining EKS EOL status", "version", kubernetesVersion, "error", err)
+			}
+			e.cache.AddMetric(prometheus.MustNewConstMetric(EKSInfos, prometheus.GaugeValue, 1, region, clusterName, kubernetesVersion, platformVersion, eolDate, eolStatus))
+		} else {
+			level.Info(e.logger).Log("msg", "EOL information not found for EKS version, setting status to 'unknown'", "version", kubernetesVersion)
+			e.cache.AddMetric(prometheus.MustNewConstMetric(EKSInfos, prometheus.GaugeValue, 1, region, clusterName, kubernetesVersion, platformVersion, "no-eol-date", "unknown"))
+		}
+	}
+}
+
+// getEKSClustersWithContext lists the cluster names and describes each cluster, as the list call does not return
+// the versions
+func getEKSClustersWithContext(client awsclient.Client, ctx context.Context) ([]*eks.Cluster, error) {
+	clusterNames, err := client.ListEKSClustersAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []*eks.Cluster
+	for _, clusterName := range clusterNames {
+		output, err := client.DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{Name: clusterName})
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.Cluster)
+	}
+	return clusters, nil
+}
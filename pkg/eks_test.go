@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestEKSClusters() []*eks.Cluster {
+	return []*eks.Cluster{
+		{
+			Name:            aws.String("test-cluster-1"),
+			Version:         aws.String("1.27"),
+			PlatformVersion: aws.String("eks.10"),
+		},
+	}
+}
+
+func createTestEKSExporter(eksInfos []EKSInfo) *EKSExporter {
+	config := EKSConfig{
+		BaseConfig: createTestBaseConfig(),
+		EKSInfos:   eksInfos,
+		Thresholds: []eol.Threshold{
+			{Name: "red", Days: 90},
+			{Name: "yellow", Days: 180},
+			{Name: "green", Days: 365},
+		},
+	}
+	return NewEKSExporter(nil, log.NewNopLogger(), config, "1234567890", nil)
+}
+
+func TestAddEKSMetricsWithEOLMatch(t *testing.T) {
+	e := createTestEKSExporter([]EKSInfo{{Version: "1.27", EOL: "2000-12-01"}})
+
+	e.addMetricFromEKSInfo("foo", createTestEKSClusters())
+
+	labels, err := getEKSMetricLabels(e, EKSInfos, "platform_version", "eol_date", "eol_status")
+	assert.Nil(t, err)
+	assert.Equal(t, "eks.10", labels["platform_version"])
+	assert.Equal(t, "2000-12-01", labels["eol_date"])
+	assert.Equal(t, "red", labels["eol_status"])
+}
+
+func TestAddEKSMetricsWithoutEOLMatch(t *testing.T) {
+	e := createTestEKSExporter([]EKSInfo{{Version: "1.20", EOL: "2000-12-01"}})
+
+	e.addMetricFromEKSInfo("foo", createTestEKSClusters())
+
+	labels, err := getEKSMetricLabels(e, EKSInfos, "eol_date", "eol_status")
+	assert.Nil(t, err)
+	assert.Equal(t, "no-eol-date", labels["eol_date"])
+	assert.Equal(t, "unknown", labels["eol_status"])
+}
+
+func TestGetEKSClustersWithContext(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListEKSClustersAll(ctx).Return([]*string{aws.String("test-cluster-1")}, nil)
+	mockClient.EXPECT().DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{Name: aws.String("test-cluster-1")}).Return(
+		&eks.DescribeClusterOutput{Cluster: createTestEKSClusters()[0]}, nil)
+
+	clusters, err := getEKSClustersWithContext(mockClient, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, "1.27", *clusters[0].Version)
+}
+
+func getEKSMetricLabels(x *EKSExporter, metricDesc *prometheus.Desc, labelNames ...string) (map[string]string, error) {
+	metricDescription := metricDesc.String()
+	metrics := x.cache.GetAllMetrics()
+
+	for _, metric := range metrics {
+		if metric.Desc().String() == metricDescription {
+			dtoMetric := &dto.Metric{}
+			if err := metric.Write(dtoMetric); err != nil {
+				return nil, err
+			}
+
+			labelValues := make(map[string]string)
+			for _, label := range dtoMetric.GetLabel() {
+				for _, labelName := range labelNames {
+					if label.GetName() == labelName {
+						labelValues[labelName] = label.GetValue()
+					}
+				}
+			}
+
+			if len(labelValues) != len(labelNames) {
+				return nil, fmt.Errorf("not all requested labels found in metric")
+			}
+
+			return labelValues, nil
+		}
+	}
+	return nil, fmt.Errorf("metric not found")
+}
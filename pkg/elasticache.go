@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -14,10 +15,67 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	elasticacheServiceCode  string = "elasticache"
+	nodesPerRegionQuotaCode string = "L-8C334AD1"
+	cacheTypeCluster        string = "cluster"
+	cacheTypeServerless     string = "serverless"
+)
+
 var RedisVersion *prometheus.Desc = prometheus.NewDesc(
 	prometheus.BuildFQName(namespace, "", "elasticache_redisversion"),
 	"The ElastiCache engine type and version.",
-	[]string{"aws_region", "replication_group_id", "engine", "engine_version", "aws_account_id"},
+	[]string{"aws_region", "replication_group_id", "engine", "engine_version", "aws_account_id", "cache_type"},
+	nil,
+)
+
+var ElastiCacheEOLInfos *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_eol_info"),
+	"The ElastiCache eol date and status for the engine version.",
+	[]string{"aws_region", "replication_group_id", "engine", "engine_version", "eol_date", "eol_status", "cache_type"},
+	nil,
+)
+
+var CacheNodeType *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_cachenodetype"),
+	"The node type of the cache cluster.",
+	[]string{"aws_region", "cache_cluster_id", "cache_node_type"},
+	nil,
+)
+var CacheNodes *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_cachenodes_total"),
+	"The number of cache nodes of the cache cluster.",
+	[]string{"aws_region", "cache_cluster_id"},
+	nil,
+)
+var CacheClusterStatus *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_cachecluster_status"),
+	"The status of the cache cluster.",
+	[]string{"aws_region", "cache_cluster_id", "cache_cluster_status", "cache_type"},
+	nil,
+)
+var SnapshotRetentionLimit *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_snapshot_retention_limit_days"),
+	"The number of days automatic snapshots of the cache cluster are retained.",
+	[]string{"aws_region", "cache_cluster_id"},
+	nil,
+)
+var AuthTokenEnabled *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_authtoken_enabled"),
+	"Indicates if an auth token (password) is required to run commands on the cache cluster",
+	[]string{"aws_region", "cache_cluster_id"},
+	nil,
+)
+var TransitEncryptionEnabled *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_transit_encryption_enabled"),
+	"Indicates if in-transit encryption is enabled for the cache cluster",
+	[]string{"aws_region", "cache_cluster_id"},
+	nil,
+)
+var AtRestEncryptionEnabled *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "elasticache_atrest_encryption_enabled"),
+	"Indicates if at-rest encryption is enabled for the cache cluster",
+	[]string{"aws_region", "cache_cluster_id"},
 	nil,
 )
 
@@ -26,6 +84,11 @@ type ElastiCacheExporter struct {
 	svcs         []awsclient.Client
 	cache        MetricsCache
 	awsAccountId string
+	eolChecker   *eol.Checker
+
+	NodesPerRegionQuota    *prometheus.Desc
+	NodesPerRegionUsage    *prometheus.Desc
+	ClustersPerRegionUsage *prometheus.Desc
 
 	logger   log.Logger
 	timeout  time.Duration
@@ -33,8 +96,9 @@ type ElastiCacheExporter struct {
 }
 
 // NewElastiCacheExporter creates a new ElastiCacheExporter instance
-func NewElastiCacheExporter(sessions []*session.Session, logger log.Logger, config ElastiCacheConfig, awsAccountId string) *ElastiCacheExporter {
+func NewElastiCacheExporter(sessions []*session.Session, logger log.Logger, config ElastiCacheConfig, awsAccountId string, eolChecker *eol.Checker) *ElastiCacheExporter {
 	level.Info(logger).Log("msg", "Initializing ElastiCache exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: elasticacheServiceCode}
 
 	var elasticaches []awsclient.Client
 	for _, session := range sessions {
@@ -44,11 +108,16 @@ func NewElastiCacheExporter(sessions []*session.Session, logger log.Logger, conf
 	return &ElastiCacheExporter{
 		sessions:     sessions,
 		svcs:         elasticaches,
-		cache:        *NewMetricsCache(*config.CacheTTL),
+		cache:        *NewMetricsCacheFromConfig(config.BaseConfig),
 		logger:       logger,
 		timeout:      *config.Timeout,
 		interval:     *config.Interval,
 		awsAccountId: awsAccountId,
+		eolChecker:   eolChecker.With(config.EOLInfos, config.Thresholds),
+
+		NodesPerRegionQuota:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elasticache_nodesperregion_quota"), "Quota for maximum number of ElastiCache nodes in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, nodesPerRegionQuotaCode)),
+		NodesPerRegionUsage:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elasticache_nodesperregion_usage"), "Number of ElastiCache nodes in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, nodesPerRegionQuotaCode)),
+		ClustersPerRegionUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elasticache_clustersperregion_usage"), "Number of ElastiCache cache clusters in this region", []string{"aws_region"}, constLabels),
 	}
 }
 
@@ -65,12 +134,101 @@ func (e *ElastiCacheExporter) addMetricFromElastiCacheInfo(sessionIndex int, clu
 		engine := aws.StringValue(cluster.Engine)
 		engineVersion := aws.StringValue(cluster.EngineVersion)
 
-		e.cache.AddMetric(prometheus.MustNewConstMetric(RedisVersion, prometheus.GaugeValue, 1, region, replicationGroupId, engine, engineVersion, e.awsAccountId))
+		e.addVersionMetrics(region, replicationGroupId, engine, engineVersion, cacheTypeCluster)
+		e.addCacheClusterDetailMetrics(region, cluster)
+	}
+}
+
+// addMetricFromServerlessCaches adds the version and status of serverless caches. They have no replication group or
+// cache cluster, the id labels carry the name of the serverless cache instead.
+func (e *ElastiCacheExporter) addMetricFromServerlessCaches(sessionIndex int, caches []*elasticache.ServerlessCache) {
+	region := e.getRegion(sessionIndex)
+
+	for _, serverlessCache := range caches {
+		name := aws.StringValue(serverlessCache.ServerlessCacheName)
+
+		e.addVersionMetrics(region, name, aws.StringValue(serverlessCache.Engine), aws.StringValue(serverlessCache.FullEngineVersion), cacheTypeServerless)
+		e.cache.AddMetric(prometheus.MustNewConstMetric(CacheClusterStatus, prometheus.GaugeValue, 1, region, name, aws.StringValue(serverlessCache.Status), cacheTypeServerless))
 	}
 }
 
+func (e *ElastiCacheExporter) addVersionMetrics(region string, id string, engine string, engineVersion string, cacheType string) {
+	e.cache.AddMetric(prometheus.MustNewConstMetric(RedisVersion, prometheus.GaugeValue, 1, region, id, engine, engineVersion, e.awsAccountId, cacheType))
+
+	if eolDate, found := e.eolChecker.Lookup(engine, engineVersion); found {
+		eolStatus, err := e.eolChecker.Status(eolDate)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Error determining ElastiCache EOL status", "engine", engine, "version", engineVersion, "error", err)
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(ElastiCacheEOLInfos, prometheus.GaugeValue, 1, region, id, engine, engineVersion, eolDate, eolStatus, cacheType))
+	} else {
+		level.Debug(e.logger).Log("msg", "EOL information not found for ElastiCache version, setting status to 'unknown'", "engine", engine, "version", engineVersion)
+		e.cache.AddMetric(prometheus.MustNewConstMetric(ElastiCacheEOLInfos, prometheus.GaugeValue, 1, region, id, engine, engineVersion, "no-eol-date", "unknown", cacheType))
+	}
+}
+
+// addCacheClusterDetailMetrics adds the node and security settings of the cache cluster
+func (e *ElastiCacheExporter) addCacheClusterDetailMetrics(region string, cluster *elasticache.CacheCluster) {
+	cacheClusterId := aws.StringValue(cluster.CacheClusterId)
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(CacheNodeType, prometheus.GaugeValue, 1, region, cacheClusterId, aws.StringValue(cluster.CacheNodeType)))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(CacheNodes, prometheus.GaugeValue, float64(aws.Int64Value(cluster.NumCacheNodes)), region, cacheClusterId))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(CacheClusterStatus, prometheus.GaugeValue, 1, region, cacheClusterId, aws.StringValue(cluster.CacheClusterStatus), cacheTypeCluster))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(SnapshotRetentionLimit, prometheus.GaugeValue, float64(aws.Int64Value(cluster.SnapshotRetentionLimit)), region, cacheClusterId))
+
+	var authToken = 0.0
+	if aws.BoolValue(cluster.AuthTokenEnabled) {
+		authToken = 1.0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(AuthTokenEnabled, prometheus.GaugeValue, authToken, region, cacheClusterId))
+
+	var transitEncryption = 0.0
+	if aws.BoolValue(cluster.TransitEncryptionEnabled) {
+		transitEncryption = 1.0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(TransitEncryptionEnabled, prometheus.GaugeValue, transitEncryption, region, cacheClusterId))
+
+	var atRestEncryption = 0.0
+	if aws.BoolValue(cluster.AtRestEncryptionEnabled) {
+		atRestEncryption = 1.0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(AtRestEncryptionEnabled, prometheus.GaugeValue, atRestEncryption, region, cacheClusterId))
+}
+
+// addQuotaMetrics adds the nodes per region quota and the node and cluster usage. The nodes of every cluster count
+// against the nodes quota, so the clusters are only exported as usage.
+func (e *ElastiCacheExporter) addQuotaMetrics(ctx context.Context, sessionIndex int, clusters []*elasticache.CacheCluster) error {
+	region := e.getRegion(sessionIndex)
+
+	var nodes int64
+	for _, cluster := range clusters {
+		nodes += aws.Int64Value(cluster.NumCacheNodes)
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.NodesPerRegionUsage, prometheus.GaugeValue, float64(nodes), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClustersPerRegionUsage, prometheus.GaugeValue, float64(len(clusters)), region))
+
+	quota, err := getQuotaValueWithContext(e.svcs[sessionIndex], elasticacheServiceCode, nodesPerRegionQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve ElastiCache nodes quota", "region", region, "err", err)
+		return err
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.NodesPerRegionQuota, prometheus.GaugeValue, quota, region))
+	return nil
+}
+
 func (e *ElastiCacheExporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- RedisVersion
+	ch <- ElastiCacheEOLInfos
+	ch <- CacheNodeType
+	ch <- CacheNodes
+	ch <- CacheClusterStatus
+	ch <- SnapshotRetentionLimit
+	ch <- AuthTokenEnabled
+	ch <- TransitEncryptionEnabled
+	ch <- AtRestEncryptionEnabled
+	ch <- e.NodesPerRegionQuota
+	ch <- e.NodesPerRegionUsage
+	ch <- e.ClustersPerRegionUsage
 }
 
 func (e *ElastiCacheExporter) Collect(ch chan<- prometheus.Metric) {
@@ -79,20 +237,41 @@ func (e *ElastiCacheExporter) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
-func (e *ElastiCacheExporter) CollectLoop() {
+func (e *ElastiCacheExporter) CollectLoop(ctx context.Context) {
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
 		for i, client := range e.svcs {
-			clusters, err := client.DescribeCacheClustersAll(ctx)
+			collectCtx, run := e.cache.startCollectorRun(collectCtx, "elasticache", e.getRegion(i))
+			clusters, err := client.DescribeCacheClustersAll(collectCtx)
 			if err != nil {
 				level.Error(e.logger).Log("msg", "Call to DescribeCacheClustersAll failed", "region", *e.sessions[i].Config.Region, "err", err)
+				run.fail()
+				run.finish()
 				continue
 			}
 			e.addMetricFromElastiCacheInfo(i, clusters)
+			if err := e.addQuotaMetrics(collectCtx, i, clusters); err != nil {
+				run.fail()
+			}
+
+			serverlessCaches, err := client.DescribeServerlessCachesAll(collectCtx)
+			if err != nil {
+				level.Error(e.logger).Log("msg", "Call to DescribeServerlessCachesAll failed", "region", e.getRegion(i), "err", err)
+				run.fail()
+			} else {
+				e.addMetricFromServerlessCaches(i, serverlessCaches)
+			}
+			run.finish()
 		}
 		level.Info(e.logger).Log("msg", "ElastiCache metrics updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "elasticache")
+		}
 
 		cancel()
-		time.Sleep(e.interval)
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
 	}
 }
@@ -1,13 +1,21 @@
 package pkg
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,5 +42,158 @@ func TestAddMetricFromElastiCacheInfo(t *testing.T) {
 	assert.Len(t, x.cache.GetAllMetrics(), 0)
 
 	x.addMetricFromElastiCacheInfo(0, createTestCacheClusters())
-	assert.Len(t, x.cache.GetAllMetrics(), 1)
+	assert.Len(t, x.cache.GetAllMetrics(), 9)
+}
+
+func TestAddCacheClusterDetailMetrics(t *testing.T) {
+	x := ElastiCacheExporter{
+		cache:  *NewMetricsCache(10 * time.Second),
+		logger: log.NewNopLogger(),
+	}
+
+	x.addCacheClusterDetailMetrics("foo", &elasticache.CacheCluster{
+		CacheClusterId:           aws.String("test-cluster"),
+		CacheClusterStatus:       aws.String("available"),
+		CacheNodeType:            aws.String("cache.r6g.large"),
+		NumCacheNodes:            aws.Int64(2),
+		SnapshotRetentionLimit:   aws.Int64(7),
+		AuthTokenEnabled:         aws.Bool(true),
+		TransitEncryptionEnabled: aws.Bool(true),
+	})
+
+	expected := map[string]float64{
+		CacheNodes.String():               2,
+		SnapshotRetentionLimit.String():   7,
+		AuthTokenEnabled.String():         1,
+		TransitEncryptionEnabled.String(): 1,
+		AtRestEncryptionEnabled.String():  0,
+	}
+	metrics := x.cache.GetAllMetrics()
+	assert.Len(t, metrics, 7)
+	for _, metric := range metrics {
+		if value, ok := expected[metric.Desc().String()]; ok {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, value, dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+		}
+	}
+}
+
+func TestAddMetricFromElastiCacheInfoWithEOLMatch(t *testing.T) {
+	x := ElastiCacheExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+		eolChecker: eol.NewChecker([]eol.Info{
+			{Engine: "redis", Version: "123", EOL: "2000-12-01"},
+		}, eol.DefaultThresholds(), nil),
+	}
+
+	x.addMetricFromElastiCacheInfo(0, createTestCacheClusters())
+
+	for _, metric := range x.cache.GetAllMetrics() {
+		if metric.Desc().String() != ElastiCacheEOLInfos.String() {
+			continue
+		}
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := make(map[string]string)
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		assert.Equal(t, "2000-12-01", labels["eol_date"])
+		assert.Equal(t, "red", labels["eol_status"])
+		return
+	}
+	t.Errorf("EOL metric not found")
+}
+
+func TestNewElastiCacheExporterEOLInfos(t *testing.T) {
+	config := ElastiCacheConfig{
+		BaseConfig: createTestBaseConfig(),
+		EOLInfos:   []eol.Info{{Engine: "redis", Version: "123", EOL: "2000-12-01"}},
+		Thresholds: []eol.Threshold{{Name: "expired", Days: 0}},
+	}
+	shared := eol.NewChecker([]eol.Info{{Engine: "redis", Version: "123", EOL: "2100-12-01"}}, eol.DefaultThresholds(), nil)
+	x := NewElastiCacheExporter([]*session.Session{session.New(&aws.Config{Region: aws.String("foo")})}, log.NewNopLogger(), config, "1234567890", shared)
+
+	// the eol_info and thresholds of the ElastiCache config take precedence over the shared ones
+	eolDate, found := x.eolChecker.Lookup("redis", "123")
+	assert.True(t, found)
+	assert.Equal(t, "2000-12-01", eolDate)
+	status, err := x.eolChecker.Status(eolDate)
+	assert.Nil(t, err)
+	assert.Equal(t, "expired", status)
+}
+
+func TestAddElastiCacheQuotaMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := NewElastiCacheExporter([]*session.Session{session.New(&aws.Config{Region: aws.String("foo")})}, log.NewNopLogger(), ElastiCacheConfig{BaseConfig: createTestBaseConfig()}, "1234567890", nil)
+	x.svcs = []awsclient.Client{mockClient}
+
+	clusters := []*elasticache.CacheCluster{
+		{CacheClusterId: aws.String("a"), NumCacheNodes: aws.Int64(2)},
+		{CacheClusterId: aws.String("b"), NumCacheNodes: aws.Int64(1)},
+	}
+
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(elasticacheServiceCode, nodesPerRegionQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(300)}}, nil)
+	assert.Nil(t, x.addQuotaMetrics(ctx, 0, clusters))
+
+	expected := map[string]float64{
+		x.NodesPerRegionQuota.String():    300,
+		x.NodesPerRegionUsage.String():    3,
+		x.ClustersPerRegionUsage.String(): 2,
+	}
+	metrics := x.cache.GetAllMetrics()
+	assert.Len(t, metrics, 3)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		assert.Equal(t, expected[metric.Desc().String()], dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+	}
+
+	// the usage is still exported if the quota can't be retrieved
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, gomock.Any()).Return(nil, errors.New("some error"))
+	x.cache = *NewMetricsCache(10 * time.Second)
+	assert.NotNil(t, x.addQuotaMetrics(ctx, 0, clusters))
+	assert.Len(t, x.cache.GetAllMetrics(), 2)
+}
+
+func TestAddMetricFromServerlessCaches(t *testing.T) {
+	x := ElastiCacheExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	x.addMetricFromServerlessCaches(0, []*elasticache.ServerlessCache{
+		{
+			ServerlessCacheName: aws.String("test-serverless"),
+			Engine:              aws.String("valkey"),
+			FullEngineVersion:   aws.String("7.2"),
+			Status:              aws.String("available"),
+		},
+	})
+
+	metrics := x.cache.GetAllMetrics()
+	// engine version, EOL info and status
+	assert.Len(t, metrics, 3)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := make(map[string]string)
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		assert.Equal(t, "serverless", labels["cache_type"], metric.Desc().String())
+		if metric.Desc().String() == RedisVersion.String() {
+			assert.Equal(t, "valkey", labels["engine"])
+			assert.Equal(t, "7.2", labels["engine_version"])
+		}
+	}
 }
@@ -0,0 +1,195 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	elbServiceCode                               string = "elasticloadbalancing"
+	applicationLoadBalancersPerRegionQuotaCode   string = "L-53DA6B97"
+	networkLoadBalancersPerRegionQuotaCode       string = "L-69A177A2"
+	listenersPerApplicationLoadBalancerQuotaCode string = "L-B6DF7632"
+	listenersPerNetworkLoadBalancerQuotaCode     string = "L-57A373D6"
+	targetGroupsPerRegionQuotaCode               string = "L-B22855CB"
+)
+
+type ELBExporter struct {
+	sessions                                 []*session.Session
+	ApplicationLoadBalancersPerRegionQuota   *prometheus.Desc
+	ApplicationLoadBalancersPerRegionUsage   *prometheus.Desc
+	NetworkLoadBalancersPerRegionQuota       *prometheus.Desc
+	NetworkLoadBalancersPerRegionUsage       *prometheus.Desc
+	ListenersPerApplicationLoadBalancerQuota *prometheus.Desc
+	ListenersPerApplicationLoadBalancerUsage *prometheus.Desc
+	ListenersPerNetworkLoadBalancerQuota     *prometheus.Desc
+	ListenersPerNetworkLoadBalancerUsage     *prometheus.Desc
+	TargetGroupsPerRegionQuota               *prometheus.Desc
+	TargetGroupsPerRegionUsage               *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewELBExporter creates a new ELBExporter instance
+func NewELBExporter(sessions []*session.Session, logger log.Logger, config ELBConfig, awsAccountId string) *ELBExporter {
+	level.Info(logger).Log("msg", "Initializing ELB exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: elbServiceCode}
+
+	return &ELBExporter{
+		sessions:                                 sessions,
+		ApplicationLoadBalancersPerRegionQuota:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_applicationloadbalancersperregion_quota"), "Quota for maximum number of Application Load Balancers in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, applicationLoadBalancersPerRegionQuotaCode)),
+		ApplicationLoadBalancersPerRegionUsage:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_applicationloadbalancersperregion_usage"), "Number of Application Load Balancers in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, applicationLoadBalancersPerRegionQuotaCode)),
+		NetworkLoadBalancersPerRegionQuota:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_networkloadbalancersperregion_quota"), "Quota for maximum number of Network Load Balancers in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, networkLoadBalancersPerRegionQuotaCode)),
+		NetworkLoadBalancersPerRegionUsage:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_networkloadbalancersperregion_usage"), "Number of Network Load Balancers in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, networkLoadBalancersPerRegionQuotaCode)),
+		ListenersPerApplicationLoadBalancerQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_listenersperapplicationloadbalancer_quota"), "Quota for maximum number of listeners per Application Load Balancer", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, listenersPerApplicationLoadBalancerQuotaCode)),
+		ListenersPerApplicationLoadBalancerUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_listenersperapplicationloadbalancer_usage"), "Number of listeners of the Application Load Balancer", []string{"aws_region", "load_balancer_name"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, listenersPerApplicationLoadBalancerQuotaCode)),
+		ListenersPerNetworkLoadBalancerQuota:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_listenerspernetworkloadbalancer_quota"), "Quota for maximum number of listeners per Network Load Balancer", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, listenersPerNetworkLoadBalancerQuotaCode)),
+		ListenersPerNetworkLoadBalancerUsage:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_listenerspernetworkloadbalancer_usage"), "Number of listeners of the Network Load Balancer", []string{"aws_region", "load_balancer_name"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, listenersPerNetworkLoadBalancerQuotaCode)),
+		TargetGroupsPerRegionQuota:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_targetgroupsperregion_quota"), "Quota for maximum number of target groups in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, targetGroupsPerRegionQuotaCode)),
+		TargetGroupsPerRegionUsage:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "elb_targetgroupsperregion_usage"), "Number of target groups in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, targetGroupsPerRegionQuotaCode)),
+		cache:                                    *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                                   logger,
+		timeout:                                  *config.Timeout,
+		interval:                                 *config.Interval,
+	}
+}
+
+func (e *ELBExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ApplicationLoadBalancersPerRegionQuota
+	ch <- e.ApplicationLoadBalancersPerRegionUsage
+	ch <- e.NetworkLoadBalancersPerRegionQuota
+	ch <- e.NetworkLoadBalancersPerRegionUsage
+	ch <- e.ListenersPerApplicationLoadBalancerQuota
+	ch <- e.ListenersPerApplicationLoadBalancerUsage
+	ch <- e.ListenersPerNetworkLoadBalancerQuota
+	ch <- e.ListenersPerNetworkLoadBalancerUsage
+	ch <- e.TargetGroupsPerRegionQuota
+	ch <- e.TargetGroupsPerRegionUsage
+}
+
+func (e *ELBExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ELBExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "ELB metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "elb")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ELBExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "elb", region)
+	defer run.finish()
+
+	e.collectQuotas(client, ctx, region, run)
+
+	loadBalancers, err := client.DescribeLoadBalancersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeLoadBalancersAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.addLoadBalancerMetrics(region, loadBalancers)
+		for _, loadBalancer := range loadBalancers {
+			e.collectListenersUsage(client, ctx, region, loadBalancer, run)
+		}
+	}
+
+	targetGroups, err := client.DescribeTargetGroupsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeTargetGroupsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TargetGroupsPerRegionUsage, prometheus.GaugeValue, float64(len(targetGroups)), region))
+}
+
+func (e *ELBExporter) collectQuotas(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	quotas := map[string]*prometheus.Desc{
+		applicationLoadBalancersPerRegionQuotaCode:   e.ApplicationLoadBalancersPerRegionQuota,
+		networkLoadBalancersPerRegionQuotaCode:       e.NetworkLoadBalancersPerRegionQuota,
+		listenersPerApplicationLoadBalancerQuotaCode: e.ListenersPerApplicationLoadBalancerQuota,
+		listenersPerNetworkLoadBalancerQuotaCode:     e.ListenersPerNetworkLoadBalancerQuota,
+		targetGroupsPerRegionQuotaCode:               e.TargetGroupsPerRegionQuota,
+	}
+
+	for quotaCode, desc := range quotas {
+		quota, err := getQuotaValueWithContext(client, elbServiceCode, quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve ELB quota", "region", region, "quota-code", quotaCode, "err", err)
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, quota, region))
+	}
+}
+
+func (e *ELBExporter) collectListenersUsage(client awsclient.Client, ctx context.Context, region string, loadBalancer *elbv2.LoadBalancer, run *collectorRun) {
+	var usageDesc *prometheus.Desc
+	switch aws.StringValue(loadBalancer.Type) {
+	case elbv2.LoadBalancerTypeEnumApplication:
+		usageDesc = e.ListenersPerApplicationLoadBalancerUsage
+	case elbv2.LoadBalancerTypeEnumNetwork:
+		usageDesc = e.ListenersPerNetworkLoadBalancerUsage
+	default:
+		return
+	}
+
+	listeners, err := client.DescribeListenersAll(ctx, aws.StringValue(loadBalancer.LoadBalancerArn))
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeListenersAll failed", "region", region, "load_balancer", aws.StringValue(loadBalancer.LoadBalancerName), "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(usageDesc, prometheus.GaugeValue, float64(len(listeners)), region, aws.StringValue(loadBalancer.LoadBalancerName)))
+}
+
+func (e *ELBExporter) addLoadBalancerMetrics(region string, loadBalancers []*elbv2.LoadBalancer) {
+	counts := countLoadBalancersByType(loadBalancers)
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ApplicationLoadBalancersPerRegionUsage, prometheus.GaugeValue, float64(counts[elbv2.LoadBalancerTypeEnumApplication]), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.NetworkLoadBalancersPerRegionUsage, prometheus.GaugeValue, float64(counts[elbv2.LoadBalancerTypeEnumNetwork]), region))
+}
+
+func countLoadBalancersByType(loadBalancers []*elbv2.LoadBalancer) map[string]int {
+	counts := make(map[string]int)
+	for _, loadBalancer := range loadBalancers {
+		counts[aws.StringValue(loadBalancer.Type)]++
+	}
+	return counts
+}
@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestLoadBalancers() []*elbv2.LoadBalancer {
+	return []*elbv2.LoadBalancer{
+		{LoadBalancerName: aws.String("alb-1"), Type: aws.String(elbv2.LoadBalancerTypeEnumApplication)},
+		{LoadBalancerName: aws.String("alb-2"), Type: aws.String(elbv2.LoadBalancerTypeEnumApplication)},
+		{LoadBalancerName: aws.String("nlb-1"), Type: aws.String(elbv2.LoadBalancerTypeEnumNetwork)},
+		{LoadBalancerName: aws.String("gwlb-1"), Type: aws.String(elbv2.LoadBalancerTypeEnumGateway)},
+	}
+}
+
+func TestCountLoadBalancersByType(t *testing.T) {
+	counts := countLoadBalancersByType(createTestLoadBalancers())
+	assert.Equal(t, 2, counts[elbv2.LoadBalancerTypeEnumApplication])
+	assert.Equal(t, 1, counts[elbv2.LoadBalancerTypeEnumNetwork])
+	assert.Equal(t, 1, counts[elbv2.LoadBalancerTypeEnumGateway])
+}
+
+func TestAddLoadBalancerMetrics(t *testing.T) {
+	e := NewELBExporter(nil, log.NewNopLogger(), ELBConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+
+	e.addLoadBalancerMetrics("foo", []*elbv2.LoadBalancer{})
+	assert.Len(t, e.cache.GetAllMetrics(), 2)
+
+	e.addLoadBalancerMetrics("foo", createTestLoadBalancers())
+	assert.Len(t, e.cache.GetAllMetrics(), 2)
+}
@@ -0,0 +1,148 @@
+// Package eol resolves the end of life (EOL) dates and status of engine versions. It is shared by the exporters, which
+// report the EOL status of the resources they export.
+package eol
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type Threshold struct {
+	Name string `yaml:"name"`
+	Days int    `yaml:"days"`
+}
+
+// Info is a statically configured EOL date of an engine version
+type Info struct {
+	Engine  string `yaml:"engine"`
+	EOL     string `yaml:"eol"`
+	Version string `yaml:"version"`
+}
+
+// VersionInfo is a statically configured EOL date of exporters with a single engine, e.g. MSK or EKS
+type VersionInfo struct {
+	EOL     string `yaml:"eol"`
+	Version string `yaml:"version"`
+}
+
+type Key struct {
+	Engine  string
+	Version string
+}
+
+// Config is the EOL configuration shared by all exporters. Exporters can still configure their own EOL dates and
+// thresholds, which take precedence.
+type Config struct {
+	Thresholds []Threshold  `yaml:"thresholds"`
+	EOLInfos   []Info       `yaml:"eol_info"`
+	Source     SourceConfig `yaml:"source"`
+}
+
+// DefaultThresholds are used when no thresholds are configured
+func DefaultThresholds() []Threshold {
+	return []Threshold{
+		{Name: "red", Days: 90},
+		{Name: "yellow", Days: 180},
+		{Name: "green", Days: 365},
+	}
+}
+
+// ForEngine sets the engine of EOL dates which are configured per version only
+func ForEngine(engine string, versionInfos []VersionInfo) []Info {
+	var infos []Info
+	for _, versionInfo := range versionInfos {
+		infos = append(infos, Info{Engine: engine, EOL: versionInfo.EOL, Version: versionInfo.Version})
+	}
+	return infos
+}
+
+// GetStatus determines status from the number of days until EOL
+func GetStatus(eol string, thresholds []Threshold) (string, error) {
+	eolDate, err := time.Parse(dateLayout, eol)
+	if err != nil {
+		return "", err
+	}
+
+	if len(thresholds) == 0 {
+		return "", errors.New("thresholds slice is empty")
+	}
+
+	currentDate := time.Now()
+	daysToEOL := int(eolDate.Sub(currentDate).Hours() / 24)
+
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i].Days < thresholds[j].Days
+	})
+
+	for _, threshold := range thresholds {
+		if daysToEOL <= threshold.Days {
+			return threshold.Name, nil
+		}
+	}
+	return thresholds[len(thresholds)-1].Name, nil
+}
+
+// Checker looks up the EOL dates of engine versions, first in the static EOL dates and then in the EOL source, if any.
+// A nil Checker knows no EOL dates.
+type Checker struct {
+	eolDates   map[Key]string
+	thresholds []Threshold
+	source     *Source
+}
+
+func NewChecker(infos []Info, thresholds []Threshold, source *Source) *Checker {
+	eolDates := make(map[Key]string)
+	for _, info := range infos {
+		eolDates[Key{Engine: info.Engine, Version: info.Version}] = info.EOL
+	}
+	return &Checker{
+		eolDates:   eolDates,
+		thresholds: thresholds,
+		source:     source,
+	}
+}
+
+// With returns a Checker for a single exporter. Its EOL dates take precedence over the shared ones, the thresholds
+// replace the shared ones unless they are empty.
+func (c *Checker) With(infos []Info, thresholds []Threshold) *Checker {
+	if c == nil {
+		return NewChecker(infos, thresholds, nil)
+	}
+	eolDates := make(map[Key]string)
+	for key, eolDate := range c.eolDates {
+		eolDates[key] = eolDate
+	}
+	for _, info := range infos {
+		eolDates[Key{Engine: info.Engine, Version: info.Version}] = info.EOL
+	}
+	if len(thresholds) == 0 {
+		thresholds = c.thresholds
+	}
+	return &Checker{
+		eolDates:   eolDates,
+		thresholds: thresholds,
+		source:     c.source,
+	}
+}
+
+// Lookup returns the EOL date of the engine version
+func (c *Checker) Lookup(engine string, version string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	if eolDate, found := c.eolDates[Key{Engine: engine, Version: version}]; found {
+		return eolDate, true
+	}
+	return c.source.Lookup(engine, version)
+}
+
+// Status determines the status of the EOL date with the thresholds of the Checker
+func (c *Checker) Status(eolDate string) (string, error) {
+	if c == nil {
+		return GetStatus(eolDate, nil)
+	}
+	return GetStatus(eolDate, c.thresholds)
+}
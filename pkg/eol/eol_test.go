@@ -0,0 +1,105 @@
+package eol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatus(t *testing.T) {
+	thresholds := []Threshold{
+		{Name: "red", Days: 90},
+		{Name: "yellow", Days: 180},
+		{Name: "green", Days: 365},
+	}
+
+	// EOL date is within 90 days
+	eol := time.Now().Add(2 * 24 * time.Hour).Format("2006-01-02")
+	expectedStatus := "red"
+	status, err := GetStatus(eol, thresholds)
+	if err != nil {
+		t.Errorf("Expected no error, but got an error: %v", err)
+	}
+	if status != expectedStatus {
+		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
+	}
+
+	// EOL date is within 180 days
+	eol = time.Now().Add(120 * 24 * time.Hour).Format("2006-01-02")
+	expectedStatus = "yellow"
+	status, err = GetStatus(eol, thresholds)
+	if err != nil {
+		t.Errorf("Expected no error, but got an error: %v", err)
+	}
+	if status != expectedStatus {
+		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
+	}
+
+	// EOL date is more than 180 days
+	eol = time.Now().Add(200 * 24 * time.Hour).Format("2006-01-02")
+	expectedStatus = "green"
+	status, err = GetStatus(eol, thresholds)
+	if err != nil {
+		t.Errorf("Expected no error, but got an error: %v", err)
+	}
+	if status != expectedStatus {
+		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
+	}
+
+	//EOL date exceeds highest threshold
+	eol = time.Now().Add(400 * 24 * time.Hour).Format("2006-01-02")
+	expectedStatus = "green"
+	status, err = GetStatus(eol, thresholds)
+	if err != nil {
+		t.Errorf("Expected no error, but got an error: %v", err)
+	}
+	if status != expectedStatus {
+		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
+	}
+
+	//Thresholds is empty
+	eol = time.Now().Add(30 * 24 * time.Hour).Format("2006-01-02")
+	emptyThresholds := []Threshold{}
+	status, err = GetStatus(eol, emptyThresholds)
+	if err == nil {
+		t.Errorf("Expected an error for empty thresholds, but got none")
+	}
+	if status != "" {
+		t.Errorf("Expected no status for empty thresholds, but got '%s'", status)
+	}
+}
+
+func TestCheckerWith(t *testing.T) {
+	shared := NewChecker([]Info{
+		{Engine: "postgres", Version: "13.7", EOL: "2025-02-28"},
+		{Engine: "kafka", Version: "2.8.1", EOL: "2024-09-11"},
+	}, DefaultThresholds(), nil)
+
+	checker := shared.With([]Info{{Engine: "postgres", Version: "13.7", EOL: "2026-02-28"}}, nil)
+
+	eolDate, found := checker.Lookup("postgres", "13.7")
+	assert.True(t, found)
+	assert.Equal(t, "2026-02-28", eolDate)
+	eolDate, found = checker.Lookup("kafka", "2.8.1")
+	assert.True(t, found)
+	assert.Equal(t, "2024-09-11", eolDate)
+	_, found = checker.Lookup("mysql", "8.0.35")
+	assert.False(t, found)
+
+	// the shared thresholds are used unless the exporter configures its own
+	status, err := checker.Status("2000-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, "red", status)
+	status, err = shared.With(nil, []Threshold{{Name: "expired", Days: 0}}).Status("2000-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, "expired", status)
+
+	// the shared EOL dates are not changed
+	eolDate, _ = shared.Lookup("postgres", "13.7")
+	assert.Equal(t, "2025-02-28", eolDate)
+
+	var disabled *Checker
+	_, found = disabled.With(nil, nil).Lookup("postgres", "13.7")
+	assert.False(t, found)
+}
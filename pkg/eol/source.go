@@ -0,0 +1,167 @@
+package eol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+const DEFAULT_SOURCE_URL = "https://endoflife.date/api"
+
+// DefaultSourceProducts maps the RDS engines to the endoflife.date products
+var DefaultSourceProducts = map[string]string{
+	"postgres":          "amazon-rds-postgresql",
+	"mysql":             "amazon-rds-mysql",
+	"mariadb":           "amazon-rds-mariadb",
+	"aurora-postgresql": "amazon-aurora-postgresql",
+	"aurora-mysql":      "amazon-aurora-mysql",
+}
+
+// SourceConfig configures the EOL dates fetched from endoflife.date or a compatible HTTPS JSON endpoint
+type SourceConfig struct {
+	Enabled  bool           `yaml:"enabled"`
+	URL      string         `yaml:"url"`
+	Interval *time.Duration `yaml:"interval"`
+	Timeout  *time.Duration `yaml:"timeout"`
+	// Products maps the engines of the exporters to the products of the endpoint
+	Products map[string]string `yaml:"products"`
+}
+
+// eolCycle is a release cycle of the endoflife.date API. The eol field is either a date or a boolean.
+type eolCycle struct {
+	Cycle string          `json:"cycle"`
+	EOL   json.RawMessage `json:"eol"`
+}
+
+// Source periodically fetches the EOL dates of the configured products. The dates are only used for engine versions
+// which are not part of the static EOL dates.
+type Source struct {
+	client   *http.Client
+	url      string
+	products map[string]string
+
+	mutex sync.RWMutex
+	// eolDates maps engine -> release cycle -> EOL date
+	eolDates map[string]map[string]string
+
+	// onUpdate is called after all products were fetched successfully
+	onUpdate func()
+
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewSource creates a new Source instance
+func NewSource(logger log.Logger, config SourceConfig, onUpdate func()) *Source {
+	level.Info(logger).Log("msg", "Initializing EOL source", "url", config.URL)
+	return &Source{
+		client:   &http.Client{},
+		url:      strings.TrimSuffix(config.URL, "/"),
+		products: config.Products,
+		eolDates: make(map[string]map[string]string),
+		onUpdate: onUpdate,
+		logger:   logger,
+		timeout:  *config.Timeout,
+		interval: *config.Interval,
+	}
+}
+
+func (s *Source) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		failed := false
+		for engine, product := range s.products {
+			eolDates, err := s.fetchEOLDates(collectCtx, product)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "Could not fetch EOL dates", "product", product, "err", err)
+				failed = true
+				continue
+			}
+			s.mutex.Lock()
+			s.eolDates[engine] = eolDates
+			s.mutex.Unlock()
+		}
+		cancel()
+
+		if !failed {
+			level.Info(s.logger).Log("msg", "EOL dates updated")
+			if s.onUpdate != nil {
+				s.onUpdate()
+			}
+		}
+
+		timer := time.NewTimer(s.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *Source) fetchEOLDates(ctx context.Context, product string) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s.json", s.url, product), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var cycles []eolCycle
+	if err := json.NewDecoder(resp.Body).Decode(&cycles); err != nil {
+		return nil, err
+	}
+	return parseEOLCycles(cycles), nil
+}
+
+// parseEOLCycles returns the EOL date per release cycle. Cycles without a date (eol is a boolean) are left out.
+func parseEOLCycles(cycles []eolCycle) map[string]string {
+	eolDates := make(map[string]string)
+	for _, cycle := range cycles {
+		var eolDate string
+		if err := json.Unmarshal(cycle.EOL, &eolDate); err != nil {
+			continue
+		}
+		if _, err := time.Parse(dateLayout, eolDate); err != nil {
+			continue
+		}
+		eolDates[cycle.Cycle] = eolDate
+	}
+	return eolDates
+}
+
+// Lookup returns the EOL date of the release cycle the version belongs to. The longest matching cycle wins, e.g.
+// version 5.7.44 matches cycle 5.7 rather than 5.
+func (s *Source) Lookup(engine string, version string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var eolDate, matchedCycle string
+	for cycle, date := range s.eolDates[engine] {
+		if version != cycle && !strings.HasPrefix(version, cycle+".") {
+			continue
+		}
+		if len(cycle) > len(matchedCycle) {
+			eolDate, matchedCycle = date, cycle
+		}
+	}
+	return eolDate, matchedCycle != ""
+}
@@ -0,0 +1,66 @@
+package eol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceFetchEOLDates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/amazon-rds-postgresql.json", r.URL.Path)
+		w.Write([]byte(`[
+			{"cycle": "16", "releaseDate": "2023-11-17", "eol": "2029-02-28"},
+			{"cycle": "11", "releaseDate": "2019-03-13", "eol": "2024-02-29"},
+			{"cycle": "10", "releaseDate": "2018-06-04", "eol": true}
+		]`))
+	}))
+	defer server.Close()
+
+	interval, timeout := time.Hour, time.Second
+	source := NewSource(log.NewNopLogger(), SourceConfig{
+		URL:      server.URL + "/api/",
+		Interval: &interval,
+		Timeout:  &timeout,
+		Products: map[string]string{"postgres": "amazon-rds-postgresql"},
+	}, nil)
+
+	eolDates, err := source.fetchEOLDates(context.TODO(), "amazon-rds-postgresql")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"16": "2029-02-28", "11": "2024-02-29"}, eolDates)
+}
+
+func TestSourceLookup(t *testing.T) {
+	source := &Source{
+		eolDates: map[string]map[string]string{
+			"mysql": {"5": "2023-01-01", "5.7": "2024-02-29", "8.0": "2026-07-31"},
+		},
+	}
+
+	testCases := []struct {
+		engine   string
+		version  string
+		expected string
+		found    bool
+	}{
+		{"mysql", "5.7.44", "2024-02-29", true},
+		{"mysql", "5.6.51", "2023-01-01", true},
+		{"mysql", "8.0", "2026-07-31", true},
+		{"mysql", "8.01", "", false},
+		{"postgres", "13.7", "", false},
+	}
+	for _, tc := range testCases {
+		eolDate, found := source.Lookup(tc.engine, tc.version)
+		assert.Equal(t, tc.found, found, tc.version)
+		assert.Equal(t, tc.expected, eolDate, tc.version)
+	}
+
+	var disabled *Source
+	_, found := disabled.Lookup("mysql", "5.7.44")
+	assert.False(t, found)
+}
@@ -0,0 +1,15 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/go-kit/kit/log"
+)
+
+// NewEOLSource creates the EOL source shared by the exporters, it publishes its last update like the collectors
+func NewEOLSource(logger log.Logger, config eol.SourceConfig) *eol.Source {
+	return eol.NewSource(logger, config, func() {
+		setCollectorLastUpdate(context.Background(), "eol_source")
+	})
+}
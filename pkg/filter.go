@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ResourceFilter selects the resources an exporter exports metrics for, e.g. to leave out ephemeral CI resources. A
+// resource is selected if it matches the include selector, if any, and doesn't match the exclude selector.
+type ResourceFilter struct {
+	Include *ResourceSelector `yaml:"include"`
+	Exclude *ResourceSelector `yaml:"exclude"`
+}
+
+// ResourceSelector matches the resources whose identifier matches the pattern and which have all the tags. The
+// identifier pattern and the tag values are regular expressions matching the whole value, a missing tag only matches
+// an empty pattern.
+type ResourceSelector struct {
+	Identifier string            `yaml:"identifier"`
+	Tags       map[string]string `yaml:"tags"`
+}
+
+func (f ResourceFilter) isEmpty() bool {
+	return f.Include == nil && f.Exclude == nil
+}
+
+func (f ResourceFilter) validate() error {
+	var errs []error
+	for i, selector := range []*ResourceSelector{f.Include, f.Exclude} {
+		if selector == nil {
+			continue
+		}
+		name := []string{"include", "exclude"}[i]
+		if _, err := regexp.Compile(anchoredPattern(selector.Identifier)); err != nil {
+			errs = append(errs, fmt.Errorf("filter %s identifier: %w", name, err))
+		}
+		for key, value := range selector.Tags {
+			if _, err := regexp.Compile(anchoredPattern(value)); err != nil {
+				errs = append(errs, fmt.Errorf("filter %s tag %s: %w", name, key, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// anchoredPattern makes the pattern match whole values, so "ci-.*" doesn't match "prod-ci-1"
+func anchoredPattern(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+type resourceSelector struct {
+	identifier *regexp.Regexp
+	tags       map[string]*regexp.Regexp
+}
+
+// resourceFilter is the compiled ResourceFilter. A nil resourceFilter selects all resources.
+type resourceFilter struct {
+	include, exclude *resourceSelector
+}
+
+// newResourceFilter compiles the filter, which has been validated with the configuration. It returns nil for an empty
+// filter.
+func newResourceFilter(config ResourceFilter) *resourceFilter {
+	if config.isEmpty() {
+		return nil
+	}
+	return &resourceFilter{include: newResourceSelector(config.Include), exclude: newResourceSelector(config.Exclude)}
+}
+
+func newResourceSelector(config *ResourceSelector) *resourceSelector {
+	if config == nil {
+		return nil
+	}
+	selector := &resourceSelector{tags: map[string]*regexp.Regexp{}}
+	if config.Identifier != "" {
+		selector.identifier = regexp.MustCompile(anchoredPattern(config.Identifier))
+	}
+	for key, value := range config.Tags {
+		selector.tags[key] = regexp.MustCompile(anchoredPattern(value))
+	}
+	return selector
+}
+
+func (s *resourceSelector) matches(identifier string, tags map[string]string) bool {
+	if s.identifier != nil && !s.identifier.MatchString(identifier) {
+		return false
+	}
+	for key, value := range s.tags {
+		if !value.MatchString(tags[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// selects returns whether the metrics of the resource are exported
+func (f *resourceFilter) selects(identifier string, tags map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	if f.include != nil && !f.include.matches(identifier, tags) {
+		return false
+	}
+	return f.exclude == nil || !f.exclude.matches(identifier, tags)
+}
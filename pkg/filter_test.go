@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceFilterSelects(t *testing.T) {
+	var all *resourceFilter
+	assert.True(t, all.selects("ci-db-1", nil))
+	assert.Nil(t, newResourceFilter(ResourceFilter{}))
+
+	filter := newResourceFilter(ResourceFilter{
+		Include: &ResourceSelector{Tags: map[string]string{"team": "sre|dba"}},
+		Exclude: &ResourceSelector{Identifier: "ci-.*"},
+	})
+	assert.True(t, filter.selects("prod-db", map[string]string{"team": "sre"}))
+	assert.True(t, filter.selects("prod-ci-db", map[string]string{"team": "dba"}))
+	assert.False(t, filter.selects("ci-db-1", map[string]string{"team": "sre"}))
+	assert.False(t, filter.selects("prod-db", map[string]string{"team": "sre-ops"}))
+	assert.False(t, filter.selects("prod-db", nil))
+
+	filter = newResourceFilter(ResourceFilter{Exclude: &ResourceSelector{Tags: map[string]string{"ephemeral": "true"}}})
+	assert.True(t, filter.selects("prod-db", nil))
+	assert.False(t, filter.selects("ci-db-1", map[string]string{"ephemeral": "true"}))
+}
+
+func TestResourceFilterValidate(t *testing.T) {
+	assert.Nil(t, ResourceFilter{Include: &ResourceSelector{Identifier: "prod-.*"}}.validate())
+
+	err := ResourceFilter{
+		Include: &ResourceSelector{Identifier: "prod-("},
+		Exclude: &ResourceSelector{Tags: map[string]string{"team": "[sre"}},
+	}.validate()
+	assert.ErrorContains(t, err, "filter include identifier")
+	assert.ErrorContains(t, err, "filter exclude tag team")
+}
@@ -0,0 +1,115 @@
+package pkg
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// forecastWindow is how far back the usage samples of the forecasts go
+	forecastWindow = 7 * 24 * time.Hour
+	// forecastSampleInterval is the minimum time between two samples, which limits the samples per usage in memory
+	forecastSampleInterval = time.Hour
+)
+
+type usageSample struct {
+	at    time.Time
+	usage float64
+}
+
+// quotaForecaster forecasts when a usage reaches its quota by a linear regression over the recent usage samples. The
+// samples are only held in memory, so the forecasts start over when the exporter restarts.
+type quotaForecaster struct {
+	mutex   sync.Mutex
+	samples map[string][]usageSample
+	// lastPrune is when the usages which weren't recorded in a while were dropped last
+	lastPrune time.Time
+}
+
+func newQuotaForecaster() *quotaForecaster {
+	return &quotaForecaster{samples: map[string][]usageSample{}}
+}
+
+// quotaForecasters keeps the forecaster of every collector, so the samples survive a reload of the configuration
+var quotaForecasters = struct {
+	mutex       sync.Mutex
+	forecasters map[string]*quotaForecaster
+}{forecasters: map[string]*quotaForecaster{}}
+
+// quotaForecasterFor returns the forecaster of the collector, the exporters created on a reload get the samples of the
+// previous ones. The keys of the usages have to be unique across the exporters of the collector, e.g. by the region.
+func quotaForecasterFor(collector string) *quotaForecaster {
+	quotaForecasters.mutex.Lock()
+	defer quotaForecasters.mutex.Unlock()
+	f, ok := quotaForecasters.forecasters[collector]
+	if !ok {
+		f = newQuotaForecaster()
+		quotaForecasters.forecasters[collector] = f
+	}
+	return f
+}
+
+// forecast records the usage identified by the key and returns the days until it reaches the quota at the growth of
+// the recent samples, +Inf if the usage doesn't grow. It returns false until there are samples of at least an hour.
+func (f *quotaForecaster) forecast(now time.Time, usage float64, quota float64, key ...string) (float64, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	id := strings.Join(key, "/")
+	samples := f.samples[id]
+	if len(samples) == 0 || now.Sub(samples[len(samples)-1].at) >= forecastSampleInterval {
+		samples = append(samples, usageSample{at: now, usage: usage})
+	}
+	samples = pruneUsageSamples(samples, now)
+	f.samples[id] = samples
+	// usages which weren't recorded in a while, e.g. of deleted VPCs, are dropped once per sample interval instead of
+	// on every call, which would be quadratic in the number of usages
+	if now.Sub(f.lastPrune) >= forecastSampleInterval {
+		for otherId, otherSamples := range f.samples {
+			if len(pruneUsageSamples(otherSamples, now)) == 0 {
+				delete(f.samples, otherId)
+			}
+		}
+		f.lastPrune = now
+	}
+
+	if quota <= 0 || len(samples) < 2 {
+		return 0, false
+	}
+	if usage >= quota {
+		return 0, true
+	}
+	slope := usageSlopePerDay(samples, now)
+	if slope <= 0 {
+		return math.Inf(1), true
+	}
+	return (quota - usage) / slope, true
+}
+
+// pruneUsageSamples drops the samples older than the forecast window
+func pruneUsageSamples(samples []usageSample, now time.Time) []usageSample {
+	for len(samples) > 0 && now.Sub(samples[0].at) > forecastWindow {
+		samples = samples[1:]
+	}
+	return samples
+}
+
+// usageSlopePerDay returns the slope of the least squares regression line through the samples in usage per day
+func usageSlopePerDay(samples []usageSample, now time.Time) float64 {
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		x := sample.at.Sub(now).Hours() / 24
+		sumX += x
+		sumY += sample.usage
+		sumXY += x * sample.usage
+		sumXX += x * x
+	}
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
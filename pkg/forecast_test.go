@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaForecast(t *testing.T) {
+	f := newQuotaForecaster()
+	start := time.Now()
+
+	// a single sample has no growth yet
+	_, ok := f.forecast(start, 10, 100, "foo")
+	assert.False(t, ok)
+
+	// 1 per day, so the remaining 89 last 89 days
+	days, ok := f.forecast(start.Add(24*time.Hour), 11, 100, "foo")
+	assert.True(t, ok)
+	assert.InDelta(t, 89, days, 0.001)
+
+	// the samples of other usages are independent
+	_, ok = f.forecast(start.Add(24*time.Hour), 50, 100, "bar")
+	assert.False(t, ok)
+}
+
+func TestQuotaForecastRegression(t *testing.T) {
+	f := newQuotaForecaster()
+	start := time.Now()
+
+	var days float64
+	for i, usage := range []float64{10, 12, 14, 16, 18} {
+		days, _ = f.forecast(start.Add(time.Duration(i)*24*time.Hour), usage, 100, "foo")
+	}
+	assert.InDelta(t, 41, days, 0.001)
+
+	// samples within the sample interval don't count, but the forecast starts from the current usage
+	days, ok := f.forecast(start.Add(4*24*time.Hour+time.Minute), 20, 100, "foo")
+	assert.True(t, ok)
+	assert.InDelta(t, 40, days, 0.001)
+}
+
+func TestQuotaForecastNoGrowth(t *testing.T) {
+	f := newQuotaForecaster()
+	start := time.Now()
+
+	f.forecast(start, 10, 100, "foo")
+	days, ok := f.forecast(start.Add(time.Hour), 5, 100, "foo")
+	assert.True(t, ok)
+	assert.True(t, math.IsInf(days, 1))
+
+	days, ok = f.forecast(start.Add(2*time.Hour), 120, 100, "foo")
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, days)
+}
+
+func TestQuotaForecasterFor(t *testing.T) {
+	f := quotaForecasterFor("test")
+	f.forecast(time.Now(), 10, 100, "foo")
+
+	// the exporters created on a reload get the samples of the previous ones
+	assert.Same(t, f, quotaForecasterFor("test"))
+	assert.NotSame(t, f, quotaForecasterFor("other"))
+}
+
+func TestQuotaForecastWindow(t *testing.T) {
+	f := newQuotaForecaster()
+	start := time.Now()
+
+	f.forecast(start, 10, 100, "foo")
+	f.forecast(start, 10, 100, "bar")
+	f.forecast(start.Add(forecastWindow-30*time.Minute), 10, 100, "foo")
+	// bar stopped being recorded, but the usages are dropped at most once per sample interval
+	f.forecast(start.Add(forecastWindow+time.Minute), 10, 100, "foo")
+	assert.Contains(t, f.samples, "bar")
+	f.forecast(start.Add(forecastWindow+30*time.Minute), 10, 100, "foo")
+	assert.NotContains(t, f.samples, "bar")
+
+	// the usage of foo dropped out of the window
+	_, ok := f.forecast(start.Add(3*forecastWindow), 20, 100, "foo")
+	assert.False(t, ok)
+
+	// unknown quotas have no forecast
+	_, ok = f.forecast(start.Add(3*forecastWindow+time.Hour), 20, 0, "foo")
+	assert.False(t, ok)
+}
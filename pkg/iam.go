@@ -0,0 +1,281 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	iamServiceCode               = "iam"
+	credentialReportRootUser     = "<root_account>"
+	credentialReportNotAvailable = "N/A"
+)
+
+// iamSummaryMetric is an entity count of the account summary and its quota, if any
+type iamSummaryMetric struct {
+	usageKey    string
+	quotaKey    string
+	Usage       *prometheus.Desc
+	Quota       *prometheus.Desc
+	Utilization *prometheus.Desc
+}
+
+type IAMExporter struct {
+	client         awsclient.Client
+	region         string
+	SummaryMetrics []iamSummaryMetric
+	AccessKeyAge   *prometheus.Desc
+	PasswordAge    *prometheus.Desc
+	MFAEnabled     *prometheus.Desc
+	RoleLastUsed   *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	interval time.Duration
+	timeout  time.Duration
+
+	credentialReport bool
+	roleLastUsed     bool
+}
+
+// NewIAMExporter creates a new IAMExporter instance. IAM is a global service, so there is a single session only.
+func NewIAMExporter(sess *session.Session, logger log.Logger, config IAMConfig, awsAccountId string) *IAMExporter {
+	level.Info(logger).Log("msg", "Initializing IAM exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: iamServiceCode}
+
+	exporter := &IAMExporter{
+		client: awsclient.NewClientFromSession(sess),
+		region: config.Region,
+		// the summary keys are listed in the GetAccountSummary documentation, the SDK lacks constants for some of them
+		SummaryMetrics: []iamSummaryMetric{
+			newIAMSummaryMetric(constLabels, "roles", "roles", "Roles", "RolesQuota"),
+			newIAMSummaryMetric(constLabels, "users", "users", "Users", "UsersQuota"),
+			newIAMSummaryMetric(constLabels, "groups", "groups", "Groups", "GroupsQuota"),
+			newIAMSummaryMetric(constLabels, "policies", "customer managed policies", "Policies", "PoliciesQuota"),
+			newIAMSummaryMetric(constLabels, "instanceprofiles", "instance profiles", "InstanceProfiles", "InstanceProfilesQuota"),
+			newIAMSummaryMetric(constLabels, "servercertificates", "server certificates", "ServerCertificates", "ServerCertificatesQuota"),
+			newIAMSummaryMetric(constLabels, "mfadevices", "MFA devices", "MFADevices", ""),
+			newIAMSummaryMetric(constLabels, "mfadevicesinuse", "MFA devices in use", "MFADevicesInUse", ""),
+		},
+		cache:    *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:   logger,
+		interval: *config.Interval,
+		timeout:  *config.Timeout,
+
+		credentialReport: config.CredentialReport,
+		roleLastUsed:     config.RoleLastUsed,
+	}
+	if exporter.credentialReport {
+		userLabels := map[string]string{"aws_account_id": awsAccountId}
+		exporter.AccessKeyAge = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_user_access_key_age_seconds"), "Time since the active access key of the IAM user was last rotated", []string{"user_name", "access_key"}, userLabels)
+		exporter.PasswordAge = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_user_password_age_seconds"), "Time since the console password of the IAM user was last changed", []string{"user_name"}, userLabels)
+		exporter.MFAEnabled = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_user_mfa_enabled"), "Whether the IAM user has an active MFA device", []string{"user_name"}, userLabels)
+	}
+	if exporter.roleLastUsed {
+		exporter.RoleLastUsed = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_role_last_used_timestamp_seconds"), "The last time the IAM role was used (UTC timestamp), 0 if it was never used", []string{"role_name"}, map[string]string{"aws_account_id": awsAccountId})
+	}
+	return exporter
+}
+
+func newIAMSummaryMetric(constLabels map[string]string, name string, description string, usageKey string, quotaKey string) iamSummaryMetric {
+	metric := iamSummaryMetric{
+		usageKey: usageKey,
+		quotaKey: quotaKey,
+		Usage:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_"+name+"_usage"), "Number of IAM "+description+" in the account", []string{}, constLabels),
+	}
+	if quotaKey != "" {
+		metric.Quota = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_"+name+"_quota"), "Quota for maximum number of IAM "+description+" in the account", []string{}, constLabels)
+		metric.Utilization = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "iam_"+name+"_quota_utilization_ratio"), "Number of IAM "+description+" in the account relative to the quota", []string{}, constLabels)
+	}
+	return metric
+}
+
+// addAccountSummaryMetrics adds the entity counts and quotas of the account, which are all part of a single summary
+func (e *IAMExporter) addAccountSummaryMetrics(ctx context.Context) error {
+	output, err := e.client.GetAccountSummaryWithContext(ctx, &iam.GetAccountSummaryInput{})
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range e.SummaryMetrics {
+		usage, usageFound := output.SummaryMap[metric.usageKey]
+		if usageFound {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(metric.Usage, prometheus.GaugeValue, float64(aws.Int64Value(usage))))
+		}
+		if quota, found := output.SummaryMap[metric.quotaKey]; found && metric.Quota != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(metric.Quota, prometheus.GaugeValue, float64(aws.Int64Value(quota))))
+			if usageFound {
+				e.cache.AddQuotaUtilization(metric.Utilization, float64(aws.Int64Value(usage)), float64(aws.Int64Value(quota)))
+			}
+		}
+	}
+	return nil
+}
+
+// addCredentialReportMetrics adds the credential age of every user. Generating the report takes a while, so if it is
+// not ready yet the metrics are added in one of the next collections. AWS regenerates it at most every 4 hours.
+func (e *IAMExporter) addCredentialReportMetrics(ctx context.Context) error {
+	generateOutput, err := e.client.GenerateCredentialReportWithContext(ctx, &iam.GenerateCredentialReportInput{})
+	if err != nil {
+		return fmt.Errorf("could not generate the credential report: %w", err)
+	}
+	if aws.StringValue(generateOutput.State) != iam.ReportStateTypeComplete {
+		level.Debug(e.logger).Log("msg", "Credential report is not ready yet", "state", aws.StringValue(generateOutput.State))
+		return nil
+	}
+
+	reportOutput, err := e.client.GetCredentialReportWithContext(ctx, &iam.GetCredentialReportInput{})
+	if err != nil {
+		return fmt.Errorf("could not get the credential report: %w", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(reportOutput.Content)).ReadAll()
+	if err != nil {
+		return fmt.Errorf("could not parse the credential report: %w", err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	now := time.Now()
+	for _, record := range records[1:] {
+		user := credentialReportUser{columns: columns, record: record}
+		userName := user.get("user")
+
+		mfaEnabled := 0.0
+		if user.get("mfa_active") == "true" {
+			mfaEnabled = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.MFAEnabled, prometheus.GaugeValue, mfaEnabled, userName))
+		// the root account has no password_last_changed, as its password is not managed by IAM
+		if user.get("password_enabled") == "true" && userName != credentialReportRootUser {
+			if changed, ok := user.getTime("password_last_changed"); ok {
+				e.cache.AddMetric(prometheus.MustNewConstMetric(e.PasswordAge, prometheus.GaugeValue, now.Sub(changed).Seconds(), userName))
+			}
+		}
+		for _, accessKey := range []string{"1", "2"} {
+			if user.get("access_key_"+accessKey+"_active") != "true" {
+				continue
+			}
+			if rotated, ok := user.getTime("access_key_" + accessKey + "_last_rotated"); ok {
+				e.cache.AddMetric(prometheus.MustNewConstMetric(e.AccessKeyAge, prometheus.GaugeValue, now.Sub(rotated).Seconds(), userName, accessKey))
+			}
+		}
+	}
+	return nil
+}
+
+// credentialReportUser is a row of the credential report CSV
+type credentialReportUser struct {
+	columns map[string]int
+	record  []string
+}
+
+func (u credentialReportUser) get(column string) string {
+	i, found := u.columns[column]
+	if !found || i >= len(u.record) {
+		return ""
+	}
+	return u.record[i]
+}
+
+func (u credentialReportUser) getTime(column string) (time.Time, bool) {
+	value := u.get(column)
+	if value == "" || value == credentialReportNotAvailable {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
+
+// addRoleLastUsedMetrics adds when every role was last used. AWS only tracks the last 400 days, roles which were not
+// used in that period are reported as never used.
+func (e *IAMExporter) addRoleLastUsedMetrics(ctx context.Context) error {
+	roles, err := e.client.GetRoleDetailsAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		lastUsed := 0.0
+		if role.RoleLastUsed != nil && role.RoleLastUsed.LastUsedDate != nil {
+			lastUsed = float64(role.RoleLastUsed.LastUsedDate.Unix())
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RoleLastUsed, prometheus.GaugeValue, lastUsed, aws.StringValue(role.RoleName)))
+	}
+	return nil
+}
+
+func (e *IAMExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "iam", e.region)
+
+		if err := e.addAccountSummaryMetrics(collectCtx); err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetAccountSummary failed", "region", e.region, "err", err)
+			run.fail()
+		}
+
+		if e.credentialReport {
+			if err := e.addCredentialReportMetrics(collectCtx); err != nil {
+				level.Error(e.logger).Log("msg", "Could not collect the credential report metrics", "region", e.region, "err", err)
+				run.fail()
+			}
+		}
+
+		if e.roleLastUsed {
+			if err := e.addRoleLastUsedMetrics(collectCtx); err != nil {
+				level.Error(e.logger).Log("msg", "Call to GetAccountAuthorizationDetails failed", "region", e.region, "err", err)
+				run.fail()
+			}
+		}
+
+		level.Info(e.logger).Log("msg", "IAM metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "iam")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *IAMExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *IAMExporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range e.SummaryMetrics {
+		ch <- metric.Usage
+		if metric.Quota != nil {
+			ch <- metric.Quota
+			ch <- metric.Utilization
+		}
+	}
+	if e.credentialReport {
+		ch <- e.AccessKeyAge
+		ch <- e.PasswordAge
+		ch <- e.MFAEnabled
+	}
+	if e.roleLastUsed {
+		ch <- e.RoleLastUsed
+	}
+}
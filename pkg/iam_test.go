@@ -0,0 +1,149 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAccountSummaryMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &IAMExporter{
+		client: mockClient,
+		cache:  *NewMetricsCache(10 * time.Second),
+		logger: log.NewNopLogger(),
+		SummaryMetrics: []iamSummaryMetric{
+			newIAMSummaryMetric(nil, "roles", "roles", "Roles", "RolesQuota"),
+			newIAMSummaryMetric(nil, "mfadevices", "MFA devices", "MFADevices", ""),
+			newIAMSummaryMetric(nil, "users", "users", "Users", "UsersQuota"),
+		},
+	}
+
+	mockClient.EXPECT().GetAccountSummaryWithContext(ctx, &iam.GetAccountSummaryInput{}).
+		Return(&iam.GetAccountSummaryOutput{SummaryMap: map[string]*int64{
+			"Roles":      aws.Int64(42),
+			"RolesQuota": aws.Int64(1000),
+			"MFADevices": aws.Int64(3),
+		}}, nil)
+
+	assert.Nil(t, e.addAccountSummaryMetrics(ctx))
+
+	expected := map[string]float64{
+		e.SummaryMetrics[0].Usage.String():       42,
+		e.SummaryMetrics[0].Quota.String():       1000,
+		e.SummaryMetrics[0].Utilization.String(): 0.042,
+		e.SummaryMetrics[1].Usage.String():       3,
+	}
+	metrics := e.cache.GetAllMetrics()
+	// the users are missing from the summary, so they are left out
+	assert.Len(t, metrics, len(expected))
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		assert.Equal(t, expected[metric.Desc().String()], dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+	}
+}
+
+func TestAddCredentialReportMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := NewIAMExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), IAMConfig{
+		BaseConfig:       createTestBaseConfig(),
+		CredentialReport: true,
+	}, "123456789012")
+	e.client = mockClient
+
+	rotated := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	report := "user,arn,password_enabled,password_last_changed,mfa_active,access_key_1_active,access_key_1_last_rotated,access_key_2_active,access_key_2_last_rotated\n" +
+		"<root_account>,arn:aws:iam::123456789012:root,not_supported,not_supported,true,false,N/A,false,N/A\n" +
+		"alice,arn:aws:iam::123456789012:user/alice,true," + rotated + ",false,true," + rotated + ",false,N/A\n"
+
+	mockClient.EXPECT().GenerateCredentialReportWithContext(ctx, &iam.GenerateCredentialReportInput{}).
+		Return(&iam.GenerateCredentialReportOutput{State: aws.String(iam.ReportStateTypeComplete)}, nil)
+	mockClient.EXPECT().GetCredentialReportWithContext(ctx, &iam.GetCredentialReportInput{}).
+		Return(&iam.GetCredentialReportOutput{Content: []byte(report)}, nil)
+
+	assert.Nil(t, e.addCredentialReportMetrics(ctx))
+
+	// MFA of both users, the password and first access key of alice
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 4)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.AccessKeyAge.String(), e.PasswordAge.String():
+			assert.InDelta(t, (48 * time.Hour).Seconds(), dtoMetric.GetGauge().GetValue(), 60)
+		}
+	}
+}
+
+func TestAddCredentialReportMetricsNotReady(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &IAMExporter{
+		client: mockClient,
+		cache:  *NewMetricsCache(10 * time.Second),
+		logger: log.NewNopLogger(),
+	}
+
+	// the report is only downloaded once it is complete
+	mockClient.EXPECT().GenerateCredentialReportWithContext(ctx, &iam.GenerateCredentialReportInput{}).
+		Return(&iam.GenerateCredentialReportOutput{State: aws.String(iam.ReportStateTypeStarted)}, nil)
+
+	assert.Nil(t, e.addCredentialReportMetrics(ctx))
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
+
+func TestAddRoleLastUsedMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := NewIAMExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), IAMConfig{
+		BaseConfig:   createTestBaseConfig(),
+		RoleLastUsed: true,
+	}, "123456789012")
+	e.client = mockClient
+
+	lastUsed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().GetRoleDetailsAll(ctx).Return([]*iam.RoleDetail{
+		{RoleName: aws.String("used"), RoleLastUsed: &iam.RoleLastUsed{LastUsedDate: aws.Time(lastUsed)}},
+		{RoleName: aws.String("unused"), RoleLastUsed: &iam.RoleLastUsed{}},
+	}, nil)
+
+	assert.Nil(t, e.addRoleLastUsedMetrics(ctx))
+
+	expected := map[string]float64{"used": float64(lastUsed.Unix()), "unused": 0}
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, len(expected))
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		for _, label := range dtoMetric.GetLabel() {
+			if label.GetName() == "role_name" {
+				assert.Equal(t, expected[label.GetValue()], dtoMetric.GetGauge().GetValue(), label.GetValue())
+			}
+		}
+	}
+}
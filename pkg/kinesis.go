@@ -0,0 +1,161 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const kinesisServiceCode string = "kinesis"
+
+type KinesisExporter struct {
+	sessions              []*session.Session
+	StreamsPerRegion      *prometheus.Desc
+	OnDemandStreamsQuota  *prometheus.Desc
+	OnDemandStreamsUsage  *prometheus.Desc
+	ShardsPerRegionQuota  *prometheus.Desc
+	ShardsPerRegionUsage  *prometheus.Desc
+	StreamOpenShards      *prometheus.Desc
+	StreamRetentionPeriod *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewKinesisExporter creates a new KinesisExporter instance
+func NewKinesisExporter(sessions []*session.Session, logger log.Logger, config KinesisConfig, awsAccountId string) *KinesisExporter {
+	level.Info(logger).Log("msg", "Initializing Kinesis exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: kinesisServiceCode}
+	streamLabels := []string{"aws_region", "stream_name", "stream_mode"}
+
+	return &KinesisExporter{
+		sessions:              sessions,
+		StreamsPerRegion:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_streamsperregion_usage"), "Number of Kinesis data streams of a capacity mode in this region", []string{"aws_region", "stream_mode"}, constLabels),
+		OnDemandStreamsQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_ondemandstreams_quota"), "Quota for maximum number of on-demand Kinesis data streams in this region", []string{"aws_region"}, constLabels),
+		OnDemandStreamsUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_ondemandstreams_usage"), "Number of on-demand Kinesis data streams in this region", []string{"aws_region"}, constLabels),
+		ShardsPerRegionQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_shardsperregion_quota"), "Quota for maximum number of open Kinesis shards in this region", []string{"aws_region"}, constLabels),
+		ShardsPerRegionUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_shardsperregion_usage"), "Number of open Kinesis shards in this region", []string{"aws_region"}, constLabels),
+		StreamOpenShards:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_stream_open_shards"), "Number of open shards of the Kinesis data stream", streamLabels, constLabels),
+		StreamRetentionPeriod: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kinesis_stream_retention_hours"), "Retention period of the Kinesis data stream in hours", streamLabels, constLabels),
+		cache:                 *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                logger,
+		timeout:               *config.Timeout,
+		interval:              *config.Interval,
+	}
+}
+
+func (e *KinesisExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.StreamsPerRegion
+	ch <- e.OnDemandStreamsQuota
+	ch <- e.OnDemandStreamsUsage
+	ch <- e.ShardsPerRegionQuota
+	ch <- e.ShardsPerRegionUsage
+	ch <- e.StreamOpenShards
+	ch <- e.StreamRetentionPeriod
+}
+
+func (e *KinesisExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *KinesisExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Kinesis metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "kinesis")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *KinesisExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "kinesis", region)
+	defer run.finish()
+
+	e.collectLimitMetrics(client, ctx, region, run)
+	e.collectStreamMetrics(client, ctx, region, run)
+}
+
+// collectLimitMetrics exports the shard and on-demand stream limits which Kinesis reports together with their usage
+func (e *KinesisExporter) collectLimitMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	limits, err := client.DescribeLimitsWithContext(ctx, &kinesis.DescribeLimitsInput{})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeLimits failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ShardsPerRegionQuota, prometheus.GaugeValue, float64(aws.Int64Value(limits.ShardLimit)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ShardsPerRegionUsage, prometheus.GaugeValue, float64(aws.Int64Value(limits.OpenShardCount)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.OnDemandStreamsQuota, prometheus.GaugeValue, float64(aws.Int64Value(limits.OnDemandStreamCountLimit)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.OnDemandStreamsUsage, prometheus.GaugeValue, float64(aws.Int64Value(limits.OnDemandStreamCount)), region))
+}
+
+func (e *KinesisExporter) collectStreamMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	streams, err := client.ListStreamsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListStreamsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	streamsPerMode := map[string]int{
+		kinesis.StreamModeOnDemand:    0,
+		kinesis.StreamModeProvisioned: 0,
+	}
+	for _, stream := range streams {
+		streamName := aws.StringValue(stream.StreamName)
+		output, err := client.DescribeStreamSummaryWithContext(ctx, &kinesis.DescribeStreamSummaryInput{
+			StreamName: stream.StreamName,
+		})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeStreamSummary failed", "region", region, "stream", streamName, "err", err)
+			run.fail()
+			continue
+		}
+
+		summary := output.StreamDescriptionSummary
+		streamMode := kinesis.StreamModeProvisioned
+		if summary.StreamModeDetails != nil {
+			streamMode = aws.StringValue(summary.StreamModeDetails.StreamMode)
+		}
+		streamsPerMode[streamMode]++
+
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.StreamOpenShards, prometheus.GaugeValue, float64(aws.Int64Value(summary.OpenShardCount)), region, streamName, streamMode))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.StreamRetentionPeriod, prometheus.GaugeValue, float64(aws.Int64Value(summary.RetentionPeriodHours)), region, streamName, streamMode))
+	}
+	for streamMode, count := range streamsPerMode {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.StreamsPerRegion, prometheus.GaugeValue, float64(count), region, streamMode))
+	}
+}
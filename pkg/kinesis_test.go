@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKinesisCollectLimitMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeLimitsWithContext(ctx, &kinesis.DescribeLimitsInput{}).Return(&kinesis.DescribeLimitsOutput{
+		ShardLimit:               aws.Int64(500),
+		OpenShardCount:           aws.Int64(12),
+		OnDemandStreamCountLimit: aws.Int64(50),
+		OnDemandStreamCount:      aws.Int64(1),
+	}, nil)
+
+	e := NewKinesisExporter(nil, log.NewNopLogger(), KinesisConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("kinesis", "foo")
+	e.collectLimitMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 4)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.ShardsPerRegionQuota.String():
+			assert.Equal(t, 500.0, dtoMetric.GetGauge().GetValue())
+		case e.ShardsPerRegionUsage.String():
+			assert.Equal(t, 12.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestKinesisCollectStreamMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListStreamsAll(ctx).Return([]*kinesis.StreamSummary{
+		{StreamName: aws.String("a")},
+		{StreamName: aws.String("b")},
+		{StreamName: aws.String("c")},
+	}, nil)
+	mockClient.EXPECT().DescribeStreamSummaryWithContext(ctx, &kinesis.DescribeStreamSummaryInput{StreamName: aws.String("a")}).Return(&kinesis.DescribeStreamSummaryOutput{
+		StreamDescriptionSummary: &kinesis.StreamDescriptionSummary{
+			OpenShardCount:       aws.Int64(4),
+			RetentionPeriodHours: aws.Int64(24),
+			StreamModeDetails:    &kinesis.StreamModeDetails{StreamMode: aws.String(kinesis.StreamModeProvisioned)},
+		},
+	}, nil)
+	mockClient.EXPECT().DescribeStreamSummaryWithContext(ctx, &kinesis.DescribeStreamSummaryInput{StreamName: aws.String("b")}).Return(&kinesis.DescribeStreamSummaryOutput{
+		StreamDescriptionSummary: &kinesis.StreamDescriptionSummary{
+			OpenShardCount:       aws.Int64(2),
+			RetentionPeriodHours: aws.Int64(168),
+			StreamModeDetails:    &kinesis.StreamModeDetails{StreamMode: aws.String(kinesis.StreamModeOnDemand)},
+		},
+	}, nil)
+	mockClient.EXPECT().DescribeStreamSummaryWithContext(ctx, &kinesis.DescribeStreamSummaryInput{StreamName: aws.String("c")}).Return(nil, errors.New("some error"))
+
+	e := NewKinesisExporter(nil, log.NewNopLogger(), KinesisConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("kinesis", "foo")
+	e.collectStreamMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+
+	// 2 metrics per described stream and the streams per capacity mode
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 6)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.StreamsPerRegion.String():
+			assert.Equal(t, 1.0, dtoMetric.GetGauge().GetValue())
+		case e.StreamRetentionPeriod.String():
+			assert.Contains(t, []float64{24, 168}, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestKinesisCollectStreamMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListStreamsAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewKinesisExporter(nil, log.NewNopLogger(), KinesisConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("kinesis", "foo")
+	e.collectStreamMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -0,0 +1,172 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	kmsServiceCode           string = "kms"
+	kmsCustomerKeysQuotaCode string = "L-C2F1777E"
+)
+
+type KMSExporter struct {
+	sessions           []*session.Session
+	CustomerKeysQuota  *prometheus.Desc
+	CustomerKeysUsage  *prometheus.Desc
+	KeysPerRegion      *prometheus.Desc
+	KeyRotationEnabled *prometheus.Desc
+	KeyDeletionDate    *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewKMSExporter creates a new KMSExporter instance
+func NewKMSExporter(sessions []*session.Session, logger log.Logger, config KMSConfig, awsAccountId string) *KMSExporter {
+	level.Info(logger).Log("msg", "Initializing KMS exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: kmsServiceCode}
+
+	return &KMSExporter{
+		sessions:           sessions,
+		CustomerKeysQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kms_customerkeys_quota"), "Quota for maximum number of customer managed KMS keys in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, kmsCustomerKeysQuotaCode)),
+		CustomerKeysUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kms_customerkeys_usage"), "Number of customer managed KMS keys in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, kmsCustomerKeysQuotaCode)),
+		KeysPerRegion:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kms_keysperregion_usage"), "Number of KMS keys in this region per key manager and state", []string{"aws_region", "key_manager", "key_state"}, constLabels),
+		KeyRotationEnabled: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kms_key_rotation_enabled"), "Whether automatic rotation is enabled for the customer managed KMS key", []string{"aws_region", "key_id"}, constLabels),
+		KeyDeletionDate:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "kms_key_deletion_timestamp_seconds"), "The time the KMS key pending deletion is deleted (UTC timestamp)", []string{"aws_region", "key_id"}, constLabels),
+		cache:              *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:             logger,
+		timeout:            *config.Timeout,
+		interval:           *config.Interval,
+	}
+}
+
+func (e *KMSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.CustomerKeysQuota
+	ch <- e.CustomerKeysUsage
+	ch <- e.KeysPerRegion
+	ch <- e.KeyRotationEnabled
+	ch <- e.KeyDeletionDate
+}
+
+func (e *KMSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *KMSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "KMS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "kms")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *KMSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "kms", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+type kmsKeysKey struct {
+	keyManager string
+	keyState   string
+}
+
+func (e *KMSExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, kmsServiceCode, kmsCustomerKeysQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve KMS customer managed keys quota", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.CustomerKeysQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	keys, err := client.ListKeysAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListKeysAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	// ListKeys doesn't tell customer and AWS managed keys apart, so every key has to be described
+	keyCounts := make(map[kmsKeysKey]int)
+	customerKeys := 0
+	for _, key := range keys {
+		output, err := client.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: key.KeyId})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeKey failed", "region", region, "key_id", aws.StringValue(key.KeyId), "err", err)
+			run.fail()
+			continue
+		}
+		metadata := output.KeyMetadata
+		keyId := aws.StringValue(metadata.KeyId)
+		keyManager := aws.StringValue(metadata.KeyManager)
+		keyState := aws.StringValue(metadata.KeyState)
+		keyCounts[kmsKeysKey{keyManager, keyState}]++
+
+		if keyManager != kms.KeyManagerTypeCustomer {
+			continue
+		}
+		customerKeys++
+
+		if metadata.DeletionDate != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.KeyDeletionDate, prometheus.GaugeValue, float64(metadata.DeletionDate.Unix()), region, keyId))
+		}
+
+		// automatic rotation is only supported for enabled symmetric keys with key material generated by KMS
+		if keyState != kms.KeyStateEnabled || aws.StringValue(metadata.KeySpec) != kms.KeySpecSymmetricDefault || aws.StringValue(metadata.Origin) != kms.OriginTypeAwsKms {
+			continue
+		}
+		rotationOutput, err := client.GetKeyRotationStatusWithContext(ctx, &kms.GetKeyRotationStatusInput{KeyId: metadata.KeyId})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetKeyRotationStatus failed", "region", region, "key_id", keyId, "err", err)
+			run.fail()
+			continue
+		}
+		rotationEnabled := 0.0
+		if aws.BoolValue(rotationOutput.KeyRotationEnabled) {
+			rotationEnabled = 1
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.KeyRotationEnabled, prometheus.GaugeValue, rotationEnabled, region, keyId))
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.CustomerKeysUsage, prometheus.GaugeValue, float64(customerKeys), region))
+	for key, count := range keyCounts {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.KeysPerRegion, prometheus.GaugeValue, float64(count), region, key.keyManager, key.keyState))
+	}
+}
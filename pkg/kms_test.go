@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMSCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deletionDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(kmsServiceCode, kmsCustomerKeysQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100000)}}, nil)
+	mockClient.EXPECT().ListKeysAll(ctx).Return([]*kms.KeyListEntry{
+		{KeyId: aws.String("customer")},
+		{KeyId: aws.String("deleted")},
+		{KeyId: aws.String("aws")},
+	}, nil)
+	mockClient.EXPECT().DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String("customer")}).Return(&kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{
+		KeyId:      aws.String("customer"),
+		KeyManager: aws.String(kms.KeyManagerTypeCustomer),
+		KeyState:   aws.String(kms.KeyStateEnabled),
+		KeySpec:    aws.String(kms.KeySpecSymmetricDefault),
+		Origin:     aws.String(kms.OriginTypeAwsKms),
+	}}, nil)
+	mockClient.EXPECT().DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String("deleted")}).Return(&kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{
+		KeyId:        aws.String("deleted"),
+		KeyManager:   aws.String(kms.KeyManagerTypeCustomer),
+		KeyState:     aws.String(kms.KeyStatePendingDeletion),
+		KeySpec:      aws.String(kms.KeySpecSymmetricDefault),
+		Origin:       aws.String(kms.OriginTypeAwsKms),
+		DeletionDate: aws.Time(deletionDate),
+	}}, nil)
+	mockClient.EXPECT().DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String("aws")}).Return(&kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{
+		KeyId:      aws.String("aws"),
+		KeyManager: aws.String(kms.KeyManagerTypeAws),
+		KeyState:   aws.String(kms.KeyStateEnabled),
+	}}, nil)
+	// the rotation status is only requested for the enabled customer managed key
+	mockClient.EXPECT().GetKeyRotationStatusWithContext(ctx, &kms.GetKeyRotationStatusInput{KeyId: aws.String("customer")}).Return(
+		&kms.GetKeyRotationStatusOutput{KeyRotationEnabled: aws.Bool(true)}, nil)
+
+	e := NewKMSExporter(nil, log.NewNopLogger(), KMSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("kms", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	expected := map[string]float64{
+		e.CustomerKeysQuota.String():  100000,
+		e.CustomerKeysUsage.String():  2,
+		e.KeyRotationEnabled.String(): 1,
+		e.KeyDeletionDate.String():    float64(deletionDate.Unix()),
+	}
+	metrics := e.cache.GetAllMetrics()
+	// the expected metrics plus the keys per manager and state
+	assert.Len(t, metrics, len(expected)+3)
+	for _, metric := range metrics {
+		if value, ok := expected[metric.Desc().String()]; ok {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, value, dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	lambdaServiceCode                string = "lambda"
+	concurrentExecutionsQuotaCode    string = "L-B99A9384"
+	functionAndLayerStorageQuotaCode string = "L-2ACBD22F"
+)
+
+type LambdaExporter struct {
+	sessions                      []*session.Session
+	ConcurrentExecutionsQuota     *prometheus.Desc
+	UnreservedConcurrentExecution *prometheus.Desc
+	FunctionsUsage                *prometheus.Desc
+	CodeStorageQuota              *prometheus.Desc
+	CodeStorageUsage              *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewLambdaExporter creates a new LambdaExporter instance
+func NewLambdaExporter(sessions []*session.Session, logger log.Logger, config LambdaConfig, awsAccountId string) *LambdaExporter {
+	level.Info(logger).Log("msg", "Initializing Lambda exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: lambdaServiceCode}
+
+	return &LambdaExporter{
+		sessions:                      sessions,
+		ConcurrentExecutionsQuota:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "lambda_concurrentexecutions_quota"), "Quota for maximum number of concurrent executions in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, concurrentExecutionsQuotaCode)),
+		UnreservedConcurrentExecution: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "lambda_unreservedconcurrentexecutions"), "Number of concurrent executions not reserved by any function in this region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, concurrentExecutionsQuotaCode)),
+		FunctionsUsage:                prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "lambda_functions_usage"), "Number of Lambda functions in this region", []string{"aws_region"}, constLabels),
+		CodeStorageQuota:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "lambda_codestorage_quota_bytes"), "Quota for the storage used by function and layer code in this region (in bytes)", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, functionAndLayerStorageQuotaCode)),
+		CodeStorageUsage:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "lambda_codestorage_usage_bytes"), "Storage used by function and layer code in this region (in bytes)", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, functionAndLayerStorageQuotaCode)),
+		cache:                         *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                        logger,
+		timeout:                       *config.Timeout,
+		interval:                      *config.Interval,
+	}
+}
+
+func (e *LambdaExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ConcurrentExecutionsQuota
+	ch <- e.UnreservedConcurrentExecution
+	ch <- e.FunctionsUsage
+	ch <- e.CodeStorageQuota
+	ch <- e.CodeStorageUsage
+}
+
+func (e *LambdaExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *LambdaExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Lambda metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "lambda")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *LambdaExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "lambda", region)
+	defer run.finish()
+
+	settings, err := getLambdaAccountSettingsWithContext(client, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve Lambda account settings", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.addAccountSettingsMetrics(region, settings)
+}
+
+// addAccountSettingsMetrics adds the limits and usage of the account, which already contain the quotas, so no
+// additional Service Quotas calls are needed.
+func (e *LambdaExporter) addAccountSettingsMetrics(region string, settings *lambda.GetAccountSettingsOutput) {
+	limit := settings.AccountLimit
+	usage := settings.AccountUsage
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ConcurrentExecutionsQuota, prometheus.GaugeValue, float64(aws.Int64Value(limit.ConcurrentExecutions)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.UnreservedConcurrentExecution, prometheus.GaugeValue, float64(aws.Int64Value(limit.UnreservedConcurrentExecutions)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.CodeStorageQuota, prometheus.GaugeValue, float64(aws.Int64Value(limit.TotalCodeSize)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.FunctionsUsage, prometheus.GaugeValue, float64(aws.Int64Value(usage.FunctionCount)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.CodeStorageUsage, prometheus.GaugeValue, float64(aws.Int64Value(usage.TotalCodeSize)), region))
+}
+
+func getLambdaAccountSettingsWithContext(client awsclient.Client, ctx context.Context) (*lambda.GetAccountSettingsOutput, error) {
+	settings, err := client.GetAccountSettingsWithContext(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, err
+	}
+	if settings.AccountLimit == nil || settings.AccountUsage == nil {
+		return nil, errors.New("account limit or usage missing in Lambda account settings")
+	}
+	return settings, nil
+}
@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestLambdaAccountSettings() *lambda.GetAccountSettingsOutput {
+	return &lambda.GetAccountSettingsOutput{
+		AccountLimit: &lambda.AccountLimit{
+			ConcurrentExecutions:           aws.Int64(1000),
+			UnreservedConcurrentExecutions: aws.Int64(900),
+			TotalCodeSize:                  aws.Int64(80530636800),
+		},
+		AccountUsage: &lambda.AccountUsage{
+			FunctionCount: aws.Int64(12),
+			TotalCodeSize: aws.Int64(1024),
+		},
+	}
+}
+
+func TestGetLambdaAccountSettingsWithContext(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetAccountSettingsWithContext(ctx, &lambda.GetAccountSettingsInput{}).Return(createTestLambdaAccountSettings(), nil)
+
+	settings, err := getLambdaAccountSettingsWithContext(mockClient, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), *settings.AccountUsage.FunctionCount)
+}
+
+func TestGetLambdaAccountSettingsWithContextMissingUsage(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetAccountSettingsWithContext(ctx, &lambda.GetAccountSettingsInput{}).Return(&lambda.GetAccountSettingsOutput{}, nil)
+
+	settings, err := getLambdaAccountSettingsWithContext(mockClient, ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, settings)
+}
+
+func TestAddAccountSettingsMetrics(t *testing.T) {
+	e := NewLambdaExporter(nil, log.NewNopLogger(), LambdaConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+
+	e.addAccountSettingsMetrics("foo", createTestLambdaAccountSettings())
+	assert.Len(t, e.cache.GetAllMetrics(), 5)
+}
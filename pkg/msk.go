@@ -2,13 +2,16 @@ package pkg
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kafkaconnect"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,22 +23,85 @@ var MSKInfos *prometheus.Desc = prometheus.NewDesc(
 	[]string{"aws_region", "cluster_name", "msk_version", "eol_date", "eol_status"},
 	nil,
 )
+var MSKBrokerNodes *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_broker_nodes_total"),
+	"The number of broker nodes of the MSK cluster.",
+	[]string{"aws_region", "cluster_name"},
+	nil,
+)
+var MSKBrokerInstanceType *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_broker_instance_type"),
+	"The instance type of the MSK cluster brokers.",
+	[]string{"aws_region", "cluster_name", "instance_type"},
+	nil,
+)
+var MSKBrokerEBSVolumeSize *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_broker_ebs_volume_size_gib"),
+	"The EBS storage per broker of the MSK cluster in GiB.",
+	[]string{"aws_region", "cluster_name"},
+	nil,
+)
+var MSKClusterState *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_cluster_state"),
+	"The state of the MSK cluster.",
+	[]string{"aws_region", "cluster_name", "state", "cluster_type"},
+	nil,
+)
+var MSKEnhancedMonitoring *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_enhanced_monitoring"),
+	"The enhanced monitoring level of the MSK cluster.",
+	[]string{"aws_region", "cluster_name", "enhanced_monitoring"},
+	nil,
+)
+var MSKClusters *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_clusters_total"),
+	"The number of MSK clusters per cluster type.",
+	[]string{"aws_region", "cluster_type"},
+	nil,
+)
+var MSKConnectors *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_connectors_total"),
+	"The number of MSK Connect connectors.",
+	[]string{"aws_region"},
+	nil,
+)
+var MSKConnectorState *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_connector_state"),
+	"The state of the MSK Connect connector.",
+	[]string{"aws_region", "connector_name", "state", "kafka_connect_version"},
+	nil,
+)
+var MSKConnectorPlugin *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "msk_connector_plugin_info"),
+	"The custom plugins and their revision used by the MSK Connect connector.",
+	[]string{"aws_region", "connector_name", "plugin_arn", "plugin_revision"},
+	nil,
+)
+
+// mskEOLEngine is the engine the MSK versions are looked up with in the EOL dates
+const mskEOLEngine = "kafka"
+
+const (
+	mskClusterTypeProvisioned = "provisioned"
+	mskClusterTypeServerless  = "serverless"
+)
 
 type MSKExporter struct {
 	sessions     []*session.Session
 	svcs         []awsclient.Client
-	mskInfos     []MSKInfo
-	thresholds   []Threshold
+	eolChecker   *eol.Checker
 	cache        MetricsCache
 	awsAccountId string
 
 	logger   log.Logger
 	timeout  time.Duration
 	interval time.Duration
+	// regionConcurrency is the number of regions collected in parallel
+	regionConcurrency int
 }
 
 // NewMSKExporter creates a new MSKExporter instance
-func NewMSKExporter(sessions []*session.Session, logger log.Logger, config MSKConfig, awsAccountId string) *MSKExporter {
+func NewMSKExporter(sessions []*session.Session, logger log.Logger, config MSKConfig, awsAccountId string, eolChecker *eol.Checker) *MSKExporter {
 	level.Info(logger).Log("msg", "Initializing MSK exporter")
 
 	var msks []awsclient.Client
@@ -44,14 +110,14 @@ func NewMSKExporter(sessions []*session.Session, logger log.Logger, config MSKCo
 	}
 
 	return &MSKExporter{
-		sessions:   sessions,
-		svcs:       msks,
-		cache:      *NewMetricsCache(*config.CacheTTL),
-		logger:     logger,
-		timeout:    *config.Timeout,
-		interval:   *config.Interval,
-		mskInfos:   config.MSKInfos,
-		thresholds: config.Thresholds,
+		sessions:          sessions,
+		svcs:              msks,
+		cache:             *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:            logger,
+		timeout:           *config.Timeout,
+		interval:          *config.Interval,
+		regionConcurrency: config.RegionConcurrency,
+		eolChecker:        eolChecker.With(eol.ForEngine(mskEOLEngine, config.MSKInfos), config.Thresholds),
 	}
 }
 
@@ -59,20 +125,17 @@ func (e *MSKExporter) getRegion(sessionIndex int) string {
 	return *e.sessions[sessionIndex].Config.Region
 }
 
-func (e *MSKExporter) addMetricFromMSKInfo(sessionIndex int, clusters []*kafka.ClusterInfo, mskInfos []MSKInfo) {
+func (e *MSKExporter) addMetricFromMSKInfo(sessionIndex int, clusters []*kafka.ClusterInfo) {
 	region := e.getRegion(sessionIndex)
 
-	eolMap := make(map[string]string)
-	for _, eolinfo := range mskInfos {
-		eolMap[eolinfo.Version] = eolinfo.EOL
-	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKClusters, prometheus.GaugeValue, float64(len(clusters)), region, mskClusterTypeProvisioned))
 
 	for _, cluster := range clusters {
 		clusterName := aws.StringValue(cluster.ClusterName)
 		mskVersion := aws.StringValue(cluster.CurrentBrokerSoftwareInfo.KafkaVersion)
 
-		if eolDate, found := eolMap[mskVersion]; found {
-			eolStatus, err := GetEOLStatus(eolDate, e.thresholds)
+		if eolDate, found := e.eolChecker.Lookup(mskEOLEngine, mskVersion); found {
+			eolStatus, err := e.eolChecker.Status(eolDate)
 			if err != nil {
 				level.Error(e.logger).Log("msg", "Error determining MSK EOL status", "version", mskVersion, "error", err)
 			}
@@ -81,11 +144,67 @@ func (e *MSKExporter) addMetricFromMSKInfo(sessionIndex int, clusters []*kafka.C
 			level.Info(e.logger).Log("msg", "EOL information not found for MSK version %s, setting status to 'unknown'", mskVersion)
 			e.cache.AddMetric(prometheus.MustNewConstMetric(MSKInfos, prometheus.GaugeValue, 1, region, clusterName, mskVersion, "no-eol-date", "unknown"))
 		}
+
+		e.addClusterDetailMetrics(region, cluster)
+	}
+}
+
+// addClusterDetailMetrics adds the broker and state details of a cluster. ListClusters already returns them, so no
+// additional call per cluster is needed.
+func (e *MSKExporter) addClusterDetailMetrics(region string, cluster *kafka.ClusterInfo) {
+	clusterName := aws.StringValue(cluster.ClusterName)
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKBrokerNodes, prometheus.GaugeValue, float64(aws.Int64Value(cluster.NumberOfBrokerNodes)), region, clusterName))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKClusterState, prometheus.GaugeValue, 1, region, clusterName, aws.StringValue(cluster.State), mskClusterTypeProvisioned))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKEnhancedMonitoring, prometheus.GaugeValue, 1, region, clusterName, aws.StringValue(cluster.EnhancedMonitoring)))
+
+	if brokers := cluster.BrokerNodeGroupInfo; brokers != nil {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(MSKBrokerInstanceType, prometheus.GaugeValue, 1, region, clusterName, aws.StringValue(brokers.InstanceType)))
+		if brokers.StorageInfo != nil && brokers.StorageInfo.EbsStorageInfo != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(MSKBrokerEBSVolumeSize, prometheus.GaugeValue, float64(aws.Int64Value(brokers.StorageInfo.EbsStorageInfo.VolumeSize)), region, clusterName))
+		}
+	}
+}
+
+// addServerlessClusterMetrics adds the serverless clusters, which have no brokers and no Kafka version to report
+func (e *MSKExporter) addServerlessClusterMetrics(sessionIndex int, clusters []*kafka.Cluster) {
+	region := e.getRegion(sessionIndex)
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKClusters, prometheus.GaugeValue, float64(len(clusters)), region, mskClusterTypeServerless))
+	for _, cluster := range clusters {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(MSKClusterState, prometheus.GaugeValue, 1, region, aws.StringValue(cluster.ClusterName), aws.StringValue(cluster.State), mskClusterTypeServerless))
+	}
+}
+
+func (e *MSKExporter) addConnectorMetrics(sessionIndex int, connectors []*kafkaconnect.ConnectorSummary) {
+	region := e.getRegion(sessionIndex)
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(MSKConnectors, prometheus.GaugeValue, float64(len(connectors)), region))
+	for _, connector := range connectors {
+		connectorName := aws.StringValue(connector.ConnectorName)
+		e.cache.AddMetric(prometheus.MustNewConstMetric(MSKConnectorState, prometheus.GaugeValue, 1, region, connectorName, aws.StringValue(connector.ConnectorState), aws.StringValue(connector.KafkaConnectVersion)))
+
+		for _, plugin := range connector.Plugins {
+			if plugin.CustomPlugin == nil {
+				continue
+			}
+			revision := strconv.FormatInt(aws.Int64Value(plugin.CustomPlugin.Revision), 10)
+			e.cache.AddMetric(prometheus.MustNewConstMetric(MSKConnectorPlugin, prometheus.GaugeValue, 1, region, connectorName, aws.StringValue(plugin.CustomPlugin.CustomPluginArn), revision))
+		}
 	}
 }
 
 func (e *MSKExporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- MSKInfos
+	ch <- MSKBrokerNodes
+	ch <- MSKBrokerInstanceType
+	ch <- MSKBrokerEBSVolumeSize
+	ch <- MSKClusterState
+	ch <- MSKEnhancedMonitoring
+	ch <- MSKClusters
+	ch <- MSKConnectors
+	ch <- MSKConnectorState
+	ch <- MSKConnectorPlugin
 }
 
 func (e *MSKExporter) Collect(ch chan<- prometheus.Metric) {
@@ -94,20 +213,47 @@ func (e *MSKExporter) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
-func (e *MSKExporter) CollectLoop() {
+func (e *MSKExporter) CollectLoop(ctx context.Context) {
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
-		for i, svc := range e.svcs {
-			clusters, err := svc.ListClustersAll(ctx)
-			if err != nil {
-				level.Error(e.logger).Log("msg", "Call to ListClustersAll failed", "region", *e.sessions[i].Config.Region, "err", err)
-				continue
-			}
-			e.addMetricFromMSKInfo(i, clusters, e.mskInfos)
-		}
+		loopCtx, loop := startCollectorLoop(ctx)
+		collectRegions(loopCtx, len(e.svcs), e.regionConcurrency, e.timeout, e.collectRegion)
 		level.Info(e.logger).Log("msg", "MSK metrics updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "msk")
+		}
+
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+// collectRegion collects the metrics of the region of the session
+func (e *MSKExporter) collectRegion(ctx context.Context, i int) {
+	svc := e.svcs[i]
+	ctx, run := e.cache.startCollectorRun(ctx, "msk", e.getRegion(i))
+	clusters, err := svc.ListClustersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListClustersAll failed", "region", *e.sessions[i].Config.Region, "err", err)
+		run.fail()
+	} else {
+		e.addMetricFromMSKInfo(i, clusters)
+	}
+
+	serverlessClusters, err := svc.ListServerlessClustersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListServerlessClustersAll failed", "region", *e.sessions[i].Config.Region, "err", err)
+		run.fail()
+	} else {
+		e.addServerlessClusterMetrics(i, serverlessClusters)
+	}
 
-		cancel()
-		time.Sleep(e.interval)
+	connectors, err := svc.ListConnectorsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListConnectorsAll failed", "region", *e.sessions[i].Config.Region, "err", err)
+		run.fail()
+	} else {
+		e.addConnectorMetrics(i, connectors)
 	}
+	run.finish()
 }
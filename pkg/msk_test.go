@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kafkaconnect"
 	"github.com/go-kit/kit/log"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -24,25 +26,114 @@ func createTestClusters() []*kafka.ClusterInfo {
 	}
 }
 
+func TestAddClusterDetailMetrics(t *testing.T) {
+	e := MSKExporter{
+		cache:  *NewMetricsCache(10 * time.Second),
+		logger: log.NewNopLogger(),
+	}
+
+	e.addClusterDetailMetrics("foo", &kafka.ClusterInfo{
+		ClusterName:         aws.String("test-cluster-1"),
+		NumberOfBrokerNodes: aws.Int64(3),
+		State:               aws.String(kafka.ClusterStateActive),
+		EnhancedMonitoring:  aws.String(kafka.EnhancedMonitoringPerBroker),
+		BrokerNodeGroupInfo: &kafka.BrokerNodeGroupInfo{
+			InstanceType: aws.String("kafka.m5.large"),
+			StorageInfo: &kafka.StorageInfo{
+				EbsStorageInfo: &kafka.EBSStorageInfo{VolumeSize: aws.Int64(100)},
+			},
+		},
+	})
+
+	metrics := e.cache.GetAllMetrics()
+	if len(metrics) != 5 {
+		t.Fatalf("Expected 5 metrics, got %d", len(metrics))
+	}
+	expected := map[string]float64{
+		MSKBrokerNodes.String():         3,
+		MSKBrokerEBSVolumeSize.String(): 100,
+	}
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		if err := metric.Write(dtoMetric); err != nil {
+			t.Fatal(err)
+		}
+		if value, ok := expected[metric.Desc().String()]; ok && dtoMetric.GetGauge().GetValue() != value {
+			t.Errorf("%s has an unexpected value. Expected: %f, Actual: %f", metric.Desc(), value, dtoMetric.GetGauge().GetValue())
+		}
+	}
+
+	labels, err := getMSKMetricLabels(&e, MSKBrokerInstanceType, "instance_type")
+	if err != nil {
+		t.Errorf("Error retrieving instance type labels: %v", err)
+	}
+	if labels["instance_type"] != "kafka.m5.large" {
+		t.Errorf("Unexpected instance type %s", labels["instance_type"])
+	}
+}
+
+func TestAddServerlessClusterAndConnectorMetrics(t *testing.T) {
+	e := MSKExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	e.addServerlessClusterMetrics(0, []*kafka.Cluster{
+		{ClusterName: aws.String("test-serverless"), State: aws.String(kafka.ClusterStateActive)},
+	})
+	e.addConnectorMetrics(0, []*kafkaconnect.ConnectorSummary{
+		{
+			ConnectorName:       aws.String("test-connector"),
+			ConnectorState:      aws.String(kafkaconnect.ConnectorStateRunning),
+			KafkaConnectVersion: aws.String("2.7.1"),
+			Plugins: []*kafkaconnect.PluginDescription{
+				{CustomPlugin: &kafkaconnect.CustomPluginDescription{CustomPluginArn: aws.String("arn:plugin"), Revision: aws.Int64(3)}},
+			},
+		},
+	})
+
+	// cluster count and state, connector count, state and plugin
+	if metrics := e.cache.GetAllMetrics(); len(metrics) != 5 {
+		t.Fatalf("Expected 5 metrics, got %d", len(metrics))
+	}
+
+	labels, err := getMSKMetricLabels(&e, MSKClusterState, "cluster_type")
+	if err != nil {
+		t.Errorf("Error retrieving cluster state labels: %v", err)
+	}
+	if labels["cluster_type"] != mskClusterTypeServerless {
+		t.Errorf("Unexpected cluster type %s", labels["cluster_type"])
+	}
+
+	labels, err = getMSKMetricLabels(&e, MSKConnectorPlugin, "plugin_arn", "plugin_revision")
+	if err != nil {
+		t.Errorf("Error retrieving connector plugin labels: %v", err)
+	}
+	if labels["plugin_arn"] != "arn:plugin" || labels["plugin_revision"] != "3" {
+		t.Errorf("Unexpected connector plugin labels %v", labels)
+	}
+}
+
 func TestAddAllMSKMetricsWithEOLMatch(t *testing.T) {
-	thresholds := []Threshold{
+	thresholds := []eol.Threshold{
 		{Name: "red", Days: 90},
 		{Name: "yellow", Days: 180},
 		{Name: "green", Days: 365},
 	}
 
+	mskInfos := []MSKInfo{
+		{Version: "1000", EOL: "2000-12-01"},
+	}
+
 	e := MSKExporter{
 		sessions:   []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
 		cache:      *NewMetricsCache(10 * time.Second),
 		logger:     log.NewNopLogger(),
-		thresholds: thresholds,
+		eolChecker: eol.NewChecker(eol.ForEngine(mskEOLEngine, mskInfos), thresholds, nil),
 	}
 
-	mskInfos := []MSKInfo{
-		{Version: "1000", EOL: "2000-12-01"},
-	}
-
-	e.addMetricFromMSKInfo(0, createTestClusters(), mskInfos)
+	e.addMetricFromMSKInfo(0, createTestClusters())
 
 	labels, err := getMSKMetricLabels(&e, MSKInfos, "eol_date", "eol_status")
 	if err != nil {
@@ -62,24 +153,24 @@ func TestAddAllMSKMetricsWithEOLMatch(t *testing.T) {
 }
 
 func TestAddAllMSKMetricsWithoutEOLMatch(t *testing.T) {
-	thresholds := []Threshold{
+	thresholds := []eol.Threshold{
 		{Name: "red", Days: 90},
 		{Name: "yellow", Days: 180},
 		{Name: "green", Days: 365},
 	}
 
+	mskInfos := []MSKInfo{
+		{Version: "2000", EOL: "2000-12-01"},
+	}
+
 	e := MSKExporter{
 		sessions:   []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
 		cache:      *NewMetricsCache(10 * time.Second),
 		logger:     log.NewNopLogger(),
-		thresholds: thresholds,
-	}
-
-	mskInfos := []MSKInfo{
-		{Version: "2000", EOL: "2000-12-01"},
+		eolChecker: eol.NewChecker(eol.ForEngine(mskEOLEngine, mskInfos), thresholds, nil),
 	}
 
-	e.addMetricFromMSKInfo(0, createTestClusters(), mskInfos)
+	e.addMetricFromMSKInfo(0, createTestClusters())
 
 	labels, err := getMSKMetricLabels(&e, MSKInfos, "eol_date", "eol_status")
 	if err != nil {
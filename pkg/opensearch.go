@@ -0,0 +1,192 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DescribeDomains accepts at most 5 domain names per call
+const describeDomainsMaxNames = 5
+
+var OpenSearchInfos *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "opensearch_eol_info"),
+	"The OpenSearch/Elasticsearch engine version and the eol date and status for the version.",
+	[]string{"aws_region", "domain_name", "engine_version", "eol_date", "eol_status"},
+	nil,
+)
+
+var OpenSearchInstanceCount *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "opensearch_instance_count"),
+	"The number of data instances of the OpenSearch domain.",
+	[]string{"aws_region", "domain_name"},
+	nil,
+)
+
+var OpenSearchEBSVolumeSize *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "opensearch_ebs_volume_size_gib"),
+	"The size of the EBS volume attached to each data instance of the OpenSearch domain (in GiB). 0 if EBS is disabled.",
+	[]string{"aws_region", "domain_name"},
+	nil,
+)
+
+var OpenSearchEncryptionAtRest *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "opensearch_encryption_at_rest_enabled"),
+	"Whether encryption at rest is enabled for the OpenSearch domain.",
+	[]string{"aws_region", "domain_name"},
+	nil,
+)
+
+// openSearchEOLEngine is the engine the domain versions are looked up with in the EOL dates
+const openSearchEOLEngine = "opensearch"
+
+type OpenSearchExporter struct {
+	sessions   []*session.Session
+	eolChecker *eol.Checker
+	cache      MetricsCache
+
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewOpenSearchExporter creates a new OpenSearchExporter instance
+func NewOpenSearchExporter(sessions []*session.Session, logger log.Logger, config OpenSearchConfig, awsAccountId string, eolChecker *eol.Checker) *OpenSearchExporter {
+	level.Info(logger).Log("msg", "Initializing OpenSearch exporter")
+
+	return &OpenSearchExporter{
+		sessions:   sessions,
+		eolChecker: eolChecker.With(eol.ForEngine(openSearchEOLEngine, config.OpenSearchInfos), config.Thresholds),
+		cache:      *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:     logger,
+		timeout:    *config.Timeout,
+		interval:   *config.Interval,
+	}
+}
+
+func (e *OpenSearchExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- OpenSearchInfos
+	ch <- OpenSearchInstanceCount
+	ch <- OpenSearchEBSVolumeSize
+	ch <- OpenSearchEncryptionAtRest
+}
+
+func (e *OpenSearchExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *OpenSearchExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "OpenSearch metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "opensearch")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *OpenSearchExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "opensearch", region)
+	defer run.finish()
+
+	domains, err := getOpenSearchDomainsWithContext(client, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve OpenSearch domains", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.addMetricFromOpenSearchInfo(region, domains)
+}
+
+func (e *OpenSearchExporter) addMetricFromOpenSearchInfo(region string, domains []*opensearchservice.DomainStatus) {
+	for _, domain := range domains {
+		domainName := aws.StringValue(domain.DomainName)
+		engineVersion := aws.StringValue(domain.EngineVersion)
+
+		if eolDate, found := e.eolChecker.Lookup(openSearchEOLEngine, engineVersion); found {
+			eolStatus, err := e.eolChecker.Status(eolDate)
+			if err != nil {
+				level.Error(e.logger).Log("msg", "Error determining OpenSearch EOL status", "version", engineVersion, "error", err)
+			}
+			e.cache.AddMetric(prometheus.MustNewConstMetric(OpenSearchInfos, prometheus.GaugeValue, 1, region, domainName, engineVersion, eolDate, eolStatus))
+		} else {
+			level.Info(e.logger).Log("msg", "EOL information not found for OpenSearch version, setting status to 'unknown'", "version", engineVersion)
+			e.cache.AddMetric(prometheus.MustNewConstMetric(OpenSearchInfos, prometheus.GaugeValue, 1, region, domainName, engineVersion, "no-eol-date", "unknown"))
+		}
+
+		var instanceCount, volumeSize float64
+		if domain.ClusterConfig != nil {
+			instanceCount = float64(aws.Int64Value(domain.ClusterConfig.InstanceCount))
+		}
+		if domain.EBSOptions != nil && aws.BoolValue(domain.EBSOptions.EBSEnabled) {
+			volumeSize = float64(aws.Int64Value(domain.EBSOptions.VolumeSize))
+		}
+		var encrypted float64
+		if domain.EncryptionAtRestOptions != nil && aws.BoolValue(domain.EncryptionAtRestOptions.Enabled) {
+			encrypted = 1
+		}
+
+		e.cache.AddMetric(prometheus.MustNewConstMetric(OpenSearchInstanceCount, prometheus.GaugeValue, instanceCount, region, domainName))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(OpenSearchEBSVolumeSize, prometheus.GaugeValue, volumeSize, region, domainName))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(OpenSearchEncryptionAtRest, prometheus.GaugeValue, encrypted, region, domainName))
+	}
+}
+
+// getOpenSearchDomainsWithContext lists the domain names and describes them in batches, as the list call does not
+// return the domain details
+func getOpenSearchDomainsWithContext(client awsclient.Client, ctx context.Context) ([]*opensearchservice.DomainStatus, error) {
+	listOutput, err := client.ListDomainNamesWithContext(ctx, &opensearchservice.ListDomainNamesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var domainNames []*string
+	for _, domainInfo := range listOutput.DomainNames {
+		domainNames = append(domainNames, domainInfo.DomainName)
+	}
+
+	var domains []*opensearchservice.DomainStatus
+	for start := 0; start < len(domainNames); start += describeDomainsMaxNames {
+		end := start + describeDomainsMaxNames
+		if end > len(domainNames) {
+			end = len(domainNames)
+		}
+
+		describeOutput, err := client.DescribeDomainsWithContext(ctx, &opensearchservice.DescribeDomainsInput{DomainNames: domainNames[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, describeOutput.DomainStatusList...)
+	}
+	return domains, nil
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestOpenSearchDomains() []*opensearchservice.DomainStatus {
+	return []*opensearchservice.DomainStatus{
+		{
+			DomainName:    aws.String("test-domain-1"),
+			EngineVersion: aws.String("OpenSearch_2.11"),
+			ClusterConfig: &opensearchservice.ClusterConfig{InstanceCount: aws.Int64(3)},
+			EBSOptions:    &opensearchservice.EBSOptions{EBSEnabled: aws.Bool(true), VolumeSize: aws.Int64(100)},
+			EncryptionAtRestOptions: &opensearchservice.EncryptionAtRestOptions{
+				Enabled: aws.Bool(true),
+			},
+		},
+	}
+}
+
+func createTestOpenSearchExporter(openSearchInfos []OpenSearchInfo) *OpenSearchExporter {
+	config := OpenSearchConfig{
+		BaseConfig:      createTestBaseConfig(),
+		OpenSearchInfos: openSearchInfos,
+		Thresholds: []eol.Threshold{
+			{Name: "red", Days: 90},
+			{Name: "yellow", Days: 180},
+			{Name: "green", Days: 365},
+		},
+	}
+	return NewOpenSearchExporter(nil, log.NewNopLogger(), config, "1234567890", nil)
+}
+
+func TestAddOpenSearchMetricsWithEOLMatch(t *testing.T) {
+	e := createTestOpenSearchExporter([]OpenSearchInfo{{Version: "OpenSearch_2.11", EOL: "2000-12-01"}})
+
+	e.addMetricFromOpenSearchInfo("foo", createTestOpenSearchDomains())
+	assert.Len(t, e.cache.GetAllMetrics(), 4)
+
+	labels, err := getOpenSearchMetricLabels(e)
+	assert.Nil(t, err)
+	assert.Equal(t, "2000-12-01", labels["eol_date"])
+	assert.Equal(t, "red", labels["eol_status"])
+}
+
+func TestAddOpenSearchMetricsWithoutEOLMatch(t *testing.T) {
+	e := createTestOpenSearchExporter([]OpenSearchInfo{{Version: "Elasticsearch_7.10", EOL: "2000-12-01"}})
+
+	e.addMetricFromOpenSearchInfo("foo", createTestOpenSearchDomains())
+
+	labels, err := getOpenSearchMetricLabels(e)
+	assert.Nil(t, err)
+	assert.Equal(t, "no-eol-date", labels["eol_date"])
+	assert.Equal(t, "unknown", labels["eol_status"])
+}
+
+func TestGetOpenSearchDomainsWithContextBatches(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var domainInfos []*opensearchservice.DomainInfo
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		domainInfos = append(domainInfos, &opensearchservice.DomainInfo{DomainName: aws.String(name)})
+	}
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListDomainNamesWithContext(ctx, &opensearchservice.ListDomainNamesInput{}).Return(
+		&opensearchservice.ListDomainNamesOutput{DomainNames: domainInfos}, nil)
+	mockClient.EXPECT().DescribeDomainsWithContext(ctx, &opensearchservice.DescribeDomainsInput{
+		DomainNames: aws.StringSlice([]string{"a", "b", "c", "d", "e"}),
+	}).Return(&opensearchservice.DescribeDomainsOutput{DomainStatusList: make([]*opensearchservice.DomainStatus, 5)}, nil)
+	mockClient.EXPECT().DescribeDomainsWithContext(ctx, &opensearchservice.DescribeDomainsInput{
+		DomainNames: aws.StringSlice([]string{"f", "g"}),
+	}).Return(&opensearchservice.DescribeDomainsOutput{DomainStatusList: make([]*opensearchservice.DomainStatus, 2)}, nil)
+
+	domains, err := getOpenSearchDomainsWithContext(mockClient, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, domains, 7)
+}
+
+func getOpenSearchMetricLabels(x *OpenSearchExporter) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, metric := range x.cache.GetAllMetrics() {
+		if metric.Desc().String() != OpenSearchInfos.String() {
+			continue
+		}
+		dtoMetric := &dto.Metric{}
+		if err := metric.Write(dtoMetric); err != nil {
+			return nil, err
+		}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+	}
+	return labels, nil
+}
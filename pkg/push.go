@@ -0,0 +1,311 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	PUSH_TYPE_REMOTE_WRITE = "remote_write"
+	PUSH_TYPE_PUSHGATEWAY  = "pushgateway"
+
+	pushDefaultJob = "aws_resource_exporter"
+)
+
+// PushConfig configures pushing the metrics to a Prometheus remote_write endpoint or a Pushgateway, for environments
+// where the exporter can't be scraped. Pushing is disabled without a URL.
+type PushConfig struct {
+	URL string `yaml:"url"`
+	// Type is remote_write or pushgateway
+	Type     string         `yaml:"type"`
+	Interval *time.Duration `yaml:"interval"`
+	Timeout  *time.Duration `yaml:"timeout"`
+	// Job groups the metrics on the Pushgateway and is the job label of the remote_write series
+	Job string `yaml:"job"`
+	// ExternalLabels are added to every pushed series, e.g. an instance label to tell several exporters apart
+	ExternalLabels map[string]string `yaml:"external_labels"`
+	BasicAuth      *PushBasicAuth    `yaml:"basic_auth"`
+	BearerToken    string            `yaml:"bearer_token"`
+}
+
+type PushBasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+func (c PushConfig) validate() error {
+	if c.URL == "" {
+		return nil
+	}
+	var errs []error
+	if u, err := url.Parse(c.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("push: invalid url %q", c.URL))
+	}
+	if c.Type != PUSH_TYPE_REMOTE_WRITE && c.Type != PUSH_TYPE_PUSHGATEWAY {
+		errs = append(errs, fmt.Errorf("push: invalid type %q, expected %s or %s", c.Type, PUSH_TYPE_REMOTE_WRITE, PUSH_TYPE_PUSHGATEWAY))
+	}
+	if durationValue(c.Timeout) <= 0 || durationValue(c.Timeout) >= durationValue(c.Interval) {
+		errs = append(errs, errors.New("push: timeout has to be positive and shorter than the interval"))
+	}
+	if c.BasicAuth != nil && c.BearerToken != "" {
+		errs = append(errs, errors.New("push: basic_auth and bearer_token are mutually exclusive"))
+	}
+	for _, name := range sortedKeys(c.ExternalLabels) {
+		if name == model.JobLabel {
+			errs = append(errs, errors.New("push: the job label can't be an external label, it's set with job"))
+		} else if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			errs = append(errs, fmt.Errorf("push: invalid external label name %q", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c PushConfig) job() string {
+	if c.Job == "" {
+		return pushDefaultJob
+	}
+	return c.Job
+}
+
+// Pusher pushes the gathered metrics every interval
+type Pusher struct {
+	config   PushConfig
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	logger   log.Logger
+}
+
+// NewPusher creates a pusher for the validated configuration
+func NewPusher(logger log.Logger, config PushConfig, gatherer prometheus.Gatherer) *Pusher {
+	return &Pusher{
+		config:   config,
+		gatherer: gatherer,
+		client:   &http.Client{},
+		logger:   logger,
+	}
+}
+
+// PushLoop pushes the metrics until the context is cancelled. The metrics are pushed a last time before it returns,
+// so the final state of the collectors isn't lost on shutdown.
+func (p *Pusher) PushLoop(ctx context.Context) {
+	for {
+		if !sleepUntilDone(ctx, *p.config.Interval) {
+			// the context is done, so the last push gets its own timeout
+			p.pushWithTimeout(context.Background())
+			return
+		}
+		p.pushWithTimeout(ctx)
+	}
+}
+
+func (p *Pusher) pushWithTimeout(ctx context.Context) {
+	pushCtx, cancel := context.WithTimeout(ctx, *p.config.Timeout)
+	defer cancel()
+	if err := p.Push(pushCtx); err != nil {
+		level.Error(p.logger).Log("msg", "Could not push metrics", "url", p.config.URL, "err", err)
+		return
+	}
+	level.Debug(p.logger).Log("msg", "Metrics pushed", "url", p.config.URL)
+}
+
+// Push pushes the gathered metrics once
+func (p *Pusher) Push(ctx context.Context) error {
+	if p.config.Type == PUSH_TYPE_PUSHGATEWAY {
+		return p.pushToPushgateway(ctx)
+	}
+	return p.pushRemoteWrite(ctx)
+}
+
+// pushToPushgateway replaces the metrics of the job on the Pushgateway, so deleted resources disappear. The external
+// labels are part of the grouping key, so the Pushgateway adds them to every series.
+func (p *Pusher) pushToPushgateway(ctx context.Context) error {
+	pusher := push.New(p.config.URL, p.config.job()).Gatherer(p.gatherer).Client(p.client)
+	for _, name := range sortedKeys(p.config.ExternalLabels) {
+		pusher = pusher.Grouping(name, p.config.ExternalLabels[name])
+	}
+	if auth := p.config.BasicAuth; auth != nil {
+		pusher = pusher.BasicAuth(auth.Username, auth.Password)
+	}
+	if p.config.BearerToken != "" {
+		pusher = pusher.Header(http.Header{"Authorization": {"Bearer " + p.config.BearerToken}})
+	}
+	return pusher.PushContext(ctx)
+}
+
+func (p *Pusher) pushRemoteWrite(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	// like the Pushgateway, the series get the job label and the external labels
+	labels := map[string]string{model.JobLabel: p.config.job()}
+	for name, value := range p.config.ExternalLabels {
+		labels[name] = value
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(families, labels, time.Now()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if auth := p.config.BasicAuth; auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	if p.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.config.BearerToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// sample is a single series of the remote_write request
+type sample struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// encodeWriteRequest encodes the metric families as remote_write v1 WriteRequest protobuf message. Histograms and
+// summaries are split into their series like in the text format. Metrics without timestamp get the given time. The
+// external labels are added to every series, unless the metric has a label of the same name.
+func encodeWriteRequest(families []*dto.MetricFamily, externalLabels map[string]string, now time.Time) []byte {
+	var request []byte
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, s := range familySamples(family, metric, externalLabels, now.UnixMilli()) {
+				request = protowire.AppendTag(request, 1, protowire.BytesType)
+				request = protowire.AppendBytes(request, encodeTimeSeries(s))
+			}
+		}
+	}
+	return request
+}
+
+func familySamples(family *dto.MetricFamily, metric *dto.Metric, externalLabels map[string]string, now int64) []sample {
+	timestamp := now
+	if metric.TimestampMs != nil {
+		timestamp = metric.GetTimestampMs()
+	}
+	newSample := func(suffix string, value float64, extra ...string) sample {
+		labels := map[string]string{}
+		for name, value := range externalLabels {
+			labels[name] = value
+		}
+		labels[model.MetricNameLabel] = family.GetName() + suffix
+		// empty labels are the same as missing ones
+		for _, label := range metric.GetLabel() {
+			if label.GetValue() != "" {
+				labels[label.GetName()] = label.GetValue()
+			}
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			labels[extra[i]] = extra[i+1]
+		}
+		return sample{labels: labels, value: value, timestamp: timestamp}
+	}
+
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		return []sample{newSample("", metric.GetCounter().GetValue())}
+	case dto.MetricType_GAUGE:
+		return []sample{newSample("", metric.GetGauge().GetValue())}
+	case dto.MetricType_HISTOGRAM:
+		histogram := metric.GetHistogram()
+		var samples []sample
+		for _, bucket := range histogram.GetBucket() {
+			samples = append(samples, newSample("_bucket", float64(bucket.GetCumulativeCount()), "le", formatFloat(bucket.GetUpperBound())))
+		}
+		return append(samples,
+			newSample("_bucket", float64(histogram.GetSampleCount()), "le", "+Inf"),
+			newSample("_sum", histogram.GetSampleSum()),
+			newSample("_count", float64(histogram.GetSampleCount())),
+		)
+	case dto.MetricType_SUMMARY:
+		summary := metric.GetSummary()
+		var samples []sample
+		for _, quantile := range summary.GetQuantile() {
+			samples = append(samples, newSample("", quantile.GetValue(), "quantile", formatFloat(quantile.GetQuantile())))
+		}
+		return append(samples,
+			newSample("_sum", summary.GetSampleSum()),
+			newSample("_count", float64(summary.GetSampleCount())),
+		)
+	default:
+		return []sample{newSample("", metric.GetUntyped().GetValue())}
+	}
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// encodeTimeSeries encodes the sample as TimeSeries message, whose labels have to be sorted by name
+func encodeTimeSeries(s sample) []byte {
+	names := make([]string, 0, len(s.labels))
+	for name := range s.labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var series []byte
+	for _, name := range names {
+		var label []byte
+		label = protowire.AppendTag(label, 1, protowire.BytesType)
+		label = protowire.AppendString(label, name)
+		label = protowire.AppendTag(label, 2, protowire.BytesType)
+		label = protowire.AppendString(label, s.labels[name])
+		series = protowire.AppendTag(series, 1, protowire.BytesType)
+		series = protowire.AppendBytes(series, label)
+	}
+
+	var value []byte
+	value = protowire.AppendTag(value, 1, protowire.Fixed64Type)
+	value = protowire.AppendFixed64(value, math.Float64bits(s.value))
+	value = protowire.AppendTag(value, 2, protowire.VarintType)
+	value = protowire.AppendVarint(value, uint64(s.timestamp))
+	series = protowire.AppendTag(series, 2, protowire.BytesType)
+	return protowire.AppendBytes(series, value)
+}
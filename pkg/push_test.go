@@ -0,0 +1,128 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest decodes the samples of a remote_write request, keyed by their sorted labels
+func decodeWriteRequest(request []byte) map[string]float64 {
+	samples := map[string]float64{}
+	for len(request) > 0 {
+		_, _, n := protowire.ConsumeTag(request)
+		series, m := protowire.ConsumeBytes(request[n:])
+		request = request[n+m:]
+
+		key := ""
+		var value float64
+		for len(series) > 0 {
+			num, _, n := protowire.ConsumeTag(series)
+			field, m := protowire.ConsumeBytes(series[n:])
+			series = series[n+m:]
+			if num == 1 {
+				_, _, n := protowire.ConsumeTag(field)
+				name, m := protowire.ConsumeString(field[n:])
+				_, _, o := protowire.ConsumeTag(field[n+m:])
+				labelValue, _ := protowire.ConsumeString(field[n+m+o:])
+				key += name + "=" + labelValue + ","
+			} else {
+				_, _, n := protowire.ConsumeTag(field)
+				bits, _ := protowire.ConsumeFixed64(field[n:])
+				value = math.Float64frombits(bits)
+			}
+		}
+		samples[key] = value
+	}
+	return samples
+}
+
+func TestPusherRemoteWrite(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge", Help: "help"}, []string{"aws_region", "empty"})
+	gauge.WithLabelValues("us-east-1", "").Set(3)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_duration", Help: "help", Buckets: []float64{1}})
+	histogram.Observe(0.5)
+	registry.MustRegister(gauge, histogram)
+
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	config := PushConfig{URL: server.URL, Type: PUSH_TYPE_REMOTE_WRITE, BearerToken: "token", ExternalLabels: map[string]string{"instance": "exporter-1", "aws_region": "eu-west-1"}}
+	assert.Nil(t, NewPusher(log.NewNopLogger(), config, registry).Push(context.Background()))
+	assert.Equal(t, "snappy", header.Get("Content-Encoding"))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+
+	request, err := snappy.Decode(nil, body)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]float64{
+		// the labels of the metric take precedence over the external labels
+		"__name__=test_gauge,aws_region=us-east-1,instance=exporter-1,job=aws_resource_exporter,":                   3,
+		"__name__=test_duration_bucket,aws_region=eu-west-1,instance=exporter-1,job=aws_resource_exporter,le=1,":    1,
+		"__name__=test_duration_bucket,aws_region=eu-west-1,instance=exporter-1,job=aws_resource_exporter,le=+Inf,": 1,
+		"__name__=test_duration_sum,aws_region=eu-west-1,instance=exporter-1,job=aws_resource_exporter,":            0.5,
+		"__name__=test_duration_count,aws_region=eu-west-1,instance=exporter-1,job=aws_resource_exporter,":          1,
+	}, decodeWriteRequest(request))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	config.URL = failing.URL
+	assert.ErrorContains(t, NewPusher(log.NewNopLogger(), config, registry).Push(context.Background()), "out of order sample")
+}
+
+func TestPusherPushgateway(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "help"}))
+
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+	}))
+	defer server.Close()
+
+	config := PushConfig{URL: server.URL, Type: PUSH_TYPE_PUSHGATEWAY}
+	assert.Nil(t, NewPusher(log.NewNopLogger(), config, registry).Push(context.Background()))
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/"+pushDefaultJob, path)
+
+	// the external labels are part of the grouping key
+	config.Job = "aws"
+	config.ExternalLabels = map[string]string{"instance": "exporter-1", "cluster": "prod"}
+	assert.Nil(t, NewPusher(log.NewNopLogger(), config, registry).Push(context.Background()))
+	// the order of the grouping labels in the path isn't fixed
+	assert.True(t, strings.HasPrefix(path, "/metrics/job/aws/"))
+	assert.Contains(t, path, "/cluster/prod")
+	assert.Contains(t, path, "/instance/exporter-1")
+}
+
+func TestPushConfigValidate(t *testing.T) {
+	assert.Nil(t, PushConfig{}.validate())
+	assert.Nil(t, PushConfig{URL: "http://pushgateway:9091", Type: PUSH_TYPE_PUSHGATEWAY, Interval: durationPtr(time.Minute), Timeout: durationPtr(time.Second)}.validate())
+
+	err := PushConfig{URL: "pushgateway", Type: "push", Interval: durationPtr(time.Second), Timeout: durationPtr(time.Minute), BasicAuth: &PushBasicAuth{}, BearerToken: "token", ExternalLabels: map[string]string{"job": "aws", "__name__": "up", "in-stance": "1"}}.validate()
+	assert.ErrorContains(t, err, "push: invalid url")
+	assert.ErrorContains(t, err, `push: invalid type "push"`)
+	assert.ErrorContains(t, err, "push: timeout")
+	assert.ErrorContains(t, err, "mutually exclusive")
+	assert.ErrorContains(t, err, "the job label can't be an external label")
+	assert.ErrorContains(t, err, `invalid external label name "__name__"`)
+	assert.ErrorContains(t, err, `invalid external label name "in-stance"`)
+}
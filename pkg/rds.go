@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/go-kit/kit/log"
@@ -18,26 +21,25 @@ import (
 // Default TTL value for RDS logs related metrics
 // To get the log metrics an api call for each instance is needed
 // Since this cause rate limit problems to the AWS api, these metrics
-// are cached for this amount of time before requesting them again
+// are cached for this amount of time before requesting them again.
+// The environment variable is deprecated in favor of rds.logs_metrics_ttl.
 var RDS_LOGS_METRICS_TTL = "LOGS_METRICS_TTL"
-var RDS_LOGS_METRICS_TTL_DEFAULT = 300
+var RDS_LOGS_METRICS_TTL_DEFAULT = 300 * time.Second
 
 // RDS log metrics are requested in parallel with a workerPool.
-// this variable sets the number of workers
+// this variable sets the number of workers.
+// The environment variable is deprecated in favor of rds.logs_metrics_workers.
 var RDS_LOGS_METRICS_WORKERS = "LOGS_METRICS_WORKERS"
 var RDS_LOGS_METRICS_WORKERS_DEFAULT = 10
 
 // Struct to store RDS Instances log files data
-// This struct is used to store the data in the MetricsProxy
 type RDSLogsMetrics struct {
 	logs         int
 	totalLogSize int64
 }
 
-// MetricsProxy
-var metricsProxy = NewMetricProxy()
-
 // DBMaxConnections is a hardcoded map of instance types and DB Parameter Group names
+// It is only used if the `max_connections` formula of the DB Parameter Group can't be evaluated.
 // This is a dump workaround created because by default the DB Parameter Group `max_connections` is a function
 // that is hard to parse and process in code and it contains a variable whose value is unknown to us (DBInstanceClassMemory)
 // AWS has no means to return the actual `max_connections` value.
@@ -416,6 +418,24 @@ var StorageEncrypted *prometheus.Desc = prometheus.NewDesc(
 	[]string{"aws_region", "dbinstance_identifier"},
 	nil,
 )
+var IAMAuthEnabled *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_iamauth_enabled"),
+	"Indicates if IAM database authentication is enabled for the DB",
+	[]string{"aws_region", "dbinstance_identifier"},
+	nil,
+)
+var DeletionProtection *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_deletion_protection"),
+	"Indicates if deletion protection is enabled for the DB",
+	[]string{"aws_region", "dbinstance_identifier"},
+	nil,
+)
+var MultiAZ *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_multiaz"),
+	"Indicates if the DB is a Multi-AZ deployment",
+	[]string{"aws_region", "dbinstance_identifier"},
+	nil,
+)
 var LogsStorageSize *prometheus.Desc = prometheus.NewDesc(
 	prometheus.BuildFQName(namespace, "", "rds_logsstorage_size_bytes"),
 	"The amount of storage consumed by log files (in bytes)",
@@ -439,55 +459,54 @@ var EOLInfos *prometheus.Desc = prometheus.NewDesc(
 type RDSExporter struct {
 	sessions     []*session.Session
 	svcs         []awsclient.Client
-	eolInfos     []EOLInfo
-	thresholds   []Threshold
+	eolChecker   *eol.Checker
 	awsAccountId string
 
-	workers        int
-	logsMetricsTTL int
+	workers int
+	// regionConcurrency is the number of regions collected in parallel
+	regionConcurrency int
+	logsMetricsTTL    time.Duration
+	maxConnections    *maxConnectionsEvaluator
+	// staticMaxConnections is DBMaxConnections with the overrides of the config applied
+	staticMaxConnections map[string]map[string]int64
+	tagLabels            *tagLabels
+	filter               *resourceFilter
 
 	logger   log.Logger
 	cache    MetricsCache
 	interval time.Duration
 	timeout  time.Duration
+
+	rdsQuotaDescs
 }
 
 // NewRDSExporter creates a new RDSExporter instance
-func NewRDSExporter(sessions []*session.Session, logger log.Logger, config RDSConfig, awsAccountId string) *RDSExporter {
+func NewRDSExporter(sessions []*session.Session, logger log.Logger, config RDSConfig, awsAccountId string, eolChecker *eol.Checker) *RDSExporter {
 	level.Info(logger).Log("msg", "Initializing RDS exporter")
 
-	workers, _ := GetEnvIntValue(RDS_LOGS_METRICS_WORKERS)
-	if workers == nil {
-		workers = &RDS_LOGS_METRICS_WORKERS_DEFAULT
-		level.Info(logger).Log("msg", fmt.Sprintf("Using default value for number Workers: %d", RDS_LOGS_METRICS_WORKERS_DEFAULT))
-	} else {
-		level.Info(logger).Log("msg", fmt.Sprintf("Using Env value for number of Workers: %d", *workers))
-	}
-
-	logMetricsTTL, _ := GetEnvIntValue(RDS_LOGS_METRICS_TTL)
-	if logMetricsTTL == nil {
-		logMetricsTTL = &RDS_LOGS_METRICS_TTL_DEFAULT
-		level.Info(logger).Log("msg", fmt.Sprintf("Using default value for logs metrics TTL: %d", RDS_LOGS_METRICS_TTL_DEFAULT))
-	} else {
-		level.Info(logger).Log("msg", fmt.Sprintf("Using Env value for logs metrics TTL: %d", *logMetricsTTL))
-	}
+	level.Info(logger).Log("msg", "Requesting the log metrics", "workers", config.LogsMetricsWorkers, "ttl", durationValue(config.LogsMetricsTTL))
 	var rdses []awsclient.Client
 	for _, session := range sessions {
 		rdses = append(rdses, awsclient.NewClientFromSession(session))
 	}
 
 	return &RDSExporter{
-		sessions:       sessions,
-		svcs:           rdses,
-		workers:        *workers,
-		logsMetricsTTL: *logMetricsTTL,
-		logger:         logger,
-		cache:          *NewMetricsCache(*config.CacheTTL),
-		interval:       *config.Interval,
-		timeout:        *config.Timeout,
-		eolInfos:       config.EOLInfos,
-		thresholds:     config.Thresholds,
-		awsAccountId:   awsAccountId,
+		sessions:             sessions,
+		svcs:                 rdses,
+		workers:              config.LogsMetricsWorkers,
+		regionConcurrency:    config.RegionConcurrency,
+		logsMetricsTTL:       durationValue(config.LogsMetricsTTL),
+		maxConnections:       newMaxConnectionsEvaluator(),
+		staticMaxConnections: mergeMaxConnections(DBMaxConnections, config.MaxConnectionsOverrides),
+		tagLabels:            newTagLabels(config.TagLabels, "dbinstance_identifier"),
+		filter:               newResourceFilter(config.Filter),
+		logger:               logger,
+		cache:                *NewMetricsCacheFromConfig(config.BaseConfig),
+		interval:             *config.Interval,
+		timeout:              *config.Timeout,
+		eolChecker:           eolChecker.With(config.EOLInfos, config.Thresholds),
+		awsAccountId:         awsAccountId,
+		rdsQuotaDescs:        newRDSQuotaDescs(awsAccountId),
 	}
 
 }
@@ -519,91 +538,116 @@ func (e *RDSExporter) requestRDSLogMetrics(ctx context.Context, sessionIndex int
 	return logMetrics, nil
 }
 
+// addRDSLogMetrics adds the log metrics of the instance with the logs metrics TTL. They are only requested again once
+// they expired in the cache.
 func (e *RDSExporter) addRDSLogMetrics(ctx context.Context, sessionIndex int, instanceId string) error {
-	instaceLogFilesId := instanceId + "-" + "logfiles"
-	var logMetrics *RDSLogsMetrics
-	cachedItem, err := metricsProxy.GetMetricById(instaceLogFilesId)
+	region := e.getRegion(sessionIndex)
+	if e.cache.HasMetric(LogsAmount, region, instanceId) && e.cache.HasMetric(LogsStorageSize, region, instanceId) {
+		return nil
+	}
+
+	logMetrics, err := e.requestRDSLogMetrics(ctx, sessionIndex, instanceId)
 	if err != nil {
-		logMetrics, err = e.requestRDSLogMetrics(ctx, sessionIndex, instanceId)
-		if err != nil {
-			return err
-		}
-		metricsProxy.StoreMetricById(instaceLogFilesId, logMetrics, e.logsMetricsTTL)
-	} else {
-		logMetrics = cachedItem.value.(*RDSLogsMetrics)
+		return err
 	}
-	e.cache.AddMetric(prometheus.MustNewConstMetric(LogsAmount, prometheus.GaugeValue, float64(logMetrics.logs), e.getRegion(sessionIndex), instanceId))
-	e.cache.AddMetric(prometheus.MustNewConstMetric(LogsStorageSize, prometheus.GaugeValue, float64(logMetrics.totalLogSize), e.getRegion(sessionIndex), instanceId))
+	e.cache.AddMetricWithTTL(prometheus.MustNewConstMetric(LogsAmount, prometheus.GaugeValue, float64(logMetrics.logs), region, instanceId), e.logsMetricsTTL)
+	e.cache.AddMetricWithTTL(prometheus.MustNewConstMetric(LogsStorageSize, prometheus.GaugeValue, float64(logMetrics.totalLogSize), region, instanceId), e.logsMetricsTTL)
 	return nil
 }
 
-func (e *RDSExporter) addAllLogMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance) {
-	wg := &sync.WaitGroup{}
-	wg.Add(len(instances))
+func (e *RDSExporter) addAllLogMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance) error {
+	var failed atomic.Int32
+	sem := newSemaphore(e.workers)
+	for i, instance := range instances {
+		instanceName := *instance.DBInstanceIdentifier
+		started := sem.Go(ctx, func() {
+			if err := e.addRDSLogMetrics(ctx, sessionIndex, instanceName); err != nil {
+				failed.Add(1)
+			}
+		})
+		if !started {
+			failed.Add(int32(len(instances) - i))
+			break
+		}
+	}
+	sem.Wait()
 
-	// this channel is used to limit the number of concurrency
-	sem := make(chan int, e.workers)
+	if failed.Load() > 0 {
+		return fmt.Errorf("could not get the log metrics of %d instances", failed.Load())
+	}
+	return nil
+}
 
-	defer close(sem)
-	for _, instance := range instances {
-		sem <- 1
-		go func(instanceName string) {
-			defer func() {
-				<-sem
-				wg.Done()
-			}()
-			e.addRDSLogMetrics(ctx, sessionIndex, instanceName)
-		}(*instance.DBInstanceIdentifier)
+// mergeMaxConnections returns a copy of the mapping with the overrides applied, so the built-in map is never modified
+func mergeMaxConnections(mapping map[string]map[string]int64, overrides map[string]map[string]int64) map[string]map[string]int64 {
+	merged := make(map[string]map[string]int64, len(mapping))
+	for instanceClass, groups := range mapping {
+		merged[instanceClass] = make(map[string]int64, len(groups))
+		for group, value := range groups {
+			merged[instanceClass][group] = value
+		}
 	}
-	wg.Wait()
+	for instanceClass, groups := range overrides {
+		if _, ok := merged[instanceClass]; !ok {
+			merged[instanceClass] = make(map[string]int64, len(groups))
+		}
+		for group, value := range groups {
+			merged[instanceClass][group] = value
+		}
+	}
+	return merged
 }
 
-func (e *RDSExporter) addAllInstanceMetrics(sessionIndex int, instances []*rds.DBInstance, eolInfos []EOLInfo) {
-	var eolMap = make(map[EOLKey]EOLInfo)
+// getStaticMaxConnections looks up the max_connections of the instance in the static mapping
+func getStaticMaxConnections(mapping map[string]map[string]int64, instanceClass string, parameterGroup string) (int64, bool) {
+	valmap, ok := mapping[instanceClass]
+	if !ok {
+		return 0, false
+	}
+	if val, ok := valmap[parameterGroup]; ok {
+		return val, true
+	}
+	val, ok := valmap["default"]
+	return val, ok
+}
 
-	// Fill eolMap with EOLInfo indexed by engine and version
-	for _, eolinfo := range eolInfos {
-		eolMap[EOLKey{Engine: eolinfo.Engine, Version: eolinfo.Version}] = eolinfo
+// addAllInstanceMetrics adds the metrics of the instances. The max_connections are taken from maxConnections
+// (evaluated from the parameter groups) and only looked up in the static map for instances missing there.
+func (e *RDSExporter) addAllInstanceMetrics(sessionIndex int, instances []*rds.DBInstance, maxConnections map[string]int64) {
+	tags := map[string]map[string]string{}
+	// the previous tags are kept if the instances couldn't be described
+	if len(instances) > 0 {
+		defer e.tagLabels.setRegionTags(e.getRegion(sessionIndex), tags)
 	}
 
 	for _, instance := range instances {
-		var maxConnections int64
-		if valmap, ok := DBMaxConnections[*instance.DBInstanceClass]; ok {
-			var maxconn int64
-			var found bool
-			if val, ok := valmap[*instance.DBParameterGroups[0].DBParameterGroupName]; ok {
-				maxconn = val
-				found = true
-			} else if val, ok := valmap["default"]; ok {
-				maxconn = val
-				found = true
-			}
+		tags[*instance.DBInstanceIdentifier] = rdsTags(instance.TagList)
+		instanceMaxConnections, found := maxConnections[*instance.DBInstanceIdentifier]
+		if !found {
+			instanceMaxConnections, found = getStaticMaxConnections(e.staticMaxConnections, *instance.DBInstanceClass, *instance.DBParameterGroups[0].DBParameterGroupName)
 			if found {
 				level.Debug(e.logger).Log("msg", "Found mapping for instance",
 					"type", *instance.DBInstanceClass,
 					"group", *instance.DBParameterGroups[0].DBParameterGroupName,
-					"value", maxconn)
-				maxConnections = maxconn
-				e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnectionsMappingError, prometheus.GaugeValue, 0, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.DBInstanceClass))
-			} else {
-				level.Error(e.logger).Log("msg", "No DB max_connections mapping exists for instance",
-					"type", *instance.DBInstanceClass,
-					"group", *instance.DBParameterGroups[0].DBParameterGroupName)
-				e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnectionsMappingError, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.DBInstanceClass))
+					"value", instanceMaxConnections)
 			}
+		}
+		if found {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnectionsMappingError, prometheus.GaugeValue, 0, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.DBInstanceClass))
 		} else {
 			level.Error(e.logger).Log("msg", "No DB max_connections mapping exists for instance",
-				"type", *instance.DBInstanceClass)
+				"type", *instance.DBInstanceClass,
+				"group", *instance.DBParameterGroups[0].DBParameterGroupName)
 			e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnectionsMappingError, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.DBInstanceClass))
 		}
 
 		//Gets EOL for engine and version
-		if eolInfo, ok := eolMap[EOLKey{Engine: *instance.Engine, Version: *instance.EngineVersion}]; ok {
-			eolStatus, err := GetEOLStatus(eolInfo.EOL, e.thresholds)
+		if eolDate, ok := e.eolChecker.Lookup(*instance.Engine, *instance.EngineVersion); ok {
+			eolStatus, err := e.eolChecker.Status(eolDate)
 			if err != nil {
 				level.Error(e.logger).Log("msg", fmt.Sprintf("Could not get days to RDS EOL for Engine %s, Version %s: %s\n", *instance.Engine, *instance.EngineVersion, err.Error()))
 			} else {
-				e.cache.AddMetric(prometheus.MustNewConstMetric(EOLInfos, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.Engine, *instance.EngineVersion, eolInfo.EOL, eolStatus))
+				e.cache.AddMetric(prometheus.MustNewConstMetric(EOLInfos, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.Engine, *instance.EngineVersion, eolDate, eolStatus))
 			}
 		} else {
 			level.Info(e.logger).Log("msg", fmt.Sprintf("RDS EOL not found for Engine %s, Version %s\n", *instance.Engine, *instance.EngineVersion))
@@ -621,13 +665,31 @@ func (e *RDSExporter) addAllInstanceMetrics(sessionIndex int, instances []*rds.D
 		}
 		e.cache.AddMetric(prometheus.MustNewConstMetric(StorageEncrypted, prometheus.GaugeValue, encrypted, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
 
+		var iamAuth = 0.0
+		if aws.BoolValue(instance.IAMDatabaseAuthenticationEnabled) {
+			iamAuth = 1.0
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(IAMAuthEnabled, prometheus.GaugeValue, iamAuth, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
+
+		var deletionProtection = 0.0
+		if aws.BoolValue(instance.DeletionProtection) {
+			deletionProtection = 1.0
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DeletionProtection, prometheus.GaugeValue, deletionProtection, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
+
+		var multiAZ = 0.0
+		if aws.BoolValue(instance.MultiAZ) {
+			multiAZ = 1.0
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(MultiAZ, prometheus.GaugeValue, multiAZ, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
+
 		var restoreTime = 0.0
 		if instance.LatestRestorableTime != nil {
 			restoreTime = float64(instance.LatestRestorableTime.Unix())
 		}
 		e.cache.AddMetric(prometheus.MustNewConstMetric(LatestRestorableTime, prometheus.CounterValue, restoreTime, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
 
-		e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnections, prometheus.GaugeValue, float64(maxConnections), e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(MaxConnections, prometheus.GaugeValue, float64(instanceMaxConnections), e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
 		e.cache.AddMetric(prometheus.MustNewConstMetric(AllocatedStorage, prometheus.GaugeValue, float64(*instance.AllocatedStorage*1024*1024*1024), e.getRegion(sessionIndex), *instance.DBInstanceIdentifier))
 		e.cache.AddMetric(prometheus.MustNewConstMetric(DBInstanceStatus, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.DBInstanceStatus))
 		e.cache.AddMetric(prometheus.MustNewConstMetric(EngineVersion, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, *instance.Engine, *instance.EngineVersion, e.awsAccountId))
@@ -635,7 +697,35 @@ func (e *RDSExporter) addAllInstanceMetrics(sessionIndex int, instances []*rds.D
 	}
 }
 
-func (e *RDSExporter) addAllPendingMaintenancesMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance) {
+// rdsTags returns the tags of a resource as a map
+func rdsTags(tagList []*rds.Tag) map[string]string {
+	tags := make(map[string]string, len(tagList))
+	for _, tag := range tagList {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags
+}
+
+// filterInstances returns the instances selected by the filter and the identifiers of the other instances
+func (e *RDSExporter) filterInstances(instances []*rds.DBInstance) ([]*rds.DBInstance, map[string]bool) {
+	if e.filter == nil {
+		return instances, nil
+	}
+	var selected []*rds.DBInstance
+	excluded := map[string]bool{}
+	for _, instance := range instances {
+		if e.filter.selects(aws.StringValue(instance.DBInstanceIdentifier), rdsTags(instance.TagList)) {
+			selected = append(selected, instance)
+		} else {
+			excluded[aws.StringValue(instance.DBInstanceIdentifier)] = true
+		}
+	}
+	return selected, excluded
+}
+
+// addAllPendingMaintenancesMetrics adds the pending maintenance actions of the instances and clusters. The actions of
+// the excluded instances are left out.
+func (e *RDSExporter) addAllPendingMaintenancesMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance, excluded map[string]bool) error {
 	// Get pending maintenance data because this isn't provided in DescribeDBInstances
 	instancesWithPendingMaint := make(map[string]bool)
 
@@ -643,14 +733,21 @@ func (e *RDSExporter) addAllPendingMaintenancesMetrics(ctx context.Context, sess
 
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to DescribePendingMaintenanceActions failed", "region", e.getRegion(sessionIndex), "err", err)
-		return
+		return err
 	}
 
+	// The labels change with every action, so the actions which have been applied are removed right away instead of
+	// lingering until they expire
+	var metrics []prometheus.Metric
+
 	// Create the metrics for all instances that have pending maintenance actions
 	for _, instance := range instancesPendMaintActionsData {
 		for _, action := range instance.PendingMaintenanceActionDetails {
 			// DescribePendingMaintenanceActions only returns ARNs, so this gets the identifier.
 			dbIdentifier := strings.Split(*instance.ResourceIdentifier, ":")[6]
+			if excluded[dbIdentifier] {
+				continue
+			}
 			instancesWithPendingMaint[dbIdentifier] = true
 
 			var autoApplyDate string
@@ -663,7 +760,7 @@ func (e *RDSExporter) addAllPendingMaintenancesMetrics(ctx context.Context, sess
 				currentApplyDate = action.CurrentApplyDate.String()
 			}
 
-			e.cache.AddMetric(prometheus.MustNewConstMetric(PendingMaintenanceActions, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), dbIdentifier, *action.Action, autoApplyDate, currentApplyDate, *action.Description))
+			metrics = append(metrics, prometheus.MustNewConstMetric(PendingMaintenanceActions, prometheus.GaugeValue, 1, e.getRegion(sessionIndex), dbIdentifier, *action.Action, autoApplyDate, currentApplyDate, *action.Description))
 		}
 	}
 
@@ -672,10 +769,16 @@ func (e *RDSExporter) addAllPendingMaintenancesMetrics(ctx context.Context, sess
 	// available.
 	for _, instance := range instances {
 		if !instancesWithPendingMaint[*instance.DBInstanceIdentifier] {
-			e.cache.AddMetric(prometheus.MustNewConstMetric(PendingMaintenanceActions, prometheus.GaugeValue, 0, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, "", "", "", ""))
+			metrics = append(metrics, prometheus.MustNewConstMetric(PendingMaintenanceActions, prometheus.GaugeValue, 0, e.getRegion(sessionIndex), *instance.DBInstanceIdentifier, "", "", "", ""))
 		}
 	}
 
+	e.cache.InvalidateByDesc(PendingMaintenanceActions, e.getRegion(sessionIndex))
+	for _, metric := range metrics {
+		e.cache.AddMetric(metric)
+	}
+
+	return nil
 }
 
 // Describe is used by the Prometheus client to return a description of the metrics
@@ -690,48 +793,116 @@ func (e *RDSExporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- PendingMaintenanceActions
 	ch <- PubliclyAccessible
 	ch <- StorageEncrypted
+	ch <- IAMAuthEnabled
+	ch <- DeletionProtection
+	ch <- MultiAZ
 	ch <- EOLInfos
-
+	ch <- DBClusterStatus
+	ch <- DBClusterEngineVersion
+	ch <- DBClusterMultiAZ
+	ch <- DBClusterBackupRetentionPeriod
+	ch <- DBClusterStorageEncrypted
+	ch <- DBClusterMembers
+	ch <- CACertificateExpiry
+	ch <- ServerCertificateExpiry
+	ch <- ReservedDBInstances
+	ch <- ReservedDBInstanceExpiry
+	ch <- ReservedDBInstanceCoverage
+	ch <- e.DBInstancesQuota
+	ch <- e.DBInstancesUsage
+	ch <- e.TotalStorageQuota
+	ch <- e.TotalStorageUsage
+	ch <- e.ManualSnapshotsQuota
+	ch <- e.ManualSnapshotsUsage
+	ch <- e.ParameterGroupsQuota
+	ch <- e.ParameterGroupsUsage
 }
 
-func (e *RDSExporter) CollectLoop() {
+func (e *RDSExporter) CollectLoop(ctx context.Context) {
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
-		for i, _ := range e.sessions {
-
-			instances, err := e.svcs[i].DescribeDBInstancesAll(ctx)
-			if err != nil {
-				level.Error(e.logger).Log("msg", "Call to DescribeDBInstances failed", "region", *e.sessions[i].Config.Region, "err", err)
-			}
+		// collecting all regions takes long, the previous metrics are served until all of them are collected
+		e.cache.BeginGeneration()
+		loopCtx, loop := startCollectorLoop(ctx)
+		collectRegions(loopCtx, len(e.sessions), e.regionConcurrency, e.timeout, e.collectRegion)
+		e.cache.CommitGeneration()
+		level.Info(e.logger).Log("msg", "RDS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "rds")
+		}
 
-			wg := sync.WaitGroup{}
-			wg.Add(3)
-
-			go func() {
-				e.addAllInstanceMetrics(i, instances, e.eolInfos)
-				wg.Done()
-			}()
-			go func() {
-				e.addAllLogMetrics(ctx, i, instances)
-				wg.Done()
-			}()
-			go func() {
-				e.addAllPendingMaintenancesMetrics(ctx, i, instances)
-				wg.Done()
-			}()
-			wg.Wait()
+		if !sleepWithContext(ctx, e.interval) {
+			return
 		}
+	}
+}
 
-		level.Info(e.logger).Log("msg", "RDS metrics Updated")
+// collectRegion collects the metrics of the region of the session
+func (e *RDSExporter) collectRegion(ctx context.Context, i int) {
+	ctx, run := e.cache.startCollectorRun(ctx, "rds", e.getRegion(i))
+
+	allInstances, instancesErr := e.svcs[i].DescribeDBInstancesAll(ctx)
+	if instancesErr != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeDBInstances failed", "region", *e.sessions[i].Config.Region, "err", instancesErr)
+		run.fail()
+	}
+	// the reservations and quotas apply to all instances of the account, so they use the unfiltered instances
+	instances, excluded := e.filterInstances(allInstances)
 
-		cancel()
-		time.Sleep(e.interval)
+	maxConnections, errs := e.maxConnections.evaluateAll(ctx, e.svcs[i], instances)
+	for _, err := range errs {
+		level.Warn(e.logger).Log("msg", "Could not evaluate max_connections, using the static mapping", "region", e.getRegion(i), "err", err)
 	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(7)
+
+	go func() {
+		e.addAllInstanceMetrics(i, instances, maxConnections)
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllLogMetrics(ctx, i, instances); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllPendingMaintenancesMetrics(ctx, i, instances, excluded); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllClusterMetrics(ctx, i); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllCertificateMetrics(ctx, i, instances); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllReservedInstanceMetrics(ctx, i, allInstances); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	go func() {
+		if err := e.addAllQuotaMetrics(ctx, i, allInstances, instancesErr == nil); err != nil {
+			run.fail()
+		}
+		wg.Done()
+	}()
+	wg.Wait()
+	run.finish()
 }
 
 // Collect is used by the Prometheus client to collect and return the metrics values
 func (e *RDSExporter) Collect(ch chan<- prometheus.Metric) {
 	for _, m := range e.cache.GetAllMetrics() {
-		ch <- m
+		ch <- e.tagLabels.wrap(m)
 	}
 }
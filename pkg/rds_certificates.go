@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var CACertificateExpiry *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_ca_certificate_expiry_timestamp_seconds"),
+	"The expiration time of the certificate authority used by the DB instance (UTC timestamp).",
+	[]string{"aws_region", "dbinstance_identifier", "ca_identifier"},
+	nil,
+)
+var ServerCertificateExpiry *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_server_certificate_expiry_timestamp_seconds"),
+	"The expiration time of the server certificate of the DB instance (UTC timestamp).",
+	[]string{"aws_region", "dbinstance_identifier", "ca_identifier"},
+	nil,
+)
+
+// addAllCertificateMetrics adds the certificate expiry of the instances. The expiry of the certificate authorities is
+// not part of the instance details and has to be looked up with DescribeCertificates.
+func (e *RDSExporter) addAllCertificateMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance) error {
+	certificates, err := e.svcs[sessionIndex].DescribeCertificatesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeCertificates failed", "region", e.getRegion(sessionIndex), "err", err)
+		return err
+	}
+	e.addCertificateMetrics(sessionIndex, instances, certificates)
+	return nil
+}
+
+func (e *RDSExporter) addCertificateMetrics(sessionIndex int, instances []*rds.DBInstance, certificates []*rds.Certificate) {
+	caExpiry := make(map[string]float64)
+	for _, certificate := range certificates {
+		if certificate.ValidTill != nil {
+			caExpiry[aws.StringValue(certificate.CertificateIdentifier)] = float64(certificate.ValidTill.Unix())
+		}
+	}
+
+	region := e.getRegion(sessionIndex)
+	for _, instance := range instances {
+		instanceId := aws.StringValue(instance.DBInstanceIdentifier)
+		caIdentifier := aws.StringValue(instance.CACertificateIdentifier)
+		if instance.CertificateDetails != nil && instance.CertificateDetails.CAIdentifier != nil {
+			caIdentifier = *instance.CertificateDetails.CAIdentifier
+		}
+		if caIdentifier == "" {
+			continue
+		}
+
+		if expiry, found := caExpiry[caIdentifier]; found {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(CACertificateExpiry, prometheus.GaugeValue, expiry, region, instanceId, caIdentifier))
+		} else {
+			level.Debug(e.logger).Log("msg", "Certificate authority of instance not found", "dbinstance_identifier", instanceId, "ca_identifier", caIdentifier)
+		}
+		if instance.CertificateDetails != nil && instance.CertificateDetails.ValidTill != nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(ServerCertificateExpiry, prometheus.GaugeValue, float64(instance.CertificateDetails.ValidTill.Unix()), region, instanceId, caIdentifier))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAllCertificateMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := RDSExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		svcs:     []awsclient.Client{mockClient},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	caValidTill := time.Date(2061, 5, 22, 0, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().DescribeCertificatesAll(ctx).Return([]*rds.Certificate{
+		{CertificateIdentifier: aws.String("rds-ca-rsa2048-g1"), ValidTill: aws.Time(caValidTill)},
+	}, nil)
+
+	instances := []*rds.DBInstance{
+		{
+			DBInstanceIdentifier: aws.String("footest"),
+			CertificateDetails: &rds.CertificateDetails{
+				CAIdentifier: aws.String("rds-ca-rsa2048-g1"),
+				ValidTill:    aws.Time(time.Date(2025, 5, 22, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			// without certificate details, e.g. for instances which are still being created
+			DBInstanceIdentifier: aws.String("creating"),
+		},
+	}
+
+	assert.Nil(t, x.addAllCertificateMetrics(ctx, 0, instances))
+	metrics := x.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+
+	for _, metric := range metrics {
+		if metric.Desc().String() == CACertificateExpiry.String() {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, float64(caValidTill.Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
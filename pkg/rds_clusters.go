@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var DBClusterStatus *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_status"),
+	"The DB cluster status.",
+	[]string{"aws_region", "dbcluster_identifier", "cluster_status"},
+	nil,
+)
+var DBClusterEngineVersion *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_engineversion"),
+	"The DB cluster engine type and version.",
+	[]string{"aws_region", "dbcluster_identifier", "engine", "engine_version", "aws_account_id"},
+	nil,
+)
+var DBClusterMultiAZ *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_multiaz"),
+	"Indicates if the DB cluster has instances in multiple availability zones",
+	[]string{"aws_region", "dbcluster_identifier"},
+	nil,
+)
+var DBClusterBackupRetentionPeriod *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_backup_retention_period_days"),
+	"The number of days for which automatic DB snapshots are retained.",
+	[]string{"aws_region", "dbcluster_identifier"},
+	nil,
+)
+var DBClusterStorageEncrypted *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_storageencrypted"),
+	"Indicates if the DB cluster storage is encrypted",
+	[]string{"aws_region", "dbcluster_identifier"},
+	nil,
+)
+var DBClusterMembers *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_dbcluster_members"),
+	"The number of instances of the DB cluster.",
+	[]string{"aws_region", "dbcluster_identifier"},
+	nil,
+)
+
+func (e *RDSExporter) addAllClusterMetrics(ctx context.Context, sessionIndex int) error {
+	clusters, err := e.svcs[sessionIndex].DescribeDBClustersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeDBClusters failed", "region", e.getRegion(sessionIndex), "err", err)
+		return err
+	}
+	e.addClusterMetrics(sessionIndex, clusters)
+	return nil
+}
+
+func (e *RDSExporter) addClusterMetrics(sessionIndex int, clusters []*rds.DBCluster) {
+	region := e.getRegion(sessionIndex)
+	for _, cluster := range clusters {
+		clusterId := aws.StringValue(cluster.DBClusterIdentifier)
+		if !e.filter.selects(clusterId, rdsTags(cluster.TagList)) {
+			continue
+		}
+
+		var multiAZ = 0.0
+		if aws.BoolValue(cluster.MultiAZ) {
+			multiAZ = 1.0
+		}
+		var encrypted = 0.0
+		if aws.BoolValue(cluster.StorageEncrypted) {
+			encrypted = 1.0
+		}
+
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterStatus, prometheus.GaugeValue, 1, region, clusterId, aws.StringValue(cluster.Status)))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterEngineVersion, prometheus.GaugeValue, 1, region, clusterId, aws.StringValue(cluster.Engine), aws.StringValue(cluster.EngineVersion), e.awsAccountId))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterMultiAZ, prometheus.GaugeValue, multiAZ, region, clusterId))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterBackupRetentionPeriod, prometheus.GaugeValue, float64(aws.Int64Value(cluster.BackupRetentionPeriod)), region, clusterId))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterStorageEncrypted, prometheus.GaugeValue, encrypted, region, clusterId))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(DBClusterMembers, prometheus.GaugeValue, float64(len(cluster.DBClusterMembers)), region, clusterId))
+	}
+}
@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAllClusterMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := RDSExporter{
+		sessions:     []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		svcs:         []awsclient.Client{mockClient},
+		cache:        *NewMetricsCache(10 * time.Second),
+		logger:       log.NewNopLogger(),
+		awsAccountId: "1234567890",
+	}
+
+	mockClient.EXPECT().DescribeDBClustersAll(ctx).Return([]*rds.DBCluster{
+		{
+			DBClusterIdentifier:   aws.String("aurora"),
+			Status:                aws.String("available"),
+			Engine:                aws.String("aurora-postgresql"),
+			EngineVersion:         aws.String("15.4"),
+			MultiAZ:               aws.Bool(true),
+			BackupRetentionPeriod: aws.Int64(7),
+			StorageEncrypted:      aws.Bool(true),
+			DBClusterMembers:      []*rds.DBClusterMember{{}, {}},
+		},
+	}, nil)
+
+	assert.Nil(t, x.addAllClusterMetrics(ctx, 0))
+	assert.Len(t, x.cache.GetAllMetrics(), 6)
+
+	labels, err := getMetricLabels(&x, DBClusterEngineVersion, "dbcluster_identifier", "engine_version")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"dbcluster_identifier": "aurora", "engine_version": "15.4"}, labels)
+}
+
+func TestAddAllClusterMetricsError(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := RDSExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		svcs:     []awsclient.Client{mockClient},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	mockClient.EXPECT().DescribeDBClustersAll(ctx).Return(nil, errors.New("test error"))
+
+	assert.NotNil(t, x.addAllClusterMetrics(ctx, 0))
+	assert.Len(t, x.cache.GetAllMetrics(), 0)
+}
@@ -0,0 +1,325 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+const maxConnectionsParameter = "max_connections"
+
+// instanceClassInfo holds the variables of an instance class which can be used in parameter group formulas
+type instanceClassInfo struct {
+	memoryBytes float64
+	vcpus       float64
+}
+
+// maxConnectionsEvaluator evaluates the max_connections parameter of the DB parameter groups. The instance class
+// memory is looked up in the EC2 instance type metadata and cached, as it never changes.
+type maxConnectionsEvaluator struct {
+	mutex      sync.Mutex
+	classInfos map[string]instanceClassInfo
+}
+
+func newMaxConnectionsEvaluator() *maxConnectionsEvaluator {
+	return &maxConnectionsEvaluator{
+		classInfos: make(map[string]instanceClassInfo),
+	}
+}
+
+// evaluateAll returns the evaluated max_connections per DB instance identifier. Instances whose value could not be
+// evaluated are left out, so the static DBMaxConnections map can be used instead.
+func (m *maxConnectionsEvaluator) evaluateAll(ctx context.Context, client awsclient.Client, instances []*rds.DBInstance) (map[string]int64, []error) {
+	results := make(map[string]int64)
+	var errs []error
+	// parameter groups can be changed at any time, so their values are only reused within a single collection
+	formulas := make(map[string]string)
+
+	for _, instance := range instances {
+		instanceId := aws.StringValue(instance.DBInstanceIdentifier)
+		if len(instance.DBParameterGroups) == 0 {
+			continue
+		}
+		groupName := aws.StringValue(instance.DBParameterGroups[0].DBParameterGroupName)
+
+		formula, found := formulas[groupName]
+		if !found {
+			var err error
+			formula, err = getMaxConnectionsFormula(ctx, client, groupName)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not get max_connections of parameter group %s: %w", groupName, err))
+				continue
+			}
+			formulas[groupName] = formula
+		}
+
+		classInfo, err := m.getInstanceClassInfo(ctx, client, aws.StringValue(instance.DBInstanceClass))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not get the instance class of %s: %w", instanceId, err))
+			continue
+		}
+
+		value, err := evaluateParameterFormula(formula, map[string]float64{
+			"dbinstanceclassmemory": classInfo.memoryBytes,
+			"dbinstancevcpu":        classInfo.vcpus,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not evaluate max_connections %q of %s: %w", formula, instanceId, err))
+			continue
+		}
+		results[instanceId] = value
+	}
+	return results, errs
+}
+
+func (m *maxConnectionsEvaluator) getInstanceClassInfo(ctx context.Context, client awsclient.Client, instanceClass string) (instanceClassInfo, error) {
+	m.mutex.Lock()
+	classInfo, found := m.classInfos[instanceClass]
+	m.mutex.Unlock()
+	if found {
+		return classInfo, nil
+	}
+
+	// RDS instance classes are the EC2 instance types prefixed with "db."
+	instanceType := strings.TrimPrefix(instanceClass, "db.")
+	output, err := client.DescribeInstanceTypesWithContext(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		return classInfo, err
+	}
+	if len(output.InstanceTypes) != 1 || output.InstanceTypes[0].MemoryInfo == nil {
+		return classInfo, fmt.Errorf("no instance type metadata found for %s", instanceType)
+	}
+
+	classInfo.memoryBytes = float64(aws.Int64Value(output.InstanceTypes[0].MemoryInfo.SizeInMiB)) * 1024 * 1024
+	if output.InstanceTypes[0].VCpuInfo != nil {
+		classInfo.vcpus = float64(aws.Int64Value(output.InstanceTypes[0].VCpuInfo.DefaultVCpus))
+	}
+
+	m.mutex.Lock()
+	m.classInfos[instanceClass] = classInfo
+	m.mutex.Unlock()
+	return classInfo, nil
+}
+
+func getMaxConnectionsFormula(ctx context.Context, client awsclient.Client, parameterGroupName string) (string, error) {
+	parameters, err := client.DescribeDBParametersAll(ctx, parameterGroupName)
+	if err != nil {
+		return "", err
+	}
+	for _, parameter := range parameters {
+		if aws.StringValue(parameter.ParameterName) == maxConnectionsParameter {
+			if parameter.ParameterValue == nil {
+				return "", fmt.Errorf("max_connections has no value")
+			}
+			return *parameter.ParameterValue, nil
+		}
+	}
+	return "", fmt.Errorf("max_connections not found")
+}
+
+// evaluateParameterFormula evaluates a DB parameter group formula like
+// "LEAST({DBInstanceClassMemory/9531392},5000)". Braces are treated like parentheses, the supported functions are
+// LEAST, GREATEST, SUM and log (base 2). The variable names are matched case insensitive.
+func evaluateParameterFormula(formula string, variables map[string]float64) (int64, error) {
+	p := &formulaParser{input: formula, variables: variables}
+	value, err := p.parseExpression()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("formula evaluated to %f", value)
+	}
+	return int64(math.Floor(value)), nil
+}
+
+// formulaParser is a recursive descent parser for the arithmetic expressions of DB parameter group formulas
+type formulaParser struct {
+	input     string
+	pos       int
+	variables map[string]float64
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *formulaParser) expect(c byte) error {
+	if p.peek() != c {
+		return fmt.Errorf("expected %q at position %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// parseExpression parses a sum or difference of terms
+func (p *formulaParser) parseExpression() (float64, error) {
+	value, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+', '-':
+			op := p.input[p.pos]
+			p.pos++
+			right, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			if op == '+' {
+				value += right
+			} else {
+				value -= right
+			}
+		default:
+			return value, nil
+		}
+	}
+}
+
+// parseTerm parses a product or quotient of factors
+func (p *formulaParser) parseTerm() (float64, error) {
+	value, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '*', '/':
+			op := p.input[p.pos]
+			p.pos++
+			right, err := p.parseFactor()
+			if err != nil {
+				return 0, err
+			}
+			if op == '*' {
+				value *= right
+			} else {
+				if right == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				value /= right
+			}
+		default:
+			return value, nil
+		}
+	}
+}
+
+// parseFactor parses a number, a variable, a function call or a nested expression
+func (p *formulaParser) parseFactor() (float64, error) {
+	c := p.peek()
+	switch {
+	case c == '(' || c == '{':
+		closing := byte(')')
+		if c == '{' {
+			closing = '}'
+		}
+		p.pos++
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		return value, p.expect(closing)
+	case c == '-':
+		p.pos++
+		value, err := p.parseFactor()
+		return -value, err
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		return strconv.ParseFloat(p.input[start:p.pos], 64)
+	case unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+			p.pos++
+		}
+		name := strings.ToLower(p.input[start:p.pos])
+		if p.peek() == '(' {
+			return p.parseFunction(name)
+		}
+		value, found := p.variables[name]
+		if !found {
+			return 0, fmt.Errorf("unknown variable %s", p.input[start:p.pos])
+		}
+		return value, nil
+	case c == 0:
+		return 0, fmt.Errorf("unexpected end of formula")
+	default:
+		return 0, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+	}
+}
+
+func (p *formulaParser) parseFunction(name string) (float64, error) {
+	var args []float64
+	p.pos++ // opening parenthesis
+	for {
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, value)
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expect(')'); err != nil {
+		return 0, err
+	}
+
+	switch name {
+	case "least":
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, nil
+	case "greatest":
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	case "sum":
+		result := 0.0
+		for _, arg := range args {
+			result += arg
+		}
+		return result, nil
+	case "log":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("log expects a single argument")
+		}
+		return math.Log2(args[0]), nil
+	default:
+		return 0, fmt.Errorf("unknown function %s", name)
+	}
+}
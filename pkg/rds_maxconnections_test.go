@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateParameterFormula(t *testing.T) {
+	// 1 GiB of memory and 2 vCPUs
+	variables := map[string]float64{"dbinstanceclassmemory": 1073741824, "dbinstancevcpu": 2}
+
+	tests := []struct {
+		formula string
+		want    int64
+	}{
+		{formula: "1000", want: 1000},
+		{formula: "{DBInstanceClassMemory/12582880}", want: 85},
+		{formula: "LEAST({DBInstanceClassMemory/9531392},5000)", want: 112},
+		{formula: "GREATEST({log(DBInstanceClassMemory/805306368)*45},{log(DBInstanceClassMemory/8187281408)*1000})", want: 18},
+		{formula: "SUM({DBInstanceVCPU*100}, 10)", want: 210},
+		{formula: "least( 10 , (2 + 3) * 4 )", want: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			got, err := evaluateParameterFormula(tt.formula, variables)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateParameterFormulaErrors(t *testing.T) {
+	for _, formula := range []string{"", "{DBInstanceClassMemory/0}", "LEAST(1,", "UNKNOWN(1)", "{Foo/2}", "1 2"} {
+		_, err := evaluateParameterFormula(formula, map[string]float64{})
+		assert.NotNil(t, err, formula)
+	}
+}
+
+func TestMaxConnectionsEvaluateAll(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	mockClient.EXPECT().DescribeDBParametersAll(ctx, "default.postgres14").Return([]*rds.Parameter{
+		{ParameterName: aws.String("shared_buffers"), ParameterValue: aws.String("{DBInstanceClassMemory/32768}")},
+		{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("LEAST({DBInstanceClassMemory/9531392},5000)")},
+	}, nil)
+	// the instance class is only looked up once
+	mockClient.EXPECT().DescribeInstanceTypesWithContext(ctx, &ec2.DescribeInstanceTypesInput{InstanceTypes: []*string{aws.String("t3.micro")}}).
+		Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []*ec2.InstanceTypeInfo{
+			{MemoryInfo: &ec2.MemoryInfo{SizeInMiB: aws.Int64(1024)}, VCpuInfo: &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)}},
+		}}, nil).Times(1)
+
+	instances := []*rds.DBInstance{
+		{
+			DBInstanceIdentifier: aws.String("foo"),
+			DBInstanceClass:      aws.String("db.t3.micro"),
+			DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("default.postgres14")}},
+		},
+		{
+			DBInstanceIdentifier: aws.String("bar"),
+			DBInstanceClass:      aws.String("db.t3.micro"),
+			DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("default.postgres14")}},
+		},
+	}
+
+	evaluator := newMaxConnectionsEvaluator()
+	results, errs := evaluator.evaluateAll(ctx, mockClient, instances)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]int64{"foo": 112, "bar": 112}, results)
+}
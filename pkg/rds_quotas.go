@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	rdsServiceCode              string = "rds"
+	rdsDBInstancesQuotaCode     string = "L-7B6409FD"
+	rdsTotalStorageQuotaCode    string = "L-7ADDB58A"
+	rdsManualSnapshotsQuotaCode string = "L-272F1212"
+	rdsParameterGroupsQuotaCode string = "L-DE55804A"
+	rdsManualSnapshotType       string = "manual"
+)
+
+// rdsQuotaDescs are the descriptions of the quotas of an RDSExporter, which carry the account id as constant label
+type rdsQuotaDescs struct {
+	DBInstancesQuota     *prometheus.Desc
+	DBInstancesUsage     *prometheus.Desc
+	TotalStorageQuota    *prometheus.Desc
+	TotalStorageUsage    *prometheus.Desc
+	ManualSnapshotsQuota *prometheus.Desc
+	ManualSnapshotsUsage *prometheus.Desc
+	ParameterGroupsQuota *prometheus.Desc
+	ParameterGroupsUsage *prometheus.Desc
+}
+
+func newRDSQuotaDescs(awsAccountId string) rdsQuotaDescs {
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: rdsServiceCode}
+
+	return rdsQuotaDescs{
+		DBInstancesQuota:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_dbinstances_quota"), "Quota for maximum number of DB instances", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsDBInstancesQuotaCode)),
+		DBInstancesUsage:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_dbinstances_usage"), "Number of DB instances", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsDBInstancesQuotaCode)),
+		TotalStorageQuota:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_totalstorage_quota"), "Quota for the total storage of all DB instances in GiB", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsTotalStorageQuotaCode)),
+		TotalStorageUsage:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_totalstorage_usage"), "Storage allocated by all DB instances in GiB", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsTotalStorageQuotaCode)),
+		ManualSnapshotsQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_manualsnapshots_quota"), "Quota for maximum number of manual DB instance snapshots", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsManualSnapshotsQuotaCode)),
+		ManualSnapshotsUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_manualsnapshots_usage"), "Number of manual DB instance snapshots", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsManualSnapshotsQuotaCode)),
+		ParameterGroupsQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_parametergroups_quota"), "Quota for maximum number of DB parameter groups", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsParameterGroupsQuotaCode)),
+		ParameterGroupsUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "rds_parametergroups_usage"), "Number of DB parameter groups", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, rdsParameterGroupsQuotaCode)),
+	}
+}
+
+// addAllQuotaMetrics adds the account level quotas of the region and their usage. The usage of DB instances and
+// storage is derived from the already described instances, so it is left out if describing them failed.
+func (e *RDSExporter) addAllQuotaMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance, instancesDescribed bool) error {
+	client := e.svcs[sessionIndex]
+	region := e.getRegion(sessionIndex)
+	var errs []error
+
+	quotas := []struct {
+		quotaCode string
+		desc      *prometheus.Desc
+	}{
+		{rdsDBInstancesQuotaCode, e.DBInstancesQuota},
+		{rdsTotalStorageQuotaCode, e.TotalStorageQuota},
+		{rdsManualSnapshotsQuotaCode, e.ManualSnapshotsQuota},
+		{rdsParameterGroupsQuotaCode, e.ParameterGroupsQuota},
+	}
+	for _, quota := range quotas {
+		value, err := getQuotaValueWithContext(client, rdsServiceCode, quota.quotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve RDS quota", "quota_code", quota.quotaCode, "region", region, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(quota.desc, prometheus.GaugeValue, value, region))
+	}
+
+	if instancesDescribed {
+		var allocatedStorage int64
+		for _, instance := range instances {
+			allocatedStorage += aws.Int64Value(instance.AllocatedStorage)
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.DBInstancesUsage, prometheus.GaugeValue, float64(len(instances)), region))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TotalStorageUsage, prometheus.GaugeValue, float64(allocatedStorage), region))
+	}
+
+	snapshots, err := client.DescribeDBSnapshotsAll(ctx, rdsManualSnapshotType)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeDBSnapshots failed", "region", region, "err", err)
+		errs = append(errs, err)
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ManualSnapshotsUsage, prometheus.GaugeValue, float64(len(snapshots)), region))
+	}
+
+	parameterGroups, err := client.DescribeDBParameterGroupsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeDBParameterGroups failed", "region", region, "err", err)
+		errs = append(errs, err)
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ParameterGroupsUsage, prometheus.GaugeValue, float64(len(parameterGroups)), region))
+	}
+
+	return errors.Join(errs...)
+}
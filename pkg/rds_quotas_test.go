@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAllQuotaMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := RDSExporter{
+		sessions:      []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		svcs:          []awsclient.Client{mockClient},
+		cache:         *NewMetricsCache(10 * time.Second),
+		logger:        log.NewNopLogger(),
+		rdsQuotaDescs: newRDSQuotaDescs("123456789012"),
+	}
+
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{Value: aws.Float64(40)},
+	}, nil).Times(4)
+	mockClient.EXPECT().DescribeDBSnapshotsAll(ctx, "manual").Return([]*rds.DBSnapshot{{}, {}}, nil)
+	mockClient.EXPECT().DescribeDBParameterGroupsAll(ctx).Return(nil, errors.New("some error"))
+
+	instances := []*rds.DBInstance{
+		{DBInstanceIdentifier: aws.String("foo"), AllocatedStorage: aws.Int64(100)},
+		{DBInstanceIdentifier: aws.String("bar"), AllocatedStorage: aws.Int64(20)},
+	}
+
+	assert.NotNil(t, x.addAllQuotaMetrics(ctx, 0, instances, true))
+	metrics := x.cache.GetAllMetrics()
+	// all quotas and the usage except the parameter groups
+	assert.Len(t, metrics, 7)
+
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case x.TotalStorageUsage.String():
+			assert.Equal(t, 120.0, dtoMetric.GetGauge().GetValue())
+		case x.ManualSnapshotsUsage.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
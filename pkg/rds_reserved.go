@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const reservedDBInstanceStateActive = "active"
+
+var ReservedDBInstances *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_reserved_instances"),
+	"The number of active reserved DB instances",
+	[]string{"aws_region", "instance_class", "product_description", "multi_az"},
+	nil,
+)
+var ReservedDBInstanceExpiry *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_reserved_instance_expiry_timestamp_seconds"),
+	"The expiration time of an active DB instance reservation (UTC timestamp).",
+	[]string{"aws_region", "reserved_instance_id", "instance_class", "product_description"},
+	nil,
+)
+var ReservedDBInstanceCoverage *prometheus.Desc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "rds_reserved_instance_coverage_ratio"),
+	"The ratio of running DB instances covered by an active reservation of the same instance class",
+	[]string{"aws_region", "instance_class"},
+	nil,
+)
+
+type reservedInstancesKey struct {
+	instanceClass      string
+	productDescription string
+	multiAZ            bool
+}
+
+// addAllReservedInstanceMetrics adds the active reservations and their coverage of the running instances
+func (e *RDSExporter) addAllReservedInstanceMetrics(ctx context.Context, sessionIndex int, instances []*rds.DBInstance) error {
+	reservedInstances, err := e.svcs[sessionIndex].DescribeReservedDBInstancesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeReservedDBInstances failed", "region", e.getRegion(sessionIndex), "err", err)
+		return err
+	}
+	e.addReservedInstanceMetrics(sessionIndex, instances, reservedInstances)
+	return nil
+}
+
+// addReservedInstanceMetrics computes the coverage per instance class only. Size flexibility and the engine of the
+// reservation are not taken into account, so the ratio is an approximation of what is billed.
+func (e *RDSExporter) addReservedInstanceMetrics(sessionIndex int, instances []*rds.DBInstance, reservedInstances []*rds.ReservedDBInstance) {
+	region := e.getRegion(sessionIndex)
+
+	reservedCounts := make(map[reservedInstancesKey]int64)
+	reservedPerClass := make(map[string]int64)
+	for _, reservedInstance := range reservedInstances {
+		if aws.StringValue(reservedInstance.State) != reservedDBInstanceStateActive {
+			continue
+		}
+		instanceClass := aws.StringValue(reservedInstance.DBInstanceClass)
+		productDescription := aws.StringValue(reservedInstance.ProductDescription)
+		count := aws.Int64Value(reservedInstance.DBInstanceCount)
+
+		reservedCounts[reservedInstancesKey{instanceClass, productDescription, aws.BoolValue(reservedInstance.MultiAZ)}] += count
+		reservedPerClass[instanceClass] += count
+
+		if reservedInstance.StartTime != nil {
+			expiry := reservedInstance.StartTime.Add(time.Duration(aws.Int64Value(reservedInstance.Duration)) * time.Second)
+			e.cache.AddMetric(prometheus.MustNewConstMetric(ReservedDBInstanceExpiry, prometheus.GaugeValue, float64(expiry.Unix()), region, aws.StringValue(reservedInstance.ReservedDBInstanceId), instanceClass, productDescription))
+		}
+	}
+
+	for key, count := range reservedCounts {
+		multiAZ := "false"
+		if key.multiAZ {
+			multiAZ = "true"
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(ReservedDBInstances, prometheus.GaugeValue, float64(count), region, key.instanceClass, key.productDescription, multiAZ))
+	}
+
+	runningPerClass := make(map[string]int64)
+	for _, instance := range instances {
+		runningPerClass[aws.StringValue(instance.DBInstanceClass)]++
+	}
+	for instanceClass, running := range runningPerClass {
+		covered := reservedPerClass[instanceClass]
+		if covered > running {
+			covered = running
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(ReservedDBInstanceCoverage, prometheus.GaugeValue, float64(covered)/float64(running), region, instanceClass))
+	}
+}
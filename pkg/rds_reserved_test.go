@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAllReservedInstanceMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	x := RDSExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		svcs:     []awsclient.Client{mockClient},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient.EXPECT().DescribeReservedDBInstancesAll(ctx).Return([]*rds.ReservedDBInstance{
+		{
+			ReservedDBInstanceId: aws.String("active"),
+			DBInstanceClass:      aws.String("db.m5.large"),
+			ProductDescription:   aws.String("postgresql"),
+			DBInstanceCount:      aws.Int64(1),
+			Duration:             aws.Int64(31536000),
+			StartTime:            aws.Time(startTime),
+			State:                aws.String("active"),
+		},
+		{
+			ReservedDBInstanceId: aws.String("retired"),
+			DBInstanceClass:      aws.String("db.m5.large"),
+			ProductDescription:   aws.String("postgresql"),
+			DBInstanceCount:      aws.Int64(1),
+			Duration:             aws.Int64(31536000),
+			StartTime:            aws.Time(startTime.AddDate(-1, 0, 0)),
+			State:                aws.String("retired"),
+		},
+	}, nil)
+
+	instances := []*rds.DBInstance{
+		{DBInstanceIdentifier: aws.String("foo"), DBInstanceClass: aws.String("db.m5.large")},
+		{DBInstanceIdentifier: aws.String("bar"), DBInstanceClass: aws.String("db.m5.large")},
+		{DBInstanceIdentifier: aws.String("baz"), DBInstanceClass: aws.String("db.t3.micro")},
+	}
+
+	assert.Nil(t, x.addAllReservedInstanceMetrics(ctx, 0, instances))
+	metrics := x.cache.GetAllMetrics()
+	// one reservation count, one expiry and the coverage of both instance classes
+	assert.Len(t, metrics, 4)
+
+	coverage := make(map[string]float64)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case ReservedDBInstanceCoverage.String():
+			for _, label := range dtoMetric.GetLabel() {
+				if label.GetName() == "instance_class" {
+					coverage[label.GetValue()] = dtoMetric.GetGauge().GetValue()
+				}
+			}
+		case ReservedDBInstanceExpiry.String():
+			assert.Equal(t, float64(startTime.Add(365*24*time.Hour).Unix()), dtoMetric.GetGauge().GetValue())
+		}
+	}
+	assert.Equal(t, map[string]float64{"db.m5.large": 0.5, "db.t3.micro": 0}, coverage)
+}
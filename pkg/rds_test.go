@@ -8,6 +8,7 @@ import (
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/app-sre/aws-resource-exporter/pkg/eol"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -67,14 +68,20 @@ func TestAddRDSLogMetrics(t *testing.T) {
 	}, nil)
 
 	x := RDSExporter{
-		svcs:     []awsclient.Client{mockClient},
-		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
-		cache:    *NewMetricsCache(10 * time.Second),
+		svcs:           []awsclient.Client{mockClient},
+		sessions:       []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:          *NewMetricsCache(10 * time.Second),
+		logsMetricsTTL: time.Minute,
 	}
 
 	err := x.addRDSLogMetrics(ctx, 0, "footest")
 	assert.Len(t, x.cache.GetAllMetrics(), 2)
 	assert.Nil(t, err)
+
+	// the cached log metrics aren't requested again
+	err = x.addRDSLogMetrics(ctx, 0, "footest")
+	assert.Len(t, x.cache.GetAllMetrics(), 2)
+	assert.Nil(t, err)
 }
 
 func TestAddAllInstanceMetrics(t *testing.T) {
@@ -87,36 +94,35 @@ func TestAddAllInstanceMetrics(t *testing.T) {
 	var instances = []*rds.DBInstance{}
 
 	// Test with no match
-	eolInfos := []EOLInfo{
+	x.eolChecker = eol.NewChecker([]eol.Info{
 		{Engine: "engine", Version: "123", EOL: "2023-12-01"},
-	}
+	}, nil, nil)
 
-	x.addAllInstanceMetrics(0, instances, eolInfos)
+	x.addAllInstanceMetrics(0, instances, nil)
 	assert.Len(t, x.cache.GetAllMetrics(), 0)
 
-	x.addAllInstanceMetrics(0, createTestDBInstances(), eolInfos)
-	assert.Len(t, x.cache.GetAllMetrics(), 9)
+	x.addAllInstanceMetrics(0, createTestDBInstances(), nil)
+	assert.Len(t, x.cache.GetAllMetrics(), 12)
 }
 
 func TestAddAllInstanceMetricsWithEOLMatch(t *testing.T) {
-	thresholds := []Threshold{
+	thresholds := []eol.Threshold{
 		{Name: "red", Days: 90},
 		{Name: "yellow", Days: 180},
 		{Name: "green", Days: 365},
 	}
+	eolInfos := []eol.Info{
+		{Engine: "SQL", Version: "1000", EOL: "2000-12-01"},
+	}
 
 	x := RDSExporter{
 		sessions:   []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
 		cache:      *NewMetricsCache(10 * time.Second),
 		logger:     log.NewNopLogger(),
-		thresholds: thresholds,
-	}
-
-	eolInfos := []EOLInfo{
-		{Engine: "SQL", Version: "1000", EOL: "2000-12-01"},
+		eolChecker: eol.NewChecker(eolInfos, thresholds, nil),
 	}
 
-	x.addAllInstanceMetrics(0, createTestDBInstances(), eolInfos)
+	x.addAllInstanceMetrics(0, createTestDBInstances(), nil)
 
 	labels, err := getMetricLabels(&x, EOLInfos, "eol_date", "eol_status")
 	if err != nil {
@@ -140,13 +146,12 @@ func TestAddAllInstanceMetricsWithGetEOLStatusError(t *testing.T) {
 		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
 		cache:    *NewMetricsCache(10 * time.Second),
 		logger:   log.NewNopLogger(),
+		eolChecker: eol.NewChecker([]eol.Info{
+			{Engine: "SQL", Version: "1000", EOL: "invalid-date"},
+		}, eol.DefaultThresholds(), nil),
 	}
 
-	eolInfos := []EOLInfo{
-		{Engine: "SQL", Version: "1000", EOL: "invalid-date"},
-	}
-
-	x.addAllInstanceMetrics(0, createTestDBInstances(), eolInfos)
+	x.addAllInstanceMetrics(0, createTestDBInstances(), nil)
 
 	labels, err := getMetricLabels(&x, EOLInfos, "eol_date", "eol_status")
 
@@ -158,69 +163,6 @@ func TestAddAllInstanceMetricsWithGetEOLStatusError(t *testing.T) {
 	}
 }
 
-func TestGetEOLStatus(t *testing.T) {
-	thresholds := []Threshold{
-		{Name: "red", Days: 90},
-		{Name: "yellow", Days: 180},
-		{Name: "green", Days: 365},
-	}
-
-	// EOL date is within 90 days
-	eol := time.Now().Add(2 * 24 * time.Hour).Format("2006-01-02")
-	expectedStatus := "red"
-	status, err := GetEOLStatus(eol, thresholds)
-	if err != nil {
-		t.Errorf("Expected no error, but got an error: %v", err)
-	}
-	if status != expectedStatus {
-		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
-	}
-
-	// EOL date is within 180 days
-	eol = time.Now().Add(120 * 24 * time.Hour).Format("2006-01-02")
-	expectedStatus = "yellow"
-	status, err = GetEOLStatus(eol, thresholds)
-	if err != nil {
-		t.Errorf("Expected no error, but got an error: %v", err)
-	}
-	if status != expectedStatus {
-		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
-	}
-
-	// EOL date is more than 180 days
-	eol = time.Now().Add(200 * 24 * time.Hour).Format("2006-01-02")
-	expectedStatus = "green"
-	status, err = GetEOLStatus(eol, thresholds)
-	if err != nil {
-		t.Errorf("Expected no error, but got an error: %v", err)
-	}
-	if status != expectedStatus {
-		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
-	}
-
-	//EOL date exceeds highest threshold
-	eol = time.Now().Add(400 * 24 * time.Hour).Format("2006-01-02")
-	expectedStatus = "green"
-	status, err = GetEOLStatus(eol, thresholds)
-	if err != nil {
-		t.Errorf("Expected no error, but got an error: %v", err)
-	}
-	if status != expectedStatus {
-		t.Errorf("Expected status '%s', but got '%s'", expectedStatus, status)
-	}
-
-	//Thresholds is empty
-	eol = time.Now().Add(30 * 24 * time.Hour).Format("2006-01-02")
-	emptyThresholds := []Threshold{}
-	status, err = GetEOLStatus(eol, emptyThresholds)
-	if err == nil {
-		t.Errorf("Expected an error for empty thresholds, but got none")
-	}
-	if status != "" {
-		t.Errorf("Expected no status for empty thresholds, but got '%s'", status)
-	}
-}
-
 func TestEngineVersionMetricIncludesAWSAccountId(t *testing.T) {
 	x := RDSExporter{
 		sessions:     []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
@@ -295,7 +237,7 @@ func TestAddAllPendingMaintenancesMetrics(t *testing.T) {
 		logger:   log.NewNopLogger(),
 	}
 
-	x.addAllPendingMaintenancesMetrics(ctx, 0, createTestDBInstances())
+	x.addAllPendingMaintenancesMetrics(ctx, 0, createTestDBInstances(), nil)
 	metrics := x.cache.GetAllMetrics()
 	assert.Len(t, metrics, 1)
 
@@ -321,8 +263,10 @@ func TestAddAllPendingMaintenancesNoMetrics(t *testing.T) {
 		cache:    *NewMetricsCache(10 * time.Second),
 		logger:   log.NewNopLogger(),
 	}
+	// the action applied since the previous collection is removed
+	x.cache.AddMetric(prometheus.MustNewConstMetric(PendingMaintenanceActions, prometheus.GaugeValue, 1, "foo", "footest", "system-update", "", "", "patch"))
 
-	x.addAllPendingMaintenancesMetrics(ctx, 0, createTestDBInstances())
+	x.addAllPendingMaintenancesMetrics(ctx, 0, createTestDBInstances(), nil)
 	metrics := x.cache.GetAllMetrics()
 	assert.Len(t, metrics, 1)
 
@@ -332,3 +276,131 @@ func TestAddAllPendingMaintenancesNoMetrics(t *testing.T) {
 	// Expecting no maintenance, thus 0 value
 	assert.Equal(t, float64(0), *dto.Gauge.Value)
 }
+
+func TestAddAllInstanceMetricsWithEvaluatedMaxConnections(t *testing.T) {
+	x := RDSExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	x.addAllInstanceMetrics(0, createTestDBInstances(), map[string]int64{"footest": 1234})
+
+	for _, metric := range x.cache.GetAllMetrics() {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case MaxConnections.String():
+			assert.Equal(t, float64(1234), dtoMetric.GetGauge().GetValue())
+		case MaxConnectionsMappingError.String():
+			assert.Equal(t, float64(0), dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestMergeMaxConnections(t *testing.T) {
+	mapping := map[string]map[string]int64{
+		"db.t3.micro": {"default": 112, "default.postgres14": 112},
+	}
+	overrides := map[string]map[string]int64{
+		"db.t3.micro":  {"custom-group": 50},
+		"db.r7g.large": {"default": 1000},
+	}
+
+	merged := mergeMaxConnections(mapping, overrides)
+	assert.Equal(t, map[string]map[string]int64{
+		"db.t3.micro":  {"default": 112, "default.postgres14": 112, "custom-group": 50},
+		"db.r7g.large": {"default": 1000},
+	}, merged)
+	// the built-in mapping stays untouched
+	assert.Len(t, mapping["db.t3.micro"], 2)
+
+	value, found := getStaticMaxConnections(merged, "db.t3.micro", "custom-group")
+	assert.True(t, found)
+	assert.Equal(t, int64(50), value)
+	value, found = getStaticMaxConnections(merged, "db.r7g.large", "default.postgres16")
+	assert.True(t, found)
+	assert.Equal(t, int64(1000), value)
+	_, found = getStaticMaxConnections(merged, "db.x2g.large", "default")
+	assert.False(t, found)
+}
+
+func TestAddAllInstanceMetricsComplianceFlags(t *testing.T) {
+	x := RDSExporter{
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	instances := createTestDBInstances()
+	instances[0].IAMDatabaseAuthenticationEnabled = aws.Bool(true)
+	instances[0].DeletionProtection = aws.Bool(true)
+	instances[0].MultiAZ = aws.Bool(false)
+	x.addAllInstanceMetrics(0, instances, nil)
+
+	expected := map[string]float64{
+		IAMAuthEnabled.String():     1,
+		DeletionProtection.String(): 1,
+		MultiAZ.String():            0,
+	}
+	for _, metric := range x.cache.GetAllMetrics() {
+		if value, ok := expected[metric.Desc().String()]; ok {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, value, dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+			delete(expected, metric.Desc().String())
+		}
+	}
+	assert.Empty(t, expected)
+}
+
+func TestFilterInstances(t *testing.T) {
+	instances := []*rds.DBInstance{
+		{DBInstanceIdentifier: aws.String("prod-db")},
+		{DBInstanceIdentifier: aws.String("ci-db-1")},
+		{DBInstanceIdentifier: aws.String("test-db"), TagList: []*rds.Tag{{Key: aws.String("ephemeral"), Value: aws.String("true")}}},
+	}
+
+	x := RDSExporter{}
+	selected, excluded := x.filterInstances(instances)
+	assert.Equal(t, instances, selected)
+	assert.Nil(t, excluded)
+
+	x.filter = newResourceFilter(ResourceFilter{Exclude: &ResourceSelector{Identifier: "ci-.*"}})
+	selected, excluded = x.filterInstances(instances)
+	assert.Equal(t, []*rds.DBInstance{instances[0], instances[2]}, selected)
+	assert.Equal(t, map[string]bool{"ci-db-1": true}, excluded)
+
+	x.filter = newResourceFilter(ResourceFilter{Exclude: &ResourceSelector{Tags: map[string]string{"ephemeral": "true"}}})
+	selected, excluded = x.filterInstances(instances)
+	assert.Equal(t, []*rds.DBInstance{instances[0], instances[1]}, selected)
+	assert.Equal(t, map[string]bool{"test-db": true}, excluded)
+}
+
+func TestAddAllPendingMaintenancesExcluded(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribePendingMaintenanceActionsAll(ctx).Return([]*rds.ResourcePendingMaintenanceActions{
+		{
+			PendingMaintenanceActionDetails: []*rds.PendingMaintenanceAction{{
+				Action:      aws.String("system-update"),
+				Description: aws.String("patch"),
+			}},
+			ResourceIdentifier: aws.String("::::::ci-db-1"),
+		},
+	}, nil)
+
+	x := RDSExporter{
+		svcs:     []awsclient.Client{mockClient},
+		sessions: []*session.Session{session.New(&aws.Config{Region: aws.String("foo")})},
+		cache:    *NewMetricsCache(10 * time.Second),
+		logger:   log.NewNopLogger(),
+	}
+
+	assert.Nil(t, x.addAllPendingMaintenancesMetrics(ctx, 0, createTestDBInstances(), map[string]bool{"ci-db-1": true}))
+	// only the metric of the selected instance without pending maintenance
+	assert.Len(t, x.cache.GetAllMetrics(), 1)
+}
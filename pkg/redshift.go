@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const redshiftServiceCode string = "redshift"
+
+type RedshiftExporter struct {
+	sessions               []*session.Session
+	ClustersPerRegion      *prometheus.Desc
+	NodesPerRegion         *prometheus.Desc
+	ClusterNodes           *prometheus.Desc
+	ClusterNodeType        *prometheus.Desc
+	ClusterVersion         *prometheus.Desc
+	ClusterStatus          *prometheus.Desc
+	Encrypted              *prometheus.Desc
+	PubliclyAccessible     *prometheus.Desc
+	SnapshotRetentionLimit *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewRedshiftExporter creates a new RedshiftExporter instance
+func NewRedshiftExporter(sessions []*session.Session, logger log.Logger, config RedshiftConfig, awsAccountId string) *RedshiftExporter {
+	level.Info(logger).Log("msg", "Initializing Redshift exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: redshiftServiceCode}
+	clusterLabels := []string{"aws_region", "cluster_identifier"}
+
+	return &RedshiftExporter{
+		sessions:               sessions,
+		ClustersPerRegion:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_clustersperregion_usage"), "Number of Redshift clusters in this region", []string{"aws_region"}, constLabels),
+		NodesPerRegion:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_nodesperregion_usage"), "Number of Redshift nodes of all clusters in this region", []string{"aws_region"}, constLabels),
+		ClusterNodes:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_nodes_total"), "The number of compute nodes of the Redshift cluster", clusterLabels, constLabels),
+		ClusterNodeType:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_nodetype"), "The node type of the Redshift cluster", append(clusterLabels, "node_type"), constLabels),
+		ClusterVersion:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_version"), "The version of the Redshift cluster", append(clusterLabels, "cluster_version"), constLabels),
+		ClusterStatus:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_status"), "The status of the Redshift cluster", append(clusterLabels, "cluster_status"), constLabels),
+		Encrypted:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_encrypted"), "Whether the Redshift cluster is encrypted", clusterLabels, constLabels),
+		PubliclyAccessible:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_publiclyaccessible"), "Whether the Redshift cluster is publicly accessible", clusterLabels, constLabels),
+		SnapshotRetentionLimit: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "redshift_cluster_snapshot_retention_days"), "Days automated snapshots of the Redshift cluster are retained", clusterLabels, constLabels),
+		cache:                  *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                 logger,
+		timeout:                *config.Timeout,
+		interval:               *config.Interval,
+	}
+}
+
+func (e *RedshiftExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ClustersPerRegion
+	ch <- e.NodesPerRegion
+	ch <- e.ClusterNodes
+	ch <- e.ClusterNodeType
+	ch <- e.ClusterVersion
+	ch <- e.ClusterStatus
+	ch <- e.Encrypted
+	ch <- e.PubliclyAccessible
+	ch <- e.SnapshotRetentionLimit
+}
+
+func (e *RedshiftExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *RedshiftExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Redshift metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "redshift")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *RedshiftExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "redshift", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *RedshiftExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	clusters, err := client.DescribeRedshiftClustersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeRedshiftClustersAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	var nodes int64
+	for _, cluster := range clusters {
+		nodes += aws.Int64Value(cluster.NumberOfNodes)
+		e.addClusterMetrics(region, cluster)
+	}
+	// Redshift limits the total number of nodes rather than the number of clusters
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClustersPerRegion, prometheus.GaugeValue, float64(len(clusters)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.NodesPerRegion, prometheus.GaugeValue, float64(nodes), region))
+}
+
+func (e *RedshiftExporter) addClusterMetrics(region string, cluster *redshift.Cluster) {
+	clusterIdentifier := aws.StringValue(cluster.ClusterIdentifier)
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClusterNodes, prometheus.GaugeValue, float64(aws.Int64Value(cluster.NumberOfNodes)), region, clusterIdentifier))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClusterNodeType, prometheus.GaugeValue, 1, region, clusterIdentifier, aws.StringValue(cluster.NodeType)))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClusterVersion, prometheus.GaugeValue, 1, region, clusterIdentifier, aws.StringValue(cluster.ClusterVersion)))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.ClusterStatus, prometheus.GaugeValue, 1, region, clusterIdentifier, aws.StringValue(cluster.ClusterStatus)))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SnapshotRetentionLimit, prometheus.GaugeValue, float64(aws.Int64Value(cluster.AutomatedSnapshotRetentionPeriod)), region, clusterIdentifier))
+
+	encrypted := 0.0
+	if aws.BoolValue(cluster.Encrypted) {
+		encrypted = 1
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.Encrypted, prometheus.GaugeValue, encrypted, region, clusterIdentifier))
+
+	publiclyAccessible := 0.0
+	if aws.BoolValue(cluster.PubliclyAccessible) {
+		publiclyAccessible = 1
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.PubliclyAccessible, prometheus.GaugeValue, publiclyAccessible, region, clusterIdentifier))
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedshiftCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeRedshiftClustersAll(ctx).Return([]*redshift.Cluster{
+		{
+			ClusterIdentifier:                aws.String("a"),
+			NodeType:                         aws.String("ra3.xlplus"),
+			NumberOfNodes:                    aws.Int64(2),
+			ClusterVersion:                   aws.String("1.0"),
+			ClusterStatus:                    aws.String("available"),
+			Encrypted:                        aws.Bool(true),
+			AutomatedSnapshotRetentionPeriod: aws.Int64(7),
+		},
+		{ClusterIdentifier: aws.String("b"), NumberOfNodes: aws.Int64(3)},
+	}, nil)
+
+	e := NewRedshiftExporter(nil, log.NewNopLogger(), RedshiftConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("redshift", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// 7 metrics per cluster and the clusters and nodes per region
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 16)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.ClustersPerRegion.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.NodesPerRegion.String():
+			assert.Equal(t, 5.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestRedshiftCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeRedshiftClustersAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewRedshiftExporter(nil, log.NewNopLogger(), RedshiftConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("redshift", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
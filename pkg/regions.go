@@ -0,0 +1,29 @@
+package pkg
+
+import (
+	"context"
+	"sort"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// DiscoverRegions returns the regions enabled for the account, i.e. the regions which don't require an opt-in and
+// the ones the account opted in to
+func DiscoverRegions(ctx context.Context, client awsclient.Client) ([]string, error) {
+	out, err := client.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(true)})
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []string
+	for _, region := range out.Regions {
+		switch aws.StringValue(region.OptInStatus) {
+		case "opt-in-not-required", "opted-in":
+			regions = append(regions, aws.StringValue(region.RegionName))
+		}
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
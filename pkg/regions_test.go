@@ -0,0 +1,31 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverRegions(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{AllRegions: aws.Bool(true)}).Return(&ec2.DescribeRegionsOutput{
+		Regions: []*ec2.Region{
+			{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+			{RegionName: aws.String("af-south-1"), OptInStatus: aws.String("not-opted-in")},
+			{RegionName: aws.String("ap-east-1"), OptInStatus: aws.String("opted-in")},
+		},
+	}, nil)
+
+	regions, err := DiscoverRegions(ctx, mockClient)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ap-east-1", "us-east-1"}, regions)
+}
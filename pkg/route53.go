@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"sync"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -19,78 +20,182 @@ import (
 
 const (
 	maxRetries                    = 10
-	route53MaxConcurrency         = 5
+	route53DefaultMaxConcurrency  = 5
 	route53ServiceCode            = "route53"
 	hostedZonesQuotaCode          = "L-4EA4796A"
 	recordsPerHostedZoneQuotaCode = "L-E209CC9F"
+	healthChecksQuotaCode         = "L-ACB674F3"
+	trafficPoliciesQuotaCode      = "L-FC688E7C"
+	trafficPolicyInstancesCode    = "L-628D5A56"
+	reusableDelegationSetsCode    = "L-A72C7A53"
 	errorCodeThrottling           = "Throttling"
 )
 
+// route53AccountLimit is an account wide Route53 limit. The usage is read from GetAccountLimit, the quota from
+// ServiceQuotas as for the hosted zones.
+type route53AccountLimit struct {
+	limitType   string
+	quotaCode   string
+	Quota       *prometheus.Desc
+	Usage       *prometheus.Desc
+	Utilization *prometheus.Desc
+}
+
 type Route53Exporter struct {
-	sess                       *session.Session
-	RecordsPerHostedZoneQuota  *prometheus.Desc
-	RecordsPerHostedZoneUsage  *prometheus.Desc
-	HostedZonesPerAccountQuota *prometheus.Desc
-	HostedZonesPerAccountUsage *prometheus.Desc
-	LastUpdateTime             *prometheus.Desc
-	Cancel                     context.CancelFunc
+	client                           awsclient.Client
+	region                           string
+	RecordsPerHostedZoneQuota        *prometheus.Desc
+	RecordsPerHostedZoneUsage        *prometheus.Desc
+	RecordsPerHostedZoneUtilization  *prometheus.Desc
+	HostedZonesPerAccountQuota       *prometheus.Desc
+	HostedZonesPerAccountUsage       *prometheus.Desc
+	HostedZonesPerAccountUtilization *prometheus.Desc
+	HostedZonesPerAccountForecast    *prometheus.Desc
+	AccountLimits                    []route53AccountLimit
+	RecordsPerType                   *prometheus.Desc
+	HealthCheckStatus                *prometheus.Desc
+	Cancel                           context.CancelFunc
 
 	cache    MetricsCache
 	logger   log.Logger
 	interval time.Duration
 	timeout  time.Duration
+
+	recordTypeBreakdown bool
+	healthCheckStatus   bool
+	zoneFilter          *route53ZoneFilter
+	// forecaster forecasts when the hosted zones reach their quota
+	forecaster *quotaForecaster
+	// maxConcurrency is the number of hosted zones and health checks requested in parallel
+	maxConcurrency int
+}
+
+// route53ZoneFilter is the compiled Route53ZoneFilter
+type route53ZoneFilter struct {
+	include, exclude *regexp.Regexp
+	privateZone      *bool
+}
+
+func newRoute53ZoneFilter(config Route53ZoneFilter) *route53ZoneFilter {
+	filter := &route53ZoneFilter{privateZone: config.PrivateZone}
+	if config.Include != "" {
+		filter.include = regexp.MustCompile(anchoredPattern(config.Include))
+	}
+	if config.Exclude != "" {
+		filter.exclude = regexp.MustCompile(anchoredPattern(config.Exclude))
+	}
+	return filter
+}
+
+// selects returns whether the records of the hosted zone are counted
+func (f *route53ZoneFilter) selects(hostedZone *route53.HostedZone) bool {
+	if f == nil {
+		return true
+	}
+	name := strings.TrimSuffix(aws.StringValue(hostedZone.Name), ".")
+	if f.include != nil && !f.include.MatchString(name) {
+		return false
+	}
+	if f.exclude != nil && f.exclude.MatchString(name) {
+		return false
+	}
+	return f.privateZone == nil || *f.privateZone == isPrivateZone(hostedZone)
+}
+
+func isPrivateZone(hostedZone *route53.HostedZone) bool {
+	return hostedZone.Config != nil && aws.BoolValue(hostedZone.Config.PrivateZone)
 }
 
-func NewRoute53Exporter(sess *session.Session, logger log.Logger, config Route53Config, awsAccountId string) *Route53Exporter {
+func NewRoute53Exporter(client awsclient.Client, logger log.Logger, config Route53Config, awsAccountId string) *Route53Exporter {
 
 	level.Info(logger).Log("msg", "Initializing Route53 exporter")
 	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: route53ServiceCode}
 
 	exporter := &Route53Exporter{
-		sess:                       sess,
-		RecordsPerHostedZoneQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_recordsperhostedzone_quota"), "Quota for maximum number of records in a Route53 hosted zone", []string{"hostedzoneid", "hostedzonename"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, recordsPerHostedZoneQuotaCode)),
-		RecordsPerHostedZoneUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_recordsperhostedzone_total"), "Number of Resource records", []string{"hostedzoneid", "hostedzonename"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, recordsPerHostedZoneQuotaCode)),
-		HostedZonesPerAccountQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_quota"), "Quota for maximum number of Route53 hosted zones in an account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
-		HostedZonesPerAccountUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_total"), "Number of Resource records", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
-		LastUpdateTime:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_last_updated_timestamp_seconds"), "Last time, the route53 metrics were sucessfully updated", []string{}, constLabels),
-		cache:                      *NewMetricsCache(*config.CacheTTL),
-		logger:                     logger,
-		interval:                   *config.Interval,
-		timeout:                    *config.Timeout,
+		client:                           client,
+		region:                           config.Region,
+		RecordsPerHostedZoneQuota:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_recordsperhostedzone_quota"), "Quota for maximum number of records in a Route53 hosted zone", []string{"hostedzoneid", "hostedzonename", "is_private_zone"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, recordsPerHostedZoneQuotaCode)),
+		RecordsPerHostedZoneUsage:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_recordsperhostedzone_total"), "Number of Resource records", []string{"hostedzoneid", "hostedzonename", "is_private_zone"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, recordsPerHostedZoneQuotaCode)),
+		RecordsPerHostedZoneUtilization:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_recordsperhostedzone_quota_utilization_ratio"), "Number of resource records in a Route53 hosted zone relative to the quota", []string{"hostedzoneid", "hostedzonename", "is_private_zone"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, recordsPerHostedZoneQuotaCode)),
+		HostedZonesPerAccountQuota:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_quota"), "Quota for maximum number of Route53 hosted zones in an account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
+		HostedZonesPerAccountUsage:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_total"), "Number of Resource records", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
+		HostedZonesPerAccountUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_quota_utilization_ratio"), "Number of Route53 hosted zones in the account relative to the quota", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
+		HostedZonesPerAccountForecast:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_hostedzonesperaccount_days_until_quota_exhausted"), "The days until the hosted zones reach their quota at the growth of the last week", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, hostedZonesQuotaCode)),
+		cache:                            *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                           logger,
+		interval:                         *config.Interval,
+		timeout:                          *config.Timeout,
+		recordTypeBreakdown:              config.RecordTypeBreakdown,
+		healthCheckStatus:                config.HealthCheckStatus,
+		zoneFilter:                       newRoute53ZoneFilter(config.ZoneFilter),
+		forecaster:                       quotaForecasterFor("route53"),
+		maxConcurrency:                   config.MaxConcurrency,
+	}
+	if exporter.recordTypeBreakdown {
+		exporter.RecordsPerType = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_records_total"), "Number of resource records per type in a Route53 hosted zone", []string{"hostedzoneid", "type"}, map[string]string{"aws_account_id": awsAccountId})
+	}
+	if exporter.healthCheckStatus {
+		exporter.HealthCheckStatus = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_healthcheck_status"), "Ratio of the Route53 health checkers reporting the health check as healthy", []string{"healthcheckid", "type"}, map[string]string{"aws_account_id": awsAccountId})
+	}
+	exporter.AccountLimits = []route53AccountLimit{
+		newRoute53AccountLimit(constLabels, "healthchecksperaccount", "health checks", route53.AccountLimitTypeMaxHealthChecksByOwner, healthChecksQuotaCode),
+		newRoute53AccountLimit(constLabels, "trafficpoliciesperaccount", "traffic policies", route53.AccountLimitTypeMaxTrafficPoliciesByOwner, trafficPoliciesQuotaCode),
+		newRoute53AccountLimit(constLabels, "trafficpolicyinstancesperaccount", "traffic policy instances", route53.AccountLimitTypeMaxTrafficPolicyInstancesByOwner, trafficPolicyInstancesCode),
+		newRoute53AccountLimit(constLabels, "reusabledelegationsetsperaccount", "reusable delegation sets", route53.AccountLimitTypeMaxReusableDelegationSetsByOwner, reusableDelegationSetsCode),
 	}
 	return exporter
 }
 
+func newRoute53AccountLimit(constLabels map[string]string, name string, description string, limitType string, quotaCode string) route53AccountLimit {
+	labels := WithKeyValue(constLabels, QUOTA_CODE_KEY, quotaCode)
+	return route53AccountLimit{
+		limitType:   limitType,
+		quotaCode:   quotaCode,
+		Quota:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_"+name+"_quota"), "Quota for maximum number of Route53 "+description+" in an account", []string{}, labels),
+		Usage:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_"+name+"_total"), "Number of Route53 "+description+" in the account", []string{}, labels),
+		Utilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "route53_"+name+"_quota_utilization_ratio"), "Number of Route53 "+description+" in the account relative to the quota", []string{}, labels),
+	}
+}
+
 func (e *Route53Exporter) getRecordsPerHostedZoneMetrics(client awsclient.Client, hostedZones []*route53.HostedZone, ctx context.Context) []error {
-	errChan := make(chan error, len(hostedZones))
+	// with the record type breakdown every hosted zone can report two errors
+	errChan := make(chan error, 2*len(hostedZones))
 	errs := []error{}
 
-	wg := &sync.WaitGroup{}
-	wg.Add(len(hostedZones))
-	sem := make(chan int, route53MaxConcurrency)
-	defer close(sem)
+	sem := newSemaphore(e.maxConcurrency)
 	for i, hostedZone := range hostedZones {
-
-		sem <- 1
-		go func(i int, hostedZone *route53.HostedZone) {
-			defer func() {
-				<-sem
-				wg.Done()
-			}()
+		i, hostedZone := i, hostedZone
+		started := sem.Go(ctx, func() {
 			hostedZoneLimitOut, err := GetHostedZoneLimitWithBackoff(client, ctx, hostedZone.Id, maxRetries, e.logger)
 
 			if err != nil {
 				errChan <- fmt.Errorf("Could not get Limits for hosted zone with ID '%s' and name '%s'. Error was: %s", *hostedZone.Id, *hostedZone.Name, err.Error())
-				awsclient.AwsExporterMetrics.IncrementErrors()
 				return
 			}
 			level.Info(e.logger).Log("msg", fmt.Sprintf("Currently at hosted zone: %d / %d", i, len(hostedZones)))
-			e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecordsPerHostedZoneQuota, prometheus.GaugeValue, float64(*hostedZoneLimitOut.Limit.Value), *hostedZone.Id, *hostedZone.Name))
-			e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecordsPerHostedZoneUsage, prometheus.GaugeValue, float64(*hostedZoneLimitOut.Count), *hostedZone.Id, *hostedZone.Name))
+			privateZone := strconv.FormatBool(isPrivateZone(hostedZone))
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecordsPerHostedZoneQuota, prometheus.GaugeValue, float64(*hostedZoneLimitOut.Limit.Value), *hostedZone.Id, *hostedZone.Name, privateZone))
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecordsPerHostedZoneUsage, prometheus.GaugeValue, float64(*hostedZoneLimitOut.Count), *hostedZone.Id, *hostedZone.Name, privateZone))
+			e.cache.AddQuotaUtilization(e.RecordsPerHostedZoneUtilization, float64(*hostedZoneLimitOut.Count), float64(*hostedZoneLimitOut.Limit.Value), *hostedZone.Id, *hostedZone.Name, privateZone)
+
+			if e.recordTypeBreakdown {
+				recordTypeCounts, err := countRecordsPerTypeWithBackoff(client, ctx, hostedZone.Id, maxRetries, e.logger)
+				if err != nil {
+					errChan <- fmt.Errorf("Could not list records for hosted zone with ID '%s' and name '%s'. Error was: %s", *hostedZone.Id, *hostedZone.Name, err.Error())
+					return
+				}
+				for recordType, count := range recordTypeCounts {
+					e.cache.AddMetric(prometheus.MustNewConstMetric(e.RecordsPerType, prometheus.GaugeValue, float64(count), *hostedZone.Id, recordType))
+				}
+			}
 
-		}(i, hostedZone)
+		})
+		if !started {
+			errs = append(errs, fmt.Errorf("Could not get Limits for %d hosted zones: %w", len(hostedZones)-i, ctx.Err()))
+			break
+		}
 	}
-	wg.Wait()
+	sem.Wait()
 	close(errChan)
 
 	for err := range errChan {
@@ -108,43 +213,156 @@ func (e *Route53Exporter) getHostedZonesPerAccountMetrics(client awsclient.Clien
 
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.HostedZonesPerAccountQuota, prometheus.GaugeValue, quota))
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.HostedZonesPerAccountUsage, prometheus.GaugeValue, float64(len(hostedZones))))
+	e.cache.AddQuotaUtilization(e.HostedZonesPerAccountUtilization, float64(len(hostedZones)), quota)
+	if days, ok := e.forecaster.forecast(time.Now(), float64(len(hostedZones)), quota); ok {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.HostedZonesPerAccountForecast, prometheus.GaugeValue, days))
+	}
 	return nil
 }
 
-// CollectLoop runs indefinitely to collect the route53 metrics in a cache. Metrics are only written into the cache once all have been collected to ensure that we don't have a partial collect.
-func (e *Route53Exporter) CollectLoop() {
-	client := awsclient.NewClientFromSession(e.sess)
+func (e *Route53Exporter) getAccountLimitMetrics(client awsclient.Client, ctx context.Context) []error {
+	errs := []error{}
+	for _, limit := range e.AccountLimits {
+		quota, err := getQuotaValueWithContext(client, route53ServiceCode, limit.quotaCode, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Could not get quota %s: %w", limit.quotaCode, err))
+			continue
+		}
+		accountLimitOut, err := GetAccountLimitWithBackoff(client, ctx, limit.limitType, maxRetries, e.logger)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Could not get account limit %s: %w", limit.limitType, err))
+			continue
+		}
+
+		e.cache.AddMetric(prometheus.MustNewConstMetric(limit.Quota, prometheus.GaugeValue, quota))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(limit.Usage, prometheus.GaugeValue, float64(aws.Int64Value(accountLimitOut.Count))))
+		e.cache.AddQuotaUtilization(limit.Utilization, float64(aws.Int64Value(accountLimitOut.Count)), quota)
+	}
+	return errs
+}
+
+// getHealthCheckStatusMetrics adds the status of the health checks as reported by the Route53 health checkers.
+// Calculated and CloudWatch alarm health checks are skipped, as GetHealthCheckStatus doesn't support them.
+func (e *Route53Exporter) getHealthCheckStatusMetrics(client awsclient.Client, ctx context.Context) []error {
+	healthChecks, err := client.ListHealthChecksAll(ctx)
+	if err != nil {
+		return []error{fmt.Errorf("Could not list health checks: %w", err)}
+	}
+
+	errChan := make(chan error, len(healthChecks))
+	errs := []error{}
+
+	sem := newSemaphore(e.maxConcurrency)
+	for _, healthCheck := range healthChecks {
+		if healthCheck.HealthCheckConfig == nil {
+			continue
+		}
+		checkType := aws.StringValue(healthCheck.HealthCheckConfig.Type)
+		if checkType == route53.HealthCheckTypeCalculated || checkType == route53.HealthCheckTypeCloudwatchMetric {
+			continue
+		}
 
+		healthCheck := healthCheck
+		started := sem.Go(ctx, func() {
+			statusOut, err := client.GetHealthCheckStatusWithContext(ctx, &route53.GetHealthCheckStatusInput{HealthCheckId: healthCheck.Id})
+			if err != nil {
+				errChan <- fmt.Errorf("Could not get the status of health check with ID '%s'. Error was: %s", aws.StringValue(healthCheck.Id), err.Error())
+				return
+			}
+			if len(statusOut.HealthCheckObservations) == 0 {
+				return
+			}
+
+			healthy := 0
+			for _, observation := range statusOut.HealthCheckObservations {
+				// the checkers report e.g. "Success: HTTP Status Code 200, OK" or "Failure: Connection timed out"
+				if observation.StatusReport != nil && strings.HasPrefix(aws.StringValue(observation.StatusReport.Status), "Success") {
+					healthy++
+				}
+			}
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.HealthCheckStatus, prometheus.GaugeValue, float64(healthy)/float64(len(statusOut.HealthCheckObservations)), aws.StringValue(healthCheck.Id), checkType))
+		})
+		if !started {
+			errs = append(errs, fmt.Errorf("Could not get the status of the health checks: %w", ctx.Err()))
+			break
+		}
+	}
+	sem.Wait()
+	close(errChan)
+
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// CollectLoop runs until the context is cancelled to collect the route53 metrics in a cache. Metrics are only written into the cache once all have been collected to ensure that we don't have a partial collect.
+func (e *Route53Exporter) CollectLoop(ctx context.Context) {
 	for {
-		ctx, ctxCancelFunc := context.WithTimeout(context.Background(), e.timeout)
+		collectCtx, ctxCancelFunc := context.WithTimeout(ctx, e.timeout)
 		e.Cancel = ctxCancelFunc
 		level.Info(e.logger).Log("msg", "Updating Route53 metrics...")
+		e.cache.BeginGeneration()
 
-		hostedZones, err := getAllHostedZones(client, ctx, e.logger)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "route53", e.region)
+		hostedZones, err := getAllHostedZones(e.client, collectCtx, e.logger)
 
 		level.Info(e.logger).Log("msg", "Got all zones")
 		if err != nil {
 			level.Error(e.logger).Log("msg", "Could not retrieve the list of hosted zones", "error", err.Error())
-			awsclient.AwsExporterMetrics.IncrementErrors()
+			run.fail()
+			// the usage is unknown, so the previous usage is kept instead of recording no hosted zones
+		} else if err = e.getHostedZonesPerAccountMetrics(e.client, hostedZones, collectCtx); err != nil {
+			run.fail()
+			level.Error(e.logger).Log("msg", "Could not get limits for hosted zone", "error", err.Error())
 		}
 
-		err = e.getHostedZonesPerAccountMetrics(client, hostedZones, ctx)
-		if err != nil {
+		// the hosted zone quota counts all zones, the filter only skips the lookups per zone
+		var selectedZones []*route53.HostedZone
+		for _, hostedZone := range hostedZones {
+			if e.zoneFilter.selects(hostedZone) {
+				selectedZones = append(selectedZones, hostedZone)
+			}
+		}
+		errs := e.getRecordsPerHostedZoneMetrics(e.client, selectedZones, collectCtx)
+		if len(errs) > 0 {
+			run.fail()
+		}
+		for _, err = range errs {
 			level.Error(e.logger).Log("msg", "Could not get limits for hosted zone", "error", err.Error())
-			awsclient.AwsExporterMetrics.IncrementErrors()
 		}
 
-		errs := e.getRecordsPerHostedZoneMetrics(client, hostedZones, ctx)
+		errs = e.getAccountLimitMetrics(e.client, collectCtx)
+		if len(errs) > 0 {
+			run.fail()
+		}
 		for _, err = range errs {
-			level.Error(e.logger).Log("msg", "Could not get limits for hosted zone", "error", err.Error())
-			awsclient.AwsExporterMetrics.IncrementErrors()
+			level.Error(e.logger).Log("msg", "Could not get account limits", "error", err.Error())
+		}
+
+		if e.healthCheckStatus {
+			errs = e.getHealthCheckStatusMetrics(e.client, collectCtx)
+			if len(errs) > 0 {
+				run.fail()
+			}
+			for _, err = range errs {
+				level.Error(e.logger).Log("msg", "Could not get health check status", "error", err.Error())
+			}
 		}
 
 		level.Info(e.logger).Log("msg", "Route53 metrics Updated")
+		finished := run.finish()
+		e.cache.CommitGeneration()
+		if finished {
+			setCollectorLastUpdate(ctx, "route53")
+		}
 
 		ctxCancelFunc() // should never do anything as we don't run stuff in the background
 
-		time.Sleep(e.interval)
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
 	}
 }
 
@@ -157,7 +375,22 @@ func (e *Route53Exporter) Collect(ch chan<- prometheus.Metric) {
 func (e *Route53Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- e.RecordsPerHostedZoneQuota
 	ch <- e.RecordsPerHostedZoneUsage
-	ch <- e.LastUpdateTime
+	ch <- e.RecordsPerHostedZoneUtilization
+	ch <- e.HostedZonesPerAccountQuota
+	ch <- e.HostedZonesPerAccountUsage
+	ch <- e.HostedZonesPerAccountUtilization
+	ch <- e.HostedZonesPerAccountForecast
+	for _, limit := range e.AccountLimits {
+		ch <- limit.Quota
+		ch <- limit.Usage
+		ch <- limit.Utilization
+	}
+	if e.recordTypeBreakdown {
+		ch <- e.RecordsPerType
+	}
+	if e.healthCheckStatus {
+		ch <- e.HealthCheckStatus
+	}
 }
 
 func getAllHostedZones(client awsclient.Client, ctx context.Context, logger log.Logger) ([]*route53.HostedZone, error) {
@@ -197,7 +430,9 @@ func ListHostedZonesWithBackoff(client awsclient.Client, ctx context.Context, in
 		}
 		level.Debug(logger).Log("msg", "Retrying throttling api call", "tries", i+1, "endpoint", "ListHostedZones")
 		backOffSeconds := math.Pow(2, float64(i-1))
-		time.Sleep(time.Duration(backOffSeconds) * time.Second)
+		if !sleepWithContext(ctx, time.Duration(backOffSeconds)*time.Second) {
+			return nil, ctx.Err()
+		}
 	}
 	return nil, err
 }
@@ -221,12 +456,85 @@ func GetHostedZoneLimitWithBackoff(client awsclient.Client, ctx context.Context,
 		}
 		level.Debug(logger).Log("msg", "Retrying throttling api call", "tries", i+1, "endpoint", "GetHostedZoneLimit", "hostedZoneID", hostedZoneId)
 		backOffSeconds := math.Pow(2, float64(i-1))
-		time.Sleep(time.Duration(backOffSeconds) * time.Second)
+		if !sleepWithContext(ctx, time.Duration(backOffSeconds)*time.Second) {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
 
+func GetAccountLimitWithBackoff(client awsclient.Client, ctx context.Context, limitType string, maxTries int, logger log.Logger) (*route53.GetAccountLimitOutput, error) {
+	accountLimitInput := &route53.GetAccountLimitInput{
+		Type: aws.String(limitType),
+	}
+	var accountLimitOut *route53.GetAccountLimitOutput
+	var err error
+
+	for i := 0; i < maxTries; i++ {
+		accountLimitOut, err = client.GetAccountLimitWithContext(ctx, accountLimitInput)
+		if err == nil {
+			return accountLimitOut, err
+		}
+
+		if !isThrottlingError(err) {
+			return nil, err
+		}
+		level.Debug(logger).Log("msg", "Retrying throttling api call", "tries", i+1, "endpoint", "GetAccountLimit", "type", limitType)
+		backOffSeconds := math.Pow(2, float64(i-1))
+		if !sleepWithContext(ctx, time.Duration(backOffSeconds)*time.Second) {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
+
+func ListResourceRecordSetsWithBackoff(client awsclient.Client, ctx context.Context, input *route53.ListResourceRecordSetsInput, maxTries int, logger log.Logger) (*route53.ListResourceRecordSetsOutput, error) {
+	var listRecordSetsOut *route53.ListResourceRecordSetsOutput
+	var err error
+
+	for i := 0; i < maxTries; i++ {
+		listRecordSetsOut, err = client.ListResourceRecordSetsWithContext(ctx, input)
+		if err == nil {
+			return listRecordSetsOut, err
+		}
+
+		if !isThrottlingError(err) {
+			return nil, err
+		}
+		level.Debug(logger).Log("msg", "Retrying throttling api call", "tries", i+1, "endpoint", "ListResourceRecordSets", "hostedZoneID", input.HostedZoneId)
+		backOffSeconds := math.Pow(2, float64(i-1))
+		if !sleepWithContext(ctx, time.Duration(backOffSeconds)*time.Second) {
+			return nil, ctx.Err()
+		}
 	}
 	return nil, err
 }
 
+// countRecordsPerTypeWithBackoff lists all record sets of the hosted zone and counts them per record type
+func countRecordsPerTypeWithBackoff(client awsclient.Client, ctx context.Context, hostedZoneId *string, maxTries int, logger log.Logger) (map[string]int, error) {
+	counts := make(map[string]int)
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: hostedZoneId,
+	}
+
+	for {
+		listRecordSetsOut, err := ListResourceRecordSetsWithBackoff(client, ctx, input, maxTries, logger)
+		if err != nil {
+			return nil, err
+		}
+		for _, recordSet := range listRecordSetsOut.ResourceRecordSets {
+			counts[aws.StringValue(recordSet.Type)]++
+		}
+
+		if !aws.BoolValue(listRecordSetsOut.IsTruncated) {
+			return counts, nil
+		}
+		input.StartRecordName = listRecordSetsOut.NextRecordName
+		input.StartRecordType = listRecordSetsOut.NextRecordType
+		input.StartRecordIdentifier = listRecordSetsOut.NextRecordIdentifier
+	}
+}
+
 func createGetHostedZoneLimitInput(hostedZoneId, limitType string) *route53.GetHostedZoneLimitInput {
 	return &route53.GetHostedZoneLimitInput{
 		HostedZoneId: aws.String(hostedZoneId),
@@ -3,12 +3,17 @@ package pkg
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/go-kit/kit/log"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -99,3 +104,150 @@ func TestListHostedZonesWithBackoff(t *testing.T) {
 	assert.Nil(t, actualErr)
 	assert.Equal(t, "10", *actualResult.MaxItems)
 }
+
+func TestGetAccountLimitMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &Route53Exporter{
+		cache:  *NewMetricsCache(10 * time.Second),
+		logger: log.NewNopLogger(),
+	}
+	e.AccountLimits = []route53AccountLimit{
+		newRoute53AccountLimit(nil, "healthchecksperaccount", "health checks", route53.AccountLimitTypeMaxHealthChecksByOwner, healthChecksQuotaCode),
+	}
+
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(route53ServiceCode, healthChecksQuotaCode)).
+		Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(200)}}, nil)
+	mockClient.EXPECT().GetAccountLimitWithContext(ctx, &route53.GetAccountLimitInput{Type: aws.String(route53.AccountLimitTypeMaxHealthChecksByOwner)}).
+		Return(&route53.GetAccountLimitOutput{Count: aws.Int64(12)}, nil)
+
+	errs := e.getAccountLimitMetrics(mockClient, ctx)
+	assert.Empty(t, errs)
+	// the quota, usage and utilization
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 3)
+	for _, metric := range metrics {
+		if metric.Desc().String() == e.AccountLimits[0].Utilization.String() {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, 0.06, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestCountRecordsPerTypeWithBackoff(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	gomock.InOrder(
+		mockClient.EXPECT().ListResourceRecordSetsWithContext(ctx, gomock.Any()).
+			Return(nil, awserr.New(errorCodeThrottling, "Rate exceeded", nil)),
+		mockClient.EXPECT().ListResourceRecordSetsWithContext(ctx, gomock.Any()).
+			Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{
+					{Type: aws.String("A")},
+					{Type: aws.String("TXT")},
+				},
+				IsTruncated:    aws.Bool(true),
+				NextRecordName: aws.String("b.example.com"),
+				NextRecordType: aws.String("TXT"),
+			}, nil),
+		mockClient.EXPECT().ListResourceRecordSetsWithContext(ctx, &route53.ListResourceRecordSetsInput{
+			HostedZoneId:    aws.String("zone"),
+			StartRecordName: aws.String("b.example.com"),
+			StartRecordType: aws.String("TXT"),
+		}).Return(&route53.ListResourceRecordSetsOutput{
+			ResourceRecordSets: []*route53.ResourceRecordSet{
+				{Type: aws.String("TXT")},
+			},
+			IsTruncated: aws.Bool(false),
+		}, nil),
+	)
+
+	counts, err := countRecordsPerTypeWithBackoff(mockClient, ctx, aws.String("zone"), maxRetries, log.NewNopLogger())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"A": 1, "TXT": 2}, counts)
+}
+
+func TestRoute53ZoneFilter(t *testing.T) {
+	public := &route53.HostedZone{Name: aws.String("example.com.")}
+	private := &route53.HostedZone{Name: aws.String("ci-123.internal.example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}}
+
+	var all *route53ZoneFilter
+	assert.True(t, all.selects(private))
+	assert.True(t, newRoute53ZoneFilter(Route53ZoneFilter{}).selects(private))
+
+	filter := newRoute53ZoneFilter(Route53ZoneFilter{Exclude: `ci-\d+\..*`})
+	assert.True(t, filter.selects(public))
+	assert.False(t, filter.selects(private))
+
+	filter = newRoute53ZoneFilter(Route53ZoneFilter{Include: `.*example\.com`, PrivateZone: aws.Bool(false)})
+	assert.True(t, filter.selects(public))
+	assert.False(t, filter.selects(private))
+}
+
+func TestGetHealthCheckStatusMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &Route53Exporter{
+		HealthCheckStatus: prometheus.NewDesc("test", "test", []string{"healthcheckid", "type"}, nil),
+		cache:             *NewMetricsCache(10 * time.Second),
+		logger:            log.NewNopLogger(),
+	}
+
+	mockClient.EXPECT().ListHealthChecksAll(ctx).Return([]*route53.HealthCheck{
+		{Id: aws.String("hc-1"), HealthCheckConfig: &route53.HealthCheckConfig{Type: aws.String(route53.HealthCheckTypeHttps)}},
+		{Id: aws.String("hc-2"), HealthCheckConfig: &route53.HealthCheckConfig{Type: aws.String(route53.HealthCheckTypeCalculated)}},
+	}, nil)
+	mockClient.EXPECT().GetHealthCheckStatusWithContext(ctx, &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-1")}).
+		Return(&route53.GetHealthCheckStatusOutput{HealthCheckObservations: []*route53.HealthCheckObservation{
+			{StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: Connection timed out")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK")}},
+		}}, nil)
+
+	errs := e.getHealthCheckStatusMetrics(mockClient, ctx)
+	assert.Empty(t, errs)
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	out := dto.Metric{}
+	assert.Nil(t, metrics[0].Write(&out))
+	assert.Equal(t, 0.75, out.GetGauge().GetValue())
+}
+
+func TestRoute53CollectLoopListFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &Route53Exporter{
+		client:                     mockClient,
+		cache:                      *NewMetricsCache(10 * time.Second),
+		logger:                     log.NewNopLogger(),
+		timeout:                    time.Minute,
+		forecaster:                 newQuotaForecaster(),
+		HostedZonesPerAccountUsage: prometheus.NewDesc("test_hostedzones_total", "help", nil, nil),
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.HostedZonesPerAccountUsage, prometheus.GaugeValue, 3))
+
+	// the quota isn't looked up, as the usage is unknown without the hosted zones
+	mockClient.EXPECT().ListHostedZonesWithContext(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "", nil))
+	CollectOnce(context.Background(), e)
+
+	// the previous usage is kept and no usage of 0 is recorded for the forecast
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, 3.0, dtoMetric.GetGauge().GetValue())
+	assert.Empty(t, e.forecaster.samples)
+}
@@ -0,0 +1,250 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	s3ServiceCode    string = "s3"
+	bucketsQuotaCode string = "L-DC2B2D3D"
+
+	s3MetricNamespace          string = "AWS/S3"
+	s3BucketSizeBytesMetric    string = "BucketSizeBytes"
+	s3NumberOfObjectsMetric    string = "NumberOfObjects"
+	s3BucketNameDimension      string = "BucketName"
+	s3StorageTypeDimension     string = "StorageType"
+	s3StorageMetricsPeriod     int64  = 86400
+	getMetricDataMaxQueries    int    = 500
+	s3StorageMetricsLookbehind        = 2 * 24 * time.Hour
+)
+
+// S3_STORAGE_METRICS_TTL_DEFAULT is how long the daily storage metrics are cached by default
+var S3_STORAGE_METRICS_TTL_DEFAULT = 6 * time.Hour
+
+type S3Exporter struct {
+	sessions       []*session.Session
+	storageMetrics bool
+	// storageMetricsTTL is how long the storage metrics of a region are cached, as they only change once a day
+	storageMetricsTTL time.Duration
+	// storageMetricsUpdated holds when the storage metrics of every region were queried last
+	storageMetricsUpdated map[string]time.Time
+	storageMetricsMutex   sync.Mutex
+	BucketsQuota          *prometheus.Desc
+	BucketsUsage          *prometheus.Desc
+	BucketSizeBytes       *prometheus.Desc
+	BucketObjects         *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// s3StorageMetric identifies the bucket and storage type a CloudWatch query was made for
+type s3StorageMetric struct {
+	metricName  string
+	bucketName  string
+	storageType string
+}
+
+// NewS3Exporter creates a new S3Exporter instance
+func NewS3Exporter(sessions []*session.Session, logger log.Logger, config S3Config, awsAccountId string) *S3Exporter {
+	level.Info(logger).Log("msg", "Initializing S3 exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: s3ServiceCode}
+	bucketLabels := []string{"aws_region", "bucket_name", "storage_type"}
+
+	return &S3Exporter{
+		sessions:              sessions,
+		storageMetrics:        config.StorageMetrics,
+		storageMetricsTTL:     durationValue(config.StorageMetricsTTL),
+		storageMetricsUpdated: map[string]time.Time{},
+		BucketsQuota:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "s3_buckets_quota"), "Quota for maximum number of S3 buckets in an account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, bucketsQuotaCode)),
+		BucketsUsage:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "s3_buckets_usage"), "Number of S3 buckets in the account", []string{}, WithKeyValue(constLabels, QUOTA_CODE_KEY, bucketsQuotaCode)),
+		BucketSizeBytes:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "s3_bucket_size_bytes"), "Daily number of bytes stored in the S3 bucket per storage type", bucketLabels, constLabels),
+		BucketObjects:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "s3_bucket_objects"), "Daily number of objects stored in the S3 bucket", bucketLabels, constLabels),
+		cache:                 *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                logger,
+		timeout:               *config.Timeout,
+		interval:              *config.Interval,
+	}
+}
+
+func (e *S3Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.BucketsQuota
+	ch <- e.BucketsUsage
+	ch <- e.BucketSizeBytes
+	ch <- e.BucketObjects
+}
+
+func (e *S3Exporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *S3Exporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			// buckets are listed for the whole account, so they are only counted in the first region
+			go e.collectInRegion(sess, i == 0, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "S3 metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "s3")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *S3Exporter) collectInRegion(sess *session.Session, countBuckets bool, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "s3", region)
+	defer run.finish()
+
+	if countBuckets {
+		e.collectBucketMetrics(client, ctx, region, run)
+	}
+	if e.storageMetrics {
+		e.collectStorageMetrics(client, ctx, region, run)
+	}
+}
+
+func (e *S3Exporter) collectBucketMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	quota, err := getQuotaValueWithContext(client, s3ServiceCode, bucketsQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve S3 buckets quota", "region", region, "error", err.Error())
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.BucketsQuota, prometheus.GaugeValue, quota))
+	}
+
+	buckets, err := client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListBuckets failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.BucketsUsage, prometheus.GaugeValue, float64(len(buckets.Buckets))))
+}
+
+// collectStorageMetrics exports the latest daily storage metrics S3 publishes to CloudWatch for the buckets of this
+// region. Listing the metrics first avoids querying storage types a bucket doesn't use.
+// storageMetricsCached returns whether the storage metrics of the region were queried within their TTL
+func (e *S3Exporter) storageMetricsCached(region string) bool {
+	e.storageMetricsMutex.Lock()
+	defer e.storageMetricsMutex.Unlock()
+	updated, ok := e.storageMetricsUpdated[region]
+	return ok && time.Since(updated) < e.storageMetricsTTL
+}
+
+// collectStorageMetrics queries the daily storage metrics of the buckets. CloudWatch bills every requested metric, so
+// they are cached for the storage metrics TTL instead of being queried on every collection.
+func (e *S3Exporter) collectStorageMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	if e.storageMetricsCached(region) {
+		level.Debug(e.logger).Log("msg", "S3 storage metrics are still cached", "region", region)
+		return
+	}
+	metrics, err := client.ListMetricsAll(ctx, &cloudwatch.ListMetricsInput{
+		Namespace: aws.String(s3MetricNamespace),
+	})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListMetricsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	var queries []*cloudwatch.MetricDataQuery
+	storageMetrics := map[string]s3StorageMetric{}
+	for _, metric := range metrics {
+		metricName := aws.StringValue(metric.MetricName)
+		if metricName != s3BucketSizeBytesMetric && metricName != s3NumberOfObjectsMetric {
+			continue
+		}
+
+		id := fmt.Sprintf("m%d", len(queries))
+		storageMetric := s3StorageMetric{metricName: metricName}
+		for _, dimension := range metric.Dimensions {
+			switch aws.StringValue(dimension.Name) {
+			case s3BucketNameDimension:
+				storageMetric.bucketName = aws.StringValue(dimension.Value)
+			case s3StorageTypeDimension:
+				storageMetric.storageType = aws.StringValue(dimension.Value)
+			}
+		}
+		storageMetrics[id] = storageMetric
+		queries = append(queries, &cloudwatch.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: metric,
+				Period: aws.Int64(s3StorageMetricsPeriod),
+				Stat:   aws.String(cloudwatch.StatisticAverage),
+			},
+		})
+	}
+
+	now := time.Now()
+	for start := 0; start < len(queries); start += getMetricDataMaxQueries {
+		end := min(start+getMetricDataMaxQueries, len(queries))
+		results, err := client.GetMetricDataAll(ctx, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries[start:end],
+			StartTime:         aws.Time(now.Add(-s3StorageMetricsLookbehind)),
+			EndTime:           aws.Time(now),
+			ScanBy:            aws.String(cloudwatch.ScanByTimestampDescending),
+		})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetMetricDataAll failed", "region", region, "err", err)
+			run.fail()
+			return
+		}
+		e.addStorageMetrics(region, storageMetrics, results)
+	}
+
+	e.storageMetricsMutex.Lock()
+	defer e.storageMetricsMutex.Unlock()
+	e.storageMetricsUpdated[region] = now
+}
+
+func (e *S3Exporter) addStorageMetrics(region string, storageMetrics map[string]s3StorageMetric, results []*cloudwatch.MetricDataResult) {
+	for _, result := range results {
+		storageMetric, ok := storageMetrics[aws.StringValue(result.Id)]
+		// the values are ordered newest first, a bucket without a value in the lookbehind is skipped
+		if !ok || len(result.Values) == 0 {
+			continue
+		}
+		// later pages of a query only contain older values
+		delete(storageMetrics, aws.StringValue(result.Id))
+
+		desc := e.BucketSizeBytes
+		if storageMetric.metricName == s3NumberOfObjectsMetric {
+			desc = e.BucketObjects
+		}
+		e.cache.AddMetricWithTTL(prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, aws.Float64Value(result.Values[0]), region, storageMetric.bucketName, storageMetric.storageType), e.storageMetricsTTL)
+	}
+}
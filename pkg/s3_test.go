@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3CollectBucketMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(s3ServiceCode, bucketsQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100)}}, nil)
+	mockClient.EXPECT().ListBucketsWithContext(ctx, &s3.ListBucketsInput{}).Return(&s3.ListBucketsOutput{
+		Buckets: []*s3.Bucket{{Name: aws.String("a")}, {Name: aws.String("b")}},
+	}, nil)
+
+	e := NewS3Exporter(nil, log.NewNopLogger(), S3Config{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("s3", "foo")
+	e.collectBucketMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.BucketsQuota.String():
+			assert.Equal(t, 100.0, dtoMetric.GetGauge().GetValue())
+		case e.BucketsUsage.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestS3CollectStorageMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dimensions := func(bucketName, storageType string) []*cloudwatch.Dimension {
+		return []*cloudwatch.Dimension{
+			{Name: aws.String(s3BucketNameDimension), Value: aws.String(bucketName)},
+			{Name: aws.String(s3StorageTypeDimension), Value: aws.String(storageType)},
+		}
+	}
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListMetricsAll(ctx, &cloudwatch.ListMetricsInput{Namespace: aws.String(s3MetricNamespace)}).Return([]*cloudwatch.Metric{
+		{MetricName: aws.String(s3BucketSizeBytesMetric), Dimensions: dimensions("a", "StandardStorage")},
+		{MetricName: aws.String(s3NumberOfObjectsMetric), Dimensions: dimensions("a", "AllStorageTypes")},
+		{MetricName: aws.String(s3BucketSizeBytesMetric), Dimensions: dimensions("b", "StandardStorage")},
+		{MetricName: aws.String("AllRequests"), Dimensions: dimensions("a", "")},
+	}, nil)
+	mockClient.EXPECT().GetMetricDataAll(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, input *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error) {
+		// the request metrics are not queried
+		assert.Len(t, input.MetricDataQueries, 3)
+		return []*cloudwatch.MetricDataResult{
+			{Id: aws.String("m0"), Values: aws.Float64Slice([]float64{2048, 1024})},
+			{Id: aws.String("m1"), Values: aws.Float64Slice([]float64{10})},
+			{Id: aws.String("m2"), Values: aws.Float64Slice([]float64{})},
+			{Id: aws.String("m0"), Values: aws.Float64Slice([]float64{512})},
+		}, nil
+	})
+
+	e := NewS3Exporter(nil, log.NewNopLogger(), S3Config{BaseConfig: createTestBaseConfig(), StorageMetrics: true, StorageMetricsTTL: durationPtr(time.Hour)}, "1234567890")
+	run := startCollectorRun("s3", "foo")
+	e.collectStorageMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+	// the daily metrics are cached, so CloudWatch isn't queried again within the TTL
+	run = startCollectorRun("s3", "foo")
+	e.collectStorageMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// bucket b has no recent value
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.BucketSizeBytes.String():
+			assert.Equal(t, 2048.0, dtoMetric.GetGauge().GetValue())
+		case e.BucketObjects.String():
+			assert.Equal(t, 10.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestS3CollectStorageMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListMetricsAll(ctx, gomock.Any()).Return(nil, errors.New("some error")).Times(2)
+
+	e := NewS3Exporter(nil, log.NewNopLogger(), S3Config{BaseConfig: createTestBaseConfig(), StorageMetrics: true, StorageMetricsTTL: durationPtr(time.Hour)}, "1234567890")
+	run := startCollectorRun("s3", "foo")
+	e.collectStorageMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+
+	// a failed query is retried on the next collection
+	run = startCollectorRun("s3", "foo")
+	e.collectStorageMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+}
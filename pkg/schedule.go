@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// defaultRegionConcurrency is the number of regions collected in parallel by the exporters supporting
+// region_concurrency
+const defaultRegionConcurrency = 4
+
+type regionStaggerKey struct{}
+
+// scheduledExporter delays the collect loop of the exporter by a random startup jitter and staggers its regions, so
+// the exporters and regions don't all call AWS at the same time every interval
+type scheduledExporter struct {
+	Exporter
+	startupJitter time.Duration
+	regionStagger time.Duration
+}
+
+// WithSchedule applies the startup jitter and region stagger of the configuration to the collect loop of the exporter
+func WithSchedule(exporter Exporter, config BaseConfig) Exporter {
+	if config.StartupJitter <= 0 && config.RegionStagger <= 0 {
+		return exporter
+	}
+	return &scheduledExporter{Exporter: exporter, startupJitter: config.StartupJitter, regionStagger: config.RegionStagger}
+}
+
+func (e *scheduledExporter) CollectLoop(ctx context.Context) {
+	if e.startupJitter > 0 && !sleepUntilDone(ctx, time.Duration(rand.Int63n(int64(e.startupJitter)))) {
+		return
+	}
+	e.Exporter.CollectLoop(context.WithValue(ctx, regionStaggerKey{}, e.regionStagger))
+}
+
+// staggerRegion delays the collection of every region but the first by the configured region stagger, so the
+// regions start one after another. The stagger counts towards the timeout of the collection.
+func staggerRegion(ctx context.Context, i int) {
+	if stagger, _ := ctx.Value(regionStaggerKey{}).(time.Duration); stagger > 0 && i > 0 {
+		sleepUntilDone(ctx, stagger)
+	}
+}
+
+// collectRegions calls collect for the regions 0 to regions-1 with up to concurrency regions in parallel and waits for
+// all of them. Every region has its own timeout starting when its collection starts, so a slow region doesn't take the
+// time of the others. The region stagger applies to the start of the regions.
+func collectRegions(ctx context.Context, regions int, concurrency int, timeout time.Duration, collect func(ctx context.Context, i int)) {
+	sem := newSemaphore(concurrency)
+	for i := 0; i < regions; i++ {
+		staggerRegion(ctx, i)
+		i := i
+		started := sem.Go(ctx, func() {
+			regionCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			collect(regionCtx, i)
+		})
+		if !started {
+			break
+		}
+	}
+	sem.Wait()
+}
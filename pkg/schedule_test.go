@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSchedule(t *testing.T) {
+	exporter := &testExporter{desc: prometheus.NewDesc("test", "test", nil, nil)}
+	assert.Same(t, exporter, WithSchedule(exporter, BaseConfig{}))
+
+	// the loop doesn't start if the context is done during the startup jitter
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	WithSchedule(exporter, BaseConfig{StartupJitter: time.Hour}).CollectLoop(ctx)
+	assert.Equal(t, int32(0), exporter.updates.Load())
+}
+
+func TestStaggerRegion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), regionStaggerKey{}, 20*time.Millisecond)
+
+	start := time.Now()
+	staggerRegion(ctx, 0)
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+	staggerRegion(ctx, 1)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// no stagger is configured
+	start = time.Now()
+	staggerRegion(context.Background(), 1)
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestCollectRegions(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	var timedOut atomic.Int32
+	collectRegions(context.Background(), 5, 2, 50*time.Millisecond, func(ctx context.Context, i int) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if current <= old || maxRunning.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		// the first region is slow, the others still finish within their own timeout
+		if i == 0 {
+			<-ctx.Done()
+			timedOut.Add(1)
+			return
+		}
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			timedOut.Add(1)
+		}
+	})
+	assert.Equal(t, int32(2), maxRunning.Load())
+	assert.Equal(t, int32(1), timedOut.Load())
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// COLLECT_MODE_LOOP updates the metrics in the background every interval and serves them from the cache
+	COLLECT_MODE_LOOP = "loop"
+	// COLLECT_MODE_SCRAPE updates the metrics when they are scraped
+	COLLECT_MODE_SCRAPE = "scrape"
+)
+
+// Exporter is implemented by all exporters, which update their metrics in CollectLoop and serve them in Collect
+type Exporter interface {
+	prometheus.Collector
+	CollectLoop(ctx context.Context)
+}
+
+type collectOnceKey struct{}
+
+// CollectOnce runs a single iteration of the collect loop of the exporter
+func CollectOnce(ctx context.Context, exporter Exporter) {
+	exporter.CollectLoop(context.WithValue(ctx, collectOnceKey{}, true))
+}
+
+// ScrapeCollector updates the metrics of an exporter whenever they are collected. Concurrent scrapes share a single
+// update, so they don't multiply the AWS API calls.
+type ScrapeCollector struct {
+	exporter Exporter
+	group    singleflight.Group
+}
+
+// NewScrapeCollector creates a new ScrapeCollector instance
+func NewScrapeCollector(exporter Exporter) *ScrapeCollector {
+	return &ScrapeCollector{exporter: exporter}
+}
+
+func (c *ScrapeCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.exporter.Describe(ch)
+}
+
+func (c *ScrapeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.group.Do("collect", func() (interface{}, error) {
+		CollectOnce(context.Background(), c.exporter)
+		return nil, nil
+	})
+	c.exporter.Collect(ch)
+}
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testExporter struct {
+	desc    *prometheus.Desc
+	updates atomic.Int32
+}
+
+func (e *testExporter) CollectLoop(ctx context.Context) {
+	for {
+		e.updates.Add(1)
+		if !sleepWithContext(ctx, time.Hour) {
+			return
+		}
+	}
+}
+
+func (e *testExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.desc
+}
+
+func (e *testExporter) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, float64(e.updates.Load()))
+}
+
+func TestScrapeCollector(t *testing.T) {
+	exporter := &testExporter{desc: prometheus.NewDesc("test", "test", nil, nil)}
+	collector := NewScrapeCollector(exporter)
+
+	// every scrape updates the metrics once instead of running the loop
+	for i := 1; i <= 2; i++ {
+		ch := make(chan prometheus.Metric, 1)
+		collector.Collect(ch)
+		assert.Equal(t, int32(i), exporter.updates.Load())
+		assert.Len(t, ch, 1)
+	}
+}
@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const secretsManagerServiceCode string = "secretsmanager"
+
+type SecretsManagerExporter struct {
+	sessions                []*session.Session
+	SecretsPerRegion        *prometheus.Desc
+	SecretsRotationOverdue  *prometheus.Desc
+	SecretRotationEnabled   *prometheus.Desc
+	SecretDaysSinceRotation *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewSecretsManagerExporter creates a new SecretsManagerExporter instance
+func NewSecretsManagerExporter(sessions []*session.Session, logger log.Logger, config SecretsManagerConfig, awsAccountId string) *SecretsManagerExporter {
+	level.Info(logger).Log("msg", "Initializing Secrets Manager exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: secretsManagerServiceCode}
+
+	return &SecretsManagerExporter{
+		sessions:                sessions,
+		SecretsPerRegion:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "secretsmanager_secretsperregion_usage"), "Number of Secrets Manager secrets in this region", []string{"aws_region"}, constLabels),
+		SecretsRotationOverdue:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "secretsmanager_secrets_rotation_overdue"), "Number of secrets with rotation enabled whose next rotation date has passed", []string{"aws_region"}, constLabels),
+		SecretRotationEnabled:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "secretsmanager_secret_rotation_enabled"), "Whether automatic rotation is enabled for the secret", []string{"aws_region", "secret_name"}, constLabels),
+		SecretDaysSinceRotation: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "secretsmanager_secret_days_since_rotation"), "Days since the secret was last rotated", []string{"aws_region", "secret_name"}, constLabels),
+		cache:                   *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                  logger,
+		timeout:                 *config.Timeout,
+		interval:                *config.Interval,
+	}
+}
+
+func (e *SecretsManagerExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.SecretsPerRegion
+	ch <- e.SecretsRotationOverdue
+	ch <- e.SecretRotationEnabled
+	ch <- e.SecretDaysSinceRotation
+}
+
+func (e *SecretsManagerExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *SecretsManagerExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Secrets Manager metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "secretsmanager")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *SecretsManagerExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "secretsmanager", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *SecretsManagerExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	secrets, err := client.ListSecretsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListSecretsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	now := time.Now()
+	rotationOverdue := 0
+	for _, secret := range secrets {
+		secretName := aws.StringValue(secret.Name)
+
+		rotationEnabled := 0.0
+		if aws.BoolValue(secret.RotationEnabled) {
+			rotationEnabled = 1
+			if secret.NextRotationDate != nil && secret.NextRotationDate.Before(now) {
+				rotationOverdue++
+			}
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecretRotationEnabled, prometheus.GaugeValue, rotationEnabled, region, secretName))
+
+		// secrets which were never rotated have no last rotated date
+		if secret.LastRotatedDate != nil {
+			days := now.Sub(*secret.LastRotatedDate).Hours() / 24
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecretDaysSinceRotation, prometheus.GaugeValue, days, region, secretName))
+		}
+	}
+
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecretsPerRegion, prometheus.GaugeValue, float64(len(secrets)), region))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecretsRotationOverdue, prometheus.GaugeValue, float64(rotationOverdue), region))
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretsManagerCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListSecretsAll(ctx).Return([]*secretsmanager.SecretListEntry{
+		{
+			Name:             aws.String("overdue"),
+			RotationEnabled:  aws.Bool(true),
+			LastRotatedDate:  aws.Time(now.Add(-10 * 24 * time.Hour)),
+			NextRotationDate: aws.Time(now.Add(-24 * time.Hour)),
+		},
+		{
+			Name:             aws.String("rotated"),
+			RotationEnabled:  aws.Bool(true),
+			LastRotatedDate:  aws.Time(now.Add(-24 * time.Hour)),
+			NextRotationDate: aws.Time(now.Add(24 * time.Hour)),
+		},
+		{Name: aws.String("static")},
+	}, nil)
+
+	e := NewSecretsManagerExporter(nil, log.NewNopLogger(), SecretsManagerConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("secretsmanager", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	expected := map[string]float64{
+		e.SecretsPerRegion.String():       3,
+		e.SecretsRotationOverdue.String(): 1,
+	}
+	metrics := e.cache.GetAllMetrics()
+	// the counts, rotation enabled of every secret and the days since rotation of the rotated ones
+	assert.Len(t, metrics, 7)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		if value, ok := expected[metric.Desc().String()]; ok {
+			assert.Equal(t, value, dtoMetric.GetGauge().GetValue(), metric.Desc().String())
+		}
+		if metric.Desc().String() == e.SecretDaysSinceRotation.String() {
+			assert.GreaterOrEqual(t, dtoMetric.GetGauge().GetValue(), 1.0)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// guardDutySeverities are the GuardDuty severity levels with the lowest score belonging to them
+var guardDutySeverities = []struct {
+	name     string
+	minScore float64
+}{
+	{"critical", 9},
+	{"high", 7},
+	{"medium", 4},
+	{"low", 0},
+}
+
+// SecurityFindingsExporter exports the active GuardDuty findings and the Security Hub compliance scores of the enabled
+// standards. Regions where a service isn't enabled only export that it is disabled.
+type SecurityFindingsExporter struct {
+	sessions            []*session.Session
+	GuardDutyEnabled    *prometheus.Desc
+	GuardDutyFindings   *prometheus.Desc
+	SecurityHubEnabled  *prometheus.Desc
+	SecurityHubControls *prometheus.Desc
+	SecurityHubScore    *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewSecurityFindingsExporter creates a new SecurityFindingsExporter instance
+func NewSecurityFindingsExporter(sessions []*session.Session, logger log.Logger, config SecurityFindingsConfig, awsAccountId string) *SecurityFindingsExporter {
+	level.Info(logger).Log("msg", "Initializing security findings exporter")
+	guardDutyLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: "guardduty"}
+	securityHubLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: "securityhub"}
+
+	return &SecurityFindingsExporter{
+		sessions:            sessions,
+		GuardDutyEnabled:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "guardduty_enabled"), "Whether GuardDuty has a detector in this region", []string{"aws_region"}, guardDutyLabels),
+		GuardDutyFindings:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "guardduty_findings"), "Number of active (not archived) GuardDuty findings per severity", []string{"aws_region", "severity"}, guardDutyLabels),
+		SecurityHubEnabled:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "securityhub_enabled"), "Whether Security Hub is enabled in this region", []string{"aws_region"}, securityHubLabels),
+		SecurityHubControls: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "securityhub_standard_controls"), "Number of controls of the Security Hub standard which passed or failed", []string{"aws_region", "standard", "status"}, securityHubLabels),
+		SecurityHubScore:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "securityhub_standard_compliance_score"), "The share of the evaluated controls of the Security Hub standard which passed", []string{"aws_region", "standard"}, securityHubLabels),
+		cache:               *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:              logger,
+		timeout:             *config.Timeout,
+		interval:            *config.Interval,
+	}
+}
+
+func (e *SecurityFindingsExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.GuardDutyEnabled
+	ch <- e.GuardDutyFindings
+	ch <- e.SecurityHubEnabled
+	ch <- e.SecurityHubControls
+	ch <- e.SecurityHubScore
+}
+
+func (e *SecurityFindingsExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *SecurityFindingsExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Security findings metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "securityfindings")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *SecurityFindingsExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "securityfindings", region)
+	defer run.finish()
+
+	e.collectGuardDutyMetrics(client, ctx, region, run)
+	e.collectSecurityHubMetrics(client, ctx, region, run)
+}
+
+func (e *SecurityFindingsExporter) collectGuardDutyMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	detectorIds, err := client.ListDetectorsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListDetectorsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	if len(detectorIds) == 0 {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.GuardDutyEnabled, prometheus.GaugeValue, 0, region))
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.GuardDutyEnabled, prometheus.GaugeValue, 1, region))
+
+	// there is at most one detector per region
+	statistics, err := client.GetFindingsStatisticsWithContext(ctx, &guardduty.GetFindingsStatisticsInput{
+		DetectorId:            detectorIds[0],
+		FindingStatisticTypes: aws.StringSlice([]string{guardduty.FindingStatisticTypeCountBySeverity}),
+		FindingCriteria: &guardduty.FindingCriteria{Criterion: map[string]*guardduty.Condition{
+			"service.archived": {Equals: aws.StringSlice([]string{"false"})},
+		}},
+	})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetFindingsStatistics failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	findings := map[string]int64{}
+	for _, severity := range guardDutySeverities {
+		findings[severity.name] = 0
+	}
+	if statistics.FindingStatistics != nil {
+		for score, count := range statistics.FindingStatistics.CountBySeverity {
+			severity, ok := guardDutySeverity(score)
+			if !ok {
+				level.Warn(e.logger).Log("msg", "Unknown GuardDuty severity", "region", region, "severity", score)
+				continue
+			}
+			findings[severity] += aws.Int64Value(count)
+		}
+	}
+	for severity, count := range findings {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.GuardDutyFindings, prometheus.GaugeValue, float64(count), region, severity))
+	}
+}
+
+// guardDutySeverity returns the severity level of the severity score
+func guardDutySeverity(score string) (string, bool) {
+	value, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return "", false
+	}
+	for _, severity := range guardDutySeverities {
+		if value >= severity.minScore {
+			return severity.name, true
+		}
+	}
+	return "", false
+}
+
+func (e *SecurityFindingsExporter) collectSecurityHubMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	subscriptions, err := client.GetEnabledStandardsAll(ctx)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == securityhub.ErrCodeInvalidAccessException {
+		// the account isn't subscribed to Security Hub in the region
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityHubEnabled, prometheus.GaugeValue, 0, region))
+		return
+	}
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetEnabledStandardsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityHubEnabled, prometheus.GaugeValue, 1, region))
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	// the findings of the controls evaluated by Security Hub itself, suppressed findings don't count against the score
+	findings, err := client.GetFindingsAll(ctx, &securityhub.AwsSecurityFindingFilters{
+		ProductName:    []*securityhub.StringFilter{{Comparison: aws.String(securityhub.StringFilterComparisonEquals), Value: aws.String("Security Hub")}},
+		RecordState:    []*securityhub.StringFilter{{Comparison: aws.String(securityhub.StringFilterComparisonEquals), Value: aws.String(securityhub.RecordStateActive)}},
+		WorkflowStatus: []*securityhub.StringFilter{{Comparison: aws.String(securityhub.StringFilterComparisonNotEquals), Value: aws.String(securityhub.WorkflowStatusSuppressed)}},
+		ComplianceStatus: []*securityhub.StringFilter{
+			{Comparison: aws.String(securityhub.StringFilterComparisonEquals), Value: aws.String(securityhub.ComplianceStatusPassed)},
+			{Comparison: aws.String(securityhub.StringFilterComparisonEquals), Value: aws.String(securityhub.ComplianceStatusWarning)},
+			{Comparison: aws.String(securityhub.StringFilterComparisonEquals), Value: aws.String(securityhub.ComplianceStatusFailed)},
+		},
+	})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to GetFindingsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	// a control fails if any of its findings doesn't pass
+	controlsFailed := map[string]map[string]bool{}
+	for _, finding := range findings {
+		if finding.Compliance == nil {
+			continue
+		}
+		controlId := aws.StringValue(finding.Compliance.SecurityControlId)
+		if controlId == "" {
+			controlId = aws.StringValue(finding.ProductFields["ControlId"])
+		}
+		failed := aws.StringValue(finding.Compliance.Status) != securityhub.ComplianceStatusPassed
+		for _, standard := range findingStandards(finding) {
+			if controlsFailed[standard] == nil {
+				controlsFailed[standard] = map[string]bool{}
+			}
+			controlsFailed[standard][controlId] = controlsFailed[standard][controlId] || failed
+		}
+	}
+
+	for _, subscription := range subscriptions {
+		if aws.StringValue(subscription.StandardsStatus) != securityhub.StandardsStatusReady {
+			continue
+		}
+		standardsId := standardsIdFromArn(aws.StringValue(subscription.StandardsArn))
+		standard := standardsId[strings.Index(standardsId, "/")+1:]
+
+		passed, failed := 0, 0
+		for _, controlFailed := range controlsFailed[standardsId] {
+			if controlFailed {
+				failed++
+			} else {
+				passed++
+			}
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityHubControls, prometheus.GaugeValue, float64(passed), region, standard, "passed"))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityHubControls, prometheus.GaugeValue, float64(failed), region, standard, "failed"))
+		// there is no score until the controls were evaluated
+		if passed+failed > 0 {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityHubScore, prometheus.GaugeValue, float64(passed)/float64(passed+failed), region, standard))
+		}
+	}
+}
+
+// findingStandards returns the IDs of the standards the control of the finding belongs to, e.g.
+// standards/aws-foundational-security-best-practices/v/1.0.0. Findings without consolidated control findings refer to
+// a single standard in their product fields.
+func findingStandards(finding *securityhub.AwsSecurityFinding) []string {
+	var standards []string
+	for _, associated := range finding.Compliance.AssociatedStandards {
+		standards = append(standards, aws.StringValue(associated.StandardsId))
+	}
+	if len(standards) > 0 {
+		return standards
+	}
+	for _, field := range []string{"StandardsArn", "StandardsGuideArn"} {
+		if arn, ok := finding.ProductFields[field]; ok {
+			return []string{standardsIdFromArn(aws.StringValue(arn))}
+		}
+	}
+	return nil
+}
+
+// standardsIdFromArn returns the standards ID of the ARN of a standard, which is the resource part of the ARN
+func standardsIdFromArn(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
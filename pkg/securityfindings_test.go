@@ -0,0 +1,192 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestSecurityFindingsExporter() *SecurityFindingsExporter {
+	return NewSecurityFindingsExporter(nil, log.NewNopLogger(), SecurityFindingsConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+}
+
+func TestGuardDutySeverity(t *testing.T) {
+	for score, expected := range map[string]string{"1.0": "low", "4.0": "medium", "5.5": "medium", "8.9": "high", "9.0": "critical"} {
+		severity, ok := guardDutySeverity(score)
+		assert.True(t, ok)
+		assert.Equal(t, expected, severity, score)
+	}
+	_, ok := guardDutySeverity("unknown")
+	assert.False(t, ok)
+}
+
+func TestCollectGuardDutyMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListDetectorsAll(ctx).Return([]*string{aws.String("detector")}, nil)
+	mockClient.EXPECT().GetFindingsStatisticsWithContext(ctx, gomock.Any()).Return(&guardduty.GetFindingsStatisticsOutput{
+		FindingStatistics: &guardduty.FindingStatistics{CountBySeverity: map[string]*int64{
+			"2.0": aws.Int64(3),
+			"5.0": aws.Int64(1),
+			"8.0": aws.Int64(2),
+			"8.5": aws.Int64(1),
+		}},
+	}, nil)
+
+	e := createTestSecurityFindingsExporter()
+	run := startCollectorRun("securityfindings", "foo")
+	e.collectGuardDutyMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// enabled and the findings of all 4 severity levels
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 5)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.GuardDutyEnabled.String():
+			assert.Equal(t, 1.0, value)
+		case e.GuardDutyFindings.String():
+			assert.Equal(t, map[string]float64{"low": 3, "medium": 1, "high": 3, "critical": 0}[labels["severity"]], value)
+		}
+	}
+}
+
+func TestCollectGuardDutyMetricsNoDetector(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListDetectorsAll(ctx).Return([]*string{}, nil)
+
+	e := createTestSecurityFindingsExporter()
+	run := startCollectorRun("securityfindings", "foo")
+	e.collectGuardDutyMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, e.GuardDutyEnabled.String(), metrics[0].Desc().String())
+	assert.Equal(t, 0.0, dtoMetric.GetGauge().GetValue())
+}
+
+func TestCollectSecurityHubMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fsbp := "standards/aws-foundational-security-best-practices/v/1.0.0"
+	cis := "standards/cis-aws-foundations-benchmark/v/1.4.0"
+	finding := func(controlId string, status string, standards ...string) *securityhub.AwsSecurityFinding {
+		compliance := &securityhub.Compliance{SecurityControlId: aws.String(controlId), Status: aws.String(status)}
+		for _, standard := range standards {
+			compliance.AssociatedStandards = append(compliance.AssociatedStandards, &securityhub.AssociatedStandard{StandardsId: aws.String(standard)})
+		}
+		return &securityhub.AwsSecurityFinding{Compliance: compliance}
+	}
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetEnabledStandardsAll(ctx).Return([]*securityhub.StandardsSubscription{
+		{StandardsArn: aws.String("arn:aws:securityhub:us-east-1::" + fsbp), StandardsStatus: aws.String(securityhub.StandardsStatusReady)},
+		{StandardsArn: aws.String("arn:aws:securityhub:::ruleset/cis-aws-foundations-benchmark/v/1.2.0"), StandardsStatus: aws.String(securityhub.StandardsStatusReady)},
+		{StandardsArn: aws.String("arn:aws:securityhub:us-east-1::" + cis), StandardsStatus: aws.String(securityhub.StandardsStatusPending)},
+	}, nil)
+	mockClient.EXPECT().GetFindingsAll(ctx, gomock.Any()).Return([]*securityhub.AwsSecurityFinding{
+		finding("S3.1", securityhub.ComplianceStatusPassed, fsbp, cis),
+		finding("IAM.1", securityhub.ComplianceStatusPassed, fsbp),
+		finding("IAM.1", securityhub.ComplianceStatusFailed, fsbp),
+		finding("EC2.2", securityhub.ComplianceStatusWarning, fsbp),
+		finding("Config.1", securityhub.ComplianceStatusPassed, fsbp),
+	}, nil)
+
+	e := createTestSecurityFindingsExporter()
+	run := startCollectorRun("securityfindings", "foo")
+	e.collectSecurityHubMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// enabled, passed and failed controls of both ready standards and the score of the evaluated one
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 6)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.SecurityHubEnabled.String():
+			assert.Equal(t, 1.0, value)
+		case e.SecurityHubControls.String():
+			expected := map[string]float64{
+				"aws-foundational-security-best-practices/v/1.0.0/passed": 2,
+				"aws-foundational-security-best-practices/v/1.0.0/failed": 2,
+				"cis-aws-foundations-benchmark/v/1.2.0/passed":            0,
+				"cis-aws-foundations-benchmark/v/1.2.0/failed":            0,
+			}
+			assert.Equal(t, expected[labels["standard"]+"/"+labels["status"]], value)
+		case e.SecurityHubScore.String():
+			assert.Equal(t, "aws-foundational-security-best-practices/v/1.0.0", labels["standard"])
+			assert.Equal(t, 0.5, value)
+		}
+	}
+}
+
+func TestCollectSecurityHubMetricsNotEnabled(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetEnabledStandardsAll(ctx).Return(nil, awserr.New(securityhub.ErrCodeInvalidAccessException, "Account is not subscribed to AWS Security Hub", nil))
+
+	e := createTestSecurityFindingsExporter()
+	run := startCollectorRun("securityfindings", "foo")
+	e.collectSecurityHubMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, e.SecurityHubEnabled.String(), metrics[0].Desc().String())
+	assert.Equal(t, 0.0, dtoMetric.GetGauge().GetValue())
+}
+
+func TestCollectSecurityHubMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetEnabledStandardsAll(ctx).Return(nil, errors.New("AccessDeniedException"))
+
+	e := createTestSecurityFindingsExporter()
+	run := startCollectorRun("securityfindings", "foo")
+	e.collectSecurityHubMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 0)
+}
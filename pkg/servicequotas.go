@@ -0,0 +1,104 @@
+package pkg
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ServiceQuotasExporter exports the values of arbitrary quotas from the configuration, so quotas without a dedicated
+// exporter can be tracked as well
+type ServiceQuotasExporter struct {
+	sessions     []*session.Session
+	quotas       []ServiceQuotaConfig
+	ServiceQuota *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewServiceQuotasExporter creates a new ServiceQuotasExporter instance
+func NewServiceQuotasExporter(sessions []*session.Session, logger log.Logger, config ServiceQuotasConfig, awsAccountId string) *ServiceQuotasExporter {
+	level.Info(logger).Log("msg", "Initializing Service Quotas exporter")
+
+	return &ServiceQuotasExporter{
+		sessions:     sessions,
+		quotas:       config.Quotas,
+		ServiceQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "servicequota"), "The value of the configured service quota", []string{SERVICE_CODE_KEY, QUOTA_CODE_KEY, "aws_region"}, map[string]string{"aws_account_id": awsAccountId}),
+		cache:        *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:       logger,
+		timeout:      *config.Timeout,
+		interval:     *config.Interval,
+	}
+}
+
+func (e *ServiceQuotasExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ServiceQuota
+}
+
+func (e *ServiceQuotasExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *ServiceQuotasExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "Service Quotas metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "servicequotas")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *ServiceQuotasExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "servicequotas", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *ServiceQuotasExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	for _, quota := range e.quotas {
+		if len(quota.Regions) > 0 && !slices.Contains(quota.Regions, region) {
+			continue
+		}
+
+		value, err := getQuotaValueWithContext(client, quota.ServiceCode, quota.QuotaCode, ctx)
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Could not retrieve service quota", "region", region, "service_code", quota.ServiceCode, "quota_code", quota.QuotaCode, "error", err.Error())
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceQuota, prometheus.GaugeValue, value, quota.ServiceCode, quota.QuotaCode, region))
+	}
+}
@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceQuotasCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput("ec2", "L-0263D0A3")).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput("lambda", "L-B99A9384")).Return(nil, errors.New("some error"))
+
+	config := ServiceQuotasConfig{
+		BaseConfig: createTestBaseConfig(),
+		Quotas: []ServiceQuotaConfig{
+			{ServiceCode: "ec2", QuotaCode: "L-0263D0A3"},
+			{ServiceCode: "lambda", QuotaCode: "L-B99A9384", Regions: []string{"foo"}},
+			{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", Regions: []string{"bar"}},
+		},
+	}
+	e := NewServiceQuotasExporter(nil, log.NewNopLogger(), config, "1234567890")
+	run := startCollectorRun("servicequotas", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+
+	// the quota restricted to another region is not requested
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 1)
+	dtoMetric := &dto.Metric{}
+	assert.Nil(t, metrics[0].Write(dtoMetric))
+	assert.Equal(t, 5.0, dtoMetric.GetGauge().GetValue())
+	for _, label := range dtoMetric.GetLabel() {
+		switch label.GetName() {
+		case SERVICE_CODE_KEY:
+			assert.Equal(t, "ec2", label.GetValue())
+		case QUOTA_CODE_KEY:
+			assert.Equal(t, "L-0263D0A3", label.GetValue())
+		case "aws_region":
+			assert.Equal(t, "foo", label.GetValue())
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// assumeRoleExpiryWindow refreshes the credentials of assumed roles before they expire, so in-flight requests don't
+// fail
+const assumeRoleExpiryWindow = time.Minute
+
+// roleSessionNamePrefix names the role sessions of the exporter, the sessions of collectors end with their name
+const roleSessionNamePrefix = "aws-resource-exporter"
+
+// sessionNamePattern matches valid role session names and source identities
+var sessionNamePattern = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// partitionDefaultRegions are the regions the exporter looks up the account in, if AWS_REGION isn't set
+var partitionDefaultRegions = map[string]string{
+	endpoints.AwsPartitionID:      "us-east-1",
+	endpoints.AwsUsGovPartitionID: "us-gov-west-1",
+	endpoints.AwsCnPartitionID:    "cn-north-1",
+}
+
+// AWSConfig configures how the exporter reaches the AWS APIs, e.g. in the GovCloud or China partitions
+type AWSConfig struct {
+	// Partition is the partition all regions have to belong to, e.g. aws-us-gov. Defaults to aws.
+	Partition       string `yaml:"partition"`
+	UseFIPSEndpoint bool   `yaml:"use_fips_endpoint"`
+	// Endpoints override the endpoint URLs of services, keyed by the endpoint ID of the service like sts or monitoring
+	Endpoints map[string]string `yaml:"endpoints"`
+	// EndpointURL is used for all services without an endpoint, e.g. LocalStack for integration tests
+	EndpointURL string `yaml:"endpoint_url"`
+	// AssumeRole is assumed by all exporters without a role of their own
+	AssumeRole *AssumeRoleConfig `yaml:"assume_role"`
+}
+
+// AssumeRoleConfig configures a role which is assumed with the credentials of the environment, or with the web identity
+// token of IRSA on EKS
+type AssumeRoleConfig struct {
+	// RoleARN defaults to AWS_ROLE_ARN with web_identity
+	RoleARN    string `yaml:"role_arn"`
+	ExternalID string `yaml:"external_id"`
+	// RoleSessionName shows up in CloudTrail, it defaults to aws-resource-exporter-<collector>
+	RoleSessionName string `yaml:"role_session_name"`
+	// Duration is the lifetime of the role session between 15m and 12h, the STS default if not set
+	Duration time.Duration `yaml:"duration"`
+	// SourceIdentity is kept by all roles assumed from the role session, it requires the sts:SetSourceIdentity permission
+	SourceIdentity string `yaml:"source_identity"`
+	// WebIdentity assumes the role with the token of AWS_WEB_IDENTITY_TOKEN_FILE instead of the environment credentials
+	WebIdentity bool `yaml:"web_identity"`
+}
+
+func (c *AssumeRoleConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	if c.RoleARN != "" || !c.WebIdentity {
+		if parsed, err := arn.Parse(c.RoleARN); err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+			errs = append(errs, fmt.Errorf("assume_role: invalid role_arn %q", c.RoleARN))
+		}
+	}
+	if c.RoleSessionName != "" && !sessionNamePattern.MatchString(c.RoleSessionName) {
+		errs = append(errs, fmt.Errorf("assume_role: invalid role_session_name %q", c.RoleSessionName))
+	}
+	if c.Duration != 0 && (c.Duration < 15*time.Minute || c.Duration > 12*time.Hour) {
+		errs = append(errs, errors.New("assume_role: duration has to be between 15m and 12h"))
+	}
+	if c.SourceIdentity != "" && (!sessionNamePattern.MatchString(c.SourceIdentity) || strings.HasPrefix(c.SourceIdentity, "aws:")) {
+		errs = append(errs, fmt.Errorf("assume_role: invalid source_identity %q", c.SourceIdentity))
+	}
+	if c.WebIdentity && (c.ExternalID != "" || c.SourceIdentity != "") {
+		errs = append(errs, errors.New("assume_role: external_id and source_identity are not supported with web_identity"))
+	}
+	if c.WebIdentity && os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") == "" {
+		errs = append(errs, errors.New("assume_role: web_identity requires AWS_WEB_IDENTITY_TOKEN_FILE"))
+	}
+	return errors.Join(errs...)
+}
+
+// withSessionName returns a copy of the role with the session name, unless it has one already
+func (c *AssumeRoleConfig) withSessionName(name string) *AssumeRoleConfig {
+	role := *c
+	if role.RoleSessionName == "" {
+		role.RoleSessionName = name
+	}
+	return &role
+}
+
+func (c AWSConfig) partition() (endpoints.Partition, bool) {
+	for _, partition := range endpoints.DefaultPartitions() {
+		if partition.ID() == c.Partition {
+			return partition, true
+		}
+	}
+	return endpoints.Partition{}, false
+}
+
+func (c AWSConfig) validate() []error {
+	var errs []error
+	if _, ok := c.partition(); !ok {
+		errs = append(errs, fmt.Errorf("aws: unknown partition %q", c.Partition))
+	}
+	for service, endpoint := range c.Endpoints {
+		if !isEndpointURL(endpoint) {
+			errs = append(errs, fmt.Errorf("aws: invalid endpoint %q of %s", endpoint, service))
+		}
+	}
+	if c.EndpointURL != "" && !isEndpointURL(c.EndpointURL) {
+		errs = append(errs, fmt.Errorf("aws: invalid endpoint_url %q", c.EndpointURL))
+	}
+	if err := c.AssumeRole.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("aws: %w", err))
+	}
+	return errs
+}
+
+func isEndpointURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// inPartition returns whether the region belongs to the configured partition. Unknown regions are accepted if they
+// match the region pattern of the partition.
+func (c AWSConfig) inPartition(region string) bool {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	return !ok || partition.ID() == c.Partition
+}
+
+// DefaultRegion returns the region the account is looked up in
+func (c AWSConfig) DefaultRegion() string {
+	if region, ok := partitionDefaultRegions[c.Partition]; ok {
+		return region
+	}
+	partition, _ := c.partition()
+	var regions []string
+	for region := range partition.Regions() {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	if len(regions) == 0 {
+		return ""
+	}
+	return regions[0]
+}
+
+// SessionConfig returns the configuration of the sessions of the exporters in the region
+func (c AWSConfig) SessionConfig(region string) *aws.Config {
+	config := aws.NewConfig().WithRegion(region)
+	if c.UseFIPSEndpoint {
+		config.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if c.EndpointURL != "" {
+		// emulators like LocalStack serve all buckets on the same host
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	if len(c.Endpoints) > 0 || c.EndpointURL != "" {
+		config.EndpointResolver = endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+			if endpoint, ok := c.Endpoints[service]; ok {
+				return endpoints.ResolvedEndpoint{URL: endpoint, SigningRegion: region}, nil
+			}
+			if c.EndpointURL != "" {
+				return endpoints.ResolvedEndpoint{URL: c.EndpointURL, SigningRegion: region}, nil
+			}
+			return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+		})
+	}
+	return config
+}
+
+// RoleCredentials returns the credentials of the role, which is assumed with the credentials of the environment or the
+// web identity token at the STS endpoint of the region. Without a role the role of the aws block is assumed. If neither
+// is configured, nil is returned, so the sessions use the credentials of the environment.
+func (c AWSConfig) RoleCredentials(assumeRole *AssumeRoleConfig, region string) (*credentials.Credentials, error) {
+	if assumeRole == nil {
+		assumeRole = c.AssumeRole
+	}
+	if assumeRole == nil {
+		return nil, nil
+	}
+	source, err := session.NewSession(c.SessionConfig(region))
+	if err != nil {
+		return nil, err
+	}
+	if assumeRole.WebIdentity {
+		return webIdentityCredentials(source, assumeRole)
+	}
+	return stscreds.NewCredentials(source, assumeRole.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		if assumeRole.ExternalID != "" {
+			p.ExternalID = aws.String(assumeRole.ExternalID)
+		}
+		if assumeRole.SourceIdentity != "" {
+			p.SourceIdentity = aws.String(assumeRole.SourceIdentity)
+		}
+		p.RoleSessionName = assumeRole.RoleSessionName
+		p.Duration = assumeRole.Duration
+		p.ExpiryWindow = assumeRoleExpiryWindow
+	}), nil
+}
+
+// webIdentityCredentials assumes the role with the token IRSA mounts into the pod. Unlike the credentials the SDK
+// creates from the same environment variables, the session name and duration can differ between the exporters.
+func webIdentityCredentials(source *session.Session, assumeRole *AssumeRoleConfig) (*credentials.Credentials, error) {
+	tokenFile := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	if tokenFile == "" {
+		return nil, errors.New("assume_role: web_identity requires AWS_WEB_IDENTITY_TOKEN_FILE")
+	}
+	// the session of the environment already failed if IRSA didn't set AWS_ROLE_ARN
+	roleARN := assumeRole.RoleARN
+	if roleARN == "" {
+		roleARN = os.Getenv("AWS_ROLE_ARN")
+	}
+	provider := stscreds.NewWebIdentityRoleProviderWithOptions(sts.New(source), roleARN, assumeRole.RoleSessionName, stscreds.FetchTokenPath(tokenFile), func(p *stscreds.WebIdentityRoleProvider) {
+		p.Duration = assumeRole.Duration
+		p.ExpiryWindow = assumeRoleExpiryWindow
+	})
+	return credentials.NewCredentials(provider), nil
+}
+
+// NewSessions creates a session per region of an exporter, which share the credentials of the role of the exporter.
+// Every exporter gets its own credentials, so exporters can assume different roles. It fails if the shared
+// configuration or the role is invalid, so a reload can keep the previous configuration.
+func (c AWSConfig) NewSessions(assumeRole *AssumeRoleConfig, regions []string) ([]*session.Session, error) {
+	if len(regions) == 0 {
+		return nil, nil
+	}
+	creds, err := c.RoleCredentials(assumeRole, regions[0])
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*session.Session
+	for _, region := range regions {
+		sess, err := session.NewSession(c.SessionConfig(region).WithCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, nil
+}
+
+// NewSession creates a session in the region with the role of an exporter, or the role of the aws block if it's nil
+func (c AWSConfig) NewSession(assumeRole *AssumeRoleConfig, region string) (*session.Session, error) {
+	sessions, err := c.NewSessions(assumeRole, []string{region})
+	if err != nil {
+		return nil, err
+	}
+	return sessions[0], nil
+}
@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSConfigDefaultRegion(t *testing.T) {
+	assert.Equal(t, "us-east-1", AWSConfig{Partition: "aws"}.DefaultRegion())
+	assert.Equal(t, "us-gov-west-1", AWSConfig{Partition: "aws-us-gov"}.DefaultRegion())
+	assert.Equal(t, "cn-north-1", AWSConfig{Partition: "aws-cn"}.DefaultRegion())
+	assert.NotEmpty(t, AWSConfig{Partition: "aws-iso"}.DefaultRegion())
+}
+
+func TestAWSConfigSessionConfig(t *testing.T) {
+	config := AWSConfig{Partition: "aws-us-gov", Endpoints: map[string]string{"sts": "https://sts.example.com"}}
+	sessionConfig := config.SessionConfig("us-gov-west-1")
+	assert.Equal(t, "us-gov-west-1", *sessionConfig.Region)
+
+	resolved, err := sessionConfig.EndpointResolver.EndpointFor("sts", "us-gov-west-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://sts.example.com", resolved.URL)
+	resolved, err = sessionConfig.EndpointResolver.EndpointFor("ec2", "us-gov-west-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://ec2.us-gov-west-1.amazonaws.com", resolved.URL)
+
+	config.UseFIPSEndpoint = true
+	assert.Equal(t, endpoints.FIPSEndpointStateEnabled, config.SessionConfig("us-gov-west-1").UseFIPSEndpoint)
+}
+
+func TestAWSConfigInPartition(t *testing.T) {
+	config := AWSConfig{Partition: "aws-cn"}
+	assert.True(t, config.inPartition("cn-northwest-1"))
+	assert.False(t, config.inPartition("us-east-1"))
+	assert.False(t, AWSConfig{Partition: "aws"}.inPartition("us-gov-east-1"))
+}
+
+func TestAWSConfigEndpointURL(t *testing.T) {
+	config := AWSConfig{Partition: "aws", EndpointURL: "http://localhost:4566", Endpoints: map[string]string{"sts": "http://localhost:4567"}}
+	sessionConfig := config.SessionConfig("us-east-1")
+	assert.True(t, *sessionConfig.S3ForcePathStyle)
+
+	resolved, err := sessionConfig.EndpointResolver.EndpointFor("rds", "us-east-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:4566", resolved.URL)
+	assert.Equal(t, "us-east-1", resolved.SigningRegion)
+	// the endpoints of single services take precedence
+	resolved, err = sessionConfig.EndpointResolver.EndpointFor("sts", "us-east-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:4567", resolved.URL)
+
+	assert.Len(t, AWSConfig{Partition: "aws", EndpointURL: "localhost:4566"}.validate(), 1)
+}
+
+func TestAWSConfigRoleCredentials(t *testing.T) {
+	config := AWSConfig{Partition: "aws"}
+	creds, err := config.RoleCredentials(nil, "us-east-1")
+	assert.Nil(t, err)
+	assert.Nil(t, creds)
+
+	config.AssumeRole = &AssumeRoleConfig{RoleARN: "arn:aws:iam::123456789012:role/exporter"}
+	creds, err = config.RoleCredentials(nil, "us-east-1")
+	assert.Nil(t, err)
+	assert.NotNil(t, creds)
+
+	// the sessions of an exporter share the credentials of its role
+	sessions, err := config.NewSessions(&AssumeRoleConfig{RoleARN: "arn:aws:iam::123456789012:role/rds"}, []string{"us-east-1", "eu-west-1"})
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Same(t, sessions[0].Config.Credentials, sessions[1].Config.Credentials)
+	sess, err := config.NewSession(nil, "us-east-1")
+	assert.Nil(t, err)
+	assert.NotSame(t, sessions[0].Config.Credentials, sess.Config.Credentials)
+	sessions, err = config.NewSessions(nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, sessions)
+}
+
+func TestAssumeRoleConfigValidate(t *testing.T) {
+	var config *AssumeRoleConfig
+	assert.Nil(t, config.validate())
+	assert.Nil(t, (&AssumeRoleConfig{RoleARN: "arn:aws-us-gov:iam::123456789012:role/path/exporter"}).validate())
+	assert.ErrorContains(t, (&AssumeRoleConfig{RoleARN: "exporter"}).validate(), `invalid role_arn "exporter"`)
+	assert.Error(t, (&AssumeRoleConfig{RoleARN: "arn:aws:iam::123456789012:user/exporter"}).validate())
+
+	// the role of IRSA is used without a role_arn
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	assert.Nil(t, (&AssumeRoleConfig{WebIdentity: true, RoleSessionName: "aws-resource-exporter-rds", Duration: time.Hour}).validate())
+	err := (&AssumeRoleConfig{RoleARN: "arn:aws:iam::123456789012:role/exporter", RoleSessionName: "rds exporter", Duration: time.Minute, SourceIdentity: "aws:exporter"}).validate()
+	assert.ErrorContains(t, err, "invalid role_session_name")
+	assert.ErrorContains(t, err, "duration")
+	assert.ErrorContains(t, err, "invalid source_identity")
+	assert.ErrorContains(t, (&AssumeRoleConfig{WebIdentity: true, SourceIdentity: "exporter"}).validate(), "not supported with web_identity")
+
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+	assert.ErrorContains(t, (&AssumeRoleConfig{WebIdentity: true}).validate(), "web_identity requires AWS_WEB_IDENTITY_TOKEN_FILE")
+}
+
+func TestAWSConfigWebIdentityCredentials(t *testing.T) {
+	config := AWSConfig{Partition: "aws", AssumeRole: &AssumeRoleConfig{WebIdentity: true, RoleSessionName: "aws-resource-exporter"}}
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+	_, err := config.RoleCredentials(nil, "us-east-1")
+	assert.ErrorContains(t, err, "AWS_WEB_IDENTITY_TOKEN_FILE")
+	// the sessions fail instead of panicking, so a reload keeps the previous configuration
+	_, err = config.NewSessions(nil, []string{"us-east-1"})
+	assert.ErrorContains(t, err, "AWS_WEB_IDENTITY_TOKEN_FILE")
+
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/exporter")
+	creds, err := config.RoleCredentials(nil, "us-east-1")
+	assert.Nil(t, err)
+	assert.NotNil(t, creds)
+}
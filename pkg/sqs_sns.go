@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	sqsServiceCode     string = "sqs"
+	snsServiceCode     string = "sns"
+	snsTopicsQuotaCode string = "L-61103206"
+)
+
+type SQSSNSExporter struct {
+	sessions               []*session.Session
+	QueuesPerRegionUsage   *prometheus.Desc
+	TopicsPerRegionQuota   *prometheus.Desc
+	TopicsPerRegionUsage   *prometheus.Desc
+	SubscriptionsPerRegion *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewSQSSNSExporter creates a new SQSSNSExporter instance
+func NewSQSSNSExporter(sessions []*session.Session, logger log.Logger, config SQSSNSConfig, awsAccountId string) *SQSSNSExporter {
+	level.Info(logger).Log("msg", "Initializing SQS/SNS exporter")
+	sqsLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: sqsServiceCode}
+	snsLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: snsServiceCode}
+
+	return &SQSSNSExporter{
+		sessions: sessions,
+		// SQS has no quota on the number of queues, so only the usage is exported
+		QueuesPerRegionUsage:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sqs_queuesperregion_usage"), "Number of SQS queues in this region", []string{"aws_region"}, sqsLabels),
+		TopicsPerRegionQuota:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sns_topicsperregion_quota"), "Quota for maximum number of SNS topics in this region", []string{"aws_region"}, WithKeyValue(snsLabels, QUOTA_CODE_KEY, snsTopicsQuotaCode)),
+		TopicsPerRegionUsage:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sns_topicsperregion_usage"), "Number of SNS topics in this region", []string{"aws_region"}, WithKeyValue(snsLabels, QUOTA_CODE_KEY, snsTopicsQuotaCode)),
+		SubscriptionsPerRegion: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sns_subscriptionsperregion_usage"), "Number of SNS subscriptions in this region", []string{"aws_region"}, snsLabels),
+		cache:                  *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:                 logger,
+		timeout:                *config.Timeout,
+		interval:               *config.Interval,
+	}
+}
+
+func (e *SQSSNSExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.QueuesPerRegionUsage
+	ch <- e.TopicsPerRegionQuota
+	ch <- e.TopicsPerRegionUsage
+	ch <- e.SubscriptionsPerRegion
+}
+
+func (e *SQSSNSExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *SQSSNSExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "SQS/SNS metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "sqs_sns")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *SQSSNSExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "sqs_sns", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *SQSSNSExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	queues, err := client.ListQueuesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListQueuesAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.QueuesPerRegionUsage, prometheus.GaugeValue, float64(len(queues)), region))
+	}
+
+	quota, err := getQuotaValueWithContext(client, snsServiceCode, snsTopicsQuotaCode, ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Could not retrieve SNS topics quota", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TopicsPerRegionQuota, prometheus.GaugeValue, quota, region))
+	}
+
+	topics, err := client.ListTopicsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListTopicsAll failed", "region", region, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TopicsPerRegionUsage, prometheus.GaugeValue, float64(len(topics)), region))
+	}
+
+	subscriptions, err := client.ListSubscriptionsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListSubscriptionsAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SubscriptionsPerRegion, prometheus.GaugeValue, float64(len(subscriptions)), region))
+}
@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQSSNSCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListQueuesAll(ctx).Return([]*string{aws.String("queue-a"), aws.String("queue-b")}, nil)
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(snsServiceCode, snsTopicsQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100000)}}, nil)
+	mockClient.EXPECT().ListTopicsAll(ctx).Return([]*sns.Topic{{TopicArn: aws.String("topic-a")}}, nil)
+	mockClient.EXPECT().ListSubscriptionsAll(ctx).Return([]*sns.Subscription{{SubscriptionArn: aws.String("sub-a")}}, nil)
+
+	e := NewSQSSNSExporter(nil, log.NewNopLogger(), SQSSNSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	e.collectMetrics(mockClient, ctx, "foo", startCollectorRun("sqs_sns", "foo"))
+	assert.Len(t, e.cache.GetAllMetrics(), 4)
+}
+
+func TestSQSSNSCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListQueuesAll(ctx).Return(nil, errors.New("some error"))
+	mockClient.EXPECT().GetServiceQuotaWithContext(ctx, createGetServiceQuotaInput(snsServiceCode, snsTopicsQuotaCode)).Return(
+		&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(100000)}}, nil)
+	mockClient.EXPECT().ListTopicsAll(ctx).Return(nil, errors.New("some error"))
+	mockClient.EXPECT().ListSubscriptionsAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewSQSSNSExporter(nil, log.NewNopLogger(), SQSSNSConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	e.collectMetrics(mockClient, ctx, "foo", startCollectorRun("sqs_sns", "foo"))
+	assert.Len(t, e.cache.GetAllMetrics(), 1)
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const ssmServiceCode string = "ssm"
+
+// ssmParameterQuotas are the maximum number of parameters per region and tier. They are fixed and not listed in
+// ServiceQuotas, see https://docs.aws.amazon.com/general/latest/gr/ssm.html#limits_ssm
+var ssmParameterQuotas = map[string]float64{
+	ssm.ParameterTierStandard: 10000,
+	ssm.ParameterTierAdvanced: 100000,
+}
+
+type SSMExporter struct {
+	sessions        []*session.Session
+	ParametersQuota *prometheus.Desc
+	ParametersUsage *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewSSMExporter creates a new SSMExporter instance
+func NewSSMExporter(sessions []*session.Session, logger log.Logger, config SSMConfig, awsAccountId string) *SSMExporter {
+	level.Info(logger).Log("msg", "Initializing SSM exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: ssmServiceCode}
+
+	return &SSMExporter{
+		sessions:        sessions,
+		ParametersQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ssm_parameters_quota"), "Quota for maximum number of SSM parameters in this region per tier", []string{"aws_region", "tier"}, constLabels),
+		ParametersUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "ssm_parameters_usage"), "Number of SSM parameters in this region per tier", []string{"aws_region", "tier"}, constLabels),
+		cache:           *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:          logger,
+		timeout:         *config.Timeout,
+		interval:        *config.Interval,
+	}
+}
+
+func (e *SSMExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ParametersQuota
+	ch <- e.ParametersUsage
+}
+
+func (e *SSMExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *SSMExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "SSM metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "ssm")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *SSMExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "ssm", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, run)
+}
+
+func (e *SSMExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, run *collectorRun) {
+	parameters, err := client.DescribeParametersAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeParametersAll failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	parametersPerTier := make(map[string]int)
+	for tier := range ssmParameterQuotas {
+		parametersPerTier[tier] = 0
+	}
+	for _, parameter := range parameters {
+		parametersPerTier[aws.StringValue(parameter.Tier)]++
+	}
+
+	for tier, count := range parametersPerTier {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ParametersUsage, prometheus.GaugeValue, float64(count), region, tier))
+		if quota, found := ssmParameterQuotas[tier]; found {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.ParametersQuota, prometheus.GaugeValue, quota, region, tier))
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSMCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeParametersAll(ctx).Return([]*ssm.ParameterMetadata{
+		{Name: aws.String("a"), Tier: aws.String(ssm.ParameterTierStandard)},
+		{Name: aws.String("b"), Tier: aws.String(ssm.ParameterTierStandard)},
+	}, nil)
+
+	e := NewSSMExporter(nil, log.NewNopLogger(), SSMConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ssm", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.True(t, run.finish())
+
+	// quota and usage of both tiers, the advanced tier without parameters is reported as 0
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 4)
+	for _, metric := range metrics {
+		if metric.Desc().String() != e.ParametersUsage.String() {
+			continue
+		}
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		for _, label := range dtoMetric.GetLabel() {
+			if label.GetName() == "tier" && label.GetValue() == ssm.ParameterTierStandard {
+				assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+			}
+		}
+	}
+}
+
+func TestSSMCollectMetricsListFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeParametersAll(ctx).Return(nil, errors.New("some error"))
+
+	e := NewSSMExporter(nil, log.NewNopLogger(), SSMConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("ssm", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// tagLabelName returns the label of a tag, e.g. tag_cost_center for the tag Cost-Center
+func tagLabelName(key string) string {
+	return "tag_" + strings.ToLower(invalidLabelChars.ReplaceAllString(key, "_"))
+}
+
+// tagLabels attaches the configured tags of resources as labels to their metrics, so they can be sliced by e.g. team
+// without joining another metric. The resources are identified by the region and resource label of the metrics,
+// metrics without the resource label are not changed. A nil tagLabels doesn't attach any labels.
+type tagLabels struct {
+	keys          []string
+	resourceLabel string
+
+	mu sync.RWMutex
+	// tags maps region -> resource -> tag key -> value
+	tags map[string]map[string]map[string]string
+}
+
+func newTagLabels(keys []string, resourceLabel string) *tagLabels {
+	return &tagLabels{keys: keys, resourceLabel: resourceLabel, tags: map[string]map[string]map[string]string{}}
+}
+
+// setRegionTags replaces the tags of the resources of a region, so deleted resources are forgotten
+func (t *tagLabels) setRegionTags(region string, tags map[string]map[string]string) {
+	if t == nil || len(t.keys) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tags[region] = tags
+}
+
+// wrap returns the metric with a label for every configured tag. Resources without the tag get an empty value, so
+// all metrics of a family have the same labels.
+func (t *tagLabels) wrap(metric prometheus.Metric) prometheus.Metric {
+	if t == nil || len(t.keys) == 0 {
+		return metric
+	}
+
+	out := &dto.Metric{}
+	if err := metric.Write(out); err != nil {
+		return metric
+	}
+	var region, resource string
+	found := false
+	for _, label := range out.GetLabel() {
+		switch label.GetName() {
+		case "aws_region":
+			region = label.GetValue()
+		case t.resourceLabel:
+			resource = label.GetValue()
+			found = true
+		}
+	}
+	if !found {
+		return metric
+	}
+
+	t.mu.RLock()
+	tags := t.tags[region][resource]
+	t.mu.RUnlock()
+
+	labels := make([]*dto.LabelPair, 0, len(t.keys))
+	for _, key := range t.keys {
+		labels = append(labels, &dto.LabelPair{Name: aws.String(tagLabelName(key)), Value: aws.String(tags[key])})
+	}
+	return &tagLabeledMetric{Metric: metric, labels: labels}
+}
+
+type tagLabeledMetric struct {
+	prometheus.Metric
+	labels []*dto.LabelPair
+}
+
+func (m *tagLabeledMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+	out.Label = append(out.Label, m.labels...)
+	// the registry expects the labels sorted by name
+	sort.Slice(out.Label, func(i, j int) bool { return out.Label[i].GetName() < out.Label[j].GetName() })
+	return nil
+}
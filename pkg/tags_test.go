@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagLabelName(t *testing.T) {
+	assert.Equal(t, "tag_team", tagLabelName("team"))
+	assert.Equal(t, "tag_cost_center", tagLabelName("Cost-Center"))
+	assert.Equal(t, "tag_aws_cloudformation_stack_name", tagLabelName("aws:cloudformation:stack-name"))
+}
+
+func TestTagLabelsWrap(t *testing.T) {
+	labels := newTagLabels([]string{"team", "environment"}, "dbinstance_identifier")
+	labels.setRegionTags("us-east-1", map[string]map[string]string{"db1": {"team": "sre", "owner": "me"}})
+
+	metric := labels.wrap(prometheus.MustNewConstMetric(AllocatedStorage, prometheus.GaugeValue, 1, "us-east-1", "db1"))
+	out := &dto.Metric{}
+	assert.Nil(t, metric.Write(out))
+	values := map[string]string{}
+	var names []string
+	for _, label := range out.GetLabel() {
+		values[label.GetName()] = label.GetValue()
+		names = append(names, label.GetName())
+	}
+	assert.Equal(t, []string{"aws_region", "dbinstance_identifier", "tag_environment", "tag_team"}, names)
+	assert.Equal(t, "sre", values["tag_team"])
+	assert.Equal(t, "", values["tag_environment"])
+
+	// metrics of other resources are not changed
+	quota := prometheus.MustNewConstMetric(prometheus.NewDesc("quota", "quota", []string{"aws_region"}, nil), prometheus.GaugeValue, 1, "us-east-1")
+	assert.Same(t, quota, labels.wrap(quota))
+
+	// the metrics are gathered with the tag labels
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(testMetricsCollector{metric})
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+	assert.Len(t, families[0].GetMetric()[0].GetLabel(), 4)
+}
+
+// testMetricsCollector is an unchecked collector of the given metrics
+type testMetricsCollector []prometheus.Metric
+
+func (c testMetricsCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c testMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c {
+		ch <- m
+	}
+}
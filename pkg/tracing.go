@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+const tracerName = "github.com/app-sre/aws-resource-exporter/pkg"
+
+// TracingConfig configures the export of the traces of the collector runs and their AWS API requests to an OTLP HTTP
+// receiver. Tracing is disabled without an endpoint.
+type TracingConfig struct {
+	// Endpoint is the host and port of the receiver, e.g. "otel-collector:4318"
+	Endpoint string `yaml:"endpoint"`
+	// Insecure sends the traces over HTTP instead of HTTPS
+	Insecure bool              `yaml:"insecure"`
+	Headers  map[string]string `yaml:"headers"`
+	// SampleRatio is the fraction of the collector runs which are traced, all of them by default
+	SampleRatio *float64 `yaml:"sample_ratio"`
+}
+
+func (c TracingConfig) validate() error {
+	if c.SampleRatio != nil && (*c.SampleRatio < 0 || *c.SampleRatio > 1) {
+		return errors.New("tracing: sample_ratio has to be between 0 and 1")
+	}
+	if c.Endpoint == "" && (c.Insecure || len(c.Headers) > 0 || c.SampleRatio != nil) {
+		return errors.New("tracing: endpoint is required")
+	}
+	return nil
+}
+
+// SetupTracing installs the global tracer provider exporting the spans to the configured receiver. Without an endpoint
+// the spans are no-ops. The returned function flushes the pending spans and has to be called before exiting.
+func SetupTracing(ctx context.Context, config TracingConfig, version string) (func(context.Context) error, error) {
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(config.Headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := 1.0
+	if config.SampleRatio != nil {
+		ratio = *config.SampleRatio
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("aws-resource-exporter"),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
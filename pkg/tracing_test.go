@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetupTracing(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	shutdown, err := SetupTracing(context.Background(), TracingConfig{}, "test")
+	assert.Nil(t, err)
+	assert.Equal(t, previous, otel.GetTracerProvider())
+	assert.Nil(t, shutdown(context.Background()))
+
+	shutdown, err = SetupTracing(context.Background(), TracingConfig{Endpoint: "localhost:4318", Insecure: true}, "test")
+	assert.Nil(t, err)
+	assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+	assert.Nil(t, shutdown(context.Background()))
+}
@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	trustedAdvisorServiceCode      string = "support"
+	trustedAdvisorLanguage         string = "en"
+	serviceLimitsCategory          string = "service_limits"
+	serviceLimitRegionColumn       string = "Region"
+	serviceLimitServiceColumn      string = "Service"
+	serviceLimitNameColumn         string = "Limit Name"
+	serviceLimitAmountColumn       string = "Limit Amount"
+	serviceLimitCurrentUsageColumn string = "Current Usage"
+)
+
+// TrustedAdvisorExporter exports the Trusted Advisor service limit checks, which require a Business or Enterprise
+// support plan. The AWS Support API is global, so there is a single session only.
+type TrustedAdvisorExporter struct {
+	client             awsclient.Client
+	region             string
+	CheckStatus        *prometheus.Desc
+	ServiceLimitUsage  *prometheus.Desc
+	ServiceLimitQuota  *prometheus.Desc
+	ServiceLimitStatus *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewTrustedAdvisorExporter creates a new TrustedAdvisorExporter instance
+func NewTrustedAdvisorExporter(sess *session.Session, logger log.Logger, config TrustedAdvisorConfig, awsAccountId string) *TrustedAdvisorExporter {
+	level.Info(logger).Log("msg", "Initializing Trusted Advisor exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: trustedAdvisorServiceCode}
+	limitLabels := []string{"aws_region", "service", "limit_name"}
+
+	return &TrustedAdvisorExporter{
+		client:             awsclient.NewClientFromSession(sess),
+		region:             config.Region,
+		CheckStatus:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "trustedadvisor_check_status"), "The status of the Trusted Advisor service limit check", []string{"check_id", "check_name", "status"}, constLabels),
+		ServiceLimitUsage:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "trustedadvisor_servicelimit_usage"), "Current usage of the service limit as reported by Trusted Advisor", limitLabels, constLabels),
+		ServiceLimitQuota:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "trustedadvisor_servicelimit_quota"), "The service limit as reported by Trusted Advisor", limitLabels, constLabels),
+		ServiceLimitStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "trustedadvisor_servicelimit_status"), "The Trusted Advisor status of the service limit", append(limitLabels, "status"), constLabels),
+		cache:              *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:             logger,
+		interval:           *config.Interval,
+		timeout:            *config.Timeout,
+	}
+}
+
+func (e *TrustedAdvisorExporter) collectMetrics(ctx context.Context, run *collectorRun) {
+	checks, err := e.client.DescribeTrustedAdvisorChecksWithContext(ctx, &support.DescribeTrustedAdvisorChecksInput{
+		Language: aws.String(trustedAdvisorLanguage),
+	})
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeTrustedAdvisorChecks failed", "err", err)
+		run.fail()
+		return
+	}
+
+	for _, check := range checks.Checks {
+		if aws.StringValue(check.Category) != serviceLimitsCategory {
+			continue
+		}
+
+		result, err := e.client.DescribeTrustedAdvisorCheckResultWithContext(ctx, &support.DescribeTrustedAdvisorCheckResultInput{
+			CheckId:  check.Id,
+			Language: aws.String(trustedAdvisorLanguage),
+		})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to DescribeTrustedAdvisorCheckResult failed", "check", aws.StringValue(check.Name), "err", err)
+			run.fail()
+			continue
+		}
+		e.addCheckMetrics(check, result.Result)
+	}
+}
+
+// addCheckMetrics exports the resources of a service limit check. Their metadata columns are named by the metadata
+// of the check.
+func (e *TrustedAdvisorExporter) addCheckMetrics(check *support.TrustedAdvisorCheckDescription, result *support.TrustedAdvisorCheckResult) {
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.CheckStatus, prometheus.GaugeValue, 1, aws.StringValue(check.Id), aws.StringValue(check.Name), aws.StringValue(result.Status)))
+
+	columns := map[string]int{}
+	for i, name := range check.Metadata {
+		columns[aws.StringValue(name)] = i
+	}
+	for _, resource := range result.FlaggedResources {
+		get := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(resource.Metadata) {
+				return ""
+			}
+			return aws.StringValue(resource.Metadata[i])
+		}
+
+		labels := []string{get(serviceLimitRegionColumn), get(serviceLimitServiceColumn), get(serviceLimitNameColumn)}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceLimitStatus, prometheus.GaugeValue, 1, append(labels, aws.StringValue(resource.Status))...))
+		if quota, err := strconv.ParseFloat(get(serviceLimitAmountColumn), 64); err == nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceLimitQuota, prometheus.GaugeValue, quota, labels...))
+		}
+		// the usage is empty for limits Trusted Advisor couldn't evaluate
+		if usage, err := strconv.ParseFloat(get(serviceLimitCurrentUsageColumn), 64); err == nil {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.ServiceLimitUsage, prometheus.GaugeValue, usage, labels...))
+		}
+	}
+}
+
+func (e *TrustedAdvisorExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, run := e.cache.startCollectorRun(collectCtx, "trustedadvisor", e.region)
+
+		e.collectMetrics(collectCtx, run)
+
+		level.Info(e.logger).Log("msg", "Trusted Advisor metrics updated")
+		if run.finish() {
+			setCollectorLastUpdate(ctx, "trustedadvisor")
+		}
+
+		cancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *TrustedAdvisorExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *TrustedAdvisorExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.CheckStatus
+	ch <- e.ServiceLimitUsage
+	ch <- e.ServiceLimitQuota
+	ch <- e.ServiceLimitStatus
+}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTrustedAdvisorExporter(mockClient *mock.MockClient) *TrustedAdvisorExporter {
+	e := NewTrustedAdvisorExporter(session.New(&aws.Config{Region: aws.String("us-east-1")}), log.NewNopLogger(), TrustedAdvisorConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	e.client = mockClient
+	return e
+}
+
+func TestTrustedAdvisorCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeTrustedAdvisorChecksWithContext(ctx, &support.DescribeTrustedAdvisorChecksInput{Language: aws.String(trustedAdvisorLanguage)}).Return(
+		&support.DescribeTrustedAdvisorChecksOutput{Checks: []*support.TrustedAdvisorCheckDescription{
+			{
+				Id:       aws.String("limits"),
+				Name:     aws.String("VPC"),
+				Category: aws.String(serviceLimitsCategory),
+				Metadata: aws.StringSlice([]string{"Region", "Service", "Limit Name", "Limit Amount", "Current Usage", "Status"}),
+			},
+			{Id: aws.String("security"), Name: aws.String("Security Groups"), Category: aws.String("security")},
+		}}, nil)
+	mockClient.EXPECT().DescribeTrustedAdvisorCheckResultWithContext(ctx, &support.DescribeTrustedAdvisorCheckResultInput{CheckId: aws.String("limits"), Language: aws.String(trustedAdvisorLanguage)}).Return(
+		&support.DescribeTrustedAdvisorCheckResultOutput{Result: &support.TrustedAdvisorCheckResult{
+			Status: aws.String("warning"),
+			FlaggedResources: []*support.TrustedAdvisorResourceDetail{
+				{Status: aws.String("warning"), Metadata: []*string{aws.String("us-east-1"), aws.String("VPC"), aws.String("VPCs"), aws.String("5"), aws.String("4"), aws.String("Yellow")}},
+				{Status: aws.String("ok"), Metadata: []*string{aws.String("us-east-1"), aws.String("VPC"), aws.String("Internet gateways"), aws.String("5"), nil, aws.String("Green")}},
+			},
+		}}, nil)
+
+	e := createTestTrustedAdvisorExporter(mockClient)
+	run := startCollectorRun("trustedadvisor", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.True(t, run.finish())
+
+	// the check status, 2 statuses and quotas, and the usage which could be evaluated
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 6)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.ServiceLimitQuota.String():
+			assert.Equal(t, 5.0, dtoMetric.GetGauge().GetValue())
+		case e.ServiceLimitUsage.String():
+			assert.Equal(t, 4.0, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestTrustedAdvisorCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().DescribeTrustedAdvisorChecksWithContext(ctx, gomock.Any()).Return(nil, errors.New("SubscriptionRequiredException"))
+
+	e := createTestTrustedAdvisorExporter(mockClient)
+	run := startCollectorRun("trustedadvisor", "us-east-1")
+	e.collectMetrics(ctx, run)
+	assert.False(t, run.finish())
+	assert.Empty(t, e.cache.GetAllMetrics())
+}
@@ -1,10 +1,10 @@
 package pkg
 
 import (
-	"errors"
+	"context"
 	"os"
-	"sort"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -22,6 +22,28 @@ func GetEnvIntValue(envname string) (*int, error) {
 	}
 }
 
+// sleepWithContext waits for the given duration and returns false if the context is done before
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	// the loop ends after the first iteration when collecting once
+	if once, _ := ctx.Value(collectOnceKey{}).(bool); once {
+		return false
+	}
+	return sleepUntilDone(ctx, d)
+}
+
+// sleepUntilDone waits for the given duration and returns false if the context is done before
+func sleepUntilDone(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 func durationPtr(duration time.Duration) *time.Duration {
 	return &duration
 }
@@ -36,28 +58,38 @@ func WithKeyValue(m map[string]string, key string, value string) map[string]stri
 	return newMap
 }
 
-// Determines status from the number of days until EOL
-func GetEOLStatus(eol string, thresholds []Threshold) (string, error) {
-	eolDate, err := time.Parse("2006-01-02", eol)
-	if err != nil {
-		return "", err
-	}
-
-	if len(thresholds) == 0 {
-		return "", errors.New("thresholds slice is empty")
-	}
-
-	currentDate := time.Now()
-	daysToEOL := int(eolDate.Sub(currentDate).Hours() / 24)
+// semaphore runs functions in parallel with a limit on the number running at the same time, e.g. to request the
+// details of many resources without being throttled
+type semaphore struct {
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
 
-	sort.Slice(thresholds, func(i, j int) bool {
-		return thresholds[i].Days < thresholds[j].Days
-	})
+// newSemaphore returns a semaphore running up to limit functions in parallel, at least one
+func newSemaphore(limit int) *semaphore {
+	return &semaphore{slots: make(chan struct{}, max(limit, 1))}
+}
 
-	for _, threshold := range thresholds {
-		if daysToEOL <= threshold.Days {
-			return threshold.Name, nil
-		}
+// Go runs fn in a new goroutine once less than limit functions are running. It returns false without running fn if
+// the context is done before.
+func (s *semaphore) Go(ctx context.Context, fn func()) bool {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
 	}
-	return thresholds[len(thresholds)-1].Name, nil
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.slots
+			s.wg.Done()
+		}()
+		fn()
+	}()
+	return true
+}
+
+// Wait waits for all functions started with Go to return
+func (s *semaphore) Wait() {
+	s.wg.Wait()
 }
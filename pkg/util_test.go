@@ -1,8 +1,13 @@
 package pkg
 
 import (
+	"context"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWithKeyValue(t *testing.T) {
@@ -43,3 +48,41 @@ func TestWithKeyValue(t *testing.T) {
 		})
 	}
 }
+
+func TestSleepWithContext(t *testing.T) {
+	if !sleepWithContext(context.Background(), time.Millisecond) {
+		t.Errorf("sleepWithContext() = false, want true when the duration elapsed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if sleepWithContext(ctx, time.Hour) {
+		t.Errorf("sleepWithContext() = true, want false when the context is cancelled")
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	var running, maxRunning, done atomic.Int32
+	sem := newSemaphore(2)
+	for i := 0; i < 5; i++ {
+		assert.True(t, sem.Go(context.Background(), func() {
+			current := running.Add(1)
+			for old := maxRunning.Load(); current > old && !maxRunning.CompareAndSwap(old, current); old = maxRunning.Load() {
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+		}))
+	}
+	sem.Wait()
+	assert.Equal(t, int32(2), maxRunning.Load())
+	assert.Equal(t, int32(5), done.Load())
+
+	// no function is started once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sem = newSemaphore(0)
+	assert.True(t, sem.Go(context.Background(), func() { <-ctx.Done() }))
+	assert.False(t, sem.Go(ctx, func() { t.Error("started after the context is done") }))
+	sem.Wait()
+}
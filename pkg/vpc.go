@@ -23,29 +23,70 @@ const (
 	QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC string = "L-29B6F2EB"
 	QUOTA_ROUTE_TABLES_PER_VPC            string = "L-589F43AA"
 	QUOTA_IPV4_BLOCKS_PER_VPC             string = "L-83CA0A9D"
+	QUOTA_SECURITY_GROUPS_PER_VPC         string = "L-E79EC296"
+	QUOTA_RULES_PER_SECURITY_GROUP        string = "L-0EA8095F"
+	QUOTA_PEERING_CONNECTIONS_PER_VPC     string = "L-7E9ECCDB"
+	QUOTA_INTERNET_GATEWAYS_PER_REGION    string = "L-A4707A72"
+	QUOTA_NETWORK_INTERFACES_PER_REGION   string = "L-DF5E4CA3"
 	SERVICE_CODE_VPC                      string = "vpc"
 )
 
+// The transit gateway attachments per VPC are a fixed limit, which can't be looked up in Service Quotas. It's exported
+// as vpc_transitgatewayattachmentspervpc_limit instead of a quota.
+const TRANSIT_GATEWAY_ATTACHMENTS_PER_VPC_LIMIT float64 = 5
+
 type VPCExporter struct {
-	awsAccountId                     string
-	sessions                         []*session.Session
-	VpcsPerRegionQuota               *prometheus.Desc
-	VpcsPerRegionUsage               *prometheus.Desc
-	SubnetsPerVpcQuota               *prometheus.Desc
-	SubnetsPerVpcUsage               *prometheus.Desc
-	RoutesPerRouteTableQuota         *prometheus.Desc
-	RoutesPerRouteTableUsage         *prometheus.Desc
-	InterfaceVpcEndpointsPerVpcQuota *prometheus.Desc
-	InterfaceVpcEndpointsPerVpcUsage *prometheus.Desc
-	RouteTablesPerVpcQuota           *prometheus.Desc
-	RouteTablesPerVpcUsage           *prometheus.Desc
-	IPv4BlocksPerVpcQuota            *prometheus.Desc
-	IPv4BlocksPerVpcUsage            *prometheus.Desc
+	awsAccountId                           string
+	sessions                               []*session.Session
+	VpcsPerRegionQuota                     *prometheus.Desc
+	VpcsPerRegionUsage                     *prometheus.Desc
+	VpcsPerRegionUtilization               *prometheus.Desc
+	VpcsPerRegionForecast                  *prometheus.Desc
+	SubnetsPerVpcQuota                     *prometheus.Desc
+	SubnetsPerVpcUsage                     *prometheus.Desc
+	SubnetsPerVpcUtilization               *prometheus.Desc
+	SubnetsPerVpcForecast                  *prometheus.Desc
+	RoutesPerRouteTableQuota               *prometheus.Desc
+	RoutesPerRouteTableUsage               *prometheus.Desc
+	RoutesPerRouteTableUtilization         *prometheus.Desc
+	InterfaceVpcEndpointsPerVpcQuota       *prometheus.Desc
+	InterfaceVpcEndpointsPerVpcUsage       *prometheus.Desc
+	InterfaceVpcEndpointsPerVpcUtilization *prometheus.Desc
+	RouteTablesPerVpcQuota                 *prometheus.Desc
+	RouteTablesPerVpcUsage                 *prometheus.Desc
+	RouteTablesPerVpcUtilization           *prometheus.Desc
+	IPv4BlocksPerVpcQuota                  *prometheus.Desc
+	IPv4BlocksPerVpcUsage                  *prometheus.Desc
+	IPv4BlocksPerVpcUtilization            *prometheus.Desc
+	SecurityGroupsPerVpcQuota              *prometheus.Desc
+	SecurityGroupsPerVpcUsage              *prometheus.Desc
+	SecurityGroupsPerVpcUtilization        *prometheus.Desc
+	RulesPerSecurityGroupQuota             *prometheus.Desc
+	RulesPerSecurityGroupUsage             *prometheus.Desc
+	RulesPerSecurityGroupUtilization       *prometheus.Desc
+	PeeringConnectionsPerVpcQuota          *prometheus.Desc
+	PeeringConnectionsPerVpcUsage          *prometheus.Desc
+	PeeringConnectionsPerVpcUtilization    *prometheus.Desc
+	TGWAttachmentsPerVpcLimit              *prometheus.Desc
+	TGWAttachmentsPerVpcUsage              *prometheus.Desc
+	TGWAttachmentsPerVpcUtilization        *prometheus.Desc
+	InternetGatewaysPerRegionQuota         *prometheus.Desc
+	InternetGatewaysPerRegionUsage         *prometheus.Desc
+	InternetGatewaysPerRegionUtilization   *prometheus.Desc
+	NetworkInterfacesPerRegionQuota        *prometheus.Desc
+	NetworkInterfacesPerRegionUsage        *prometheus.Desc
+	NetworkInterfacesPerRegionUtilization  *prometheus.Desc
+	NetworkInterfacesPerVpcUsage           *prometheus.Desc
 
 	logger   log.Logger
 	timeout  time.Duration
 	cache    MetricsCache
 	interval time.Duration
+	filter   *resourceFilter
+	// forecaster forecasts when the VPCs and subnets reach their quotas
+	forecaster *quotaForecaster
+	// maxConcurrency is the number of regions collected in parallel
+	maxConcurrency int
 }
 
 type VPCCollector struct {
@@ -60,80 +101,174 @@ func NewVPCExporter(sess []*session.Session, logger log.Logger, config VPCConfig
 	level.Info(logger).Log("msg", "Initializing VPC exporter")
 	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: SERVICE_CODE_VPC}
 	return &VPCExporter{
-		awsAccountId:                     awsAccountId,
-		sessions:                         sess,
-		VpcsPerRegionQuota:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_quota"), "The quota of VPCs per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
-		VpcsPerRegionUsage:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_usage"), "The usage of VPCs per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
-		SubnetsPerVpcQuota:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_quota"), "The quota of subnets per VPC", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
-		SubnetsPerVpcUsage:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_usage"), "The usage of subnets per VPC", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
-		RoutesPerRouteTableQuota:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routesperroutetable_quota"), "The quota of routes per routetable", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTES_PER_ROUTE_TABLE)),
-		RoutesPerRouteTableUsage:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routesperroutetable_usage"), "The usage of routes per routetable", []string{"aws_region", "vpcid", "routetableid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTES_PER_ROUTE_TABLE)),
-		InterfaceVpcEndpointsPerVpcQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_interfacevpcendpointspervpc_quota"), "The quota of interface vpc endpoints per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)),
-		InterfaceVpcEndpointsPerVpcUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_interfacevpcendpointspervpc_usage"), "The usage of interface vpc endpoints per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)),
-		RouteTablesPerVpcQuota:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routetablespervpc_quota"), "The quota of route tables per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTE_TABLES_PER_VPC)),
-		RouteTablesPerVpcUsage:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routetablespervpc_usage"), "The usage of route tables per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTE_TABLES_PER_VPC)),
-		IPv4BlocksPerVpcQuota:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_ipv4blockspervpc_quota"), "The quota of ipv4 blocks per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_IPV4_BLOCKS_PER_VPC)),
-		IPv4BlocksPerVpcUsage:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_ipv4blockspervpc_usage"), "The usage of ipv4 blocks per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_IPV4_BLOCKS_PER_VPC)),
-		logger:                           logger,
-		timeout:                          *config.Timeout,
-		cache:                            *NewMetricsCache(*config.CacheTTL),
-		interval:                         *config.Interval,
-	}
-}
-
-func (e *VPCExporter) CollectInRegion(session *session.Session, region *string, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	ec2Svc := ec2.New(session)
-	quotaSvc := servicequotas.New(session)
-
-	e.collectVpcsPerRegionQuota(quotaSvc, *region)
-	e.collectVpcsPerRegionUsage(ec2Svc, *region)
-	e.collectRoutesTablesPerVpcQuota(quotaSvc, *region)
-	e.collectInterfaceVpcEndpointsPerVpcQuota(quotaSvc, *region)
-	e.collectSubnetsPerVpcQuota(quotaSvc, *region)
-	e.collectIPv4BlocksPerVpcQuota(quotaSvc, *region)
-	vpcCtx, vpcCancel := context.WithTimeout(context.Background(), e.timeout)
+		awsAccountId:                           awsAccountId,
+		sessions:                               sess,
+		VpcsPerRegionQuota:                     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_quota"), "The quota of VPCs per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
+		VpcsPerRegionUsage:                     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_usage"), "The usage of VPCs per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
+		VpcsPerRegionUtilization:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_quota_utilization_ratio"), "The usage of VPCs in the region relative to the quota", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
+		VpcsPerRegionForecast:                  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_vpcsperregion_days_until_quota_exhausted"), "The days until the VPCs per region reach their quota at the growth of the last week", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_VPCS_PER_REGION)),
+		SubnetsPerVpcQuota:                     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_quota"), "The quota of subnets per VPC", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
+		SubnetsPerVpcUsage:                     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_usage"), "The usage of subnets per VPC", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
+		SubnetsPerVpcUtilization:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_quota_utilization_ratio"), "The usage of subnets of the VPC relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
+		SubnetsPerVpcForecast:                  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_subnetspervpc_days_until_quota_exhausted"), "The days until the subnets of the VPC reach their quota at the growth of the last week", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SUBNETS_PER_VPC)),
+		RoutesPerRouteTableQuota:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routesperroutetable_quota"), "The quota of routes per routetable", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTES_PER_ROUTE_TABLE)),
+		RoutesPerRouteTableUsage:               prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routesperroutetable_usage"), "The usage of routes per routetable", []string{"aws_region", "vpcid", "routetableid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTES_PER_ROUTE_TABLE)),
+		RoutesPerRouteTableUtilization:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routesperroutetable_quota_utilization_ratio"), "The usage of routes of the routetable relative to the quota", []string{"aws_region", "vpcid", "routetableid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTES_PER_ROUTE_TABLE)),
+		InterfaceVpcEndpointsPerVpcQuota:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_interfacevpcendpointspervpc_quota"), "The quota of interface vpc endpoints per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)),
+		InterfaceVpcEndpointsPerVpcUsage:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_interfacevpcendpointspervpc_usage"), "The usage of interface vpc endpoints per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)),
+		InterfaceVpcEndpointsPerVpcUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_interfacevpcendpointspervpc_quota_utilization_ratio"), "The usage of interface vpc endpoints of the vpc relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)),
+		RouteTablesPerVpcQuota:                 prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routetablespervpc_quota"), "The quota of route tables per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTE_TABLES_PER_VPC)),
+		RouteTablesPerVpcUsage:                 prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routetablespervpc_usage"), "The usage of route tables per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTE_TABLES_PER_VPC)),
+		RouteTablesPerVpcUtilization:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_routetablespervpc_quota_utilization_ratio"), "The usage of route tables of the vpc relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_ROUTE_TABLES_PER_VPC)),
+		IPv4BlocksPerVpcQuota:                  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_ipv4blockspervpc_quota"), "The quota of ipv4 blocks per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_IPV4_BLOCKS_PER_VPC)),
+		IPv4BlocksPerVpcUsage:                  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_ipv4blockspervpc_usage"), "The usage of ipv4 blocks per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_IPV4_BLOCKS_PER_VPC)),
+		IPv4BlocksPerVpcUtilization:            prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_ipv4blockspervpc_quota_utilization_ratio"), "The usage of ipv4 blocks of the vpc relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_IPV4_BLOCKS_PER_VPC)),
+		SecurityGroupsPerVpcQuota:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_securitygroupspervpc_quota"), "The quota of security groups per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SECURITY_GROUPS_PER_VPC)),
+		SecurityGroupsPerVpcUsage:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_securitygroupspervpc_usage"), "The usage of security groups per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SECURITY_GROUPS_PER_VPC)),
+		SecurityGroupsPerVpcUtilization:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_securitygroupspervpc_quota_utilization_ratio"), "The usage of security groups of the vpc relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_SECURITY_GROUPS_PER_VPC)),
+		RulesPerSecurityGroupQuota:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_rulespersecuritygroup_quota"), "The quota of inbound or outbound rules per security group", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_RULES_PER_SECURITY_GROUP)),
+		RulesPerSecurityGroupUsage:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_rulespersecuritygroup_usage"), "The usage of inbound or outbound rules per security group", []string{"aws_region", "vpcid", "securitygroupid", "direction"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_RULES_PER_SECURITY_GROUP)),
+		RulesPerSecurityGroupUtilization:       prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_rulespersecuritygroup_quota_utilization_ratio"), "The usage of inbound or outbound rules of the security group relative to the quota", []string{"aws_region", "vpcid", "securitygroupid", "direction"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_RULES_PER_SECURITY_GROUP)),
+		PeeringConnectionsPerVpcQuota:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_peeringconnectionspervpc_quota"), "The quota of active peering connections per vpc", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_PEERING_CONNECTIONS_PER_VPC)),
+		PeeringConnectionsPerVpcUsage:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_peeringconnectionspervpc_usage"), "The usage of active peering connections per vpc", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_PEERING_CONNECTIONS_PER_VPC)),
+		PeeringConnectionsPerVpcUtilization:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_peeringconnectionspervpc_quota_utilization_ratio"), "The usage of active peering connections of the vpc relative to the quota", []string{"aws_region", "vpcid"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_PEERING_CONNECTIONS_PER_VPC)),
+		TGWAttachmentsPerVpcLimit:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_transitgatewayattachmentspervpc_limit"), "The fixed AWS limit of transit gateway attachments per vpc, which isn't a Service Quotas quota", []string{"aws_region"}, constLabels),
+		TGWAttachmentsPerVpcUsage:              prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_transitgatewayattachmentspervpc_usage"), "The usage of transit gateway attachments per vpc", []string{"aws_region", "vpcid"}, constLabels),
+		TGWAttachmentsPerVpcUtilization:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_transitgatewayattachmentspervpc_quota_utilization_ratio"), "The usage of transit gateway attachments of the vpc relative to the fixed limit", []string{"aws_region", "vpcid"}, constLabels),
+		InternetGatewaysPerRegionQuota:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_internetgatewaysperregion_quota"), "The quota of internet gateways per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERNET_GATEWAYS_PER_REGION)),
+		InternetGatewaysPerRegionUsage:         prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_internetgatewaysperregion_usage"), "The usage of internet gateways per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERNET_GATEWAYS_PER_REGION)),
+		InternetGatewaysPerRegionUtilization:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_internetgatewaysperregion_quota_utilization_ratio"), "The usage of internet gateways in the region relative to the quota", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_INTERNET_GATEWAYS_PER_REGION)),
+		NetworkInterfacesPerRegionQuota:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_networkinterfacesperregion_quota"), "The quota of network interfaces per region", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_NETWORK_INTERFACES_PER_REGION)),
+		NetworkInterfacesPerRegionUsage:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_networkinterfacesperregion_usage"), "The usage of network interfaces per region", []string{"aws_region", "status"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_NETWORK_INTERFACES_PER_REGION)),
+		NetworkInterfacesPerRegionUtilization:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_networkinterfacesperregion_quota_utilization_ratio"), "The usage of network interfaces in the region relative to the quota", []string{"aws_region"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_NETWORK_INTERFACES_PER_REGION)),
+		NetworkInterfacesPerVpcUsage:           prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "vpc_networkinterfacespervpc_usage"), "The usage of network interfaces per vpc", []string{"aws_region", "vpcid", "status"}, WithKeyValue(constLabels, QUOTA_CODE_KEY, QUOTA_NETWORK_INTERFACES_PER_REGION)),
+		logger:                                 logger,
+		timeout:                                *config.Timeout,
+		cache:                                  *NewMetricsCacheFromConfig(config.BaseConfig),
+		interval:                               *config.Interval,
+		filter:                                 newResourceFilter(config.Filter),
+		forecaster:                             quotaForecasterFor("vpc"),
+		maxConcurrency:                         config.MaxConcurrency,
+	}
+}
+
+func (e *VPCExporter) CollectInRegion(ctx context.Context, session *session.Session, region *string) {
+	client := awsclient.NewClientFromSession(session)
+	ctx, run := e.cache.startCollectorRun(ctx, "vpc", *region)
+	defer run.finish()
+
+	// the quotas are passed on for the utilization of the usages, they are 0 if they couldn't be looked up
+	vpcsQuota := e.collectVpcsPerRegionQuota(ctx, run, client, *region)
+	routeTablesQuota := e.collectRoutesTablesPerVpcQuota(ctx, run, client, *region)
+	endpointsQuota := e.collectInterfaceVpcEndpointsPerVpcQuota(ctx, run, client, *region)
+	subnetsQuota := e.collectSubnetsPerVpcQuota(ctx, run, client, *region)
+	ipv4BlocksQuota := e.collectIPv4BlocksPerVpcQuota(ctx, run, client, *region)
+	routesQuota := e.collectRoutesPerRouteTableQuota(ctx, run, client, *region)
+	securityGroupsQuota := e.collectSecurityGroupsPerVpcQuota(ctx, run, client, *region)
+	rulesQuota := e.collectRulesPerSecurityGroupQuota(ctx, run, client, *region)
+	peeringConnectionsQuota := e.collectPeeringConnectionsPerVpcQuota(ctx, run, client, *region)
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.TGWAttachmentsPerVpcLimit, prometheus.GaugeValue, TRANSIT_GATEWAY_ATTACHMENTS_PER_VPC_LIMIT, *region))
+	internetGatewaysQuota := e.collectInternetGatewaysPerRegionQuota(ctx, run, client, *region)
+	e.collectInternetGatewaysPerRegionUsage(ctx, run, internetGatewaysQuota, client, *region)
+	networkInterfacesQuota := e.collectNetworkInterfacesPerRegionQuota(ctx, run, client, *region)
+
+	// All resources of the region are fetched once and grouped by VPC in memory, instead of describing them per VPC
+	vpcCtx, vpcCancel := context.WithTimeout(ctx, e.timeout)
 	defer vpcCancel()
-	allVpcs, err := ec2Svc.DescribeVpcsWithContext(vpcCtx, &ec2.DescribeVpcsInput{})
+	allVpcs, err := client.DescribeVpcsAll(vpcCtx)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to DescribeVpcs failed", "region", region, "err", err)
-	} else {
-		for i, _ := range allVpcs.Vpcs {
-			e.collectSubnetsPerVpcUsage(allVpcs.Vpcs[i], ec2Svc, *region)
-			e.collectInterfaceVpcEndpointsPerVpcUsage(allVpcs.Vpcs[i], ec2Svc, *region)
-			e.collectRoutesTablesPerVpcUsage(allVpcs.Vpcs[i], ec2Svc, *region)
-			e.collectIPv4BlocksPerVpcUsage(allVpcs.Vpcs[i], ec2Svc, *region)
-		}
+		run.fail()
+		// the network interfaces per region don't depend on the VPCs
+		e.collectNetworkInterfacesUsage(ctx, run, e.selectionWithoutVpcs(), networkInterfacesQuota, client, *region)
+		return
 	}
-	e.collectRoutesPerRouteTableQuota(quotaSvc, *region)
-	routesCtx, routesCancel := context.WithTimeout(context.Background(), e.timeout)
+	e.collectVpcsPerRegionUsage(allVpcs, vpcsQuota, *region)
+	// the usage per region counts the resources of all VPCs, only the usage per VPC is filtered
+	vpcs, selected := e.filterVpcs(allVpcs)
+	e.collectNetworkInterfacesUsage(ctx, run, selected, networkInterfacesQuota, client, *region)
+	e.collectSubnetsPerVpcUsage(ctx, run, vpcs, subnetsQuota, client, *region)
+	e.collectInterfaceVpcEndpointsPerVpcUsage(ctx, run, vpcs, endpointsQuota, client, *region)
+	e.collectIPv4BlocksPerVpcUsage(vpcs, ipv4BlocksQuota, *region)
+	e.collectSecurityGroupsUsage(ctx, run, vpcs, selected, securityGroupsQuota, rulesQuota, client, *region)
+	e.collectPeeringConnectionsPerVpcUsage(ctx, run, vpcs, peeringConnectionsQuota, client, *region)
+	e.collectTGWAttachmentsPerVpcUsage(ctx, run, vpcs, client, *region)
+
+	routesCtx, routesCancel := context.WithTimeout(ctx, e.timeout)
 	defer routesCancel()
-	allRouteTables, err := ec2Svc.DescribeRouteTablesWithContext(routesCtx, &ec2.DescribeRouteTablesInput{})
+	allRouteTables, err := client.DescribeRouteTablesAll(routesCtx)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to DescribeRouteTables failed", "region", region, "err", err)
-	} else {
-		for i, _ := range allRouteTables.RouteTables {
-			e.collectRoutesPerRouteTableUsage(allRouteTables.RouteTables[i], ec2Svc, *region)
+		run.fail()
+		return
+	}
+	e.collectRoutesTablesPerVpcUsage(vpcs, allRouteTables, routeTablesQuota, *region)
+	e.collectRoutesPerRouteTableUsage(allRouteTables, selected, routesQuota, *region)
+}
+
+// vpcSelection is the set of VPC IDs selected by the filter. A nil vpcSelection selects all VPCs.
+type vpcSelection map[string]bool
+
+func (s vpcSelection) selects(vpcId string) bool {
+	return s == nil || s[vpcId]
+}
+
+// selectionWithoutVpcs returns the selection if the VPCs couldn't be described. Without a filter all VPCs are selected,
+// with a filter none, as the filter needs the tags of the VPCs.
+func (e *VPCExporter) selectionWithoutVpcs() vpcSelection {
+	if e.filter == nil {
+		return nil
+	}
+	return vpcSelection{}
+}
+
+// filterVpcs returns the VPCs selected by the filter and their IDs
+func (e *VPCExporter) filterVpcs(vpcs []*ec2.Vpc) ([]*ec2.Vpc, vpcSelection) {
+	if e.filter == nil {
+		return vpcs, nil
+	}
+	var filtered []*ec2.Vpc
+	selected := vpcSelection{}
+	for _, vpc := range vpcs {
+		tags := make(map[string]string, len(vpc.Tags))
+		for _, tag := range vpc.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if e.filter.selects(aws.StringValue(vpc.VpcId), tags) {
+			filtered = append(filtered, vpc)
+			selected[aws.StringValue(vpc.VpcId)] = true
 		}
 	}
+	return filtered, selected
 }
 
-func (e *VPCExporter) CollectLoop() {
+func (e *VPCExporter) CollectLoop(ctx context.Context) {
 	for {
 
-		wg := &sync.WaitGroup{}
-		wg.Add(len(e.sessions))
+		concurrency := e.maxConcurrency
+		if concurrency == 0 {
+			concurrency = len(e.sessions)
+		}
+		loopCtx, loop := startCollectorLoop(ctx)
+		sem := newSemaphore(concurrency)
 		for i, _ := range e.sessions {
 			session := e.sessions[i]
 			region := session.Config.Region
-			go e.CollectInRegion(session, region, wg)
+			staggerRegion(ctx, i)
+			if !sem.Go(ctx, func() { e.CollectInRegion(loopCtx, session, region) }) {
+				break
+			}
 		}
-		wg.Wait()
+		sem.Wait()
 
 		level.Info(e.logger).Log("msg", "VPC metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "vpc")
+		}
 
-		time.Sleep(e.interval)
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
 	}
 }
 
@@ -143,8 +278,8 @@ func (e *VPCExporter) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
-func (e *VPCExporter) GetQuotaValue(client *servicequotas.ServiceQuotas, serviceCode string, quotaCode string) (float64, error) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
+func (e *VPCExporter) GetQuotaValue(ctx context.Context, client awsclient.Client, serviceCode string, quotaCode string) (float64, error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
 	defer cancelFunc()
 	sqOutput, err := client.GetServiceQuotaWithContext(ctx, &servicequotas.GetServiceQuotaInput{
 		QuotaCode:   aws.String(quotaCode),
@@ -163,177 +298,402 @@ func (e *VPCExporter) GetQuotaValue(client *servicequotas.ServiceQuotas, service
 	return *sqOutput.Quota.Value, nil
 }
 
-func (e *VPCExporter) collectVpcsPerRegionQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_VPCS_PER_REGION)
+func (e *VPCExporter) collectVpcsPerRegionQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_VPCS_PER_REGION)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to VpcsPerRegion ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+		run.fail()
+		return 0
 	}
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.VpcsPerRegionQuota, prometheus.GaugeValue, quota, region))
+	return quota
 }
 
-func (e *VPCExporter) collectVpcsPerRegionUsage(ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
-	defer cancelFunc()
-	describeVpcsOutput, err := ec2Svc.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{})
-	if err != nil {
-		level.Error(e.logger).Log("msg", "Call to DescribeVpcs failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
-	}
-	usage := len(describeVpcsOutput.Vpcs)
+func (e *VPCExporter) collectVpcsPerRegionUsage(vpcs []*ec2.Vpc, quota float64, region string) {
+	usage := len(vpcs)
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.VpcsPerRegionUsage, prometheus.GaugeValue, float64(usage), region))
+	e.cache.AddQuotaUtilization(e.VpcsPerRegionUtilization, float64(usage), quota, region)
+	if days, ok := e.forecaster.forecast(time.Now(), float64(usage), quota, "vpcsperregion", region); ok {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.VpcsPerRegionForecast, prometheus.GaugeValue, days, region))
+	}
 }
 
-func (e *VPCExporter) collectSubnetsPerVpcQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_SUBNETS_PER_VPC)
+func (e *VPCExporter) collectSubnetsPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_SUBNETS_PER_VPC)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to SubnetsPerVpc ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+		run.fail()
+		return 0
 	}
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SubnetsPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
 }
 
-func (e *VPCExporter) collectSubnetsPerVpcUsage(vpc *ec2.Vpc, ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
+func (e *VPCExporter) collectSubnetsPerVpcUsage(ctx context.Context, run *collectorRun, vpcs []*ec2.Vpc, quota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
 	defer cancelFunc()
-	describeSubnetsOutput, err := ec2Svc.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{
-		Filters: []*ec2.Filter{&ec2.Filter{
-			Name:   aws.String("vpc-id"),
-			Values: []*string{vpc.VpcId},
-		}},
-	})
+	subnets, err := client.DescribeSubnetsAll(ctx)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to DescribeSubnets failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		run.fail()
 		return
 	}
-	usage := len(describeSubnetsOutput.Subnets)
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SubnetsPerVpcUsage, prometheus.GaugeValue, float64(usage), region, *vpc.VpcId))
+	usage := countSubnetsPerVpc(subnets)
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SubnetsPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.SubnetsPerVpcUtilization, float64(usage[*vpc.VpcId]), quota, region, *vpc.VpcId)
+		if days, ok := e.forecaster.forecast(time.Now(), float64(usage[*vpc.VpcId]), quota, "subnetspervpc", region, *vpc.VpcId); ok {
+			e.cache.AddMetric(prometheus.MustNewConstMetric(e.SubnetsPerVpcForecast, prometheus.GaugeValue, days, region, *vpc.VpcId))
+		}
+	}
 }
 
-func (e *VPCExporter) collectRoutesPerRouteTableQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_ROUTES_PER_ROUTE_TABLE)
+func (e *VPCExporter) collectRoutesPerRouteTableQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_ROUTES_PER_ROUTE_TABLE)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to RoutesPerRouteTable ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+		run.fail()
+		return 0
 	}
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RoutesPerRouteTableQuota, prometheus.GaugeValue, quota, region))
+	return quota
 }
 
-func (e *VPCExporter) collectRoutesPerRouteTableUsage(rtb *ec2.RouteTable, ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
-	defer cancelFunc()
-	descRouteTableOutput, err := ec2Svc.DescribeRouteTablesWithContext(ctx, &ec2.DescribeRouteTablesInput{
-		RouteTableIds: []*string{rtb.RouteTableId},
-	})
-	if err != nil {
-		level.Error(e.logger).Log("msg", "Call to DescribeRouteTables failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+func (e *VPCExporter) collectRoutesPerRouteTableUsage(routeTables []*ec2.RouteTable, selected vpcSelection, quota float64, region string) {
+	for _, rtb := range routeTables {
+		if !selected.selects(aws.StringValue(rtb.VpcId)) {
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RoutesPerRouteTableUsage, prometheus.GaugeValue, float64(len(rtb.Routes)), region, *rtb.VpcId, *rtb.RouteTableId))
+		e.cache.AddQuotaUtilization(e.RoutesPerRouteTableUtilization, float64(len(rtb.Routes)), quota, region, *rtb.VpcId, *rtb.RouteTableId)
 	}
-	quota := len(descRouteTableOutput.RouteTables)
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RoutesPerRouteTableUsage, prometheus.GaugeValue, float64(quota), region, *rtb.VpcId, *rtb.RouteTableId))
 }
 
-func (e *VPCExporter) collectInterfaceVpcEndpointsPerVpcQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)
+func (e *VPCExporter) collectInterfaceVpcEndpointsPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_INTERFACE_VPC_ENDPOINTS_PER_VPC)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to InterfaceVpcEndpointsPerVpc ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+		run.fail()
+		return 0
 	}
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.InterfaceVpcEndpointsPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
 }
 
-func (e *VPCExporter) collectInterfaceVpcEndpointsPerVpcUsage(vpc *ec2.Vpc, ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
+func (e *VPCExporter) collectInterfaceVpcEndpointsPerVpcUsage(ctx context.Context, run *collectorRun, vpcs []*ec2.Vpc, quota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
 	defer cancelFunc()
-	descVpcEndpoints, err := ec2Svc.DescribeVpcEndpointsWithContext(ctx, &ec2.DescribeVpcEndpointsInput{
-		Filters: []*ec2.Filter{{
-			Name:   aws.String("vpc-id"),
-			Values: []*string{vpc.VpcId},
-		}},
-	})
+	vpcEndpoints, err := client.DescribeVpcEndpointsAll(ctx)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to DescribeVpcEndpoints failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		run.fail()
 		return
 	}
-	quota := len(descVpcEndpoints.VpcEndpoints)
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.InterfaceVpcEndpointsPerVpcUsage, prometheus.GaugeValue, float64(quota), region, *vpc.VpcId))
+	usage := countVpcEndpointsPerVpc(vpcEndpoints)
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.InterfaceVpcEndpointsPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.InterfaceVpcEndpointsPerVpcUtilization, float64(usage[*vpc.VpcId]), quota, region, *vpc.VpcId)
+	}
 }
 
-func (e *VPCExporter) collectRoutesTablesPerVpcQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_ROUTE_TABLES_PER_VPC)
+func (e *VPCExporter) collectRoutesTablesPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_ROUTE_TABLES_PER_VPC)
 	if err != nil {
 		level.Error(e.logger).Log("msg", "Call to RoutesTablesPerVpc ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
-		return
+		run.fail()
+		return 0
 	}
 	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RouteTablesPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectRoutesTablesPerVpcUsage(vpcs []*ec2.Vpc, routeTables []*ec2.RouteTable, quota float64, region string) {
+	usage := countRouteTablesPerVpc(routeTables)
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RouteTablesPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.RouteTablesPerVpcUtilization, float64(usage[*vpc.VpcId]), quota, region, *vpc.VpcId)
+	}
+}
+
+func (e *VPCExporter) collectIPv4BlocksPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_IPV4_BLOCKS_PER_VPC)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to IPv4BlocksPerVpc ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.IPv4BlocksPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectIPv4BlocksPerVpcUsage(vpcs []*ec2.Vpc, quota float64, region string) {
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.IPv4BlocksPerVpcUsage, prometheus.GaugeValue, float64(len(vpc.CidrBlockAssociationSet)), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.IPv4BlocksPerVpcUtilization, float64(len(vpc.CidrBlockAssociationSet)), quota, region, *vpc.VpcId)
+	}
+}
+
+func (e *VPCExporter) collectSecurityGroupsPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_SECURITY_GROUPS_PER_VPC)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to SecurityGroupsPerVpc ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityGroupsPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectRulesPerSecurityGroupQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_RULES_PER_SECURITY_GROUP)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to RulesPerSecurityGroup ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RulesPerSecurityGroupQuota, prometheus.GaugeValue, quota, region))
+	return quota
 }
 
-func (e *VPCExporter) collectRoutesTablesPerVpcUsage(vpc *ec2.Vpc, ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
+func (e *VPCExporter) collectSecurityGroupsUsage(ctx context.Context, run *collectorRun, vpcs []*ec2.Vpc, selected vpcSelection, quota float64, rulesQuota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
 	defer cancelFunc()
-	descRouteTables, err := ec2Svc.DescribeRouteTablesWithContext(ctx, &ec2.DescribeRouteTablesInput{
-		Filters: []*ec2.Filter{{
-			Name:   aws.String("vpc-id"),
-			Values: []*string{vpc.VpcId},
-		}},
-	})
+	securityGroups, err := client.DescribeSecurityGroupsAll(ctx)
 	if err != nil {
-		level.Error(e.logger).Log("msg", "Call to DescribeRouteTables failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		level.Error(e.logger).Log("msg", "Call to DescribeSecurityGroups failed", "region", region, "err", err)
+		run.fail()
 		return
 	}
-	quota := len(descRouteTables.RouteTables)
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.RouteTablesPerVpcUsage, prometheus.GaugeValue, float64(quota), region, *vpc.VpcId))
+
+	usage := make(map[string]int)
+	for _, sg := range securityGroups {
+		vpcId := aws.StringValue(sg.VpcId)
+		if !selected.selects(vpcId) {
+			continue
+		}
+		usage[vpcId]++
+		inbound, outbound := float64(countSecurityGroupRules(sg.IpPermissions)), float64(countSecurityGroupRules(sg.IpPermissionsEgress))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RulesPerSecurityGroupUsage, prometheus.GaugeValue, inbound, region, vpcId, aws.StringValue(sg.GroupId), "inbound"))
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RulesPerSecurityGroupUsage, prometheus.GaugeValue, outbound, region, vpcId, aws.StringValue(sg.GroupId), "outbound"))
+		e.cache.AddQuotaUtilization(e.RulesPerSecurityGroupUtilization, inbound, rulesQuota, region, vpcId, aws.StringValue(sg.GroupId), "inbound")
+		e.cache.AddQuotaUtilization(e.RulesPerSecurityGroupUtilization, outbound, rulesQuota, region, vpcId, aws.StringValue(sg.GroupId), "outbound")
+	}
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.SecurityGroupsPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.SecurityGroupsPerVpcUtilization, float64(usage[*vpc.VpcId]), quota, region, *vpc.VpcId)
+	}
 }
 
-func (e *VPCExporter) collectIPv4BlocksPerVpcQuota(client *servicequotas.ServiceQuotas, region string) {
-	quota, err := e.GetQuotaValue(client, SERVICE_CODE_VPC, QUOTA_IPV4_BLOCKS_PER_VPC)
+func (e *VPCExporter) collectPeeringConnectionsPerVpcQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_PEERING_CONNECTIONS_PER_VPC)
 	if err != nil {
-		level.Error(e.logger).Log("msg", "Call to IPv4BlocksPerVpc ServiceQuota failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		level.Error(e.logger).Log("msg", "Call to PeeringConnectionsPerVpc ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.PeeringConnectionsPerVpcQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectPeeringConnectionsPerVpcUsage(ctx context.Context, run *collectorRun, vpcs []*ec2.Vpc, quota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
+	defer cancelFunc()
+	peeringConnections, err := client.DescribeVpcPeeringConnectionsAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeVpcPeeringConnections failed", "region", region, "err", err)
+		run.fail()
 		return
 	}
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.IPv4BlocksPerVpcQuota, prometheus.GaugeValue, quota, region))
+	usage := countActivePeeringConnectionsPerVpc(peeringConnections)
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.PeeringConnectionsPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.PeeringConnectionsPerVpcUtilization, float64(usage[*vpc.VpcId]), quota, region, *vpc.VpcId)
+	}
 }
 
-func (e *VPCExporter) collectIPv4BlocksPerVpcUsage(vpc *ec2.Vpc, ec2Svc *ec2.EC2, region string) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), e.timeout)
+func (e *VPCExporter) collectTGWAttachmentsPerVpcUsage(ctx context.Context, run *collectorRun, vpcs []*ec2.Vpc, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
 	defer cancelFunc()
-	descVpcs, err := ec2Svc.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
-		VpcIds: []*string{vpc.VpcId},
-	})
+	attachments, err := client.DescribeTransitGatewayVpcAttachmentsAll(ctx)
 	if err != nil {
-		level.Error(e.logger).Log("msg", "Call to DescribeVpcs failed", "region", region, "err", err)
-		awsclient.AwsExporterMetrics.IncrementErrors()
+		level.Error(e.logger).Log("msg", "Call to DescribeTransitGatewayVpcAttachments failed", "region", region, "err", err)
+		run.fail()
 		return
 	}
-	if len(descVpcs.Vpcs) != 1 {
-		level.Error(e.logger).Log("msg", "Unexpected numbers of VPCs (!= 1) returned", "region", region, "vpcId", vpc.VpcId)
+	usage := countTGWAttachmentsPerVpc(attachments)
+	for _, vpc := range vpcs {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.TGWAttachmentsPerVpcUsage, prometheus.GaugeValue, float64(usage[*vpc.VpcId]), region, *vpc.VpcId))
+		e.cache.AddQuotaUtilization(e.TGWAttachmentsPerVpcUtilization, float64(usage[*vpc.VpcId]), TRANSIT_GATEWAY_ATTACHMENTS_PER_VPC_LIMIT, region, *vpc.VpcId)
+	}
+}
+
+func (e *VPCExporter) collectInternetGatewaysPerRegionQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_INTERNET_GATEWAYS_PER_REGION)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to InternetGatewaysPerRegion ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.InternetGatewaysPerRegionQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectInternetGatewaysPerRegionUsage(ctx context.Context, run *collectorRun, quota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
+	defer cancelFunc()
+	internetGateways, err := client.DescribeInternetGatewaysAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeInternetGateways failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.InternetGatewaysPerRegionUsage, prometheus.GaugeValue, float64(len(internetGateways)), region))
+	e.cache.AddQuotaUtilization(e.InternetGatewaysPerRegionUtilization, float64(len(internetGateways)), quota, region)
+}
+
+func (e *VPCExporter) collectNetworkInterfacesPerRegionQuota(ctx context.Context, run *collectorRun, client awsclient.Client, region string) float64 {
+	quota, err := e.GetQuotaValue(ctx, client, SERVICE_CODE_VPC, QUOTA_NETWORK_INTERFACES_PER_REGION)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to NetworkInterfacesPerRegion ServiceQuota failed", "region", region, "err", err)
+		run.fail()
+		return 0
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.NetworkInterfacesPerRegionQuota, prometheus.GaugeValue, quota, region))
+	return quota
+}
+
+func (e *VPCExporter) collectNetworkInterfacesUsage(ctx context.Context, run *collectorRun, selected vpcSelection, quota float64, client awsclient.Client, region string) {
+	ctx, cancelFunc := context.WithTimeout(ctx, e.timeout)
+	defer cancelFunc()
+	networkInterfaces, err := client.DescribeNetworkInterfacesAll(ctx)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to DescribeNetworkInterfaces failed", "region", region, "err", err)
+		run.fail()
+		return
+	}
+
+	regionUsage := make(map[string]int)
+	vpcUsage := make(map[[2]string]int)
+	for _, eni := range networkInterfaces {
+		status := aws.StringValue(eni.Status)
+		regionUsage[status]++
+		vpcUsage[[2]string{aws.StringValue(eni.VpcId), status}]++
+	}
+	for status, count := range regionUsage {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.NetworkInterfacesPerRegionUsage, prometheus.GaugeValue, float64(count), region, status))
+	}
+	// the quota counts the network interfaces of all statuses
+	e.cache.AddQuotaUtilization(e.NetworkInterfacesPerRegionUtilization, float64(len(networkInterfaces)), quota, region)
+	for key, count := range vpcUsage {
+		if !selected.selects(key[0]) {
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.NetworkInterfacesPerVpcUsage, prometheus.GaugeValue, float64(count), region, key[0], key[1]))
 	}
-	quota := len(descVpcs.Vpcs[0].CidrBlockAssociationSet)
-	e.cache.AddMetric(prometheus.MustNewConstMetric(e.IPv4BlocksPerVpcUsage, prometheus.GaugeValue, float64(quota), region, *vpc.VpcId))
 }
 
 func (e *VPCExporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- e.VpcsPerRegionQuota
 	ch <- e.VpcsPerRegionUsage
+	ch <- e.VpcsPerRegionUtilization
+	ch <- e.VpcsPerRegionForecast
 	ch <- e.SubnetsPerVpcQuota
 	ch <- e.SubnetsPerVpcUsage
+	ch <- e.SubnetsPerVpcUtilization
+	ch <- e.SubnetsPerVpcForecast
 	ch <- e.RoutesPerRouteTableQuota
 	ch <- e.RoutesPerRouteTableUsage
+	ch <- e.RoutesPerRouteTableUtilization
 	ch <- e.IPv4BlocksPerVpcQuota
 	ch <- e.IPv4BlocksPerVpcUsage
+	ch <- e.IPv4BlocksPerVpcUtilization
 	ch <- e.InterfaceVpcEndpointsPerVpcQuota
 	ch <- e.InterfaceVpcEndpointsPerVpcUsage
+	ch <- e.InterfaceVpcEndpointsPerVpcUtilization
 	ch <- e.RouteTablesPerVpcQuota
-	ch <- e.RoutesPerRouteTableUsage
+	ch <- e.RouteTablesPerVpcUsage
+	ch <- e.RouteTablesPerVpcUtilization
+	ch <- e.SecurityGroupsPerVpcQuota
+	ch <- e.SecurityGroupsPerVpcUsage
+	ch <- e.SecurityGroupsPerVpcUtilization
+	ch <- e.RulesPerSecurityGroupQuota
+	ch <- e.RulesPerSecurityGroupUsage
+	ch <- e.RulesPerSecurityGroupUtilization
+	ch <- e.PeeringConnectionsPerVpcQuota
+	ch <- e.PeeringConnectionsPerVpcUsage
+	ch <- e.PeeringConnectionsPerVpcUtilization
+	ch <- e.TGWAttachmentsPerVpcLimit
+	ch <- e.TGWAttachmentsPerVpcUsage
+	ch <- e.TGWAttachmentsPerVpcUtilization
+	ch <- e.InternetGatewaysPerRegionQuota
+	ch <- e.InternetGatewaysPerRegionUsage
+	ch <- e.InternetGatewaysPerRegionUtilization
+	ch <- e.NetworkInterfacesPerRegionQuota
+	ch <- e.NetworkInterfacesPerRegionUsage
+	ch <- e.NetworkInterfacesPerRegionUtilization
+	ch <- e.NetworkInterfacesPerVpcUsage
+}
+
+func countSubnetsPerVpc(subnets []*ec2.Subnet) map[string]int {
+	counts := make(map[string]int)
+	for _, subnet := range subnets {
+		counts[aws.StringValue(subnet.VpcId)]++
+	}
+	return counts
+}
+
+func countVpcEndpointsPerVpc(endpoints []*ec2.VpcEndpoint) map[string]int {
+	counts := make(map[string]int)
+	for _, endpoint := range endpoints {
+		counts[aws.StringValue(endpoint.VpcId)]++
+	}
+	return counts
+}
+
+func countRouteTablesPerVpc(routeTables []*ec2.RouteTable) map[string]int {
+	counts := make(map[string]int)
+	for _, rtb := range routeTables {
+		counts[aws.StringValue(rtb.VpcId)]++
+	}
+	return counts
+}
+
+// countActivePeeringConnectionsPerVpc counts the active peering connections for both the requester and the accepter VPC
+func countActivePeeringConnectionsPerVpc(peeringConnections []*ec2.VpcPeeringConnection) map[string]int {
+	counts := make(map[string]int)
+	for _, pcx := range peeringConnections {
+		if pcx.Status == nil || aws.StringValue(pcx.Status.Code) != ec2.VpcPeeringConnectionStateReasonCodeActive {
+			continue
+		}
+		if pcx.RequesterVpcInfo != nil {
+			counts[aws.StringValue(pcx.RequesterVpcInfo.VpcId)]++
+		}
+		if pcx.AccepterVpcInfo != nil {
+			counts[aws.StringValue(pcx.AccepterVpcInfo.VpcId)]++
+		}
+	}
+	return counts
+}
+
+// countTGWAttachmentsPerVpc counts the transit gateway attachments which are not deleted (or being deleted) per VPC
+func countTGWAttachmentsPerVpc(attachments []*ec2.TransitGatewayVpcAttachment) map[string]int {
+	counts := make(map[string]int)
+	for _, attachment := range attachments {
+		switch aws.StringValue(attachment.State) {
+		case ec2.TransitGatewayAttachmentStateDeleting, ec2.TransitGatewayAttachmentStateDeleted, ec2.TransitGatewayAttachmentStateFailed, ec2.TransitGatewayAttachmentStateRejected:
+			continue
+		}
+		counts[aws.StringValue(attachment.VpcId)]++
+	}
+	return counts
+}
+
+// countSecurityGroupRules counts the rules of the permissions the way AWS does for the quota: every CIDR range,
+// prefix list and referenced security group is a separate rule
+func countSecurityGroupRules(permissions []*ec2.IpPermission) int {
+	count := 0
+	for _, permission := range permissions {
+		count += len(permission.IpRanges) + len(permission.Ipv6Ranges) + len(permission.PrefixListIds) + len(permission.UserIdGroupPairs)
+	}
+	return count
 }
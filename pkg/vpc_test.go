@@ -0,0 +1,250 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectSubnetsPerVpcUsage(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &VPCExporter{
+		SubnetsPerVpcUsage:       prometheus.NewDesc("test", "test", []string{"aws_region", "vpcid"}, nil),
+		SubnetsPerVpcUtilization: prometheus.NewDesc("utilization", "test", []string{"aws_region", "vpcid"}, nil),
+		cache:                    *NewMetricsCache(10 * time.Second),
+		logger:                   log.NewNopLogger(),
+		timeout:                  10 * time.Second,
+		forecaster:               newQuotaForecaster(),
+	}
+
+	mockClient.EXPECT().DescribeSubnetsAll(gomock.Any()).Return([]*ec2.Subnet{
+		{VpcId: aws.String("vpc-1")},
+		{VpcId: aws.String("vpc-1")},
+	}, nil)
+
+	run := startCollectorRun("vpc", "foo")
+	vpcs := []*ec2.Vpc{{VpcId: aws.String("vpc-1")}, {VpcId: aws.String("vpc-2")}}
+	e.collectSubnetsPerVpcUsage(ctx, run, vpcs, 200, mockClient, "foo")
+
+	// the usage and utilization of both VPCs, there is no forecast until there are two samples
+	assert.True(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 4)
+}
+
+func TestCollectVpcsPerRegionUsage(t *testing.T) {
+	e := &VPCExporter{
+		VpcsPerRegionUsage:       prometheus.NewDesc("test", "test", []string{"aws_region"}, nil),
+		VpcsPerRegionUtilization: prometheus.NewDesc("utilization", "test", []string{"aws_region"}, nil),
+		cache:                    *NewMetricsCache(10 * time.Second),
+		forecaster:               newQuotaForecaster(),
+	}
+
+	// the VPCs described for the usages per VPC are counted, they aren't described again
+	e.collectVpcsPerRegionUsage([]*ec2.Vpc{{VpcId: aws.String("vpc-1")}, {VpcId: aws.String("vpc-2")}}, 5, "foo")
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 2)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		switch metric.Desc().String() {
+		case e.VpcsPerRegionUsage.String():
+			assert.Equal(t, 2.0, dtoMetric.GetGauge().GetValue())
+		case e.VpcsPerRegionUtilization.String():
+			assert.Equal(t, 0.4, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestCountSubnetsPerVpc(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{VpcId: aws.String("vpc-1")},
+		{VpcId: aws.String("vpc-1")},
+		{VpcId: aws.String("vpc-2")},
+	}
+
+	assert.Equal(t, map[string]int{"vpc-1": 2, "vpc-2": 1}, countSubnetsPerVpc(subnets))
+}
+
+func TestCountVpcEndpointsPerVpc(t *testing.T) {
+	endpoints := []*ec2.VpcEndpoint{
+		{VpcId: aws.String("vpc-1")},
+		{VpcId: aws.String("vpc-2")},
+	}
+
+	assert.Equal(t, map[string]int{"vpc-1": 1, "vpc-2": 1}, countVpcEndpointsPerVpc(endpoints))
+}
+
+func TestCountRouteTablesPerVpc(t *testing.T) {
+	routeTables := []*ec2.RouteTable{
+		{VpcId: aws.String("vpc-1")},
+		{VpcId: aws.String("vpc-1")},
+	}
+
+	assert.Equal(t, map[string]int{"vpc-1": 2}, countRouteTablesPerVpc(routeTables))
+	assert.Empty(t, countRouteTablesPerVpc(nil))
+}
+
+func TestCountSecurityGroupRules(t *testing.T) {
+	permissions := []*ec2.IpPermission{
+		{
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}, {CidrIp: aws.String("192.168.0.0/16")}},
+			Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+		},
+		{
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-1")}},
+			PrefixListIds:    []*ec2.PrefixListId{{PrefixListId: aws.String("pl-1")}},
+		},
+	}
+
+	assert.Equal(t, 5, countSecurityGroupRules(permissions))
+	assert.Equal(t, 0, countSecurityGroupRules(nil))
+}
+
+func TestCountActivePeeringConnectionsPerVpc(t *testing.T) {
+	peeringConnections := []*ec2.VpcPeeringConnection{
+		{
+			Status:           &ec2.VpcPeeringConnectionStateReason{Code: aws.String(ec2.VpcPeeringConnectionStateReasonCodeActive)},
+			RequesterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-1")},
+			AccepterVpcInfo:  &ec2.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-2")},
+		},
+		{
+			Status:           &ec2.VpcPeeringConnectionStateReason{Code: aws.String(ec2.VpcPeeringConnectionStateReasonCodeDeleted)},
+			RequesterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-1")},
+			AccepterVpcInfo:  &ec2.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-3")},
+		},
+	}
+
+	assert.Equal(t, map[string]int{"vpc-1": 1, "vpc-2": 1}, countActivePeeringConnectionsPerVpc(peeringConnections))
+}
+
+func TestCountTGWAttachmentsPerVpc(t *testing.T) {
+	attachments := []*ec2.TransitGatewayVpcAttachment{
+		{VpcId: aws.String("vpc-1"), State: aws.String(ec2.TransitGatewayAttachmentStateAvailable)},
+		{VpcId: aws.String("vpc-1"), State: aws.String(ec2.TransitGatewayAttachmentStatePending)},
+		{VpcId: aws.String("vpc-2"), State: aws.String(ec2.TransitGatewayAttachmentStateDeleted)},
+	}
+
+	assert.Equal(t, map[string]int{"vpc-1": 2}, countTGWAttachmentsPerVpc(attachments))
+}
+
+func TestCollectInternetGatewaysPerRegionUsage(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &VPCExporter{
+		InternetGatewaysPerRegionUsage: prometheus.NewDesc("test", "test", []string{"aws_region"}, nil),
+		cache:                          *NewMetricsCache(10 * time.Second),
+		logger:                         log.NewNopLogger(),
+		timeout:                        10 * time.Second,
+	}
+
+	mockClient.EXPECT().DescribeInternetGatewaysAll(gomock.Any()).Return(nil, errors.New("test error"))
+
+	run := startCollectorRun("vpc", "foo")
+	e.collectInternetGatewaysPerRegionUsage(ctx, run, 5, mockClient, "foo")
+
+	assert.False(t, run.finish())
+	assert.Len(t, e.cache.GetAllMetrics(), 0)
+}
+
+func TestCollectNetworkInterfacesUsage(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &VPCExporter{
+		NetworkInterfacesPerRegionUsage:       prometheus.NewDesc("region", "test", []string{"aws_region", "status"}, nil),
+		NetworkInterfacesPerRegionUtilization: prometheus.NewDesc("utilization", "test", []string{"aws_region"}, nil),
+		NetworkInterfacesPerVpcUsage:          prometheus.NewDesc("vpc", "test", []string{"aws_region", "vpcid", "status"}, nil),
+		cache:                                 *NewMetricsCache(10 * time.Second),
+		logger:                                log.NewNopLogger(),
+		timeout:                               10 * time.Second,
+	}
+
+	mockClient.EXPECT().DescribeNetworkInterfacesAll(gomock.Any()).Return([]*ec2.NetworkInterface{
+		{VpcId: aws.String("vpc-1"), Status: aws.String(ec2.NetworkInterfaceStatusInUse)},
+		{VpcId: aws.String("vpc-1"), Status: aws.String(ec2.NetworkInterfaceStatusAvailable)},
+		{VpcId: aws.String("vpc-2"), Status: aws.String(ec2.NetworkInterfaceStatusInUse)},
+	}, nil)
+
+	run := startCollectorRun("vpc", "foo")
+	e.collectNetworkInterfacesUsage(ctx, run, nil, 6, mockClient, "foo")
+
+	assert.True(t, run.finish())
+	// 2 statuses for the region, 3 VPC/status combinations and the utilization of the region
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 6)
+	for _, metric := range metrics {
+		if metric.Desc().String() == e.NetworkInterfacesPerRegionUtilization.String() {
+			dtoMetric := &dto.Metric{}
+			assert.Nil(t, metric.Write(dtoMetric))
+			assert.Equal(t, 0.5, dtoMetric.GetGauge().GetValue())
+		}
+	}
+}
+
+func TestFilterVpcs(t *testing.T) {
+	vpcs := []*ec2.Vpc{
+		{VpcId: aws.String("vpc-1"), Tags: []*ec2.Tag{{Key: aws.String("environment"), Value: aws.String("production")}}},
+		{VpcId: aws.String("vpc-2"), Tags: []*ec2.Tag{{Key: aws.String("environment"), Value: aws.String("test")}}},
+	}
+
+	e := &VPCExporter{}
+	filtered, selected := e.filterVpcs(vpcs)
+	assert.Equal(t, vpcs, filtered)
+	assert.True(t, selected.selects("vpc-2"))
+	assert.True(t, e.selectionWithoutVpcs().selects("vpc-2"))
+
+	e.filter = newResourceFilter(ResourceFilter{Exclude: &ResourceSelector{Tags: map[string]string{"environment": "test"}}})
+	filtered, selected = e.filterVpcs(vpcs)
+	assert.Equal(t, vpcs[:1], filtered)
+	assert.True(t, selected.selects("vpc-1"))
+	assert.False(t, selected.selects("vpc-2"))
+	// without the tags of the VPCs, no VPC is known to pass the filter
+	assert.False(t, e.selectionWithoutVpcs().selects("vpc-1"))
+}
+
+func TestCollectNetworkInterfacesUsageFiltered(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockClient(ctrl)
+
+	e := &VPCExporter{
+		NetworkInterfacesPerRegionUsage: prometheus.NewDesc("region", "test", []string{"aws_region", "status"}, nil),
+		NetworkInterfacesPerVpcUsage:    prometheus.NewDesc("vpc", "test", []string{"aws_region", "vpcid", "status"}, nil),
+		cache:                           *NewMetricsCache(10 * time.Second),
+		logger:                          log.NewNopLogger(),
+		timeout:                         10 * time.Second,
+	}
+
+	mockClient.EXPECT().DescribeNetworkInterfacesAll(gomock.Any()).Return([]*ec2.NetworkInterface{
+		{VpcId: aws.String("vpc-1"), Status: aws.String(ec2.NetworkInterfaceStatusInUse)},
+		{VpcId: aws.String("vpc-2"), Status: aws.String(ec2.NetworkInterfaceStatusInUse)},
+	}, nil)
+
+	run := startCollectorRun("vpc", "foo")
+	e.collectNetworkInterfacesUsage(ctx, run, vpcSelection{"vpc-1": true}, 0, mockClient, "foo")
+
+	assert.True(t, run.finish())
+	// the region usage counts both VPCs, only vpc-1 has a metric of its own
+	assert.Len(t, e.cache.GetAllMetrics(), 2)
+}
@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	wafServiceCode string = "wafv2"
+	// wafWebACLCapacityQuota is the maximum of web ACL capacity units (WCU) of a web ACL
+	wafWebACLCapacityQuota = 5000
+	// wafCloudFrontRegion is the only region in which the web ACLs of CloudFront distributions are managed
+	wafCloudFrontRegion = "us-east-1"
+)
+
+// WAFExporter exports the web ACL capacity units used by the WAFv2 web ACLs and the number of web ACLs and rule groups.
+// The web ACLs of the CLOUDFRONT scope are collected with the us-east-1 region, all regions collect the REGIONAL scope.
+type WAFExporter struct {
+	sessions            []*session.Session
+	WebACLs             *prometheus.Desc
+	RuleGroups          *prometheus.Desc
+	WebACLCapacityQuota *prometheus.Desc
+	WebACLCapacityUsage *prometheus.Desc
+
+	cache    MetricsCache
+	logger   log.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewWAFExporter creates a new WAFExporter instance
+func NewWAFExporter(sessions []*session.Session, logger log.Logger, config WAFConfig, awsAccountId string) *WAFExporter {
+	level.Info(logger).Log("msg", "Initializing WAF exporter")
+	constLabels := map[string]string{"aws_account_id": awsAccountId, SERVICE_CODE_KEY: wafServiceCode}
+
+	return &WAFExporter{
+		sessions:            sessions,
+		WebACLs:             prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "wafv2_web_acls_total"), "Number of WAFv2 web ACLs per scope", []string{"aws_region", "scope"}, constLabels),
+		RuleGroups:          prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "wafv2_rule_groups_total"), "Number of WAFv2 rule groups per scope", []string{"aws_region", "scope"}, constLabels),
+		WebACLCapacityQuota: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "wafv2_web_acl_capacity_quota"), "The quota of web ACL capacity units (WCU) per web ACL", []string{"aws_region", "scope"}, constLabels),
+		WebACLCapacityUsage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "wafv2_web_acl_capacity_usage"), "The web ACL capacity units (WCU) used by the rules of the web ACL", []string{"aws_region", "scope", "web_acl"}, constLabels),
+		cache:               *NewMetricsCacheFromConfig(config.BaseConfig),
+		logger:              logger,
+		timeout:             *config.Timeout,
+		interval:            *config.Interval,
+	}
+}
+
+func (e *WAFExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.WebACLs
+	ch <- e.RuleGroups
+	ch <- e.WebACLCapacityQuota
+	ch <- e.WebACLCapacityUsage
+}
+
+func (e *WAFExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cache.GetAllMetrics() {
+		ch <- m
+	}
+}
+
+func (e *WAFExporter) CollectLoop(ctx context.Context) {
+	for {
+		collectCtx, ctxCancel := context.WithTimeout(ctx, e.timeout)
+		collectCtx, loop := startCollectorLoop(collectCtx)
+		wg := &sync.WaitGroup{}
+		wg.Add(len(e.sessions))
+
+		for i, sess := range e.sessions {
+			staggerRegion(ctx, i)
+			go e.collectInRegion(sess, wg, collectCtx)
+		}
+		wg.Wait()
+
+		level.Info(e.logger).Log("msg", "WAF metrics Updated")
+		if loop.finish() {
+			setCollectorLastUpdate(ctx, "waf")
+		}
+
+		ctxCancel()
+		if !sleepWithContext(ctx, e.interval) {
+			return
+		}
+	}
+}
+
+func (e *WAFExporter) collectInRegion(sess *session.Session, wg *sync.WaitGroup, ctx context.Context) {
+	defer wg.Done()
+
+	client := awsclient.NewClientFromSession(sess)
+	region := *sess.Config.Region
+	ctx, run := e.cache.startCollectorRun(ctx, "waf", region)
+	defer run.finish()
+
+	e.collectMetrics(client, ctx, region, wafv2.ScopeRegional, run)
+	if region == wafCloudFrontRegion {
+		e.collectMetrics(client, ctx, region, wafv2.ScopeCloudfront, run)
+	}
+}
+
+func (e *WAFExporter) collectMetrics(client awsclient.Client, ctx context.Context, region string, scope string, run *collectorRun) {
+	ruleGroups, err := client.ListRuleGroupsAll(ctx, scope)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListRuleGroupsAll failed", "region", region, "scope", scope, "err", err)
+		run.fail()
+	} else {
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.RuleGroups, prometheus.GaugeValue, float64(len(ruleGroups)), region, scope))
+	}
+
+	webACLs, err := client.ListWebACLsAll(ctx, scope)
+	if err != nil {
+		level.Error(e.logger).Log("msg", "Call to ListWebACLsAll failed", "region", region, "scope", scope, "err", err)
+		run.fail()
+		return
+	}
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.WebACLs, prometheus.GaugeValue, float64(len(webACLs)), region, scope))
+	e.cache.AddMetric(prometheus.MustNewConstMetric(e.WebACLCapacityQuota, prometheus.GaugeValue, wafWebACLCapacityQuota, region, scope))
+
+	// the capacity is only part of the web ACL itself, not of its summary
+	for _, webACL := range webACLs {
+		output, err := client.GetWebACLWithContext(ctx, &wafv2.GetWebACLInput{Id: webACL.Id, Name: webACL.Name, Scope: aws.String(scope)})
+		if err != nil {
+			level.Error(e.logger).Log("msg", "Call to GetWebACL failed", "region", region, "scope", scope, "web_acl", aws.StringValue(webACL.Name), "err", err)
+			run.fail()
+			continue
+		}
+		e.cache.AddMetric(prometheus.MustNewConstMetric(e.WebACLCapacityUsage, prometheus.GaugeValue, float64(aws.Int64Value(output.WebACL.Capacity)), region, scope, aws.StringValue(webACL.Name)))
+	}
+}
@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/app-sre/aws-resource-exporter/pkg/awsclient/mock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/go-kit/kit/log"
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWAFCollectMetrics(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListRuleGroupsAll(ctx, wafv2.ScopeRegional).Return([]*wafv2.RuleGroupSummary{{Name: aws.String("rate-limit")}}, nil)
+	mockClient.EXPECT().ListWebACLsAll(ctx, wafv2.ScopeRegional).Return([]*wafv2.WebACLSummary{
+		{Id: aws.String("1"), Name: aws.String("api")},
+		{Id: aws.String("2"), Name: aws.String("console")},
+	}, nil)
+	mockClient.EXPECT().GetWebACLWithContext(ctx, &wafv2.GetWebACLInput{Id: aws.String("1"), Name: aws.String("api"), Scope: aws.String(wafv2.ScopeRegional)}).
+		Return(&wafv2.GetWebACLOutput{WebACL: &wafv2.WebACL{Capacity: aws.Int64(1500)}}, nil)
+	mockClient.EXPECT().GetWebACLWithContext(ctx, &wafv2.GetWebACLInput{Id: aws.String("2"), Name: aws.String("console"), Scope: aws.String(wafv2.ScopeRegional)}).
+		Return(&wafv2.GetWebACLOutput{WebACL: &wafv2.WebACL{Capacity: aws.Int64(4800)}}, nil)
+
+	e := NewWAFExporter(nil, log.NewNopLogger(), WAFConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("waf", "foo")
+	e.collectMetrics(mockClient, ctx, "foo", wafv2.ScopeRegional, run)
+	assert.True(t, run.finish())
+
+	metrics := e.cache.GetAllMetrics()
+	assert.Len(t, metrics, 5)
+	for _, metric := range metrics {
+		dtoMetric := &dto.Metric{}
+		assert.Nil(t, metric.Write(dtoMetric))
+		labels := map[string]string{}
+		for _, label := range dtoMetric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		assert.Equal(t, wafv2.ScopeRegional, labels["scope"])
+		value := dtoMetric.GetGauge().GetValue()
+		switch metric.Desc().String() {
+		case e.RuleGroups.String():
+			assert.Equal(t, 1.0, value)
+		case e.WebACLs.String():
+			assert.Equal(t, 2.0, value)
+		case e.WebACLCapacityQuota.String():
+			assert.Equal(t, 5000.0, value)
+		case e.WebACLCapacityUsage.String():
+			assert.Equal(t, map[string]float64{"api": 1500, "console": 4800}[labels["web_acl"]], value)
+		}
+	}
+}
+
+func TestWAFCollectMetricsFailure(t *testing.T) {
+	ctx := context.TODO()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMockClient(ctrl)
+	mockClient.EXPECT().ListRuleGroupsAll(ctx, wafv2.ScopeCloudfront).Return([]*wafv2.RuleGroupSummary{}, nil)
+	mockClient.EXPECT().ListWebACLsAll(ctx, wafv2.ScopeCloudfront).Return([]*wafv2.WebACLSummary{{Id: aws.String("1"), Name: aws.String("cdn")}}, nil)
+	mockClient.EXPECT().GetWebACLWithContext(ctx, gomock.Any()).Return(nil, errors.New("WAFNonexistentItemException"))
+
+	e := NewWAFExporter(nil, log.NewNopLogger(), WAFConfig{BaseConfig: createTestBaseConfig()}, "1234567890")
+	run := startCollectorRun("waf", "us-east-1")
+	e.collectMetrics(mockClient, ctx, "us-east-1", wafv2.ScopeCloudfront, run)
+	assert.False(t, run.finish())
+
+	// the counts and the quota without the usage of the web ACL
+	assert.Len(t, e.cache.GetAllMetrics(), 3)
+}